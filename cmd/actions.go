@@ -0,0 +1,56 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/pmossman/claudew/internal/config"
+	"github.com/pmossman/claudew/internal/execx"
+)
+
+// runCustomAction renders a user-defined action's command and runs it via sh -c
+func runCustomAction(cfg *config.Config, action *config.CustomAction) error {
+	vars := config.ActionVars{WorkspaceDir: cfg.Settings.WorkspaceDir}
+
+	var dir string
+	if action.NeedsWorkspace() {
+		name, err := selectWorkspaceInteractive(cfg)
+		if err != nil {
+			return err
+		}
+		if name == "" {
+			return nil // User cancelled
+		}
+
+		ws, err := cfg.GetWorkspace(name)
+		if err != nil {
+			return err
+		}
+		vars.Workspace = name
+		vars.ClonePath = ws.ClonePath
+		vars.RepoPath = ws.GetRepoPath()
+		dir = ws.GetWorkDir()
+	}
+
+	command, err := action.Render(vars)
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("→ %s\n", command)
+
+	cmd := execx.Command("sh", "-c", command)
+	cmd.Stdin = os.Stdin
+	cmd.Stdout = os.Stdout
+	cmd.Stderr = os.Stderr
+	if dir != "" {
+		if _, err := os.Stat(dir); err == nil {
+			cmd.Dir = dir
+		}
+	}
+
+	if err := cmd.Run(); err != nil {
+		return fmt.Errorf("action '%s' failed: %w", action.Label, err)
+	}
+	return nil
+}
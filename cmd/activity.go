@@ -0,0 +1,32 @@
+package cmd
+
+import (
+	"github.com/pmossman/claudew/internal/config"
+	"github.com/pmossman/claudew/internal/session"
+)
+
+// syncSessionActivity advances each workspace's LastActive to its tmux session's last activity,
+// so long-lived sessions don't look stale. Reports whether any workspace changed.
+func syncSessionActivity(cfg *config.Config, sessionMgr *session.Manager) bool {
+	activity, err := sessionMgr.ListActivity()
+	if err != nil {
+		return false
+	}
+
+	changed := false
+	for name, ws := range cfg.Workspaces {
+		last, ok := activity[sessionMgr.GetSessionName(name)]
+		if ok && last.After(ws.LastActive) {
+			ws.LastActive = last
+			changed = true
+		}
+	}
+	return changed
+}
+
+// refreshLastActive syncs session activity into the config and saves it if anything changed
+func refreshLastActive(cfg *config.Config) {
+	if syncSessionActivity(cfg, session.NewManagerForConfig(cfg)) {
+		cfg.Save() // Best effort - display still uses the refreshed values
+	}
+}
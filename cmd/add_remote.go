@@ -6,6 +6,7 @@ import (
 	"path/filepath"
 
 	"github.com/pmossman/claudew/internal/config"
+	"github.com/pmossman/claudew/internal/events"
 	"github.com/spf13/cobra"
 )
 
@@ -14,6 +15,8 @@ var addRemoteCmd = &cobra.Command{
 	Short: "Register a remote repository",
 	Long: `Registers a remote repository for clone management.
 The clone-dir is where new clones will be created (e.g., ~/dev/airbyte-clones).
+--clone-naming names their directories: {n} is the clone number and {remote}
+the remote's name, so "{remote}-{n}" gives airbyte-1, airbyte-2 (default "{n}").
 
 If called without arguments, runs interactively.`,
 	Args: cobra.MaximumNArgs(2),
@@ -22,11 +25,8 @@ If called without arguments, runs interactively.`,
 
 		// Interactive mode if no args provided
 		if len(args) == 0 {
-			// Reopen /dev/tty for both reading and writing to ensure prompts are visible after fzf
-			tty, err := os.OpenFile("/dev/tty", os.O_RDWR, 0)
-			if err != nil {
-				return fmt.Errorf("failed to open terminal: %w", err)
-			}
+			// Prompt on the terminal even after fzf or when stdout is captured
+			tty := openTerminal()
 			defer tty.Close()
 
 			fmt.Fprintln(tty)
@@ -83,19 +83,54 @@ If called without arguments, runs interactively.`,
 			return fmt.Errorf("failed to load config: %w", err)
 		}
 
+		permissions, _ := cmd.Flags().GetString("permissions")
+		if permissions != "" {
+			if _, err := cfg.GetPermissionPreset(permissions); err != nil {
+				return err
+			}
+		}
+		cloneStrategy, _ := cmd.Flags().GetString("clone-strategy")
+		if cloneStrategy != "" {
+			if err := config.ValidateCloneStrategy(cloneStrategy); err != nil {
+				return err
+			}
+		}
+		cloneNaming, _ := cmd.Flags().GetString("clone-naming")
+		if cloneNaming != "" {
+			if err := config.ValidateCloneNaming(cloneNaming); err != nil {
+				return err
+			}
+		}
+
 		// Add remote
 		if err := cfg.AddRemote(name, url, absCloneDir); err != nil {
 			return err
 		}
 
+		remote, _ := cfg.GetRemote(name)
+		remote.Permissions = permissions
+		remote.CloneStrategy = cloneStrategy
+		remote.CloneNaming = cloneNaming
+
 		// Save config
 		if err := cfg.Save(); err != nil {
 			return fmt.Errorf("failed to save config: %w", err)
 		}
 
+		emitEvent(cfg, events.RemoteAdded, "", map[string]string{"remote": name, "url": url})
+
 		fmt.Printf("✓ Added remote '%s'\n", name)
 		fmt.Printf("  URL: %s\n", url)
 		fmt.Printf("  Clone directory: %s\n", absCloneDir)
+		if permissions != "" {
+			fmt.Printf("  Permissions: %s\n", permissions)
+		}
+		if cloneStrategy != "" {
+			fmt.Printf("  Clone strategy: %s\n", cloneStrategy)
+		}
+		if cloneNaming != "" {
+			fmt.Printf("  Clone naming: %s\n", cloneNaming)
+		}
 		fmt.Println()
 		fmt.Println("Next: Create a workspace for this remote")
 		fmt.Println("  Run 'claudew' to open the interactive menu")
@@ -107,4 +142,9 @@ If called without arguments, runs interactively.`,
 func init() {
 	addRemoteCmd.Flags().String("clone-dir", "", "Base directory for clones (required)")
 	addRemoteCmd.MarkFlagRequired("clone-dir")
+	addRemoteCmd.Flags().String("permissions", "", "Permission preset for workspaces created on this remote")
+	addRemoteCmd.RegisterFlagCompletionFunc("permissions", validPermissionPresets)
+	addRemoteCmd.Flags().String("clone-strategy", "", "Which free clone new workspaces get: lowest (default), lru, mru or cleanest")
+	addRemoteCmd.RegisterFlagCompletionFunc("clone-strategy", validCloneStrategies)
+	addRemoteCmd.Flags().String("clone-naming", "", "Clone directory name pattern with {n} and optionally {remote}, e.g. \"{remote}-{n}\" (default \"{n}\")")
 }
@@ -2,18 +2,37 @@ package cmd
 
 import (
 	"fmt"
+	"os"
+	"path/filepath"
 
+	"github.com/pmossman/claudew/internal/app"
+	"github.com/pmossman/claudew/internal/backup"
 	"github.com/pmossman/claudew/internal/config"
-	"github.com/pmossman/claudew/internal/template"
+	"github.com/pmossman/claudew/internal/events"
 	"github.com/pmossman/claudew/internal/workspace"
 	"github.com/spf13/cobra"
 )
 
+var (
+	archiveWip     bool
+	archiveOffload bool
+)
+
 var archiveCmd = &cobra.Command{
 	Use:   "archive <name>",
 	Short: "Archive a workspace",
-	Long:  `Archives a workspace by moving its directory and updating its status.`,
-	Args:  cobra.ExactArgs(1),
+	Long: `Archives a workspace by moving its directory and updating its status.
+
+The files claudew added to the clone (CLAUDE.md, its .claude/settings.local.json
+entries and the .gitignore entry) are removed; see clean-repo.
+
+Use --wip (or the wip_commit setting) to first commit any uncommitted changes in
+the clone to a local wip/<workspace> branch.
+
+Use --offload to also upload the workspace's directory to the "backup_target"
+setting (see 'claudew backup'), check the upload, and then delete the local copy.
+'claudew restore' brings it back.`,
+	Args: cobra.ExactArgs(1),
 	RunE: func(cmd *cobra.Command, args []string) error {
 		name := args[0]
 
@@ -34,29 +53,17 @@ var archiveCmd = &cobra.Command{
 			return fmt.Errorf("cannot archive active workspace '%s'. Stop the session first.", name)
 		}
 
-		wsMgr := workspace.NewManager(cfg.Settings.WorkspaceDir)
-
-		// Archive workspace directory
-		if err := wsMgr.Archive(name); err != nil {
-			return err
-		}
-
-		// Remove CLAUDE.md from repo
-		if err := template.RemoveClaudeMd(ws.GetRepoPath()); err != nil {
-			fmt.Printf("Warning: failed to remove CLAUDE.md: %v\n", err)
-		}
-
-		// Free the clone if it's managed
-		if ws.ClonePath != "" {
-			if err := cfg.FreeClone(ws.ClonePath); err != nil {
-				fmt.Printf("Warning: failed to free clone: %v\n", err)
-			} else {
-				fmt.Printf("  Clone freed: %s\n", ws.ClonePath)
+		// Check the target before archiving, so a bad setting doesn't leave the job half done
+		var target backup.Target
+		if archiveOffload {
+			if target, err = backup.ParseTarget(cfg.Settings.BackupTarget); err != nil {
+				return err
 			}
 		}
 
-		// Update status and save
-		if err := cfg.UpdateWorkspaceStatus(name, config.StatusArchived, 0); err != nil {
+		svc := newService(cfg)
+		wsMgr := svc.Workspaces
+		if err := archiveWorkspace(svc, name, ws, archiveWip); err != nil {
 			return err
 		}
 
@@ -64,12 +71,83 @@ var archiveCmd = &cobra.Command{
 			return fmt.Errorf("failed to save config: %w", err)
 		}
 
+		emitArchiveEvents(cfg, name, ws)
+
 		fmt.Printf("✓ Archived workspace '%s'\n", name)
 
+		if archiveOffload {
+			return offloadWorkspace(cfg, wsMgr, target, name, ws)
+		}
 		return nil
 	},
 }
 
+// offloadWorkspace uploads an archived workspace's directory to target and deletes
+// the local copy once the upload is verified. On failure the directory is kept.
+func offloadWorkspace(cfg *config.Config, wsMgr *workspace.Manager, target backup.Target, name string, ws *config.Workspace) error {
+	fmt.Printf("Uploading to %s...\n", target)
+	if _, err := backupWorkspace(cfg, wsMgr, target, name, true); err != nil {
+		return fmt.Errorf("offload failed, the archived directory was kept at %s: %w", wsMgr.ArchivedPath(name), err)
+	}
+	if _, err := os.Stat(filepath.Join(wsMgr.ArchivedPath(name), workspace.EnvFileName)); err == nil && !cfg.Settings.BackupIncludeEnv {
+		fmt.Printf("Note: %s was not uploaded (see backup_include_env) and is deleted with the local copy\n", workspace.EnvFileName)
+	}
+	if err := wsMgr.RemoveArchived(name); err != nil {
+		return err
+	}
+	ws.Offloaded = true
+	if err := cfg.Save(); err != nil {
+		return fmt.Errorf("failed to save config: %w", err)
+	}
+	fmt.Printf("✓ Offloaded '%s'; bring it back with: claudew restore %s\n", name, name)
+	return nil
+}
+
+// archiveWorkspace moves a workspace's directory to archived/, removes its container, ports and
+// the files claudew added to its repo, frees its clone and marks it archived. The caller saves the config.
+// Uncommitted changes are saved to a wip branch first if wip or the wip_commit setting is set.
+func archiveWorkspace(svc *app.Service, name string, ws *config.Workspace, wip bool) error {
+	cfg := svc.Config
+
+	// Archive workspace directory
+	if err := svc.Workspaces.Archive(name); err != nil {
+		return err
+	}
+
+	svc.RemoveContainer(name, ws)
+	_ = cfg.ReleasePorts(name)
+	if commit, err := app.SaveWIP(cfg, name, ws, wip); err != nil {
+		fmt.Printf("Warning: failed to save uncommitted changes: %v\n", err)
+	} else if commit != "" {
+		fmt.Printf("Saved uncommitted changes to %s (%s)\n", app.WIPBranch(name), commit[:7])
+	}
+
+	// Remove CLAUDE.md, settings and the .gitignore entry from the repo
+	cleanWorkspaceRepo(cfg, name, ws)
+
+	// Free the clone if it's managed
+	if ws.ClonePath != "" {
+		app.RecordCloneUsage(cfg, ws)
+		if err := cfg.FreeClone(ws.ClonePath); err != nil {
+			fmt.Printf("Warning: failed to free clone: %v\n", err)
+		} else {
+			fmt.Printf("  Clone freed: %s\n", ws.ClonePath)
+		}
+	}
+
+	return cfg.UpdateWorkspaceStatus(name, config.StatusArchived, 0)
+}
+
+// emitArchiveEvents reports a workspace archived by archiveWorkspace
+func emitArchiveEvents(cfg *config.Config, name string, ws *config.Workspace) {
+	emitEvent(cfg, events.WorkspaceArchived, name, nil)
+	if ws.ClonePath != "" {
+		emitEvent(cfg, events.CloneFreed, name, map[string]string{"clone_path": ws.ClonePath})
+	}
+}
+
 func init() {
+	archiveCmd.Flags().BoolVar(&archiveWip, "wip", false, "Commit uncommitted changes to a local wip/<workspace> branch first")
+	archiveCmd.Flags().BoolVar(&archiveOffload, "offload", false, "Upload the directory to backup_target, then delete it locally")
 	archiveCmd.ValidArgsFunction = validWorkspaceNamesExcludeArchived
 }
@@ -0,0 +1,108 @@
+package cmd
+
+import (
+	"fmt"
+	"path/filepath"
+
+	"github.com/pmossman/claudew/internal/config"
+	"github.com/spf13/cobra"
+)
+
+var assignCloneCmd = &cobra.Command{
+	Use:   "assign-clone <workspace-name> <clone-path>",
+	Short: "Bind a workspace to a specific clone",
+	Long: `Points a workspace at a registered clone, e.g. after manual git surgery.
+The workspace's previous clone is freed, and CLAUDE.md is moved to the new clone.
+
+The clone must be registered (see import-clone) and free or already assigned to
+this workspace. The workspace must not have a running session.
+
+Example:
+  claudew assign-clone feature-auth ~/dev/airbyte-clones/airbyte-3`,
+	Args: cobra.ExactArgs(2),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		name := args[0]
+
+		absClonePath, err := filepath.Abs(args[1])
+		if err != nil {
+			return fmt.Errorf("invalid clone path: %w", err)
+		}
+
+		svc, err := loadService()
+		if err != nil {
+			return err
+		}
+		freed, err := svc.AssignClone(name, absClonePath)
+		if err != nil {
+			return err
+		}
+
+		fmt.Printf("✓ Assigned clone to workspace '%s'\n", name)
+		fmt.Printf("  Clone: %s\n", absClonePath)
+		if freed != "" {
+			fmt.Printf("  Freed: %s\n", freed)
+		}
+
+		return nil
+	},
+}
+
+var unassignCloneCmd = &cobra.Command{
+	Use:   "unassign-clone <workspace-name>",
+	Short: "Detach a workspace from its clone",
+	Long: `Frees the workspace's clone so other workspaces can use it. The workspace
+keeps its context files but can't be started until a clone is assigned again
+with 'claudew assign-clone'.
+
+Example:
+  claudew unassign-clone feature-auth`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		name := args[0]
+
+		svc, err := loadService()
+		if err != nil {
+			return err
+		}
+		clonePath, err := svc.UnassignClone(name)
+		if err != nil {
+			return err
+		}
+
+		fmt.Printf("✓ Unassigned clone from workspace '%s'\n", name)
+		fmt.Printf("  Freed: %s\n", clonePath)
+		fmt.Printf("\nAssign another with: claudew assign-clone %s <clone-path>\n", name)
+
+		return nil
+	},
+}
+
+// validAssignCloneArgs completes a workspace name, then the paths of free clones
+func validAssignCloneArgs(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+	if len(args) == 0 {
+		return validWorkspaceNamesExcludeArchived(cmd, args, toComplete)
+	}
+	if len(args) > 1 {
+		return nil, cobra.ShellCompDirectiveNoFileComp
+	}
+
+	cfg, err := config.Load()
+	if err != nil {
+		return nil, cobra.ShellCompDirectiveNoFileComp
+	}
+
+	var paths []string
+	for path, clone := range cfg.Clones {
+		if clone.InUseBy == "" || clone.InUseBy == args[0] {
+			paths = append(paths, path)
+		}
+	}
+	return paths, cobra.ShellCompDirectiveNoFileComp
+}
+
+func init() {
+	rootCmd.AddCommand(assignCloneCmd)
+	rootCmd.AddCommand(unassignCloneCmd)
+	assignCloneCmd.ValidArgsFunction = validAssignCloneArgs
+	unassignCloneCmd.ValidArgsFunction = validWorkspaceNamesExcludeArchived
+}
@@ -0,0 +1,241 @@
+package cmd
+
+import (
+	"fmt"
+	"net/url"
+	"os"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/pmossman/claudew/internal/app"
+	"github.com/pmossman/claudew/internal/config"
+	"github.com/pmossman/claudew/internal/git"
+	"github.com/pmossman/claudew/internal/menu"
+	"github.com/pmossman/claudew/internal/session"
+	"github.com/pmossman/claudew/internal/workspace"
+)
+
+// Kinds of problems listed in the select menu's ATTENTION section
+const (
+	attentionDeadSession  = "dead session"
+	attentionMissingClone = "missing clone"
+	attentionStaleLock    = "stale lock"
+)
+
+// attentionItem is a workspace or clone whose state needs fixing
+type attentionItem struct {
+	kind   string
+	target string // workspace name, or clone path for missing clones
+	reason string
+}
+
+// menuLine formats the item for the select menu as "⚠ [kind] target — reason"
+func (a attentionItem) menuLine() string {
+	display := fmt.Sprintf("%s %s %s %s",
+		colorize(colorYellow, "⚠"),
+		colorize(colorGray, "["+a.kind+"]"),
+		a.target,
+		colorize(colorGray, "— "+a.reason),
+	)
+	return menu.Line(menuItemID(menuItemAttention, a.id()), display)
+}
+
+// id encodes the item for its menu ID, so the item survives the trip through fzf
+// whatever characters its target and reason contain
+func (a attentionItem) id() string {
+	return url.Values{"kind": {a.kind}, "target": {a.target}, "reason": {a.reason}}.Encode()
+}
+
+// parseAttentionID parses an ID written by id
+func parseAttentionID(id string) (attentionItem, error) {
+	values, err := url.ParseQuery(id)
+	if err != nil || values.Get("kind") == "" {
+		return attentionItem{}, fmt.Errorf("invalid attention item: %s", id)
+	}
+	return attentionItem{kind: values.Get("kind"), target: values.Get("target"), reason: values.Get("reason")}, nil
+}
+
+// collectAttentionItems finds workspaces still marked active whose session is gone,
+// clones whose directory is missing, and lock files left behind by dead processes
+func collectAttentionItems(cfg *config.Config, wsMgr *workspace.Manager, sessionMgr *session.Manager) []attentionItem {
+	var names []string
+	for name, ws := range cfg.Workspaces {
+		if ws.Status != config.StatusArchived {
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names)
+
+	var items []attentionItem
+	for _, name := range names {
+		if cfg.Workspaces[name].Status != config.StatusActive {
+			continue
+		}
+		if _, ok := app.ForegroundPID(cfg, name); ok {
+			continue
+		}
+		if exists, err := sessionMgr.Exists(sessionMgr.GetSessionName(name)); err == nil && !exists {
+			items = append(items, attentionItem{attentionDeadSession, name, "marked active but its tmux session is gone"})
+		}
+	}
+
+	var paths []string
+	for path := range cfg.Clones {
+		paths = append(paths, path)
+	}
+	sort.Strings(paths)
+	for _, path := range paths {
+		if _, err := os.Stat(path); os.IsNotExist(err) {
+			items = append(items, attentionItem{attentionMissingClone, path, "directory no longer exists"})
+		}
+	}
+
+	for _, name := range names {
+		if reason := staleLockReason(wsMgr, sessionMgr, name); reason != "" {
+			items = append(items, attentionItem{attentionStaleLock, name, reason})
+		}
+	}
+	return items
+}
+
+// buildAttentionMenuItems creates the ATTENTION section of the menu, empty if nothing needs fixing
+func buildAttentionMenuItems(items []attentionItem) []string {
+	if len(items) == 0 {
+		return nil
+	}
+	lines := []string{colorize(colorGray, "──── ATTENTION ────")}
+	for _, item := range items {
+		lines = append(lines, item.menuLine())
+	}
+	return lines
+}
+
+// attentionPreview describes an attention item and its fixes in the menu preview
+func attentionPreview(cfg *config.Config, item attentionItem) {
+	fmt.Printf("%s: %s\n", strings.ToUpper(item.kind), item.target)
+	fmt.Println()
+	switch item.kind {
+	case attentionDeadSession:
+		fmt.Println("The workspace is marked active, but its tmux session no longer")
+		fmt.Println("exists (e.g. tmux was killed or the machine rebooted).")
+		fmt.Println()
+		fmt.Println("Fixes:")
+		fmt.Println("  • Restart the session")
+		fmt.Println("  • Mark the workspace idle")
+	case attentionMissingClone:
+		clone, err := cfg.GetClone(item.target)
+		if err == nil {
+			fmt.Printf("Remote: %s\n", clone.RemoteName)
+			if clone.InUseBy != "" {
+				fmt.Printf("Used by: %s\n", clone.InUseBy)
+			}
+			fmt.Println()
+		}
+		fmt.Println("The clone is registered but its directory was deleted or moved.")
+		fmt.Println()
+		fmt.Println("Fixes:")
+		fmt.Println("  • Clone it again from its remote")
+		fmt.Println("  • Remove it from claudew")
+	case attentionStaleLock:
+		fmt.Printf("The workspace's lock file is stale (%s).\n", item.reason)
+		fmt.Println()
+		fmt.Println("Fixes:")
+		fmt.Println("  • Remove the lock file")
+	}
+}
+
+// fixAttentionItem walks the user through fixing a problem from the ATTENTION section
+func fixAttentionItem(cfg *config.Config, item attentionItem) error {
+	wsMgr := workspace.NewManagerForConfig(cfg)
+
+	var choices []string
+	switch item.kind {
+	case attentionDeadSession:
+		choices = []string{"Restart the session", "Mark the workspace idle"}
+	case attentionMissingClone:
+		choices = []string{"Clone it again from its remote", "Remove it from claudew"}
+	case attentionStaleLock:
+		choices = []string{"Remove the lock file"}
+	default:
+		return fmt.Errorf("unknown attention item: %s", item.kind)
+	}
+
+	choice, err := runMenu(append(choices, "Cancel"), applyFzfSettings(menuOptions{
+		header: fmt.Sprintf("%s: %s (%s)", item.kind, item.target, item.reason),
+		prompt: "Fix> ",
+		height: "40%",
+		noSort: true,
+	}, cfg.Settings))
+	if err != nil || choice == "" || choice == "Cancel" {
+		return err
+	}
+
+	switch choice {
+	case "Restart the session":
+		return startCmd.RunE(nil, []string{item.target})
+
+	case "Mark the workspace idle":
+		if err := cfg.UpdateWorkspaceStatus(item.target, config.StatusIdle, 0); err != nil {
+			return err
+		}
+		if err := cfg.Save(); err != nil {
+			return fmt.Errorf("failed to save config: %w", err)
+		}
+		fmt.Printf("✓ Marked '%s' idle\n", item.target)
+
+	case "Clone it again from its remote":
+		clone, err := cfg.GetClone(item.target)
+		if err != nil {
+			return err
+		}
+		remote, err := cfg.GetRemote(clone.RemoteName)
+		if err != nil {
+			return err
+		}
+		fmt.Printf("Cloning %s into %s...\n", remote.URL, item.target)
+		if err := git.Clone(remote.URL, item.target); err != nil {
+			return err
+		}
+		if branch, err := git.GetCurrentBranch(item.target); err == nil {
+			clone.SetBranch(branch, time.Now())
+		}
+		if err := cfg.Save(); err != nil {
+			return fmt.Errorf("failed to save config: %w", err)
+		}
+		fmt.Printf("✓ Restored clone at %s\n", item.target)
+
+	case "Remove it from claudew":
+		clone, err := cfg.GetClone(item.target)
+		if err != nil {
+			return err
+		}
+		if name := clone.InUseBy; name != "" {
+			if _, err := cfg.UnbindWorkspaceClone(name); err != nil {
+				return err
+			}
+			newService(cfg).LinkRepo(name)
+			fmt.Printf("Unassigned the clone from '%s' (assign a new one with: claudew assign-clone %s <path>)\n", name, name)
+		}
+		if err := cfg.RemoveClone(item.target); err != nil {
+			return err
+		}
+		if err := cfg.Save(); err != nil {
+			return fmt.Errorf("failed to save config: %w", err)
+		}
+		fmt.Printf("✓ Removed clone %s\n", item.target)
+
+	case "Remove the lock file":
+		// The lock may have been taken again since the menu was shown
+		reason, err := removeStaleLock(wsMgr, session.NewManagerForConfig(cfg), item.target)
+		if err != nil {
+			return fmt.Errorf("failed to remove lock: %w", err)
+		}
+		if reason == "" {
+			fmt.Printf("The lock for '%s' is no longer stale; left it alone\n", item.target)
+			return nil
+		}
+		fmt.Printf("✓ Removed stale lock for '%s'\n", item.target)
+	}
+	return nil
+}
@@ -0,0 +1,156 @@
+package cmd
+
+import (
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/pmossman/claudew/internal/app"
+	"github.com/pmossman/claudew/internal/config"
+	"github.com/pmossman/claudew/internal/events"
+	"github.com/spf13/cobra"
+)
+
+var (
+	autostopIdle   time.Duration
+	autostopDetach bool
+	autostopNoSave bool
+	autostopDryRun bool
+)
+
+var autostopCmd = &cobra.Command{
+	Use:   "autostop",
+	Short: "Stop sessions that have been idle for too long",
+	Long: `Stops the tmux sessions of workspaces whose panes have had no activity for
+longer than --idle, so idle Claude sessions don't use API credit and memory overnight.
+
+Before stopping, Claude writes continuation.md from the end of the session's
+transcript so the work can be resumed with 'claudew start'. Clones stay assigned
+to their workspaces.
+
+With --detach, attached clients are detached instead and sessions keep running.
+
+Run it periodically, e.g. from cron:
+  */30 * * * * claudew autostop --idle 4h
+
+Example:
+  claudew autostop --idle 4h            # Stop sessions idle for 4 hours
+  claudew autostop --idle 2h --dry-run  # Show what would be stopped`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if autostopIdle <= 0 {
+			return fmt.Errorf("--idle must be positive")
+		}
+
+		svc, err := loadService()
+		if err != nil {
+			return err
+		}
+		cfg, sessionMgr := svc.Config, svc.Sessions
+
+		activity, err := sessionMgr.ListActivity()
+		if err != nil {
+			return err
+		}
+
+		cutoff := time.Now().Add(-autostopIdle)
+		var names []string
+		for name, ws := range cfg.Workspaces {
+			if ws.Status == config.StatusArchived {
+				continue
+			}
+			last, ok := activity[sessionMgr.GetSessionName(name)]
+			if ok && last.Before(cutoff) {
+				names = append(names, name)
+			}
+		}
+		sort.Strings(names)
+
+		if len(names) == 0 {
+			fmt.Printf("No sessions idle for more than %s.\n", autostopIdle)
+			return nil
+		}
+
+		for _, name := range names {
+			last := activity[sessionMgr.GetSessionName(name)]
+			if autostopDryRun {
+				fmt.Printf("Would %s '%s' (idle since %s)\n", autostopVerb(), name, formatTimeAgo(last))
+				continue
+			}
+			if err := autostopWorkspace(svc, name); err != nil {
+				fmt.Printf("✗ %s: %v\n", name, err)
+				continue
+			}
+			fmt.Printf("✓ %s '%s' (idle since %s)\n", autostopPastVerb(), name, formatTimeAgo(last))
+		}
+
+		if autostopDryRun {
+			return nil
+		}
+
+		// Save config
+		if err := cfg.Save(); err != nil {
+			return fmt.Errorf("failed to save config: %w", err)
+		}
+		return nil
+	},
+}
+
+// autostopWorkspace saves a continuation and stops (or detaches) one idle workspace session
+func autostopWorkspace(svc *app.Service, name string) error {
+	cfg, wsMgr, sessionMgr := svc.Config, svc.Workspaces, svc.Sessions
+	ws, err := cfg.GetWorkspace(name)
+	if err != nil {
+		return err
+	}
+	sessionName := sessionMgr.GetSessionName(name)
+
+	if autostopDetach {
+		if err := sessionMgr.Detach(sessionName); err != nil {
+			return fmt.Errorf("failed to detach session: %w", err)
+		}
+		emitEvent(cfg, events.SessionDetached, name, map[string]string{"session": sessionName, "reason": "autostop"})
+		return nil
+	}
+
+	if !autostopNoSave {
+		if err := writeContinuationFromTranscript(cfg, wsMgr, name, ws); err != nil {
+			fmt.Printf("  Warning: could not save continuation for '%s': %v\n", name, err)
+		}
+	}
+
+	if err := sessionMgr.Kill(sessionName); err != nil {
+		return fmt.Errorf("failed to kill session: %w", err)
+	}
+	svc.StopContainer(name, ws)
+
+	if err := cfg.UpdateWorkspaceStatus(name, config.StatusIdle, 0); err != nil {
+		return err
+	}
+	emitEvent(cfg, events.SessionStopped, name, map[string]string{"session": sessionName, "reason": "autostop"})
+	return nil
+}
+
+// autostopVerb describes the action autostop takes, for dry runs
+func autostopVerb() string {
+	if autostopDetach {
+		return "detach"
+	}
+	return "stop"
+}
+
+// autostopPastVerb describes the action autostop took
+func autostopPastVerb() string {
+	if autostopDetach {
+		return "Detached"
+	}
+	return "Stopped"
+}
+
+func init() {
+	rootCmd.AddCommand(autostopCmd)
+	autostopCmd.Flags().DurationVar(&autostopIdle, "idle", 4*time.Hour, "Stop sessions with no activity for this long")
+	autostopCmd.Flags().BoolVar(&autostopDetach, "detach", false, "Detach clients instead of stopping sessions")
+	autostopCmd.Flags().BoolVar(&autostopNoSave, "no-save", false, "Don't write continuation.md before stopping")
+	autostopCmd.Flags().BoolVar(&autostopDryRun, "dry-run", false, "Show idle sessions without stopping them")
+}
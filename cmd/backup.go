@@ -0,0 +1,267 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"sort"
+	"time"
+
+	"github.com/pmossman/claudew/internal/backup"
+	"github.com/pmossman/claudew/internal/config"
+	"github.com/pmossman/claudew/internal/workspace"
+	"github.com/spf13/cobra"
+)
+
+var (
+	backupAll    bool
+	backupForce  bool
+	restoreForce bool
+)
+
+var backupCmd = &cobra.Command{
+	Use:   "backup [workspace-name...]",
+	Short: "Upload workspace directories to the backup target",
+	Long: `Compresses each workspace's directory (notes, research, decisions) and uploads
+it to the "backup_target" setting as <workspace>.tar.gz, replacing the previous
+backup. Targets are:
+
+  s3://bucket/prefix      uploaded with the aws CLI
+  gs://bucket/prefix      uploaded with gcloud storage
+  rclone:remote:path      uploaded with rclone, for any of its backends
+  /absolute/directory     copied, e.g. to a mounted NAS or a synced folder
+
+Enable versioning on the bucket to keep older backups. Workspaces that haven't
+changed since their last backup are skipped unless --force is given, so --all is
+cheap to run on a schedule, e.g. from cron:
+
+  0 * * * * claudew backup --all
+
+Session state is left out, and so is .claudew.env unless the "backup_include_env"
+setting is on, since it often holds secrets. Restore with 'claudew restore'.
+
+Example:
+  claudew backup feature-auth
+  claudew backup --all`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if backupAll == (len(args) > 0) {
+			return fmt.Errorf("give workspace names or --all")
+		}
+
+		// Load config
+		cfg, err := config.Load()
+		if err != nil {
+			return fmt.Errorf("failed to load config: %w", err)
+		}
+		target, err := backup.ParseTarget(cfg.Settings.BackupTarget)
+		if err != nil {
+			return err
+		}
+		wsMgr := workspace.NewManagerForConfig(cfg)
+
+		names := args
+		if backupAll {
+			for name, ws := range cfg.Workspaces {
+				if ws.Status != config.StatusArchived {
+					names = append(names, name)
+				}
+			}
+			sort.Strings(names)
+		}
+
+		var failed []string
+		uploaded := 0
+		for _, name := range names {
+			ok, err := backupWorkspace(cfg, wsMgr, target, name, backupForce)
+			switch {
+			case err != nil:
+				fmt.Printf("✗ %s: %v\n", name, err)
+				failed = append(failed, name)
+			case ok:
+				fmt.Printf("✓ Backed up '%s' to %s\n", name, target)
+				uploaded++
+			default:
+				fmt.Printf("  '%s' is unchanged since its last backup\n", name)
+			}
+		}
+
+		if uploaded > 0 {
+			if err := cfg.Save(); err != nil {
+				return fmt.Errorf("failed to save config: %w", err)
+			}
+		}
+		if len(failed) > 0 {
+			return fmt.Errorf("failed to back up %d of %d workspace(s): %v", len(failed), len(names), failed)
+		}
+		return nil
+	},
+}
+
+var restoreCmd = &cobra.Command{
+	Use:   "restore <workspace-name>",
+	Short: "Restore a workspace directory from the backup target",
+	Long: `Downloads <workspace>.tar.gz from the "backup_target" setting and extracts it
+into the workspace's directory. A workspace the config doesn't know, e.g. on a new
+machine, is added back without a clone; archived workspaces are made idle again.
+Either way, assign a clone with 'claudew assign-clone' before starting it.
+
+An existing workspace directory is only overwritten with --force.
+
+Example:
+  claudew restore feature-auth`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		name := args[0]
+		if err := config.ValidateWorkspaceName(name); err != nil {
+			return err
+		}
+
+		// Load config
+		cfg, err := config.Load()
+		if err != nil {
+			return fmt.Errorf("failed to load config: %w", err)
+		}
+		target, err := backup.ParseTarget(cfg.Settings.BackupTarget)
+		if err != nil {
+			return err
+		}
+		wsMgr := workspace.NewManagerForConfig(cfg)
+
+		if wsMgr.Exists(name) && !restoreForce {
+			return fmt.Errorf("workspace directory %s already exists; use --force to overwrite its notes with the backup", wsMgr.GetPath(name))
+		}
+
+		tmp, err := os.CreateTemp("", "claudew-restore-*.tar.gz")
+		if err != nil {
+			return fmt.Errorf("failed to create temp file: %w", err)
+		}
+		tmp.Close()
+		defer os.Remove(tmp.Name())
+
+		fmt.Printf("Downloading %s from %s...\n", backup.ObjectName(name), target)
+		if err := target.Download(backup.ObjectName(name), tmp.Name()); err != nil {
+			return err
+		}
+		f, err := os.Open(tmp.Name())
+		if err != nil {
+			return fmt.Errorf("failed to open backup: %w", err)
+		}
+		defer f.Close()
+		metadata, err := backup.Unpack(f, wsMgr.GetPath(name))
+		if err != nil {
+			return err
+		}
+
+		ws, err := cfg.GetWorkspace(name)
+		if err != nil {
+			ws = restoredWorkspace(name, metadata)
+			cfg.Workspaces[name] = ws
+		} else if ws.Status == config.StatusArchived {
+			// The archived copy of the directory is left where it is; the clone may
+			// have gone to another workspace since
+			ws.ClonePath = ""
+			ws.RepoPath = ""
+			ws.Status = config.StatusIdle
+			ws.Offloaded = false
+		}
+		if err := cfg.Save(); err != nil {
+			return fmt.Errorf("failed to save config: %w", err)
+		}
+		recordNotesHistory(wsMgr, name, "restore from backup")
+
+		fmt.Printf("✓ Restored '%s' to %s\n", name, wsMgr.GetPath(name))
+		if ws.GetRepoPath() == "" {
+			fmt.Printf("\nAssign a clone with: claudew assign-clone %s <clone-path>\n", name)
+		}
+		return nil
+	},
+}
+
+// backupWorkspace uploads a workspace's directory to target and checks the upload
+// arrived whole. Unchanged workspaces are skipped unless force is set; reports
+// whether anything was uploaded. The caller saves the config.
+func backupWorkspace(cfg *config.Config, wsMgr *workspace.Manager, target backup.Target, name string, force bool) (bool, error) {
+	ws, err := cfg.GetWorkspace(name)
+	if err != nil {
+		return false, err
+	}
+	dir := wsMgr.GetPath(name)
+	if ws.Status == config.StatusArchived {
+		dir = wsMgr.ArchivedPath(name)
+	}
+	if _, err := os.Stat(dir); err != nil {
+		return false, fmt.Errorf("workspace directory %s not found", dir)
+	}
+
+	latest, err := backup.LatestChange(dir)
+	if err != nil {
+		return false, fmt.Errorf("failed to read workspace directory: %w", err)
+	}
+	if !force && !ws.LastBackup.IsZero() && !latest.After(ws.LastBackup) {
+		return false, nil
+	}
+
+	metadata, err := json.MarshalIndent(ws, "", "  ")
+	if err != nil {
+		return false, fmt.Errorf("failed to encode workspace: %w", err)
+	}
+	tmp, err := os.CreateTemp("", "claudew-backup-*.tar.gz")
+	if err != nil {
+		return false, fmt.Errorf("failed to create temp file: %w", err)
+	}
+	defer os.Remove(tmp.Name())
+	started := time.Now()
+	if err := backup.Pack(dir, metadata, tmp, cfg.Settings.BackupIncludeEnv); err != nil {
+		tmp.Close()
+		return false, err
+	}
+	info, err := tmp.Stat()
+	tmp.Close()
+	if err != nil {
+		return false, fmt.Errorf("failed to write backup: %w", err)
+	}
+
+	object := backup.ObjectName(name)
+	if err := target.Upload(tmp.Name(), object); err != nil {
+		return false, err
+	}
+	size, err := target.Size(object)
+	if err != nil {
+		return false, fmt.Errorf("failed to verify upload: %w", err)
+	}
+	if size != info.Size() {
+		return false, fmt.Errorf("upload verification failed: %s has %d bytes on %s, expected %d", object, size, target, info.Size())
+	}
+
+	ws.LastBackup = started
+	return true, nil
+}
+
+// restoredWorkspace rebuilds a workspace's config entry from its backup metadata,
+// without the clone, session and ports that belonged to the machine it came from
+func restoredWorkspace(name string, metadata []byte) *config.Workspace {
+	ws := &config.Workspace{}
+	if err := json.Unmarshal(metadata, ws); err != nil {
+		ws = &config.Workspace{CreatedAt: time.Now()}
+	}
+	ws.Name = name
+	ws.Dir = ""
+	ws.ClonePath = ""
+	ws.RepoPath = ""
+	ws.SessionPID = 0
+	ws.Agents = nil
+	ws.Ports = nil
+	ws.Offloaded = false
+	ws.Status = config.StatusIdle
+	ws.LastActive = time.Now()
+	return ws
+}
+
+func init() {
+	rootCmd.AddCommand(backupCmd)
+	rootCmd.AddCommand(restoreCmd)
+	backupCmd.ValidArgsFunction = validWorkspaceNames
+	backupCmd.Flags().BoolVar(&backupAll, "all", false, "Back up every non-archived workspace")
+	backupCmd.Flags().BoolVar(&backupForce, "force", false, "Upload even if nothing changed since the last backup")
+	restoreCmd.Flags().BoolVar(&restoreForce, "force", false, "Overwrite an existing workspace directory")
+}
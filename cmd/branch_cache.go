@@ -0,0 +1,40 @@
+package cmd
+
+import (
+	"time"
+
+	"github.com/pmossman/claudew/internal/config"
+	"github.com/pmossman/claudew/internal/git"
+)
+
+// defaultBranchCacheMinutes is used when branch_cache_minutes is unset
+const defaultBranchCacheMinutes = 5
+
+// branchCacheTTL returns how long a clone's cached branch is trusted
+func branchCacheTTL(cfg *config.Config) time.Duration {
+	if cfg.Settings.BranchCacheMinutes > 0 {
+		return time.Duration(cfg.Settings.BranchCacheMinutes) * time.Minute
+	}
+	return defaultBranchCacheMinutes * time.Minute
+}
+
+// refreshCloneBranches re-reads the branch of each clone whose cached branch is stale
+// (or of every clone with force) and saves the config if anything was refreshed
+func refreshCloneBranches(cfg *config.Config, clones []*config.Clone, force bool) {
+	now := time.Now()
+	ttl := branchCacheTTL(cfg)
+
+	refreshed := false
+	for _, clone := range clones {
+		if !force && !clone.BranchStale(ttl, now) {
+			continue
+		}
+		if branch, err := git.GetCurrentBranch(clone.Path); err == nil {
+			clone.SetBranch(branch, now)
+			refreshed = true
+		}
+	}
+	if refreshed {
+		cfg.Save() // Best effort - the cache is refreshed again next time
+	}
+}
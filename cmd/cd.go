@@ -47,8 +47,8 @@ Example:
 			return fmt.Errorf("workspace '%s' not found", workspaceName)
 		}
 
-		// Get the clone path
-		clonePath := ws.GetRepoPath()
+		// Get the clone path (or its monorepo subdir)
+		clonePath := ws.GetWorkDir()
 		if clonePath == "" {
 			return fmt.Errorf("workspace '%s' has no clone path configured", workspaceName)
 		}
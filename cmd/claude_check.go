@@ -0,0 +1,109 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+
+	"github.com/pmossman/claudew/internal/config"
+	"github.com/pmossman/claudew/internal/execx"
+	"github.com/pmossman/claudew/internal/update"
+)
+
+// claudeInstallHint tells the user how to get a working claude_command
+const claudeInstallHint = "Install Claude Code with: npm install -g @anthropic-ai/claude-code (or point claude_command in ~/.claude-workspaces/config.json at it)"
+
+// claudeBinary returns the program claude_command runs, skipping leading VAR=value assignments
+func claudeBinary(cfg *config.Config) string {
+	for _, field := range strings.Fields(cfg.Settings.ClaudeCommand) {
+		if !strings.Contains(field, "=") {
+			return field
+		}
+	}
+	return ""
+}
+
+// claudeVersion runs claude_command's program with --version and returns its version
+func claudeVersion(binary string) (string, error) {
+	output, err := execx.Command(binary, "--version").Output()
+	if err != nil {
+		return "", fmt.Errorf("failed to run %s --version: %w", binary, err)
+	}
+	version := update.ExtractVersion(string(output))
+	if version == "" {
+		return "", fmt.Errorf("no version in %s --version output: %s", binary, strings.TrimSpace(string(output)))
+	}
+	return version, nil
+}
+
+// claudeShellChars mark a claude_command that only the shell can make sense of,
+// e.g. "~/bin/claude" or "source ~/.nvm/nvm.sh && claude"
+const claudeShellChars = "|&;<>()$`\\\"'*?[]{}~"
+
+// resolveClaudeBinary finds claude_command's program on PATH or, since Claude runs in
+// the user's interactive shell, as that shell would find it, which also covers PATH
+// entries, aliases and functions set up in its rc file. It returns the program's
+// path, "" when the shell knows it as an alias or function, and whether it was found.
+func resolveClaudeBinary(binary string) (string, bool) {
+	if path, err := exec.LookPath(binary); err == nil {
+		return path, true
+	}
+	shell := os.Getenv("SHELL")
+	if shell == "" {
+		return "", false
+	}
+	output, err := execx.Command(shell, "-ic", "command -v "+escapeShellArg(binary)).Output()
+	if err != nil {
+		return "", false
+	}
+	// Interactive shells may print a banner first; command -v's answer comes last
+	lines := strings.Split(strings.TrimSpace(string(output)), "\n")
+	found := strings.TrimSpace(lines[len(lines)-1])
+	if found == "" {
+		return "", false
+	}
+	if filepath.IsAbs(found) {
+		return found, true
+	}
+	return "", true
+}
+
+// checkClaudeCommand verifies, before Claude is launched for a workspace, that
+// claude_command exists and is at least claude_min_version, so an outdated install
+// fails here instead of inside tmux. A program that can't be found is only warned
+// about, since the shell in tmux may still find it, and commands using shell syntax
+// aren't checked at all. Claude in a container comes from the image, which is not
+// checked either.
+func checkClaudeCommand(cfg *config.Config, ws *config.Workspace) error {
+	if ws.Container != nil {
+		return nil
+	}
+
+	binary := claudeBinary(cfg)
+	if binary == "" {
+		return fmt.Errorf("claude_command is not configured. %s", claudeInstallHint)
+	}
+	if strings.ContainsAny(cfg.Settings.ClaudeCommand, claudeShellChars) {
+		return nil
+	}
+	path, found := resolveClaudeBinary(binary)
+	if !found {
+		fmt.Printf("Warning: claude command '%s' not found. %s\n", binary, claudeInstallHint)
+		return nil
+	}
+
+	minVersion := cfg.Settings.ClaudeMinVersion
+	if minVersion == "" || path == "" {
+		return nil
+	}
+	version, err := claudeVersion(path)
+	if err != nil {
+		return fmt.Errorf("could not check claude against claude_min_version %s: %w", minVersion, err)
+	}
+	if update.CompareVersions(version, minVersion) < 0 {
+		return fmt.Errorf("claude %s is older than claude_min_version %s. Update with: claude update", version, minVersion)
+	}
+	return nil
+}
@@ -0,0 +1,160 @@
+package cmd
+
+import (
+	"fmt"
+	"path/filepath"
+
+	"github.com/pmossman/claudew/internal/config"
+	"github.com/pmossman/claudew/internal/git"
+	"github.com/pmossman/claudew/internal/template"
+	"github.com/spf13/cobra"
+)
+
+var (
+	cleanRepoSubdirs []string
+	cleanRepoForce   bool
+)
+
+var cleanRepoCmd = &cobra.Command{
+	Use:   "clean-repo <clone-path>",
+	Short: "Remove every file claudew added to a clone or repo",
+	Long: `Restores a clone (or any repo a workspace used) to its state before claudew:
+removes the generated CLAUDE.md block, the permissions and statusline claudew put in
+.claude/settings.local.json, the .claude directory once it is empty, and the
+.claude/ entry claudew added to .gitignore, and the commit hook. Anything else in .claude is left in
+place and listed.
+
+Archive does this automatically. The repo root is cleaned, along with the sub-path
+the clone's last workspace used and any given with --subdir.
+
+Example:
+  claudew clean-repo ~/dev/airbyte-clones/airbyte-3
+  claudew clean-repo ~/dev/monorepo --subdir services/api`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		repoPath, err := filepath.Abs(args[0])
+		if err != nil {
+			return fmt.Errorf("invalid clone path: %w", err)
+		}
+
+		// Load config
+		cfg, err := config.Load()
+		if err != nil {
+			return fmt.Errorf("failed to load config: %w", err)
+		}
+
+		if users := cfg.WorkspacesUsingRepo(repoPath, ""); len(users) > 0 && !cleanRepoForce {
+			return fmt.Errorf("%s is used by workspace '%s'. Archive it first, or pass --force", repoPath, users[0])
+		}
+
+		subdirs := []string{""}
+		if clone, err := cfg.GetClone(repoPath); err == nil && clone.LastSubdir != "" {
+			subdirs = append(subdirs, clone.LastSubdir)
+		}
+		for _, subdir := range cleanRepoSubdirs {
+			if err := config.ValidateSubdir(subdir); err != nil {
+				return err
+			}
+			subdirs = append(subdirs, filepath.Clean(subdir))
+		}
+
+		clean := true
+		seen := map[string]bool{}
+		for _, subdir := range subdirs {
+			if seen[subdir] {
+				continue
+			}
+			seen[subdir] = true
+
+			report, err := template.CleanRepo(repoPath, filepath.Join(repoPath, subdir))
+			if err != nil {
+				return err
+			}
+			printCleanupReport(report)
+			if len(report.Kept) > 0 {
+				clean = false
+			}
+		}
+
+		if err := removeCommitHook(repoPath); err != nil {
+			return err
+		}
+
+		if clean {
+			fmt.Printf("✓ No claudew files left in %s\n", repoPath)
+		}
+		return nil
+	},
+}
+
+// cleanWorkspaceRepo removes what claudew added to a workspace's clone or repo, unless
+// another workspace now uses it and the files are that workspace's
+func cleanWorkspaceRepo(cfg *config.Config, name string, ws *config.Workspace) {
+	repoPath := ws.GetRepoPath()
+	if repoPath == "" {
+		return
+	}
+	if users := cfg.WorkspacesUsingRepo(repoPath, name); len(users) > 0 {
+		fmt.Printf("  Leaving %s as is: workspace '%s' uses it\n", repoPath, users[0])
+		return
+	}
+
+	report, err := template.CleanRepo(repoPath, ws.GetWorkDir())
+	if err != nil {
+		fmt.Printf("Warning: failed to clean up %s: %v\n", repoPath, err)
+		return
+	}
+	printCleanupReport(report)
+	if err := removeCommitHook(repoPath); err != nil {
+		fmt.Printf("Warning: %v\n", err)
+	}
+}
+
+// removeCommitHook takes the commit hook out of a repo, if it was installed
+func removeCommitHook(repoPath string) error {
+	if !git.HasPostCommitHook(repoPath) {
+		return nil
+	}
+	if err := git.RemovePostCommitHook(repoPath); err != nil {
+		return err
+	}
+	fmt.Println("  Removed the commit hook")
+	return nil
+}
+
+// printCleanupReport lists what a cleanup removed and what it left behind
+func printCleanupReport(report template.CleanupReport) {
+	for _, path := range report.Removed {
+		fmt.Printf("  Removed %s\n", path)
+	}
+	for _, path := range report.Kept {
+		fmt.Printf("  %s\n", paint(colorYellow, "Left in place (not created by claudew): "+path))
+	}
+}
+
+// validCleanRepoArgs completes the paths of clones no workspace is using
+func validCleanRepoArgs(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+	if len(args) > 0 {
+		return nil, cobra.ShellCompDirectiveNoFileComp
+	}
+
+	cfg, err := config.Load()
+	if err != nil {
+		return nil, cobra.ShellCompDirectiveDefault
+	}
+
+	var paths []string
+	for path, clone := range cfg.Clones {
+		if clone.InUseBy == "" {
+			paths = append(paths, path)
+		}
+	}
+	return paths, cobra.ShellCompDirectiveDefault
+}
+
+func init() {
+	rootCmd.AddCommand(cleanRepoCmd)
+	cleanRepoCmd.ValidArgsFunction = validCleanRepoArgs
+	cleanRepoCmd.Flags().StringArrayVar(&cleanRepoSubdirs, "subdir", nil, "Also clean this monorepo sub-path (repeatable)")
+	cleanRepoCmd.Flags().BoolVar(&cleanRepoForce, "force", false, "Clean even if a workspace still uses the repo")
+}
@@ -0,0 +1,141 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"sort"
+
+	"github.com/pmossman/claudew/internal/config"
+	"github.com/pmossman/claudew/internal/git"
+	"github.com/spf13/cobra"
+)
+
+var (
+	cloneCheckRemote    string
+	cloneCheckMaxBehind int
+)
+
+var cloneCheckCmd = &cobra.Command{
+	Use:   "clone-check",
+	Short: "Check the health of every registered clone",
+	Long: `Runs health checks against every registered clone and reports problems:
+
+- The directory is missing or is not a git repository
+- No commit is checked out (e.g. a partially-failed clone)
+- 'git fsck --no-full' reports corruption
+- The origin URL doesn't match the registered remote
+
+It also warns about drift: a detached HEAD, or falling more than --max-behind
+commits behind origin's default branch (as of the last fetch).
+
+Exits non-zero if any clone has problems.
+
+Example:
+  claudew clone-check                  # Check all clones
+  claudew clone-check --remote airbyte # Only check clones of one remote
+  claudew clone-check --max-behind 200 # Allow clones to drift further`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		// Load config
+		cfg, err := config.Load()
+		if err != nil {
+			return fmt.Errorf("failed to load config: %w", err)
+		}
+
+		if cloneCheckRemote != "" {
+			if _, err := cfg.GetRemote(cloneCheckRemote); err != nil {
+				return err
+			}
+		}
+
+		var clones []*config.Clone
+		for _, clone := range cfg.Clones {
+			if cloneCheckRemote == "" || clone.RemoteName == cloneCheckRemote {
+				clones = append(clones, clone)
+			}
+		}
+		sort.Slice(clones, func(i, j int) bool {
+			return clones[i].Path < clones[j].Path
+		})
+
+		if len(clones) == 0 {
+			fmt.Println("No clones registered.")
+			return nil
+		}
+
+		unhealthy := 0
+		for _, clone := range clones {
+			problems, warnings := checkClone(cfg, clone, cloneCheckMaxBehind)
+
+			switch {
+			case len(problems) > 0:
+				fmt.Printf("✗ %s\n", clone.Path)
+				unhealthy++
+			case len(warnings) > 0:
+				fmt.Printf("⚠ %s\n", clone.Path)
+			default:
+				fmt.Printf("✓ %s\n", clone.Path)
+			}
+			for _, p := range problems {
+				fmt.Printf("    • %s\n", p)
+			}
+			for _, w := range warnings {
+				fmt.Printf("    • %s\n", w)
+			}
+		}
+
+		fmt.Println()
+		if unhealthy > 0 {
+			return fmt.Errorf("%d of %d clone(s) have problems", unhealthy, len(clones))
+		}
+		fmt.Printf("✓ All %d clone(s) healthy\n", len(clones))
+		return nil
+	},
+}
+
+// checkClone returns problems (the clone is broken) and warnings (the clone has drifted)
+func checkClone(cfg *config.Config, clone *config.Clone, maxBehind int) (problems, warnings []string) {
+	if _, err := os.Stat(clone.Path); os.IsNotExist(err) {
+		return []string{"directory does not exist"}, nil
+	}
+
+	if !git.IsGitRepo(clone.Path) {
+		return []string{"not a git repository"}, nil
+	}
+
+	if !git.HasValidHead(clone.Path) {
+		return []string{"no commit checked out (partially-failed clone?)"}, nil
+	}
+
+	if err := git.Fsck(clone.Path); err != nil {
+		problems = append(problems, err.Error())
+	}
+
+	if remote, err := cfg.GetRemote(clone.RemoteName); err != nil {
+		problems = append(problems, fmt.Sprintf("remote '%s' is not registered", clone.RemoteName))
+	} else if url, err := git.GetRemoteURL(clone.Path); err != nil {
+		problems = append(problems, "no origin remote")
+	} else if git.NormalizeRemoteURL(url) != git.NormalizeRemoteURL(remote.URL) {
+		problems = append(problems, fmt.Sprintf("origin is %s, expected %s", url, remote.URL))
+	}
+
+	if detached, err := git.IsDetachedHead(clone.Path); err == nil && detached {
+		warnings = append(warnings, "detached HEAD")
+	}
+
+	if defaultBranch, err := git.GetDefaultBranch(clone.Path); err == nil {
+		behind, err := git.CommitsBehind(clone.Path, "origin/"+defaultBranch)
+		if err == nil && behind > maxBehind {
+			warnings = append(warnings, fmt.Sprintf("%d commits behind origin/%s", behind, defaultBranch))
+		}
+	}
+
+	return problems, warnings
+}
+
+func init() {
+	rootCmd.AddCommand(cloneCheckCmd)
+	cloneCheckCmd.Flags().StringVar(&cloneCheckRemote, "remote", "", "Only check clones of this remote")
+	cloneCheckCmd.Flags().IntVar(&cloneCheckMaxBehind, "max-behind", 100, "Warn when a clone is more than this many commits behind the default branch")
+	cloneCheckCmd.RegisterFlagCompletionFunc("remote", validRemoteNames)
+}
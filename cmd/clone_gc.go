@@ -0,0 +1,163 @@
+package cmd
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/pmossman/claudew/internal/config"
+	"github.com/pmossman/claudew/internal/git"
+	"github.com/spf13/cobra"
+)
+
+var (
+	cloneGCDays   int
+	cloneGCRemote string
+	cloneGCDryRun bool
+	cloneGCYes    bool
+)
+
+var cloneGCCmd = &cobra.Command{
+	Use:   "clone-gc",
+	Short: "Remove clones that have been free for a long time",
+	Long: `Removes clones that have not been used by any workspace for more than N days,
+deleting their directories and removing them from the config.
+
+Clones with uncommitted changes or unpushed commits are always skipped.
+
+The default age comes from the clone_gc_days setting (14 days if unset).
+
+Example:
+  claudew clone-gc                   # Remove clones free for more than clone_gc_days
+  claudew clone-gc --days 30         # Remove clones free for more than 30 days
+  claudew clone-gc --remote airbyte  # Only consider clones of one remote
+  claudew clone-gc --dry-run         # Show what would be removed`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		// Load config
+		cfg, err := config.Load()
+		if err != nil {
+			return fmt.Errorf("failed to load config: %w", err)
+		}
+
+		if cloneGCRemote != "" {
+			if _, err := cfg.GetRemote(cloneGCRemote); err != nil {
+				return err
+			}
+		}
+
+		days := cloneGCDays
+		if days <= 0 {
+			days = cfg.Settings.CloneGCDays
+		}
+		if days <= 0 {
+			days = config.DefaultCloneGCDays
+		}
+
+		cutoff := time.Now().Add(-time.Duration(days) * 24 * time.Hour)
+		candidates := cfg.FindStaleFreeClones(cloneGCRemote, cutoff)
+		if len(candidates) == 0 {
+			fmt.Printf("✓ No clones have been free for more than %d days\n", days)
+			return nil
+		}
+
+		// Never delete work that only exists in the clone
+		var removable []*config.Clone
+		for _, clone := range candidates {
+			if reason := cloneGCSkipReason(clone.Path); reason != "" {
+				fmt.Printf("  Skipping %s: %s\n", clone.Path, reason)
+				continue
+			}
+			removable = append(removable, clone)
+		}
+
+		if len(removable) == 0 {
+			fmt.Println("\nNo clones can be removed safely.")
+			return nil
+		}
+
+		fmt.Printf("\nClones free for more than %d days:\n", days)
+		for _, clone := range removable {
+			fmt.Printf("  • %s (%s, free since %s)\n", clone.Path, clone.RemoteName, clone.FreedAt.Format("2006-01-02"))
+		}
+
+		if cloneGCDryRun {
+			fmt.Println("\nDry run - nothing removed.")
+			return nil
+		}
+
+		if !cloneGCYes {
+			fmt.Println()
+			fmt.Printf("Remove %d clone(s)? [y/N]: ", len(removable))
+			reader := bufio.NewReader(os.Stdin)
+			response, _ := reader.ReadString('\n')
+			response = strings.TrimSpace(strings.ToLower(response))
+			if response != "y" && response != "yes" {
+				fmt.Println("Cancelled.")
+				return nil
+			}
+		}
+
+		removed := 0
+		for _, clone := range removable {
+			if err := os.RemoveAll(clone.Path); err != nil {
+				fmt.Printf("  ✗ Failed to delete %s: %v\n", clone.Path, err)
+				continue
+			}
+			if err := cfg.RemoveClone(clone.Path); err != nil {
+				fmt.Printf("  ✗ Failed to remove %s from config: %v\n", clone.Path, err)
+				continue
+			}
+			fmt.Printf("  ✓ Removed %s\n", clone.Path)
+			removed++
+		}
+
+		if err := cfg.Save(); err != nil {
+			return fmt.Errorf("failed to save config: %w", err)
+		}
+
+		fmt.Printf("\n✓ Removed %d clone(s)\n", removed)
+		return nil
+	},
+}
+
+// cloneGCSkipReason returns why a clone must not be deleted, or "" if it is safe to delete
+func cloneGCSkipReason(clonePath string) string {
+	if _, err := os.Stat(clonePath); os.IsNotExist(err) {
+		// Directory already gone - only the config entry needs removing
+		return ""
+	}
+
+	if !git.IsGitRepo(clonePath) {
+		return "not a git repository"
+	}
+
+	dirty, err := git.HasUncommittedChanges(clonePath)
+	if err != nil {
+		return fmt.Sprintf("could not check status (%v)", err)
+	}
+	if dirty {
+		return "has uncommitted changes"
+	}
+
+	unpushed, err := git.HasUnpushedCommits(clonePath)
+	if err != nil {
+		return fmt.Sprintf("could not check for unpushed commits (%v)", err)
+	}
+	if unpushed {
+		return "has unpushed commits"
+	}
+
+	return ""
+}
+
+func init() {
+	rootCmd.AddCommand(cloneGCCmd)
+	cloneGCCmd.Flags().IntVar(&cloneGCDays, "days", 0, "Remove clones free for more than this many days (default: clone_gc_days setting)")
+	cloneGCCmd.Flags().StringVar(&cloneGCRemote, "remote", "", "Only consider clones of this remote")
+	cloneGCCmd.Flags().BoolVar(&cloneGCDryRun, "dry-run", false, "Show which clones would be removed without removing them")
+	cloneGCCmd.Flags().BoolVarP(&cloneGCYes, "yes", "y", false, "Remove without asking for confirmation")
+	cloneGCCmd.RegisterFlagCompletionFunc("remote", validRemoteNames)
+}
@@ -0,0 +1,98 @@
+package cmd
+
+import (
+	"fmt"
+	"math"
+	"path/filepath"
+	"sort"
+
+	"github.com/pmossman/claudew/internal/app"
+	"github.com/pmossman/claudew/internal/config"
+	"github.com/pmossman/claudew/internal/git"
+	"github.com/spf13/cobra"
+)
+
+// pickFreeClone returns the free clone of a remote a new workspace focused on subdir
+// should get, or nil if none is free. Clones last used for the same subdir come
+// first, since their build caches are warm; otherwise the remote's clone_strategy decides.
+func pickFreeClone(cfg *config.Config, remoteName, subdir string) *config.Clone {
+	free := cfg.FreeClones(remoteName)
+	if len(free) == 0 {
+		return nil
+	}
+
+	if remote, err := cfg.GetRemote(remoteName); err == nil && remote.GetCloneStrategy() == config.CloneStrategyCleanest {
+		scores := make(map[*config.Clone]int, len(free))
+		for _, clone := range free {
+			scores[clone] = cloneDirtiness(clone.Path)
+		}
+		sort.SliceStable(free, func(i, j int) bool {
+			return scores[free[i]] < scores[free[j]]
+		})
+	}
+
+	// Subdirs are stored cleaned, as resolveSubdir leaves them
+	if subdir = filepath.Clean(subdir); subdir == "." {
+		subdir = ""
+	}
+	sort.SliceStable(free, func(i, j int) bool {
+		return warmFor(free[i], subdir) && !warmFor(free[j], subdir)
+	})
+	return free[0]
+}
+
+// warmFor reports whether a clone's build caches come from work on subdir
+func warmFor(clone *config.Clone, subdir string) bool {
+	return clone.BuildTool != "" && clone.LastSubdir == subdir
+}
+
+// cloneCacheHint describes a clone's build caches for menus, e.g.
+// "gradle cache 1.2 GB, last used for services/api", or "" if nothing is known.
+// The caches are measured now, so this is only called for clones being shown.
+func cloneCacheHint(clone *config.Clone) string {
+	if clone.BuildTool == "" {
+		return ""
+	}
+	hint := clone.BuildTool + " cache " + formatBytes(app.CloneCacheSize(clone))
+	if clone.LastSubdir != "" {
+		hint += ", last used for " + clone.LastSubdir
+	}
+	return hint
+}
+
+// formatBytes abbreviates a size, e.g. 512 B, 3.4 MB or 1.2 GB
+func formatBytes(n int64) string {
+	const unit = 1024
+	if n < unit {
+		return fmt.Sprintf("%d B", n)
+	}
+	div, exp := int64(unit), 0
+	for m := n / unit; m >= unit; m /= unit {
+		div *= unit
+		exp++
+	}
+	return fmt.Sprintf("%.1f %cB", float64(n)/float64(div), "KMGTPE"[exp])
+}
+
+// cloneDirtiness scores how much work reusing a clone takes: the commits it is
+// behind origin's default branch, with uncommitted changes or an unreadable
+// clone counting as worst
+func cloneDirtiness(path string) int {
+	if dirty, err := git.HasUncommittedChanges(path); err != nil || dirty {
+		return math.MaxInt
+	}
+	defaultBranch, err := git.GetDefaultBranch(path)
+	if err != nil {
+		return math.MaxInt - 1
+	}
+	behind, err := git.CommitsBehind(path, "origin/"+defaultBranch)
+	if err != nil {
+		return math.MaxInt - 1
+	}
+	return behind
+}
+
+// validCloneStrategies completes --clone-strategy
+func validCloneStrategies(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+	return config.CloneStrategies, cobra.ShellCompDirectiveNoFileComp
+}
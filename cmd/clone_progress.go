@@ -0,0 +1,90 @@
+package cmd
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/pmossman/claudew/internal/git"
+)
+
+// progressBarWidth is the number of cells in each clone's progress bar
+const progressBarWidth = 20
+
+// cloneProgress shows one line per clone running in parallel, redrawn in place as
+// git reports progress. When stdout is not a terminal nothing is drawn, and the
+// caller reports each clone once it finishes.
+type cloneProgress struct {
+	mu       sync.Mutex
+	out      io.Writer
+	live     bool
+	labels   []string
+	lines    []string
+	drawn    bool
+	lastDraw time.Time
+}
+
+// newCloneProgress starts a display with a line for each label, e.g. a clone path
+func newCloneProgress(labels []string) *cloneProgress {
+	p := &cloneProgress{
+		out:    os.Stdout,
+		live:   isTerminal(os.Stdout),
+		labels: labels,
+		lines:  make([]string, len(labels)),
+	}
+	for i := range p.lines {
+		p.lines[i] = paint(colorGray, "waiting...")
+	}
+	p.draw(true)
+	return p
+}
+
+// update records git's latest progress for clone i
+func (p *cloneProgress) update(i int, progress git.Progress) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	filled := progress.Percent * progressBarWidth / 100
+	line := fmt.Sprintf("[%s%s] %3d%% %s", strings.Repeat("#", filled), strings.Repeat("-", progressBarWidth-filled), progress.Percent, progress.Phase)
+	if progress.Speed != "" {
+		line += paint(colorGray, "  "+progress.Speed)
+	}
+	p.lines[i] = line
+	p.draw(false)
+}
+
+// finish marks clone i done, or failed with err
+func (p *cloneProgress) finish(i int, err error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if err != nil {
+		p.lines[i] = paint(colorRed, "✗ failed")
+	} else {
+		p.lines[i] = paint(colorGreen, "✓ cloned")
+	}
+	p.draw(true)
+}
+
+// draw rewrites every line, at most ten times a second unless force is set
+func (p *cloneProgress) draw(force bool) {
+	if !p.live || (!force && time.Since(p.lastDraw) < 100*time.Millisecond) {
+		return
+	}
+	p.lastDraw = time.Now()
+
+	width := 0
+	for _, label := range p.labels {
+		width = max(width, len(label))
+	}
+	var out strings.Builder
+	if p.drawn {
+		fmt.Fprintf(&out, "\033[%dA", len(p.lines))
+	}
+	for i, line := range p.lines {
+		fmt.Fprintf(&out, "\r\033[K  %-*s  %s\n", width, p.labels[i], line)
+	}
+	p.drawn = true
+	fmt.Fprint(p.out, out.String())
+}
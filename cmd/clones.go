@@ -1,20 +1,20 @@
 package cmd
 
 import (
-	"bytes"
 	"fmt"
 	"os"
-	"os/exec"
 	"sort"
-	"strings"
 
 	"github.com/pmossman/claudew/internal/config"
-	"github.com/pmossman/claudew/internal/git"
+	"github.com/pmossman/claudew/internal/menu"
+	"github.com/pmossman/claudew/internal/table"
 	"github.com/spf13/cobra"
 )
 
 var (
 	clonesInteractive bool
+	clonesWide        bool
+	clonesRefresh     bool
 )
 
 var clonesCmd = &cobra.Command{
@@ -77,30 +77,34 @@ Use -i/--interactive for fzf selection to cd into a clone.`,
 			return entries[i].path < entries[j].path
 		})
 
-		// Print header
-		fmt.Printf("%-40s %-12s %-15s %-10s %s\n", "CLONE PATH", "REMOTE", "BRANCH", "STATUS", "WORKSPACE")
-		fmt.Println("──────────────────────────────────────────────────────────────────────────────────────────────────────────────")
+		// Branches come from the cache unless it's stale or --refresh is given
+		var clones []*config.Clone
+		for _, entry := range entries {
+			clones = append(clones, entry.clone)
+		}
+		refreshCloneBranches(cfg, clones, clonesRefresh)
+
+		tbl := table.New(
+			table.Column{Header: "CLONE PATH", Max: 50, KeepEnd: true},
+			table.Column{Header: "REMOTE", Max: 20},
+			table.Column{Header: "BRANCH", Max: 30},
+			table.Column{Header: "STATUS"},
+			table.Column{Header: "WORKSPACE", Max: 30},
+		)
+		tbl.Wide = clonesWide
 
-		// Print clones
 		currentRemote := ""
 		for _, entry := range entries {
 			clone := entry.clone
 
-			// Print remote separator
+			// Separate remotes with a blank line
 			if clone.RemoteName != currentRemote {
 				if currentRemote != "" {
-					fmt.Println()
+					tbl.AddLine("")
 				}
 				currentRemote = clone.RemoteName
 			}
 
-			// Update branch info
-			branch, err := git.GetCurrentBranch(clone.Path)
-			if err == nil && branch != clone.CurrentBranch {
-				clone.CurrentBranch = branch
-				cfg.Save() // Save updated branch
-			}
-
 			// Format status
 			status := "free"
 			workspace := "-"
@@ -115,31 +119,18 @@ Use -i/--interactive for fzf selection to cd into a clone.`,
 				}
 			}
 
-			// Truncate path if too long
-			displayPath := clone.Path
-			if len(displayPath) > 40 {
-				displayPath = "..." + displayPath[len(displayPath)-37:]
+			tbl.AddRow(clone.Path, clone.RemoteName, clone.CurrentBranch, status, workspace)
+			if hint := cloneCacheHint(clone); hint != "" && clone.InUseBy == "" {
+				tbl.AddLine("  └─ " + paint(colorGray, hint))
 			}
-
-			fmt.Printf("%-40s %-12s %-15s %-10s %s\n",
-				displayPath,
-				clone.RemoteName,
-				clone.CurrentBranch,
-				status,
-				workspace,
-			)
 		}
+		tbl.Render(os.Stdout)
 
 		return nil
 	},
 }
 
 func interactiveCloneSelect(cfg *config.Config, remoteName string) error {
-	// Check if fzf is installed
-	if err := checkFzfInstalled(); err != nil {
-		return err
-	}
-
 	// Collect clones
 	type cloneEntry struct {
 		path  string
@@ -168,18 +159,18 @@ func interactiveCloneSelect(cfg *config.Config, remoteName string) error {
 		return entries[i].path < entries[j].path
 	})
 
+	// Branches come from the cache unless it's stale or --refresh is given
+	var clones []*config.Clone
+	for _, entry := range entries {
+		clones = append(clones, entry.clone)
+	}
+	refreshCloneBranches(cfg, clones, clonesRefresh)
+
 	// Build fzf input
 	var inputLines []string
 	for _, entry := range entries {
 		clone := entry.clone
 
-		// Update branch info
-		branch, err := git.GetCurrentBranch(clone.Path)
-		if err == nil && branch != clone.CurrentBranch {
-			clone.CurrentBranch = branch
-			cfg.Save()
-		}
-
 		// Format status
 		status := "free"
 		if clone.InUseBy != "" {
@@ -195,48 +186,25 @@ func interactiveCloneSelect(cfg *config.Config, remoteName string) error {
 			clone.CurrentBranch,
 			status,
 		)
-		inputLines = append(inputLines, line)
+		inputLines = append(inputLines, menu.Line(clone.Path, line))
 	}
 
-	input := strings.Join(inputLines, "\n")
-
-	// Run fzf
-	fzfCmd := exec.Command("fzf",
-		"--ansi",
-		"--no-sort",
-		"--height=50%",
-		"--header=Select a clone (Ctrl-C to cancel)",
-		"--prompt=Clone> ",
-	)
-
-	fzfCmd.Stdin = strings.NewReader(input)
-	fzfCmd.Stderr = os.Stderr
-
-	var outBuf bytes.Buffer
-	fzfCmd.Stdout = &outBuf
-
-	if err := fzfCmd.Run(); err != nil {
-		// User cancelled
-		if exitErr, ok := err.(*exec.ExitError); ok {
-			if exitErr.ExitCode() == 130 {
-				return nil
-			}
-		}
-		return fmt.Errorf("fzf failed: %w", err)
+	// Run menu
+	selected, err := runMenu(inputLines, applyFzfSettings(menuOptions{
+		header:  "Select a clone (Ctrl-C to cancel)",
+		prompt:  "Clone> ",
+		height:  "50%",
+		noSort:  true,
+		withIDs: true,
+	}, cfg.Settings))
+	if err != nil {
+		return err
 	}
-
-	// Extract selected path (first field)
-	selected := strings.TrimSpace(outBuf.String())
-	if selected == "" {
+	selectedPath := menu.ID(selected)
+	if selectedPath == "" {
 		return nil
 	}
 
-	parts := strings.Fields(selected)
-	if len(parts) == 0 {
-		return fmt.Errorf("invalid selection")
-	}
-	selectedPath := parts[0]
-
 	// Output CD marker for shell function to detect
 	// Use CD::: delimiter to handle paths with colons
 	fmt.Printf("CD:::%s\n", selectedPath)
@@ -246,4 +214,6 @@ func interactiveCloneSelect(cfg *config.Config, remoteName string) error {
 
 func init() {
 	clonesCmd.Flags().BoolVarP(&clonesInteractive, "interactive", "i", false, "Interactive clone selection with fzf")
+	clonesCmd.Flags().BoolVarP(&clonesWide, "wide", "w", false, "Don't truncate long paths or branch names")
+	clonesCmd.Flags().BoolVar(&clonesRefresh, "refresh", false, "Re-read every clone's branch from git instead of using the cache")
 }
@@ -0,0 +1,90 @@
+package cmd
+
+import (
+	"os"
+
+	"github.com/pmossman/claudew/internal/config"
+)
+
+// ANSI color codes for terminal output
+const (
+	colorReset   = "\033[0m"
+	colorGray    = "\033[90m"
+	colorCyan    = "\033[36m"
+	colorRed     = "\033[31m"
+	colorGreen   = "\033[32m"
+	colorYellow  = "\033[33m"
+	colorBlue    = "\033[34m"
+	colorMagenta = "\033[35m"
+)
+
+// workspaceColorCodes maps each of config.WorkspaceColors to its ANSI code
+var workspaceColorCodes = map[string]string{
+	"red":     colorRed,
+	"green":   colorGreen,
+	"yellow":  colorYellow,
+	"blue":    colorBlue,
+	"magenta": colorMagenta,
+	"cyan":    colorCyan,
+}
+
+// noColor is set by the global --no-color flag
+var noColor bool
+
+// colorsAllowed reports whether the user has allowed colored output
+// via --no-color or the NO_COLOR environment variable (https://no-color.org)
+func colorsAllowed() bool {
+	if noColor {
+		return false
+	}
+	_, set := os.LookupEnv("NO_COLOR")
+	return !set
+}
+
+// colorOutput reports whether colors should be written to stdout.
+// Colors are also disabled when stdout is not a terminal, so piped output stays clean.
+func colorOutput() bool {
+	return colorsAllowed() && isTerminal(os.Stdout)
+}
+
+// isTerminal reports whether f is attached to a terminal
+func isTerminal(f *os.File) bool {
+	info, err := f.Stat()
+	if err != nil {
+		return false
+	}
+	return info.Mode()&os.ModeCharDevice != 0
+}
+
+// colorize wraps s in the given color for fzf menus and previews.
+// fzf renders these itself, so only --no-color and NO_COLOR apply.
+func colorize(color, s string) string {
+	if !colorsAllowed() {
+		return s
+	}
+	return color + s + colorReset
+}
+
+// paint wraps s in the given color for text printed directly to stdout
+func paint(color, s string) string {
+	if !colorOutput() {
+		return s
+	}
+	return color + s + colorReset
+}
+
+// fzfColorArgs returns extra fzf arguments that disable fzf's own colors when requested
+func fzfColorArgs() []string {
+	if colorsAllowed() {
+		return nil
+	}
+	return []string{"--color=bw"}
+}
+
+// workspaceColor returns the ANSI code for a workspace's color
+func workspaceColor(ws *config.Workspace) string {
+	if code, ok := workspaceColorCodes[ws.DisplayColor()]; ok {
+		return code
+	}
+	return colorCyan
+}
@@ -0,0 +1,128 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/pmossman/claudew/internal/config"
+	"github.com/pmossman/claudew/internal/git"
+	"github.com/pmossman/claudew/internal/workspace"
+	"github.com/spf13/cobra"
+)
+
+var commitHookRemove bool
+
+var commitHookCmd = &cobra.Command{
+	Use:   "commit-hook <workspace-name>...",
+	Short: "Log commits in a workspace's clone to its context.md",
+	Long: `Installs a post-commit hook in each workspace's clone that appends the subject
+and hash of every commit to a "Recent commits" section of the workspace's
+context.md, so Claude's working memory keeps up with the repo's history even when
+Claude doesn't update it. Only the latest 15 commits are kept.
+
+The hook is added to any post-commit hook the clone already has, and is removed
+again with --remove or when the workspace is archived. Set "commit_hook": true to
+install it in every workspace's clone when its session starts.
+
+Example:
+  claudew commit-hook feature-auth
+  claudew commit-hook feature-auth --remove`,
+	Args: cobra.MinimumNArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		// Load config
+		cfg, err := config.Load()
+		if err != nil {
+			return fmt.Errorf("failed to load config: %w", err)
+		}
+
+		for _, name := range args {
+			ws, err := cfg.GetWorkspace(name)
+			if err != nil {
+				return err
+			}
+			repoPath := ws.GetRepoPath()
+			if repoPath == "" {
+				return fmt.Errorf("workspace '%s' has no clone assigned", name)
+			}
+
+			if commitHookRemove {
+				if err := git.RemovePostCommitHook(repoPath); err != nil {
+					return err
+				}
+				fmt.Printf("✓ Removed the commit hook from %s\n", repoPath)
+				continue
+			}
+
+			if err := installCommitHook(repoPath); err != nil {
+				return err
+			}
+			fmt.Printf("✓ Commits in %s are now logged to %s's context.md\n", repoPath, name)
+		}
+		return nil
+	},
+}
+
+var hookCmd = &cobra.Command{
+	Use:    "_hook <event>",
+	Short:  "Handle a git hook in a workspace's clone",
+	Hidden: true, // Invoked by the hooks "claudew commit-hook" installs, not by users
+	Long: `Run by git hooks from the root of a clone. "commit" appends the commit just made
+to the context.md of the workspace using the clone; clones no workspace uses are
+ignored.`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if args[0] != "commit" {
+			return fmt.Errorf("unknown hook event '%s'", args[0])
+		}
+
+		repoPath, err := os.Getwd()
+		if err != nil {
+			return fmt.Errorf("failed to get current directory: %w", err)
+		}
+
+		cfg, err := config.Load()
+		if err != nil {
+			return fmt.Errorf("failed to load config: %w", err)
+		}
+		users := cfg.WorkspacesUsingRepo(repoPath, "")
+		if len(users) == 0 {
+			return nil
+		}
+
+		hash, subject, err := git.LastCommit(repoPath)
+		if err != nil {
+			return err
+		}
+		wsMgr := workspace.NewManagerForConfig(cfg)
+		return wsMgr.AppendCommit(users[0], hash, subject, time.Now())
+	},
+}
+
+// installCommitHook installs the post-commit hook that runs "claudew _hook commit"
+// in a repo, pointing it at this executable
+func installCommitHook(repoPath string) error {
+	self, err := os.Executable()
+	if err != nil {
+		return fmt.Errorf("failed to get executable path: %w", err)
+	}
+	return git.InstallPostCommitHook(repoPath, escapeShellArg(self)+" _hook commit")
+}
+
+// ensureCommitHook installs the commit hook in a workspace's clone when the commit_hook
+// setting asks for it, warning instead of failing
+func ensureCommitHook(cfg *config.Config, ws *config.Workspace) {
+	if !cfg.Settings.CommitHook || ws.GetRepoPath() == "" {
+		return
+	}
+	if err := installCommitHook(ws.GetRepoPath()); err != nil {
+		fmt.Printf("Warning: failed to install commit hook: %v\n", err)
+	}
+}
+
+func init() {
+	rootCmd.AddCommand(commitHookCmd)
+	rootCmd.AddCommand(hookCmd)
+	commitHookCmd.ValidArgsFunction = validWorkspaceNamesExcludeArchived
+	commitHookCmd.Flags().BoolVar(&commitHookRemove, "remove", false, "Remove the hook instead")
+}
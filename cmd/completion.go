@@ -1,10 +1,43 @@
 package cmd
 
 import (
+	"fmt"
+
 	"github.com/pmossman/claudew/internal/config"
 	"github.com/spf13/cobra"
 )
 
+var completionCmd = &cobra.Command{
+	Use:   "completion <bash|zsh>",
+	Short: "Print the shell completion script",
+	Long: `Prints the completion script for bash or zsh to stdout, for package managers and
+dotfiles that install completion themselves. install-shell sets up completion
+(and the claudew shell function) without this.
+
+Example:
+  claudew completion zsh > "${fpath[1]}/_claudew"
+  claudew completion bash > /usr/local/etc/bash_completion.d/claudew
+
+See also: claudew install-shell --print-integration`,
+	Args:      cobra.ExactArgs(1),
+	ValidArgs: []string{"bash", "zsh"},
+	RunE: func(cmd *cobra.Command, args []string) error {
+		out := cmd.OutOrStdout()
+		switch args[0] {
+		case "bash":
+			return rootCmd.GenBashCompletion(out)
+		case "zsh":
+			return rootCmd.GenZshCompletion(out)
+		default:
+			return fmt.Errorf("unsupported shell: %s (only bash and zsh supported)", args[0])
+		}
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(completionCmd)
+}
+
 // validWorkspaceNames returns a list of valid workspace names for completion
 func validWorkspaceNames(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
 	// Load config
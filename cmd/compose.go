@@ -0,0 +1,119 @@
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/pmossman/claudew/internal/clipboard"
+	"github.com/pmossman/claudew/internal/compose"
+	"github.com/pmossman/claudew/internal/config"
+	"github.com/pmossman/claudew/internal/git"
+	"github.com/pmossman/claudew/internal/session"
+	"github.com/pmossman/claudew/internal/workspace"
+	"github.com/spf13/cobra"
+)
+
+var (
+	composeDecisions int
+	composeCopy      bool
+	composeSend      bool
+)
+
+var composeCmd = &cobra.Command{
+	Use:   "compose <name>",
+	Short: "Compose a restart prompt from a workspace's notes and git state",
+	Long: `Assembles a single prompt for restarting Claude in a workspace from its
+continuation.md, the most recent decisions, the current branch and the list of
+uncommitted files.
+
+The prompt is printed. Use --copy to copy it to the clipboard, or --send to paste
+it into the workspace's running Claude session and submit it.
+
+Example:
+  claudew compose auth-refactor                # Show the prompt
+  claudew compose auth-refactor --copy         # Copy it to the clipboard
+  claudew compose auth-refactor --send -n 10   # Send it with the last 10 decisions`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		name := args[0]
+
+		// Load config
+		cfg, err := config.Load()
+		if err != nil {
+			return fmt.Errorf("failed to load config: %w", err)
+		}
+
+		ws, err := cfg.GetWorkspace(name)
+		if err != nil {
+			return err
+		}
+
+		wsMgr := workspace.NewManagerForConfig(cfg)
+		prompt := compose.Build(composeInput(wsMgr, name, ws, composeDecisions))
+
+		if composeSend {
+			sessionMgr := session.NewManagerForConfig(cfg)
+			sessionName := sessionMgr.GetSessionName(name)
+			exists, err := sessionMgr.Exists(sessionName)
+			if err != nil {
+				return fmt.Errorf("failed to check session: %w", err)
+			}
+			if !exists {
+				return fmt.Errorf("workspace '%s' has no running session (start it with: claudew start %s)", name, name)
+			}
+			if err := sessionMgr.Paste(sessionName, prompt); err != nil {
+				return err
+			}
+			if err := sessionMgr.SendKeysLiteral(sessionName, "Enter"); err != nil {
+				return fmt.Errorf("failed to submit prompt: %w", err)
+			}
+			fmt.Printf("✓ Sent restart prompt to session %s\n", sessionName)
+			return nil
+		}
+
+		fmt.Print(prompt)
+
+		if composeCopy {
+			fmt.Println()
+			method, err := clipboard.Copy(prompt, cfg.Settings.ClipboardCommand)
+			if err != nil {
+				return fmt.Errorf("failed to copy prompt: %w", err)
+			}
+			if method == clipboard.MethodOSC52 {
+				fmt.Println("✓ Prompt sent to terminal clipboard (OSC52)")
+			} else {
+				fmt.Println("✓ Prompt copied to clipboard")
+			}
+		}
+		return nil
+	},
+}
+
+// composeInput gathers the notes and git state a restart prompt is built from
+func composeInput(wsMgr *workspace.Manager, name string, ws *config.Workspace, decisions int) compose.Input {
+	in := compose.Input{
+		Workspace:    name,
+		Continuation: wsMgr.GetContinuation(name),
+		Decisions:    compose.LastDecisions(wsMgr.ReadDecisions(name), decisions),
+	}
+
+	repoPath := ws.GetRepoPath()
+	if repoPath == "" {
+		return in
+	}
+	if branch, err := git.GetCurrentBranch(repoPath); err == nil {
+		in.Branch = branch
+	}
+	if files, err := git.UncommittedFiles(repoPath); err == nil {
+		in.Uncommitted = files
+	}
+	return in
+}
+
+func init() {
+	rootCmd.AddCommand(composeCmd)
+	composeCmd.ValidArgsFunction = validWorkspaceNamesExcludeArchived
+	composeCmd.Flags().IntVarP(&composeDecisions, "decisions", "n", 5, "Number of recent decisions to include (0 for all)")
+	composeCmd.Flags().BoolVarP(&composeCopy, "copy", "c", false, "Copy the prompt to the clipboard")
+	composeCmd.Flags().BoolVar(&composeSend, "send", false, "Paste the prompt into the running Claude session and submit it")
+	composeCmd.MarkFlagsMutuallyExclusive("copy", "send")
+}
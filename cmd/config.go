@@ -0,0 +1,110 @@
+package cmd
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/pmossman/claudew/internal/config"
+	"github.com/spf13/cobra"
+)
+
+var configCmd = &cobra.Command{
+	Use:   "config",
+	Short: "Manage the claudew config file",
+}
+
+var configRestoreCmd = &cobra.Command{
+	Use:   "restore [backup]",
+	Short: "Roll the config back to a timestamped backup",
+	Long: `Replaces the config with one of the timestamped backups (config.json.bak-<time>)
+that are kept next to it. A backup is taken at most once an hour when the config is
+saved, and always when a save drops workspaces; config_backups sets how many are kept
+(default 10, -1 disables them).
+
+Without an argument, lists the backups and asks which one to restore. The backup can
+also be given by its number in that list (1 is the newest) or its file name.
+The current config is backed up first, so a restore can be undone the same way.
+
+Example:
+  claudew config restore     # Pick a backup interactively
+  claudew config restore 1   # Restore the newest backup`,
+	Args: cobra.MaximumNArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		backups, err := config.ListBackups()
+		if err != nil {
+			return err
+		}
+		if len(backups) == 0 {
+			fmt.Println("No config backups found.")
+			return nil
+		}
+
+		var choice string
+		if len(args) == 1 {
+			choice = args[0]
+		} else {
+			fmt.Println("Config backups (newest first):")
+			for i, backup := range backups {
+				fmt.Printf("  %2d. %s  %s (%s)\n", i+1, backup.Time.Local().Format("2006-01-02 15:04:05"), formatBackupWorkspaces(backup), formatTimeAgo(backup.Time))
+			}
+			fmt.Println()
+			fmt.Printf("Restore which backup? [1-%d, Enter to cancel]: ", len(backups))
+			reader := bufio.NewReader(os.Stdin)
+			response, _ := reader.ReadString('\n')
+			choice = strings.TrimSpace(response)
+			if choice == "" {
+				fmt.Println("Cancelled.")
+				return nil
+			}
+		}
+
+		backup, err := findBackup(backups, choice)
+		if err != nil {
+			return err
+		}
+
+		saved, err := config.RestoreBackup(backup.Path)
+		if err != nil {
+			return err
+		}
+
+		fmt.Printf("✓ Restored config from %s (%s)\n", filepath.Base(backup.Path), formatBackupWorkspaces(backup))
+		if saved != "" {
+			fmt.Printf("  Previous config saved as %s\n", filepath.Base(saved))
+		}
+		return nil
+	},
+}
+
+// findBackup resolves a backup by its 1-based position in the list or its file name
+func findBackup(backups []config.Backup, choice string) (config.Backup, error) {
+	if n, err := strconv.Atoi(choice); err == nil {
+		if n < 1 || n > len(backups) {
+			return config.Backup{}, fmt.Errorf("no backup %d (there are %d)", n, len(backups))
+		}
+		return backups[n-1], nil
+	}
+	for _, backup := range backups {
+		if filepath.Base(backup.Path) == filepath.Base(choice) {
+			return backup, nil
+		}
+	}
+	return config.Backup{}, fmt.Errorf("backup '%s' not found", choice)
+}
+
+// formatBackupWorkspaces describes how many workspaces a backup holds
+func formatBackupWorkspaces(backup config.Backup) string {
+	if backup.Workspaces < 0 {
+		return "unreadable"
+	}
+	return fmt.Sprintf("%d workspace(s)", backup.Workspaces)
+}
+
+func init() {
+	rootCmd.AddCommand(configCmd)
+	configCmd.AddCommand(configRestoreCmd)
+}
@@ -0,0 +1,59 @@
+package cmd
+
+import (
+	"strings"
+
+	"github.com/pmossman/claudew/internal/config"
+	"github.com/pmossman/claudew/internal/container"
+	"github.com/pmossman/claudew/internal/execx"
+	"github.com/pmossman/claudew/internal/workspace"
+)
+
+// workspaceContainerSpec builds the container spec for a workspace. The clone and the
+// workspace directory are mounted at their host paths so paths in CLAUDE.md still resolve.
+func workspaceContainerSpec(wsMgr *workspace.Manager, name string, ws *config.Workspace) container.Spec {
+	return container.Spec{
+		Image:   ws.Container.Image,
+		Network: ws.Container.Network,
+		Mounts:  []string{ws.GetRepoPath(), wsMgr.GetPath(name)},
+		WorkDir: ws.GetWorkDir(),
+		Ports:   ws.Ports,
+		Args:    ws.Container.Args,
+	}
+}
+
+// ensureWorkspaceContainer starts the workspace's container, if it runs in one
+func ensureWorkspaceContainer(wsMgr *workspace.Manager, name string, ws *config.Workspace) error {
+	if ws.Container == nil {
+		return nil
+	}
+
+	containerMgr := container.NewManager()
+	if err := containerMgr.CheckDockerInstalled(); err != nil {
+		return err
+	}
+	return containerMgr.Ensure(containerMgr.GetContainerName(name), workspaceContainerSpec(wsMgr, name, ws))
+}
+
+// containerizeCommand wraps a shell command so it runs inside the workspace's container
+func containerizeCommand(wsMgr *workspace.Manager, name string, ws *config.Workspace, command string) string {
+	env, err := workspaceEnv(wsMgr, name, ws)
+	if err != nil {
+		env = nil
+	}
+
+	containerMgr := container.NewManager()
+	args := container.ExecArgs(containerMgr.GetContainerName(name), ws.GetWorkDir(), env, []string{"sh", "-c", command})
+
+	quoted := []string{"docker"}
+	for _, arg := range args {
+		quoted = append(quoted, escapeShellArg(arg))
+	}
+	return strings.Join(quoted, " ")
+}
+
+// killContainerClaude terminates Claude inside the workspace's container
+func killContainerClaude(name string) {
+	containerName := container.NewManager().GetContainerName(name)
+	_ = execx.Command("docker", "exec", containerName, "pkill", "-TERM", "claude").Run()
+}
@@ -7,17 +7,29 @@ import (
 	"path/filepath"
 	"sort"
 	"strings"
+	"time"
 
+	"github.com/pmossman/claudew/internal/app"
 	"github.com/pmossman/claudew/internal/config"
+	"github.com/pmossman/claudew/internal/events"
 	"github.com/pmossman/claudew/internal/git"
-	"github.com/pmossman/claudew/internal/template"
+	"github.com/pmossman/claudew/internal/issue"
+	"github.com/pmossman/claudew/internal/jira"
 	"github.com/pmossman/claudew/internal/workspace"
 	"github.com/spf13/cobra"
 )
 
 var (
-	createSummary string
-	createRemote  string
+	createSummary     string
+	createRemote      string
+	createSubdir      string
+	createPermissions string
+	createImage       string
+	createNetwork     string
+	createFromIssue   string
+	createFromJira    string
+	createNoPrompt    bool
+	createDir         string
 )
 
 var createCmd = &cobra.Command{
@@ -30,9 +42,29 @@ Interactive mode (recommended):
 
 Direct mode:
   claudew create feature-auth --remote airbyte
+  claudew create feature-auth --remote airbyte --no-prompt  # For scripts: free clone, else a new one
 
 Legacy mode (without clone management):
-  claudew create feature-auth ~/dev/my-repo`,
+  claudew create feature-auth ~/dev/my-repo
+
+Monorepos: use --subdir to start sessions in a sub-path of the repo
+  claudew create billing-fix --remote mono --subdir services/billing
+
+Notes elsewhere: use --dir to keep the workspace directory (context.md, decisions.md,
+research/...) somewhere other than workspace_dir, e.g. inside the repo
+  claudew create billing-fix --remote mono --dir ~/notes/billing-fix
+
+Isolation: use --container-image to run Claude inside a Docker container
+  claudew create spike --remote airbyte --container-image claude-sandbox --container-network none
+
+From a GitHub issue: the title and body (fetched with gh) seed the summary and
+context.md, the name defaults to the issue number and title, and the issue URL is
+kept for linking the pull request
+  claudew create --from-issue airbytehq/airbyte#123 --remote airbyte
+
+From a Jira ticket: the same, with the description and acceptance criteria fetched
+from jira_base_url (authenticated with jira_email and jira_token or $JIRA_API_TOKEN)
+  claudew create --from-jira PROJ-123 --remote airbyte`,
 	Args: cobra.MaximumNArgs(2),
 	RunE: func(cmd *cobra.Command, args []string) error {
 		// Load config
@@ -45,21 +77,37 @@ Legacy mode (without clone management):
 		var absRepoPath string
 
 		// Interactive mode if no args provided
-		if len(args) == 0 && createRemote == "" {
+		if len(args) == 0 && createRemote == "" && createFromIssue == "" && createFromJira == "" {
 			return interactiveCreate(cfg)
 		}
 
-		// Get name from args
+		seed, err := fetchWorkspaceSeed(cfg)
+		if err != nil {
+			return err
+		}
+
+		// Get name from args, or from the issue
 		if len(args) > 0 {
 			name = args[0]
+		} else if seed != nil {
+			name = seed.name
 		} else {
 			return fmt.Errorf("workspace name required when using --remote")
 		}
 
+		if createPermissions != "" {
+			if _, err := cfg.GetPermissionPreset(createPermissions); err != nil {
+				return err
+			}
+		}
+		if createNetwork != "" && createImage == "" {
+			return fmt.Errorf("--container-network requires --container-image")
+		}
+
 		// Determine mode: remote-based or path-based
 		if createRemote != "" {
 			// Remote-based mode: find or create clone
-			absRepoPath, err = findOrCreateClone(cfg, name, createRemote)
+			absRepoPath, err = findOrCreateClone(cfg, name, createRemote, createSubdir)
 			if err != nil {
 				return err
 			}
@@ -83,85 +131,145 @@ Legacy mode (without clone management):
 			if _, err := os.Stat(absRepoPath); os.IsNotExist(err) {
 				return fmt.Errorf("repo path does not exist: %s", absRepoPath)
 			}
+
+			// Two Claude sessions in one checkout would trample each other's changes
+			if err := cfg.CheckRepoAvailable(absRepoPath, name); err != nil {
+				return fmt.Errorf("%w. Give each workspace its own clone, e.g.: claudew create %s --remote <remote>", err, name)
+			}
 		} else {
 			return fmt.Errorf("must specify either --remote or <repo-path>")
 		}
 
-		// Add workspace to config
-		if err := cfg.AddWorkspace(name, absRepoPath); err != nil {
+		subdir, err := resolveSubdir(absRepoPath, createSubdir)
+		if err != nil {
 			return err
 		}
-
-		// Set ClonePath for new format
-		ws, _ := cfg.GetWorkspace(name)
-		ws.ClonePath = absRepoPath
-
-		// If using remote-based mode, assign clone to workspace
-		if createRemote != "" {
-			if err := cfg.AssignCloneToWorkspace(absRepoPath, name); err != nil {
-				return err
-			}
-		}
-
-		// Create workspace directory structure
-		wsMgr := workspace.NewManager(cfg.Settings.WorkspaceDir)
-		if err := wsMgr.Create(name); err != nil {
+		dir, err := resolveWorkspaceDir(cfg, name, createDir)
+		if err != nil {
 			return err
 		}
 
-		// Write initial summary if provided
-		if createSummary != "" {
-			summaryPath := filepath.Join(wsMgr.GetPath(name), "summary.txt")
-			if err := os.WriteFile(summaryPath, []byte(createSummary), 0644); err != nil {
-				return fmt.Errorf("failed to write summary: %w", err)
+		req := app.CreateRequest{
+			Name:        name,
+			RepoPath:    absRepoPath,
+			Remote:      createRemote,
+			Subdir:      subdir,
+			Dir:         dir,
+			Permissions: createPermissions,
+			Summary:     createSummary,
+		}
+		if seed != nil {
+			req.Issue = seed.url
+			req.Context = seed.context
+			if req.Summary == "" {
+				req.Summary = seed.summary
 			}
 		}
-
-		// Generate CLAUDE.md in repo
-		workspaceDir := wsMgr.GetPath(name)
-		if err := template.GenerateClaudeMd(name, workspaceDir, absRepoPath); err != nil {
-			return err
+		if createImage != "" {
+			req.Container = &config.Container{Image: createImage, Network: createNetwork}
 		}
-
-		// Ensure .gitignore has .claude/
-		if err := template.EnsureGitignore(absRepoPath); err != nil {
+		svc := newService(cfg)
+		if _, err := svc.CreateWorkspace(req); err != nil {
 			return err
 		}
-
-		// Save config
-		if err := cfg.Save(); err != nil {
-			return fmt.Errorf("failed to save config: %w", err)
-		}
+		workspaceDir := svc.Workspaces.GetPath(name)
 
 		fmt.Printf("✓ Created workspace '%s'\n", name)
 		fmt.Printf("  Repository: %s\n", absRepoPath)
+		if subdir != "" {
+			fmt.Printf("  Subdir: %s\n", subdir)
+		}
 		if createRemote != "" {
 			fmt.Printf("  Remote: %s\n", createRemote)
 		}
+		if seed != nil {
+			fmt.Printf("  Issue: %s\n", seed.url)
+		}
 		fmt.Printf("  Workspace dir: %s\n", workspaceDir)
+		if rel, err := filepath.Rel(absRepoPath, workspaceDir); err == nil && !strings.HasPrefix(rel, "..") {
+			fmt.Printf("\nNote: the workspace dir is inside the repo; add %s to .gitignore or .git/info/exclude to keep the notes out of commits\n", filepath.ToSlash(rel)+"/")
+		}
 		fmt.Println("\nNext: claudew start", name)
 
 		return nil
 	},
 }
 
-// findOrCreateClone finds a free clone or prompts user to create/takeover
-func findOrCreateClone(cfg *config.Config, workspaceName, remoteName string) (string, error) {
+// workspaceSeed is what a GitHub issue or Jira ticket contributes to a new workspace
+type workspaceSeed struct {
+	name    string // default workspace name
+	summary string // initial summary.txt
+	context string // initial context.md
+	url     string // recorded for linking the pull request
+}
+
+// fetchWorkspaceSeed fetches the issue or ticket named by --from-issue or --from-jira, or returns nil
+func fetchWorkspaceSeed(cfg *config.Config) (*workspaceSeed, error) {
+	if createFromIssue != "" && createFromJira != "" {
+		return nil, fmt.Errorf("--from-issue and --from-jira cannot be combined")
+	}
+
+	if createFromIssue != "" {
+		ref, err := issue.ParseRef(createFromIssue)
+		if err != nil {
+			return nil, err
+		}
+		fmt.Printf("Fetching %s...\n", ref)
+		iss, err := issue.Fetch(ref)
+		if err != nil {
+			return nil, err
+		}
+		return &workspaceSeed{name: iss.Slug(), summary: iss.Title, context: iss.Context(), url: iss.URL}, nil
+	}
+
+	if createFromJira != "" {
+		key := strings.ToUpper(strings.TrimSpace(createFromJira))
+		if err := jira.ValidateKey(key); err != nil {
+			return nil, err
+		}
+		if cfg.Settings.JiraBaseURL == "" {
+			return nil, fmt.Errorf("--from-jira requires the jira_base_url setting")
+		}
+		token := cfg.Settings.JiraToken
+		if env := os.Getenv("JIRA_API_TOKEN"); env != "" {
+			token = env
+		}
+		fmt.Printf("Fetching %s...\n", key)
+		client := jira.NewClient(cfg.Settings.JiraBaseURL, cfg.Settings.JiraEmail, token, cfg.Settings.JiraAcceptanceField)
+		ticket, err := client.Fetch(key)
+		if err != nil {
+			return nil, err
+		}
+		return &workspaceSeed{name: ticket.Slug(), summary: ticket.Summary, context: ticket.Context(), url: ticket.URL}, nil
+	}
+
+	return nil, nil
+}
+
+// findOrCreateClone finds a free clone or prompts user to create/takeover.
+// subdir is the workspace's monorepo sub-path, if known, to prefer clones warm for it.
+func findOrCreateClone(cfg *config.Config, workspaceName, remoteName, subdir string) (string, error) {
 	// Get remote (validates it exists)
 	_, err := cfg.GetRemote(remoteName)
 	if err != nil {
 		return "", err
 	}
 
-	// Reopen /dev/tty for both reading and writing to ensure output is displayed immediately
-	tty, err := os.OpenFile("/dev/tty", os.O_RDWR, 0)
-	if err != nil {
-		return "", fmt.Errorf("failed to open terminal: %w", err)
-	}
+	// Prompt on the terminal even after fzf or when stdout is captured
+	tty := openTerminal()
 	defer tty.Close()
 
-	// Try to find a free clone
-	freeClone := cfg.FindFreeClone(remoteName)
+	// Try to find a free clone, by the remote's clone strategy
+	freeClone := pickFreeClone(cfg, remoteName, subdir)
+
+	// Without prompting, never take over another workspace's clone
+	if createNoPrompt {
+		if freeClone != nil {
+			fmt.Fprintf(tty, "Using free clone: %s\n", freeClone.Path)
+			return freeClone.Path, nil
+		}
+		return createNewClone(cfg, remoteName)
+	}
 
 	// Check for idle clones
 	idleClones := cfg.FindIdleClones(remoteName)
@@ -172,7 +280,11 @@ func findOrCreateClone(cfg *config.Config, workspaceName, remoteName string) (st
 		fmt.Fprintf(tty, "Found free clone: %s\n", freeClone.Path)
 		fmt.Fprintln(tty)
 		fmt.Fprintln(tty, "Options:")
-		fmt.Fprintf(tty, "  1. Use free clone: %s\n", freeClone.Path)
+		if hint := cloneCacheHint(freeClone); hint != "" {
+			fmt.Fprintf(tty, "  1. Use free clone: %s (%s)\n", freeClone.Path, hint)
+		} else {
+			fmt.Fprintf(tty, "  1. Use free clone: %s\n", freeClone.Path)
+		}
 		fmt.Fprintln(tty, "  2. Create a new clone")
 
 		optionOffset := 3
@@ -229,6 +341,7 @@ func findOrCreateClone(cfg *config.Config, workspaceName, remoteName string) (st
 				if err := cfg.FreeClone(clone.Path); err != nil {
 					return "", err
 				}
+				emitEvent(cfg, events.CloneFreed, oldWorkspace, map[string]string{"clone_path": clone.Path})
 				fmt.Fprintf(tty, "Took over clone from workspace '%s'\n", oldWorkspace)
 				return clone.Path, nil
 			}
@@ -248,6 +361,7 @@ func findOrCreateClone(cfg *config.Config, workspaceName, remoteName string) (st
 				if err := cfg.FreeClone(clone.Path); err != nil {
 					return "", err
 				}
+				emitEvent(cfg, events.CloneFreed, oldWorkspace, map[string]string{"clone_path": clone.Path})
 				fmt.Fprintf(tty, "Took over clone from workspace '%s'\n", oldWorkspace)
 				return clone.Path, nil
 			}
@@ -263,20 +377,17 @@ func createNewClone(cfg *config.Config, remoteName string) (string, error) {
 		return "", err
 	}
 
-	// Reopen /dev/tty for writing
-	tty, err := os.OpenFile("/dev/tty", os.O_WRONLY, 0)
+	// Show progress on the terminal even after fzf or when stdout is captured
+	tty := openTerminal()
+	defer tty.Close()
+
+	// Get next clone path, named by the remote's naming pattern
+	clonePath, err := cfg.NextClonePath(remoteName, "")
 	if err != nil {
-		// Fallback to stdout if tty not available
-		tty = os.Stdout
-	} else {
-		defer tty.Close()
+		return "", err
 	}
 
-	// Get next clone number
-	cloneNum := cfg.GetNextCloneNumber(remoteName)
-	clonePath := filepath.Join(remote.CloneBaseDir, fmt.Sprintf("%d", cloneNum))
-
-	fmt.Fprintf(tty, "\nCreating clone %d...\n", cloneNum)
+	fmt.Fprintf(tty, "\nCreating clone %s...\n", filepath.Base(clonePath))
 	fmt.Fprintf(tty, "  Cloning from: %s\n", remote.URL)
 	fmt.Fprintf(tty, "  To: %s\n", clonePath)
 	fmt.Fprintln(tty)
@@ -298,7 +409,9 @@ func createNewClone(cfg *config.Config, remoteName string) (string, error) {
 	}
 
 	clone, _ := cfg.GetClone(clonePath)
-	clone.CurrentBranch = branch
+	clone.SetBranch(branch, time.Now())
+
+	emitEvent(cfg, events.CloneCreated, "", map[string]string{"clone_path": clonePath, "remote": remoteName})
 
 	fmt.Fprintf(tty, "✓ Created clone at %s\n\n", clonePath)
 	return clonePath, nil
@@ -306,11 +419,8 @@ func createNewClone(cfg *config.Config, remoteName string) (string, error) {
 
 // interactiveCreate prompts user for workspace details
 func interactiveCreate(cfg *config.Config) error {
-	// Reopen /dev/tty for both reading and writing to ensure we can interact with terminal after fzf
-	tty, err := os.OpenFile("/dev/tty", os.O_RDWR, 0)
-	if err != nil {
-		return fmt.Errorf("failed to open terminal: %w", err)
-	}
+	// Prompt on the terminal even after fzf or when stdout is captured
+	tty := openTerminal()
 	defer tty.Close()
 
 	reader := bufio.NewReader(tty)
@@ -400,61 +510,102 @@ func interactiveCreate(cfg *config.Config) error {
 	}
 
 	// Find or create clone
-	absRepoPath, err := findOrCreateClone(cfg, name, remoteName)
+	absRepoPath, err := findOrCreateClone(cfg, name, remoteName, "")
 	if err != nil {
 		return err
 	}
 
-	// Create workspace
-	if err := cfg.AddWorkspace(name, absRepoPath); err != nil {
+	// Optional monorepo sub-path
+	fmt.Fprintln(tty)
+	fmt.Fprint(tty, "Subdirectory to focus on (optional, Enter for repo root): ")
+	subdirInput, _ := reader.ReadString('\n')
+	subdir, err := resolveSubdir(absRepoPath, strings.TrimSpace(subdirInput))
+	if err != nil {
 		return err
 	}
 
-	ws, _ := cfg.GetWorkspace(name)
-	ws.ClonePath = absRepoPath
-
-	// Assign clone to workspace
-	if err := cfg.AssignCloneToWorkspace(absRepoPath, name); err != nil {
+	// Create workspace
+	svc := newService(cfg)
+	if _, err := svc.CreateWorkspace(app.CreateRequest{
+		Name:     name,
+		RepoPath: absRepoPath,
+		Remote:   remoteName,
+		Subdir:   subdir,
+		Summary:  summary,
+	}); err != nil {
 		return err
 	}
+	workspaceDir := svc.Workspaces.GetPath(name)
 
-	// Create workspace directory structure
-	wsMgr := workspace.NewManager(cfg.Settings.WorkspaceDir)
-	if err := wsMgr.Create(name); err != nil {
-		return err
+	fmt.Println()
+	fmt.Printf("✓ Created workspace '%s'\n", name)
+	fmt.Printf("  Repository: %s\n", absRepoPath)
+	if subdir != "" {
+		fmt.Printf("  Subdir: %s\n", subdir)
 	}
+	fmt.Printf("  Remote: %s\n", remoteName)
+	fmt.Printf("  Summary: %s\n", summary)
+	fmt.Printf("  Workspace dir: %s\n", workspaceDir)
+	fmt.Println("\nNext: claudew start", name)
 
-	// Write summary
-	summaryPath := filepath.Join(wsMgr.GetPath(name), "summary.txt")
-	if err := os.WriteFile(summaryPath, []byte(summary), 0644); err != nil {
-		return fmt.Errorf("failed to write summary: %w", err)
+	return nil
+}
+
+// resolveSubdir validates a workspace subdir and checks it exists in the repo
+func resolveSubdir(repoPath, subdir string) (string, error) {
+	if subdir == "" {
+		return "", nil
+	}
+	if err := config.ValidateSubdir(subdir); err != nil {
+		return "", err
 	}
 
-	// Generate CLAUDE.md in repo
-	workspaceDir := wsMgr.GetPath(name)
-	if err := template.GenerateClaudeMd(name, workspaceDir, absRepoPath); err != nil {
-		return err
+	subdir = filepath.Clean(subdir)
+	if subdir == "." {
+		return "", nil
 	}
 
-	// Ensure .gitignore has .claude/
-	if err := template.EnsureGitignore(absRepoPath); err != nil {
-		return err
+	info, err := os.Stat(filepath.Join(repoPath, subdir))
+	if err != nil || !info.IsDir() {
+		return "", fmt.Errorf("subdir does not exist in %s: %s", repoPath, subdir)
 	}
+	return subdir, nil
+}
 
-	// Save config
-	if err := cfg.Save(); err != nil {
-		return fmt.Errorf("failed to save config: %w", err)
+// resolveWorkspaceDir validates a --dir override for a new workspace and returns it
+// as an absolute path, or "" to keep the workspace under workspace_dir. The
+// directory must be new or empty, so nothing in it is overwritten, and not used by
+// another workspace.
+func resolveWorkspaceDir(cfg *config.Config, name, dir string) (string, error) {
+	if dir == "" {
+		return "", nil
 	}
 
-	fmt.Println()
-	fmt.Printf("✓ Created workspace '%s'\n", name)
-	fmt.Printf("  Repository: %s\n", absRepoPath)
-	fmt.Printf("  Remote: %s\n", remoteName)
-	fmt.Printf("  Summary: %s\n", summary)
-	fmt.Printf("  Workspace dir: %s\n", workspaceDir)
-	fmt.Println("\nNext: claudew start", name)
+	// Expand ~ in path
+	if strings.HasPrefix(dir, "~/") {
+		home, _ := os.UserHomeDir()
+		dir = filepath.Join(home, dir[2:])
+	}
+	absDir, err := filepath.Abs(dir)
+	if err != nil {
+		return "", fmt.Errorf("invalid workspace dir: %w", err)
+	}
 
-	return nil
+	entries, err := os.ReadDir(absDir)
+	if err != nil && !os.IsNotExist(err) {
+		return "", fmt.Errorf("invalid workspace dir: %w", err)
+	}
+	if len(entries) > 0 {
+		return "", fmt.Errorf("workspace dir %s is not empty", absDir)
+	}
+
+	wsMgr := workspace.NewManagerForConfig(cfg)
+	for other := range cfg.Workspaces {
+		if other != name && wsMgr.GetPath(other) == absDir {
+			return "", fmt.Errorf("workspace dir %s is already used by workspace '%s'", absDir, other)
+		}
+	}
+	return absDir, nil
 }
 
 // generateSummary creates a human-readable summary from a workspace name
@@ -477,4 +628,13 @@ func generateSummary(name string) string {
 func init() {
 	createCmd.Flags().StringVar(&createSummary, "summary", "", "Initial workspace summary (optional, Claude will update it)")
 	createCmd.Flags().StringVar(&createRemote, "remote", "", "Remote to use for clone management")
+	createCmd.Flags().StringVar(&createSubdir, "subdir", "", "Start sessions in this sub-path of the repo (for monorepos)")
+	createCmd.Flags().StringVar(&createDir, "dir", "", "Keep the workspace directory here instead of under workspace_dir, e.g. inside the repo")
+	createCmd.Flags().StringVar(&createPermissions, "permissions", "", "Permission preset for .claude/settings.local.json (overrides the remote's)")
+	createCmd.RegisterFlagCompletionFunc("permissions", validPermissionPresets)
+	createCmd.Flags().StringVar(&createImage, "container-image", "", "Run Claude inside a Docker container from this image")
+	createCmd.Flags().StringVar(&createNetwork, "container-network", "", "Docker network for the container (e.g. none)")
+	createCmd.Flags().StringVar(&createFromIssue, "from-issue", "", "Seed the workspace from a GitHub issue (owner/repo#123 or issue URL, requires gh)")
+	createCmd.Flags().StringVar(&createFromJira, "from-jira", "", "Seed the workspace from a Jira ticket (e.g. PROJ-123, requires jira_base_url)")
+	createCmd.Flags().BoolVar(&createNoPrompt, "no-prompt", false, "With --remote, use a free clone or create a new one instead of asking")
 }
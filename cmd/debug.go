@@ -0,0 +1,64 @@
+package cmd
+
+import (
+	"fmt"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/pmossman/claudew/internal/config"
+)
+
+// debugEnv enables the debug log like --debug. Set it to 1, or to a log file path.
+const debugEnv = "CLAUDEW_DEBUG"
+
+// debug is set by the global --debug flag
+var debug bool
+
+// debugLogPath returns where the debug log is written: the path in CLAUDEW_DEBUG,
+// or debug.log next to the config file. "" means debug logging is off.
+func debugLogPath() (string, error) {
+	value := os.Getenv(debugEnv)
+	switch strings.ToLower(value) {
+	case "", "0", "false":
+		if !debug {
+			return "", nil
+		}
+	case "1", "true":
+	default:
+		return value, nil
+	}
+
+	configPath, err := config.GetConfigPath()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(filepath.Dir(configPath), "debug.log"), nil
+}
+
+// setupDebugLog sends debug-level logs, including every external command run
+// through execx, to the debug log file
+func setupDebugLog(args []string) error {
+	path, err := debugLogPath()
+	if err != nil || path == "" {
+		return err
+	}
+
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return fmt.Errorf("failed to create debug log directory: %w", err)
+	}
+	f, err := os.OpenFile(path, os.O_CREATE|os.O_APPEND|os.O_WRONLY, 0644)
+	if err != nil {
+		return fmt.Errorf("failed to open debug log: %w", err)
+	}
+	// The file stays open until claudew exits
+	logger := slog.New(slog.NewTextHandler(f, &slog.HandlerOptions{Level: slog.LevelDebug}))
+	slog.SetDefault(logger.With("pid", os.Getpid()))
+
+	// Processes claudew starts itself, like fzf previews, log to the same file
+	os.Setenv(debugEnv, path)
+
+	slog.Debug("claudew", "version", Version, "args", args)
+	return nil
+}
@@ -0,0 +1,113 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/pmossman/claudew/internal/config"
+	"github.com/pmossman/claudew/internal/session"
+	"github.com/pmossman/claudew/internal/workspace"
+	"github.com/spf13/cobra"
+)
+
+// defaultDecisionKey is the tmux key, after the prefix, that captures a decision
+const defaultDecisionKey = "D"
+
+var (
+	decisionWorkspace  string
+	decisionSession    string
+	decisionTmuxBuffer bool
+)
+
+var decisionCmd = &cobra.Command{
+	Use:   "decision [text...]",
+	Short: "Append a timestamped decision to a workspace's decisions.md",
+	Long: `Records a decision or user correction in decisions.md, so Claude picks it up
+on its next read. The workspace is the one whose tmux session this runs in,
+unless --workspace is given.
+
+Inside a workspace session, prefix+D (the decision_key setting; "none" disables it)
+prompts for the text and runs this for you.
+
+Example:
+  claudew decision "Use Postgres, not SQLite"
+  claudew decision --workspace feature-auth "Keep the v1 API for mobile clients"`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		// Load config
+		cfg, err := config.Load()
+		if err != nil {
+			return fmt.Errorf("failed to load config: %w", err)
+		}
+
+		sessionMgr := session.NewManagerForConfig(cfg)
+		name := decisionWorkspace
+		if name == "" {
+			sessionName := decisionSession
+			if sessionName == "" {
+				sessionName = sessionMgr.CurrentSession()
+			}
+			name = sessionMgr.WorkspaceName(sessionName)
+			if name == "" {
+				return fmt.Errorf("not inside a workspace session; pass --workspace")
+			}
+		}
+		if _, err := cfg.GetWorkspace(name); err != nil {
+			return err
+		}
+
+		text := strings.Join(args, " ")
+		if decisionTmuxBuffer {
+			text, err = sessionMgr.TakeBuffer(session.DecisionBuffer)
+			if err != nil {
+				return err
+			}
+		}
+
+		wsMgr := workspace.NewManagerForConfig(cfg)
+		if err := wsMgr.AppendDecision(name, text, time.Now()); err != nil {
+			if decisionTmuxBuffer {
+				_ = sessionMgr.DisplayMessage(sessionMgr.GetSessionName(name), "claudew: "+err.Error())
+			}
+			return err
+		}
+
+		if decisionTmuxBuffer {
+			// Run from a key binding, where only the status line is visible
+			_ = sessionMgr.DisplayMessage(sessionMgr.GetSessionName(name), "✓ Decision saved to decisions.md")
+		} else {
+			fmt.Printf("✓ Decision saved to %s's decisions.md\n", name)
+		}
+		return nil
+	},
+}
+
+// bindDecisionKey installs the tmux key binding that prompts for a decision, unless
+// decision_key is "none"
+func bindDecisionKey(cfg *config.Config, sessionMgr *session.Manager) error {
+	key := cfg.Settings.DecisionKey
+	if key == "none" {
+		return nil
+	}
+	if key == "" {
+		key = defaultDecisionKey
+	}
+
+	self, err := os.Executable()
+	if err != nil {
+		return fmt.Errorf("failed to get executable path: %w", err)
+	}
+	command := fmt.Sprintf("%s decision --session '#{session_name}' --tmux-buffer", escapeShellArg(self))
+	return sessionMgr.BindDecisionKey(key, command)
+}
+
+func init() {
+	rootCmd.AddCommand(decisionCmd)
+	decisionCmd.Flags().StringVarP(&decisionWorkspace, "workspace", "w", "", "Workspace to record the decision in (default: the current session's)")
+	decisionCmd.Flags().StringVar(&decisionSession, "session", "", "tmux session whose workspace to record the decision in")
+	decisionCmd.Flags().BoolVar(&decisionTmuxBuffer, "tmux-buffer", false, "Read the decision from the key binding's tmux buffer")
+	decisionCmd.Flags().MarkHidden("session")
+	decisionCmd.Flags().MarkHidden("tmux-buffer")
+	decisionCmd.RegisterFlagCompletionFunc("workspace", validWorkspaceNamesExcludeArchived)
+}
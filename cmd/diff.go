@@ -0,0 +1,144 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/pmossman/claudew/internal/config"
+	"github.com/pmossman/claudew/internal/textdiff"
+	"github.com/pmossman/claudew/internal/workspace"
+	"github.com/spf13/cobra"
+)
+
+var (
+	diffSideBySide bool
+	diffWidth      int
+)
+
+// diffContextLines is how many unchanged lines surround each change in unified diffs
+const diffContextLines = 3
+
+var diffCmd = &cobra.Command{
+	Use:   "diff <workspace-a> <workspace-b>",
+	Short: "Show how two workspaces' notes differ",
+	Long: `Compares the summary, context, decisions and continuation of two workspaces.
+Useful after forking a workspace to see what diverged.
+
+Example:
+  claudew diff auth auth-v2        # Unified diff
+  claudew diff auth auth-v2 -y     # Side by side`,
+	Args: cobra.ExactArgs(2),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		nameA, nameB := args[0], args[1]
+
+		// Load config
+		cfg, err := config.Load()
+		if err != nil {
+			return fmt.Errorf("failed to load config: %w", err)
+		}
+
+		for _, name := range args {
+			if _, err := cfg.GetWorkspace(name); err != nil {
+				return err
+			}
+		}
+
+		wsMgr := workspace.NewManagerForConfig(cfg)
+		filesA := workspaceNoteFiles(wsMgr, nameA)
+		filesB := workspaceNoteFiles(wsMgr, nameB)
+
+		width := diffWidth
+		if width <= 0 {
+			width, _ = strconv.Atoi(os.Getenv("COLUMNS"))
+		}
+		if width <= 0 {
+			width = 160
+		}
+
+		changed := false
+		for i, file := range filesA {
+			a, b := file.content, filesB[i].content
+
+			var out string
+			if diffSideBySide {
+				out = textdiff.SideBySide(a, b, width)
+			} else {
+				out = textdiff.Unified(a, b, nameA+"/"+file.name, nameB+"/"+file.name, diffContextLines)
+			}
+			if out == "" {
+				continue
+			}
+
+			if changed {
+				fmt.Println()
+			}
+			changed = true
+			if diffSideBySide {
+				fmt.Println(paint(colorCyan, fmt.Sprintf("─── %s ───", file.name)))
+				fmt.Print(out)
+			} else {
+				fmt.Print(colorizeUnifiedDiff(out))
+			}
+		}
+
+		if !changed {
+			fmt.Printf("No differences between '%s' and '%s'\n", nameA, nameB)
+		}
+		return nil
+	},
+}
+
+// noteFile is one of a workspace's notes files with its contents
+type noteFile struct {
+	name    string
+	content string
+}
+
+// workspaceNoteFiles returns a workspace's summary, context, decisions and continuation
+func workspaceNoteFiles(wsMgr *workspace.Manager, name string) []noteFile {
+	summary := wsMgr.GetSummary(name)
+	if summary == "(no summary)" {
+		summary = ""
+	} else {
+		summary += "\n"
+	}
+	return []noteFile{
+		{"summary.txt", summary},
+		{"context.md", wsMgr.ReadContext(name)},
+		{"decisions.md", wsMgr.ReadDecisions(name)},
+		{"continuation.md", wsMgr.GetContinuation(name)},
+	}
+}
+
+// colorizeUnifiedDiff colors the headers, hunk markers and changed lines of a unified diff
+func colorizeUnifiedDiff(diff string) string {
+	var out strings.Builder
+	for _, line := range strings.SplitAfter(diff, "\n") {
+		text := strings.TrimSuffix(line, "\n")
+		switch {
+		case text == "":
+		case strings.HasPrefix(text, "---"), strings.HasPrefix(text, "+++"):
+			text = paint(colorGray, text)
+		case strings.HasPrefix(text, "@@"):
+			text = paint(colorCyan, text)
+		case strings.HasPrefix(text, "-"):
+			text = paint(colorRed, text)
+		case strings.HasPrefix(text, "+"):
+			text = paint(colorGreen, text)
+		}
+		out.WriteString(text)
+		if strings.HasSuffix(line, "\n") {
+			out.WriteString("\n")
+		}
+	}
+	return out.String()
+}
+
+func init() {
+	rootCmd.AddCommand(diffCmd)
+	diffCmd.ValidArgsFunction = validWorkspaceNamesExcludeArchived
+	diffCmd.Flags().BoolVarP(&diffSideBySide, "side-by-side", "y", false, "Show the notes in two columns")
+	diffCmd.Flags().IntVarP(&diffWidth, "width", "W", 0, "Total width for --side-by-side (default $COLUMNS or 160)")
+}
@@ -0,0 +1,111 @@
+package cmd
+
+import (
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/pmossman/claudew/internal/config"
+	"github.com/pmossman/claudew/internal/digest"
+	"github.com/pmossman/claudew/internal/git"
+	"github.com/pmossman/claudew/internal/session"
+	"github.com/pmossman/claudew/internal/workspace"
+	"github.com/spf13/cobra"
+)
+
+var digestSend bool
+
+var digestCmd = &cobra.Command{
+	Use:   "digest",
+	Short: "Summarize every workspace for standup prep",
+	Long: `Prints a digest of every non-archived workspace: its branch, when it was last
+active, its summary and the start of its continuation prompt.
+
+With --send, the digest is delivered instead of printed: POSTed as {"text": ...} to
+digest_webhook_url (a Slack incoming webhook works as is) and/or piped to
+digest_command via sh -c (e.g. mail -s "claudew digest" you@example.com).
+
+Example:
+  claudew digest          # Print the digest
+  claudew digest --send   # Deliver it, e.g. from a morning cron job`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		// Load config
+		cfg, err := config.Load()
+		if err != nil {
+			return fmt.Errorf("failed to load config: %w", err)
+		}
+
+		now := time.Now()
+		text := digest.Render(digestEntries(cfg, now), now)
+
+		if !digestSend {
+			fmt.Print(text)
+			return nil
+		}
+
+		sender := digest.NewSender(cfg.Settings.DigestWebhookURL, cfg.Settings.DigestCommand)
+		if !sender.Enabled() {
+			return fmt.Errorf("--send requires the digest_webhook_url or digest_command setting")
+		}
+		if err := sender.Send(text); err != nil {
+			return err
+		}
+		fmt.Println("✓ Digest sent")
+		return nil
+	},
+}
+
+// digestEntries collects the digest line of every non-archived workspace, in menu order
+func digestEntries(cfg *config.Config, now time.Time) []digest.Entry {
+	refreshLastActive(cfg)
+	wsMgr := workspace.NewManagerForConfig(cfg)
+	sessionMgr := session.NewManagerForConfig(cfg)
+
+	running := map[string]bool{}
+	if sessions, err := sessionMgr.ListWorkspaceSessions(); err == nil {
+		for _, name := range sessions {
+			running[name] = true
+		}
+	}
+
+	var names []string
+	for name, ws := range cfg.Workspaces {
+		if ws.Status != config.StatusArchived {
+			names = append(names, name)
+		}
+	}
+	sort.Slice(names, func(i, j int) bool {
+		return menuOrderLess(cfg.Workspaces[names[i]], cfg.Workspaces[names[j]])
+	})
+
+	entries := make([]digest.Entry, 0, len(names))
+	for _, name := range names {
+		ws := cfg.Workspaces[name]
+		entry := digest.Entry{
+			Name:         name,
+			LastActive:   formatTimeAgo(ws.LastActive),
+			Running:      running[sessionMgr.GetSessionName(name)],
+			State:        ws.State,
+			StateReason:  ws.StateReason,
+			BlockedUntil: ws.BlockedUntil,
+			ReminderDue:  ws.ReminderDue(now),
+			Continuation: wsMgr.GetContinuation(name),
+		}
+		if summary := wsMgr.GetSummary(name); summary != "(no summary)" {
+			entry.Summary = summary
+		}
+		if repoPath := ws.GetRepoPath(); repoPath != "" {
+			if branch, err := git.GetCurrentBranch(repoPath); err == nil {
+				entry.Branch = branch
+			}
+		}
+		entries = append(entries, entry)
+	}
+	return entries
+}
+
+func init() {
+	rootCmd.AddCommand(digestCmd)
+	digestCmd.Flags().BoolVar(&digestSend, "send", false, "Deliver the digest to digest_webhook_url / digest_command instead of printing it")
+}
@@ -0,0 +1,199 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+	"sort"
+	"strings"
+
+	"github.com/pmossman/claudew/internal/config"
+	"github.com/pmossman/claudew/internal/session"
+	"github.com/pmossman/claudew/internal/update"
+	"github.com/pmossman/claudew/internal/workspace"
+	"github.com/spf13/cobra"
+)
+
+var (
+	doctorFix bool
+)
+
+var doctorCmd = &cobra.Command{
+	Use:   "doctor",
+	Short: "Check your claudew setup for problems",
+	Long: `Checks dependencies, workspaces, clones and lock files, reporting anything
+that needs attention:
+
+- tmux and the configured claude command are installed (fzf is optional), and
+  claude is at least claude_min_version if set
+- Workspace repositories and clone directories exist
+- No two workspaces point at the same clone or repo, where their Claude sessions
+  would trample each other
+- Clones are not assigned to missing or archived workspaces
+- No stale lock files are left behind by dead processes
+
+With --fix, stale lock files are removed.
+
+Example:
+  claudew doctor        # Report problems
+  claudew doctor --fix  # Also remove stale locks`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		// Load config
+		cfg, err := config.Load()
+		if err != nil {
+			return fmt.Errorf("failed to load config: %w", err)
+		}
+
+		wsMgr := workspace.NewManagerForConfig(cfg)
+		sessionMgr := session.NewManagerForConfig(cfg)
+		problems := 0
+
+		report := func(ok bool, format string, a ...interface{}) {
+			if ok {
+				fmt.Printf("✓ "+format+"\n", a...)
+			} else {
+				fmt.Printf("✗ "+format+"\n", a...)
+				problems++
+			}
+		}
+
+		// Dependencies
+		fmt.Println("Dependencies:")
+		switch cfg.Settings.SessionMode {
+		case config.SessionModeWindowsTerminal:
+			_, err := exec.LookPath("wt.exe")
+			report(err == nil, "Windows Terminal (wt.exe)")
+		case config.SessionModeForeground:
+			fmt.Println("- tmux not needed (session_mode is foreground)")
+		default:
+			report(sessionMgr.CheckTmuxInstalled() == nil, "tmux")
+		}
+		if fzfInstalled() {
+			report(true, "fzf")
+		} else {
+			fmt.Println("- fzf not found (optional: menus fall back to a numbered list)")
+		}
+		claudeBin := claudeBinary(cfg)
+		claudePath, claudeFound := "", false
+		if claudeBin != "" {
+			claudePath, claudeFound = resolveClaudeBinary(claudeBin)
+		}
+		if claudeBin == "" {
+			report(false, "claude command is not configured")
+		} else if !claudeFound {
+			report(false, "claude command (%s) not found. %s", claudeBin, claudeInstallHint)
+		} else if minVersion := cfg.Settings.ClaudeMinVersion; minVersion != "" && claudePath != "" {
+			version, err := claudeVersion(claudePath)
+			switch {
+			case err != nil:
+				report(false, "claude command (%s): %v", claudeBin, err)
+			case update.CompareVersions(version, minVersion) < 0:
+				report(false, "claude command (%s) is %s, older than claude_min_version %s", claudeBin, version, minVersion)
+			default:
+				report(true, "claude command (%s %s)", claudeBin, version)
+			}
+		} else {
+			report(true, "claude command (%s)", claudeBin)
+		}
+
+		// Workspaces
+		fmt.Println()
+		fmt.Println("Workspaces:")
+		var names []string
+		for name, ws := range cfg.Workspaces {
+			if ws.Status != config.StatusArchived {
+				names = append(names, name)
+			}
+		}
+		sort.Strings(names)
+		wsProblems := problems
+		for _, name := range names {
+			ws := cfg.Workspaces[name]
+			if _, err := os.Stat(ws.GetWorkDir()); err != nil {
+				report(false, "%s: repository %s does not exist", name, ws.GetWorkDir())
+			}
+			if !wsMgr.Exists(name) {
+				report(false, "%s: workspace directory %s does not exist", name, wsMgr.GetPath(name))
+			}
+		}
+		shared := cfg.SharedRepos()
+		var sharedPaths []string
+		for path := range shared {
+			sharedPaths = append(sharedPaths, path)
+		}
+		sort.Strings(sharedPaths)
+		for _, path := range sharedPaths {
+			users := shared[path]
+			report(false, "%s: shared by workspaces %s; give all but one their own clone with: claudew assign-clone <name> <clone-path>", path, strings.Join(users, ", "))
+		}
+		if problems == wsProblems {
+			report(true, "%d workspace(s) OK", len(names))
+		}
+
+		// Clones
+		fmt.Println()
+		fmt.Println("Clones:")
+		var paths []string
+		for path := range cfg.Clones {
+			paths = append(paths, path)
+		}
+		sort.Strings(paths)
+		cloneProblems := problems
+		for _, path := range paths {
+			clone := cfg.Clones[path]
+			if _, err := os.Stat(path); err != nil {
+				report(false, "%s: directory does not exist", path)
+			}
+			if clone.InUseBy != "" {
+				ws, err := cfg.GetWorkspace(clone.InUseBy)
+				if err != nil {
+					report(false, "%s: assigned to missing workspace '%s'", path, clone.InUseBy)
+				} else if ws.Status == config.StatusArchived {
+					report(false, "%s: assigned to archived workspace '%s'", path, clone.InUseBy)
+				}
+			}
+		}
+		if problems == cloneProblems {
+			report(true, "%d clone(s) OK", len(paths))
+		}
+
+		// Locks
+		fmt.Println()
+		fmt.Println("Locks:")
+		lockProblems := problems
+		for _, name := range names {
+			reason := staleLockReason(wsMgr, sessionMgr, name)
+			if reason == "" {
+				continue
+			}
+			if doctorFix {
+				if reason, err := removeStaleLock(wsMgr, sessionMgr, name); err != nil {
+					report(false, "%s: failed to remove stale lock: %v", name, err)
+				} else if reason != "" {
+					fmt.Printf("🧹 %s: removed stale lock (%s)\n", name, reason)
+				}
+				continue
+			}
+			report(false, "%s: stale lock (%s)", name, reason)
+		}
+		if problems == lockProblems {
+			report(true, "no stale locks")
+		}
+
+		fmt.Println()
+		if problems > 0 {
+			if !doctorFix {
+				fmt.Println("Run 'claudew doctor --fix' to remove stale locks.")
+			}
+			return fmt.Errorf("found %d problem(s)", problems)
+		}
+		fmt.Println("✓ Everything looks good")
+		return nil
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(doctorCmd)
+	doctorCmd.Flags().BoolVar(&doctorFix, "fix", false, "Remove stale lock files")
+}
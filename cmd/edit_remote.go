@@ -0,0 +1,311 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+
+	"github.com/pmossman/claudew/internal/config"
+	"github.com/pmossman/claudew/internal/git"
+	"github.com/pmossman/claudew/internal/session"
+	"github.com/spf13/cobra"
+)
+
+var (
+	editRemoteURL           string
+	editRemoteCloneDir      string
+	editRemoteRenameTo      string
+	editRemoteUpdateOrigins bool
+	editRemoteMoveClones    bool
+	editRemotePermissions   string
+	editRemoteCloneStrategy string
+	editRemoteCloneNaming   string
+	editRemoteRenameClones  bool
+)
+
+var editRemoteCmd = &cobra.Command{
+	Use:   "edit-remote <name>",
+	Short: "Rename a remote or change its URL or clone directory",
+	Long: `Updates a registered remote.
+
+--rename-to renames the remote and updates every clone that belongs to it.
+--url changes the remote URL; add --update-origins to also rewrite the origin
+URL of every existing clone.
+--clone-dir changes where new clones are created; add --move-clones to move
+existing clones into the new directory (renumbering any that would collide).
+Clones whose workspace has a running session are never moved.
+--permissions sets the permission preset for workspaces created on this
+remote; pass "none" to clear it.
+--clone-strategy sets which free clone new workspaces get: lowest (the lowest
+clone number, default), lru (freed longest ago), mru (freed most recently, with
+the warmest build caches) or cleanest (no uncommitted changes, fewest commits
+behind origin).
+--clone-naming sets how new clones' directories are named: {n} is the clone
+number and {remote} the remote's name, e.g. "{remote}-{n}" for airbyte-1,
+airbyte-2 instead of 1, 2. Add --rename-clones to rename existing clones to the
+pattern too, keeping their numbers; --rename-clones on its own migrates clones
+(e.g. older numeric ones) to the current pattern.
+
+Example:
+  claudew edit-remote airbyte --rename-to airbyte-platform
+  claudew edit-remote airbyte --url git@github.com:org/new.git --update-origins
+  claudew edit-remote airbyte --clone-dir ~/src/airbyte --move-clones
+  claudew edit-remote airbyte --clone-strategy mru
+  claudew edit-remote airbyte --clone-naming "{remote}-{n}" --rename-clones`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		name := args[0]
+
+		if editRemoteURL == "" && editRemoteCloneDir == "" && editRemoteRenameTo == "" && editRemotePermissions == "" && editRemoteCloneStrategy == "" && editRemoteCloneNaming == "" && !editRemoteRenameClones {
+			return fmt.Errorf("nothing to change: specify --url, --clone-dir, --rename-to, --permissions, --clone-strategy, --clone-naming and/or --rename-clones")
+		}
+		if editRemoteCloneStrategy != "" {
+			if err := config.ValidateCloneStrategy(editRemoteCloneStrategy); err != nil {
+				return err
+			}
+		}
+		if editRemoteCloneNaming != "" {
+			if err := config.ValidateCloneNaming(editRemoteCloneNaming); err != nil {
+				return err
+			}
+		}
+		if editRemoteUpdateOrigins && editRemoteURL == "" {
+			return fmt.Errorf("--update-origins requires --url")
+		}
+		if editRemoteMoveClones && editRemoteCloneDir == "" {
+			return fmt.Errorf("--move-clones requires --clone-dir")
+		}
+
+		// Load config
+		cfg, err := config.Load()
+		if err != nil {
+			return fmt.Errorf("failed to load config: %w", err)
+		}
+
+		if remote, err := cfg.GetRemote(name); err != nil {
+			return err
+		} else if remote.Shared {
+			return fmt.Errorf("remote '%s' comes from the shared remotes file (%s); edit it there", name, cfg.Settings.SharedRemotesFile)
+		}
+
+		// Clone numbers are read before a rename or a new pattern changes how names parse
+		numbers := cloneNumbers(cfg, name)
+
+		if editRemoteRenameTo != "" && editRemoteRenameTo != name {
+			if err := cfg.RenameRemote(name, editRemoteRenameTo); err != nil {
+				return err
+			}
+			fmt.Printf("✓ Renamed remote '%s' to '%s'\n", name, editRemoteRenameTo)
+			name = editRemoteRenameTo
+		}
+
+		remote, err := cfg.GetRemote(name)
+		if err != nil {
+			return err
+		}
+
+		if editRemoteURL != "" {
+			remote.URL = editRemoteURL
+			fmt.Printf("✓ Updated URL to %s\n", editRemoteURL)
+
+			if editRemoteUpdateOrigins {
+				for _, clone := range cfg.GetClonesForRemote(name) {
+					if err := git.SetRemoteURL(clone.Path, editRemoteURL); err != nil {
+						fmt.Printf("  ✗ %s: %v\n", clone.Path, err)
+						continue
+					}
+					fmt.Printf("  ✓ Updated origin in %s\n", clone.Path)
+				}
+			}
+		}
+
+		if editRemoteCloneDir != "" {
+			cloneDir := editRemoteCloneDir
+
+			// Expand ~ in path
+			if len(cloneDir) >= 2 && cloneDir[:2] == "~/" {
+				home, _ := os.UserHomeDir()
+				cloneDir = filepath.Join(home, cloneDir[2:])
+			} else if cloneDir == "~" {
+				cloneDir, _ = os.UserHomeDir()
+			}
+
+			absCloneDir, err := filepath.Abs(cloneDir)
+			if err != nil {
+				return fmt.Errorf("invalid clone-dir path: %w", err)
+			}
+
+			if err := os.MkdirAll(absCloneDir, 0755); err != nil {
+				return fmt.Errorf("failed to create clone directory: %w", err)
+			}
+
+			remote.CloneBaseDir = absCloneDir
+			fmt.Printf("✓ Updated clone directory to %s\n", absCloneDir)
+
+			if editRemoteMoveClones {
+				moveRemoteClones(cfg, name, absCloneDir)
+			}
+		}
+
+		if editRemotePermissions == "none" {
+			remote.Permissions = ""
+			fmt.Println("✓ Cleared permission preset")
+		} else if editRemotePermissions != "" {
+			if _, err := cfg.GetPermissionPreset(editRemotePermissions); err != nil {
+				return err
+			}
+			remote.Permissions = editRemotePermissions
+			fmt.Printf("✓ Updated permission preset to %s\n", editRemotePermissions)
+		}
+
+		if editRemoteCloneStrategy != "" {
+			remote.CloneStrategy = editRemoteCloneStrategy
+			fmt.Printf("✓ Updated clone strategy to %s\n", editRemoteCloneStrategy)
+		}
+
+		if editRemoteCloneNaming != "" {
+			remote.CloneNaming = editRemoteCloneNaming
+			fmt.Printf("✓ Updated clone naming to %s\n", editRemoteCloneNaming)
+		}
+
+		if editRemoteRenameClones {
+			renameRemoteClones(cfg, remote, numbers)
+		}
+
+		// Save config
+		if err := cfg.Save(); err != nil {
+			return fmt.Errorf("failed to save config: %w", err)
+		}
+
+		return nil
+	},
+}
+
+// moveRemoteClones moves a remote's clones into a new base directory, renumbering on collision
+func moveRemoteClones(cfg *config.Config, remoteName, baseDir string) {
+	sessionMgr := session.NewManagerForConfig(cfg)
+
+	clones := cfg.GetClonesForRemote(remoteName)
+	sort.Slice(clones, func(i, j int) bool {
+		return clones[i].Path < clones[j].Path
+	})
+
+	for _, clone := range clones {
+		oldPath := clone.Path
+		if filepath.Dir(oldPath) == baseDir {
+			continue
+		}
+
+		// Moving a directory out from under a running session would break it
+		if clone.InUseBy != "" {
+			if exists, _ := sessionMgr.Exists(sessionMgr.GetSessionName(clone.InUseBy)); exists {
+				fmt.Printf("  ⚠ Skipping %s: workspace '%s' has a running session\n", oldPath, clone.InUseBy)
+				continue
+			}
+		}
+
+		newPath := filepath.Join(baseDir, filepath.Base(oldPath))
+		if _, err := os.Stat(newPath); err == nil || cfg.Clones[newPath] != nil {
+			next, err := cfg.NextClonePath(remoteName, baseDir)
+			if err != nil {
+				fmt.Printf("  ✗ Failed to move %s: %v\n", oldPath, err)
+				continue
+			}
+			newPath = next
+		}
+
+		if err := os.Rename(oldPath, newPath); err != nil {
+			fmt.Printf("  ✗ Failed to move %s: %v\n", oldPath, err)
+			continue
+		}
+		if err := cfg.MoveClone(oldPath, newPath); err != nil {
+			fmt.Printf("  ✗ Failed to update config for %s: %v\n", oldPath, err)
+			continue
+		}
+		if clone.InUseBy != "" {
+			newService(cfg).LinkRepo(clone.InUseBy)
+		}
+		fmt.Printf("  ✓ Moved %s → %s\n", oldPath, newPath)
+	}
+}
+
+// cloneNumbers returns the number of each of a remote's clones that has one in its name
+func cloneNumbers(cfg *config.Config, remoteName string) map[*config.Clone]int {
+	numbers := map[*config.Clone]int{}
+	remote, err := cfg.GetRemote(remoteName)
+	if err != nil {
+		return numbers
+	}
+	for _, clone := range cfg.GetClonesForRemote(remoteName) {
+		if n, ok := remote.CloneNumber(clone.Path); ok {
+			numbers[clone] = n
+		}
+	}
+	return numbers
+}
+
+// renameRemoteClones renames a remote's clones to its naming pattern, keeping each
+// clone's number and directory
+func renameRemoteClones(cfg *config.Config, remote *config.Remote, numbers map[*config.Clone]int) {
+	sessionMgr := session.NewManagerForConfig(cfg)
+
+	clones := cfg.GetClonesForRemote(remote.Name)
+	sort.Slice(clones, func(i, j int) bool {
+		return clones[i].Path < clones[j].Path
+	})
+
+	for _, clone := range clones {
+		oldPath := clone.Path
+		n, ok := numbers[clone]
+		if !ok {
+			fmt.Printf("  ⚠ Skipping %s: its name has no clone number\n", oldPath)
+			continue
+		}
+		newPath := filepath.Join(filepath.Dir(oldPath), remote.CloneDirName(n))
+		if newPath == oldPath {
+			continue
+		}
+
+		// Renaming a directory out from under a running session would break it
+		if clone.InUseBy != "" {
+			if exists, _ := sessionMgr.Exists(sessionMgr.GetSessionName(clone.InUseBy)); exists {
+				fmt.Printf("  ⚠ Skipping %s: workspace '%s' has a running session\n", oldPath, clone.InUseBy)
+				continue
+			}
+		}
+		if _, err := os.Stat(newPath); err == nil || cfg.Clones[newPath] != nil {
+			fmt.Printf("  ⚠ Skipping %s: %s already exists\n", oldPath, newPath)
+			continue
+		}
+
+		if err := os.Rename(oldPath, newPath); err != nil {
+			fmt.Printf("  ✗ Failed to rename %s: %v\n", oldPath, err)
+			continue
+		}
+		if err := cfg.MoveClone(oldPath, newPath); err != nil {
+			fmt.Printf("  ✗ Failed to update config for %s: %v\n", oldPath, err)
+			continue
+		}
+		if clone.InUseBy != "" {
+			newService(cfg).LinkRepo(clone.InUseBy)
+		}
+		fmt.Printf("  ✓ Renamed %s → %s\n", oldPath, newPath)
+	}
+}
+
+func init() {
+	rootCmd.AddCommand(editRemoteCmd)
+	editRemoteCmd.ValidArgsFunction = validRemoteNames
+	editRemoteCmd.Flags().StringVar(&editRemoteURL, "url", "", "New git URL for the remote")
+	editRemoteCmd.Flags().StringVar(&editRemoteCloneDir, "clone-dir", "", "New base directory for clones")
+	editRemoteCmd.Flags().StringVar(&editRemoteRenameTo, "rename-to", "", "New name for the remote")
+	editRemoteCmd.Flags().BoolVar(&editRemoteUpdateOrigins, "update-origins", false, "Also rewrite the origin URL in existing clones (with --url)")
+	editRemoteCmd.Flags().BoolVar(&editRemoteMoveClones, "move-clones", false, "Also move existing clones into the new directory (with --clone-dir)")
+	editRemoteCmd.Flags().StringVar(&editRemotePermissions, "permissions", "", "Permission preset for new workspaces on this remote (\"none\" to clear)")
+	editRemoteCmd.RegisterFlagCompletionFunc("permissions", validPermissionPresets)
+	editRemoteCmd.Flags().StringVar(&editRemoteCloneStrategy, "clone-strategy", "", "Which free clone new workspaces get: lowest, lru, mru or cleanest")
+	editRemoteCmd.RegisterFlagCompletionFunc("clone-strategy", validCloneStrategies)
+	editRemoteCmd.Flags().StringVar(&editRemoteCloneNaming, "clone-naming", "", "Clone directory name pattern with {n} and optionally {remote}, e.g. \"{remote}-{n}\"")
+	editRemoteCmd.Flags().BoolVar(&editRemoteRenameClones, "rename-clones", false, "Rename existing clones to the naming pattern, keeping their numbers")
+}
@@ -0,0 +1,13 @@
+package cmd
+
+import (
+	"github.com/pmossman/claudew/internal/app"
+	"github.com/pmossman/claudew/internal/config"
+)
+
+// emitEvent sends a lifecycle event through the workspace service, for commands that
+// don't otherwise use one. Delivery failures are reported as warnings and never fail
+// the calling command.
+func emitEvent(cfg *config.Config, eventType, workspaceName string, data map[string]string) {
+	app.New(cfg).Emit(eventType, workspaceName, data)
+}
@@ -0,0 +1,147 @@
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/pmossman/claudew/internal/config"
+	"github.com/pmossman/claudew/internal/container"
+	"github.com/pmossman/claudew/internal/execx"
+	"github.com/pmossman/claudew/internal/session"
+	"github.com/pmossman/claudew/internal/workspace"
+	"github.com/spf13/cobra"
+)
+
+var execWindow string
+
+var execCmd = &cobra.Command{
+	Use:   "exec <workspace> -- <command> [args...]",
+	Short: "Run a command in a workspace's clone",
+	Long: `Runs a command in a workspace's clone directory (its sub-path, for monorepo
+workspaces) with the workspace environment exported, streaming its output.
+claudew exits with the command's exit status. For a workspace with a container, the
+command runs inside the container, which is started first if needed.
+
+With --window, the command is typed into a new window of the workspace's running
+tmux session instead, so it keeps running after this terminal closes; its output
+is then only visible in that window.
+
+Example:
+  claudew exec feature-auth -- go test ./...
+  claudew exec feature-auth -- git log --oneline -5
+  claudew exec feature-auth --window server -- npm run dev`,
+	Args: func(cmd *cobra.Command, args []string) error {
+		if cmd.ArgsLenAtDash() != 1 || len(args) < 2 {
+			return fmt.Errorf("usage: claudew exec <workspace> -- <command> [args...]")
+		}
+		return nil
+	},
+	RunE: func(cmd *cobra.Command, args []string) error {
+		name, command := args[0], args[1:]
+
+		// Load config
+		cfg, err := config.Load()
+		if err != nil {
+			return fmt.Errorf("failed to load config: %w", err)
+		}
+
+		ws, err := cfg.GetWorkspace(name)
+		if err != nil {
+			return err
+		}
+		if ws.GetRepoPath() == "" {
+			return fmt.Errorf("workspace '%s' has no clone assigned. Assign one with: claudew assign-clone %s <clone-path>", name, name)
+		}
+
+		if execWindow != "" {
+			return execInWindow(cfg, name, ws, execWindow, command)
+		}
+
+		wsMgr := workspace.NewManagerForConfig(cfg)
+		env, err := workspaceEnv(wsMgr, name, ws)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Warning: %v\n", err)
+		}
+
+		var run *execx.Cmd
+		if ws.Container != nil {
+			if err := ensureWorkspaceContainer(wsMgr, name, ws); err != nil {
+				return err
+			}
+			run = execx.Command("docker", containerExecArgs(name, ws, env, command)...)
+		} else {
+			run = execx.Command(command[0], command[1:]...)
+			run.Dir = ws.GetWorkDir()
+			run.Env = os.Environ()
+			for key, value := range env {
+				run.Env = append(run.Env, key+"="+value)
+			}
+		}
+		run.Stdin = os.Stdin
+		run.Stdout = os.Stdout
+		run.Stderr = os.Stderr
+
+		if err := run.Run(); err != nil {
+			var exitErr *execx.ExitError
+			if errors.As(err, &exitErr) {
+				// Pass the status through, like the command had been run directly
+				cmd.SilenceErrors = true
+				cmd.SilenceUsage = true
+				return &ExitCodeError{Code: exitErr.ExitCode()}
+			}
+			return fmt.Errorf("failed to run %s: %w", command[0], err)
+		}
+		return nil
+	},
+}
+
+// containerExecArgs returns the docker arguments that run command in the workspace's
+// container, with a terminal only when this one has one
+func containerExecArgs(name string, ws *config.Workspace, env map[string]string, command []string) []string {
+	containerName := container.NewManager().GetContainerName(name)
+	if isTerminal(os.Stdin) && isTerminal(os.Stdout) {
+		return container.ExecArgs(containerName, ws.GetWorkDir(), env, command)
+	}
+	return container.PipeExecArgs(containerName, ws.GetWorkDir(), env, command)
+}
+
+// execInWindow types a command into a new window of the workspace's running session
+func execInWindow(cfg *config.Config, name string, ws *config.Workspace, window string, command []string) error {
+	sessionMgr := session.NewManagerForConfig(cfg)
+	sessionName := sessionMgr.GetSessionName(name)
+	exists, err := sessionMgr.Exists(sessionName)
+	if err != nil {
+		return fmt.Errorf("failed to check session: %w", err)
+	}
+	if !exists {
+		return fmt.Errorf("workspace '%s' has no running session. Start it first with: claudew start %s", name, name)
+	}
+
+	if err := sessionMgr.NewWindow(sessionName, window, ws.GetWorkDir()); err != nil {
+		return err
+	}
+
+	quoted := make([]string, len(command))
+	for i, arg := range command {
+		quoted[i] = escapeShellArg(arg)
+	}
+	line := strings.Join(quoted, " ")
+	if ws.Container != nil {
+		line = containerizeCommand(workspace.NewManagerForConfig(cfg), name, ws, line)
+	}
+	if err := sessionMgr.SendKeysWhenReady(sessionName+":"+window, line, shellReadyTimeout); err != nil {
+		return fmt.Errorf("failed to run command in window: %w", err)
+	}
+
+	fmt.Printf("✓ Running in window '%s' of '%s'\n", window, name)
+	fmt.Printf("  Switch to it with Ctrl-b w, or: tmux select-window -t %s:%s\n", sessionName, window)
+	return nil
+}
+
+func init() {
+	rootCmd.AddCommand(execCmd)
+	execCmd.ValidArgsFunction = validWorkspaceNamesExcludeArchived
+	execCmd.Flags().StringVar(&execWindow, "window", "", "Run the command in a new window with this name in the workspace's tmux session")
+}
@@ -56,10 +56,11 @@ Useful when branching work to a new feature from an existing workspace.`,
 		}
 
 		// Clone workspace directory
-		wsMgr := workspace.NewManager(cfg.Settings.WorkspaceDir)
+		wsMgr := workspace.NewManagerForConfig(cfg)
 		if err := wsMgr.Clone(fromName, toName); err != nil {
 			return err
 		}
+		newService(cfg).LinkRepo(toName)
 
 		// Generate CLAUDE.md in new repo
 		workspaceDir := wsMgr.GetPath(toName)
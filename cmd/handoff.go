@@ -0,0 +1,131 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+
+	"github.com/pmossman/claudew/internal/config"
+	"github.com/pmossman/claudew/internal/execx"
+	"github.com/pmossman/claudew/internal/git"
+	"github.com/pmossman/claudew/internal/handoff"
+	"github.com/pmossman/claudew/internal/workspace"
+	"github.com/spf13/cobra"
+)
+
+var (
+	handoffOutput     string
+	handoffNoResearch bool
+)
+
+var handoffCmd = &cobra.Command{
+	Use:   "handoff <name>",
+	Short: "Export a sanitized bundle for handing a workstream to a teammate",
+	Long: `Writes a single markdown document with the workspace's summary, branch, pull
+request link, continuation, context, decisions and research notes.
+
+Tokens, keys and password assignments are replaced with [REDACTED]. Add your own
+regexes with the "redact_patterns" setting. Review the bundle before sharing it:
+redaction catches common formats, not every secret.
+
+Example:
+  claudew handoff auth-refactor                    # Print to stdout
+  claudew handoff auth-refactor -o handoff.md      # Write to a file`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		name := args[0]
+
+		// Load config
+		cfg, err := config.Load()
+		if err != nil {
+			return fmt.Errorf("failed to load config: %w", err)
+		}
+
+		ws, err := cfg.GetWorkspace(name)
+		if err != nil {
+			return err
+		}
+
+		redactor, err := handoff.NewRedactor(cfg.Settings.RedactPatterns)
+		if err != nil {
+			return err
+		}
+
+		wsMgr := workspace.NewManagerForConfig(cfg)
+		bundle, err := buildHandoffBundle(wsMgr, name, ws)
+		if err != nil {
+			return err
+		}
+		out := handoff.Render(bundle, redactor)
+
+		if handoffOutput == "" {
+			fmt.Print(out)
+			return nil
+		}
+		if err := os.WriteFile(handoffOutput, []byte(out), 0644); err != nil {
+			return fmt.Errorf("failed to write bundle: %w", err)
+		}
+		fmt.Printf("✓ Wrote handoff bundle for '%s' to %s\n", name, handoffOutput)
+		return nil
+	},
+}
+
+// buildHandoffBundle gathers a workspace's notes and git state into a bundle
+func buildHandoffBundle(wsMgr *workspace.Manager, name string, ws *config.Workspace) (handoff.Bundle, error) {
+	bundle := handoff.Bundle{
+		Workspace:    name,
+		Context:      wsMgr.ReadContext(name),
+		Decisions:    wsMgr.ReadDecisions(name),
+		Continuation: wsMgr.GetContinuation(name),
+		IssueURL:     ws.Issue,
+	}
+	if summary := wsMgr.GetSummary(name); summary != "(no summary)" {
+		bundle.Summary = summary
+	}
+
+	if ws.RepoPath != "" {
+		if branch, err := git.GetCurrentBranch(ws.RepoPath); err == nil {
+			bundle.Branch = branch
+		}
+		bundle.PRURL = pullRequestURL(ws.RepoPath)
+	}
+
+	if handoffNoResearch {
+		return bundle, nil
+	}
+	files, err := wsMgr.ListResearch(name)
+	if err != nil {
+		return bundle, err
+	}
+	for _, file := range files {
+		data, err := os.ReadFile(filepath.Join(wsMgr.GetPath(name), "research", file))
+		if err != nil {
+			return bundle, fmt.Errorf("failed to read research file %s: %w", file, err)
+		}
+		bundle.Research = append(bundle.Research, handoff.File{Name: file, Content: string(data)})
+	}
+	return bundle, nil
+}
+
+// pullRequestURL returns the URL of the open pull request for the repo's branch, if gh knows one
+func pullRequestURL(repoPath string) string {
+	if _, err := exec.LookPath("gh"); err != nil {
+		return ""
+	}
+	cmd := execx.Command("gh", "pr", "view", "--json", "url", "-q", ".url")
+	cmd.Dir = repoPath
+	output, err := cmd.Output()
+	if err != nil {
+		return ""
+	}
+	return strings.TrimSpace(string(output))
+}
+
+func init() {
+	rootCmd.AddCommand(handoffCmd)
+	handoffCmd.ValidArgsFunction = validWorkspaceNamesExcludeArchived
+	handoffCmd.Flags().StringVarP(&handoffOutput, "output", "o", "", "Write the bundle to a file instead of stdout")
+	handoffCmd.Flags().BoolVar(&handoffNoResearch, "no-research", false, "Leave out research notes")
+}
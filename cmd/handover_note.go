@@ -0,0 +1,92 @@
+package cmd
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/pmossman/claudew/internal/config"
+	"github.com/spf13/cobra"
+)
+
+var handoverNoteCmd = &cobra.Command{
+	Use:   "handover-note <workspace-name> [message|default|none]",
+	Short: "Show or set the note Claude is given when a session starts",
+	Long: `The handover note is typed into Claude as its first message whenever the
+workspace starts a new Claude session (start and restart), ahead of any
+continuation, so Claude gets going without waiting for you. It complements the
+startup instructions in CLAUDE.md, which Claude may not act on until prompted.
+
+The handover_note setting applies to every workspace; a note set here replaces it
+for one workspace. "default" goes back to the setting, "none" turns the note off.
+
+Example:
+  claudew handover-note feature-auth
+  claudew handover-note feature-auth "Read continuation.md and decisions.md, then summarize the plan"
+  claudew handover-note feature-auth none`,
+	Args: cobra.MinimumNArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		name := args[0]
+
+		// Load config
+		cfg, err := config.Load()
+		if err != nil {
+			return fmt.Errorf("failed to load config: %w", err)
+		}
+
+		ws, err := cfg.GetWorkspace(name)
+		if err != nil {
+			return err
+		}
+
+		if len(args) == 1 {
+			printHandoverNote(cfg, ws)
+			return nil
+		}
+
+		note := strings.TrimSpace(strings.Join(args[1:], " "))
+		switch note {
+		case "", "default":
+			ws.Handover = ""
+		default:
+			ws.Handover = note
+		}
+		if err := cfg.Save(); err != nil {
+			return fmt.Errorf("failed to save config: %w", err)
+		}
+
+		fmt.Printf("✓ Handover note for '%s' updated; it applies from the next session\n", name)
+		printHandoverNote(cfg, ws)
+		return nil
+	},
+}
+
+// printHandoverNote shows the handover note a workspace's sessions start with, and where it comes from
+func printHandoverNote(cfg *config.Config, ws *config.Workspace) {
+	note := cfg.HandoverNote(ws)
+	switch {
+	case note == "" && ws.Handover == config.HandoverNone:
+		fmt.Println(paint(colorGray, "(none, turned off for this workspace)"))
+	case note == "":
+		fmt.Println(paint(colorGray, "(none; set one here or with the handover_note setting)"))
+	case ws.Handover == "":
+		fmt.Printf("%s\n%s\n", note, paint(colorGray, "(from the handover_note setting)"))
+	default:
+		fmt.Println(note)
+	}
+}
+
+// validHandoverNoteArgs completes the workspace name, then the keywords
+func validHandoverNoteArgs(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+	if len(args) == 0 {
+		return validWorkspaceNamesExcludeArchived(cmd, args, toComplete)
+	}
+	if len(args) == 1 {
+		return []string{"default", config.HandoverNone}, cobra.ShellCompDirectiveNoFileComp
+	}
+	return nil, cobra.ShellCompDirectiveNoFileComp
+}
+
+func init() {
+	rootCmd.AddCommand(handoverNoteCmd)
+	handoverNoteCmd.ValidArgsFunction = validHandoverNoteArgs
+}
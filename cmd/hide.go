@@ -0,0 +1,91 @@
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/pmossman/claudew/internal/config"
+	"github.com/spf13/cobra"
+)
+
+var hideCmd = &cobra.Command{
+	Use:   "hide <workspace-name>",
+	Short: "Hide a workspace from the select menu",
+	Long: `Hides a workspace from the main select menu, e.g. a long-running reference
+workspace you rarely switch to, to keep the picker focused. It can still be used by
+name (claudew start <name>), and "claudew select --all" lists it.
+
+Example:
+  claudew hide docs-reference`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return setHidden(args[0], true)
+	},
+}
+
+var unhideCmd = &cobra.Command{
+	Use:   "unhide <workspace-name>",
+	Short: "Show a hidden workspace in the select menu again",
+	Args:  cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return setHidden(args[0], false)
+	},
+}
+
+// setHidden updates a workspace's hidden flag and saves the config
+func setHidden(name string, hidden bool) error {
+	// Load config
+	cfg, err := config.Load()
+	if err != nil {
+		return fmt.Errorf("failed to load config: %w", err)
+	}
+
+	ws, err := cfg.GetWorkspace(name)
+	if err != nil {
+		return err
+	}
+
+	ws.Hidden = hidden
+	if err := cfg.Save(); err != nil {
+		return fmt.Errorf("failed to save config: %w", err)
+	}
+
+	if hidden {
+		fmt.Printf("✓ Hid workspace '%s' from the select menu (show it with: claudew select --all)\n", name)
+	} else {
+		fmt.Printf("✓ Workspace '%s' is shown in the select menu again\n", name)
+	}
+	return nil
+}
+
+// hiddenMarker returns the menu marker for hidden workspaces, listed with select --all
+func hiddenMarker(ws *config.Workspace) string {
+	if ws.Hidden {
+		return colorize(colorGray, "(hidden) ")
+	}
+	return ""
+}
+
+// validHiddenWorkspaceNames completes the names of hidden workspaces
+func validHiddenWorkspaceNames(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+	if len(args) > 0 {
+		return nil, cobra.ShellCompDirectiveNoFileComp
+	}
+	cfg, err := config.Load()
+	if err != nil {
+		return nil, cobra.ShellCompDirectiveNoFileComp
+	}
+	var names []string
+	for name, ws := range cfg.Workspaces {
+		if ws.Hidden {
+			names = append(names, name)
+		}
+	}
+	return names, cobra.ShellCompDirectiveNoFileComp
+}
+
+func init() {
+	rootCmd.AddCommand(hideCmd)
+	rootCmd.AddCommand(unhideCmd)
+	hideCmd.ValidArgsFunction = validWorkspaceNamesExcludeArchived
+	unhideCmd.ValidArgsFunction = validHiddenWorkspaceNames
+}
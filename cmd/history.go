@@ -0,0 +1,112 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/pmossman/claudew/internal/config"
+	"github.com/pmossman/claudew/internal/execx"
+	"github.com/pmossman/claudew/internal/workspace"
+	"github.com/spf13/cobra"
+)
+
+var (
+	historyEnable bool
+	historyPatch  bool
+	historyLimit  int
+)
+
+var historyCmd = &cobra.Command{
+	Use:   "history [workspace-name] [file]",
+	Short: "Show how workspace notes changed over time",
+	Long: `With history enabled, the workspaces directory is a git repository and every
+change claudew makes to a workspace's notes is committed: saved continuations,
+decisions, logged commits, summaries, forks, merges and archiving. Edits Claude
+makes directly are committed when the workspace is stopped or restarted.
+
+history lists those commits for one workspace (or one of its files), or for every
+workspace when no name is given. Since it's plain git, the directory can also be
+browsed with any git tool or pushed to a private remote to sync notes between
+machines. Session state, caches and .claudew.env files are never committed.
+
+Example:
+  claudew history --enable
+  claudew history feature-auth
+  claudew history feature-auth continuation.md -p`,
+	Args: cobra.MaximumNArgs(2),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		// Load config
+		cfg, err := config.Load()
+		if err != nil {
+			return fmt.Errorf("failed to load config: %w", err)
+		}
+		wsMgr := workspace.NewManagerForConfig(cfg)
+
+		if historyEnable {
+			if err := wsMgr.EnableHistory(); err != nil {
+				return err
+			}
+			fmt.Printf("✓ Workspace notes in %s are now versioned with git\n", cfg.Settings.WorkspaceDir)
+			return nil
+		}
+		if !wsMgr.HistoryEnabled() {
+			return fmt.Errorf("history isn't enabled; turn it on with 'claudew history --enable'")
+		}
+
+		gitArgs := []string{"-C", cfg.Settings.WorkspaceDir, "log", "--date=format:%Y-%m-%d %H:%M",
+			"--format=%C(yellow)%h%C(reset) %ad %s"}
+		if historyPatch {
+			gitArgs = append(gitArgs, "--patch")
+		}
+		if historyLimit > 0 {
+			gitArgs = append(gitArgs, fmt.Sprintf("--max-count=%d", historyLimit))
+		}
+		if len(args) > 0 {
+			if _, err := cfg.GetWorkspace(args[0]); err != nil {
+				return err
+			}
+			file := ""
+			if len(args) == 2 {
+				file = args[1]
+			}
+			path, err := wsMgr.HistoryPath(args[0], file)
+			if err != nil {
+				return err
+			}
+			gitArgs = append(gitArgs, "--", path)
+		}
+
+		gitCmd := execx.Command("git", gitArgs...)
+		gitCmd.Stdin = os.Stdin
+		gitCmd.Stdout = os.Stdout
+		gitCmd.Stderr = os.Stderr
+		return gitCmd.Run()
+	},
+}
+
+// recordNotesHistory commits a workspace's notes as they are, if history is enabled,
+// so edits Claude made directly are captured
+func recordNotesHistory(wsMgr *workspace.Manager, name, message string) {
+	if err := wsMgr.RecordHistory(name, message); err != nil {
+		fmt.Printf("Warning: failed to record notes history: %v\n", err)
+	}
+}
+
+// validHistoryArgs completes the workspace name, then its files
+func validHistoryArgs(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+	if len(args) == 0 {
+		return validWorkspaceNames(cmd, args, toComplete)
+	}
+	if len(args) == 1 {
+		return []string{"context.md", "decisions.md", "continuation.md", "summary.txt", "research/"}, cobra.ShellCompDirectiveNoFileComp
+	}
+	return nil, cobra.ShellCompDirectiveNoFileComp
+}
+
+func init() {
+	rootCmd.AddCommand(historyCmd)
+	historyCmd.ValidArgsFunction = validHistoryArgs
+	historyCmd.Flags().BoolVar(&historyEnable, "enable", false, "Make the workspaces directory a git repository and start recording changes")
+	historyCmd.Flags().BoolVarP(&historyPatch, "patch", "p", false, "Show the changes made by each commit")
+	historyCmd.Flags().IntVarP(&historyLimit, "limit", "n", 0, "Show only the latest N commits")
+}
@@ -0,0 +1,266 @@
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/pmossman/claudew/internal/config"
+	"github.com/pmossman/claudew/internal/events"
+	"github.com/pmossman/claudew/internal/git"
+	"github.com/spf13/cobra"
+)
+
+var (
+	importClonesScanDir  string
+	importClonesMaxDepth int
+	importClonesDryRun   bool
+)
+
+// Import errors that import-clones reports as skipped rather than failed
+var (
+	errCloneAlreadyRegistered = errors.New("already registered")
+	errCloneOriginMismatch    = errors.New("origin does not match remote")
+)
+
+var importCloneCmd = &cobra.Command{
+	Use:   "import-clone <remote-name> <path>",
+	Short: "Register an existing clone of a remote",
+	Long: `Registers an existing git checkout as a clone of a remote so workspaces can use it.
+The checkout's origin URL must match the remote's URL.
+
+Example:
+  claudew import-clone airbyte ~/dev/airbyte`,
+	Args: cobra.ExactArgs(2),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		remoteName := args[0]
+
+		// Load config
+		cfg, err := config.Load()
+		if err != nil {
+			return fmt.Errorf("failed to load config: %w", err)
+		}
+
+		remote, err := cfg.GetRemote(remoteName)
+		if err != nil {
+			return err
+		}
+
+		clonePath, err := filepath.Abs(args[1])
+		if err != nil {
+			return fmt.Errorf("invalid path: %w", err)
+		}
+
+		if err := importClone(cfg, remote, clonePath); err != nil {
+			return fmt.Errorf("cannot import %s: %w", clonePath, err)
+		}
+
+		// Save config
+		if err := cfg.Save(); err != nil {
+			return fmt.Errorf("failed to save config: %w", err)
+		}
+
+		fmt.Printf("✓ Imported clone at %s\n", clonePath)
+		fmt.Printf("  Branch: %s\n", cfg.Clones[clonePath].CurrentBranch)
+		fmt.Printf("  Status: Free (available for workspaces)\n")
+
+		return nil
+	},
+}
+
+var importClonesCmd = &cobra.Command{
+	Use:   "import-clones <remote-name> --scan <dir>",
+	Short: "Register every existing clone of a remote found under a directory",
+	Long: `Scans a directory for git repositories whose origin URL matches the remote and
+registers them all as clones in one pass. Repositories with a different origin,
+no origin, or that are already registered are reported and skipped.
+
+Example:
+  claudew import-clones airbyte --scan ~/dev
+  claudew import-clones airbyte --scan ~/dev --max-depth 2 --dry-run`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		remoteName := args[0]
+
+		// Load config
+		cfg, err := config.Load()
+		if err != nil {
+			return fmt.Errorf("failed to load config: %w", err)
+		}
+
+		remote, err := cfg.GetRemote(remoteName)
+		if err != nil {
+			return err
+		}
+
+		scanDir, err := filepath.Abs(importClonesScanDir)
+		if err != nil {
+			return fmt.Errorf("invalid scan directory: %w", err)
+		}
+
+		repos, err := findGitRepos(scanDir, importClonesMaxDepth)
+		if err != nil {
+			return err
+		}
+
+		if len(repos) == 0 {
+			fmt.Printf("No git repositories found under %s\n", scanDir)
+			return nil
+		}
+
+		var imported, registered, mismatched, failed []string
+		for _, repoPath := range repos {
+			if importClonesDryRun {
+				err = checkImportable(cfg, remote, repoPath)
+			} else {
+				err = importClone(cfg, remote, repoPath)
+			}
+
+			switch {
+			case err == nil:
+				imported = append(imported, repoPath)
+			case errors.Is(err, errCloneAlreadyRegistered):
+				registered = append(registered, repoPath)
+			case errors.Is(err, errCloneOriginMismatch):
+				mismatched = append(mismatched, fmt.Sprintf("%s (%v)", repoPath, err))
+			default:
+				failed = append(failed, fmt.Sprintf("%s (%v)", repoPath, err))
+			}
+		}
+
+		if !importClonesDryRun && len(imported) > 0 {
+			if err := cfg.Save(); err != nil {
+				return fmt.Errorf("failed to save config: %w", err)
+			}
+		}
+
+		verb := "Imported"
+		if importClonesDryRun {
+			verb = "Would import"
+		}
+		printImportGroup("✓ "+verb, imported)
+		printImportGroup("• Already registered", registered)
+		printImportGroup("⚠ Skipped (origin mismatch)", mismatched)
+		printImportGroup("✗ Failed", failed)
+
+		return nil
+	},
+}
+
+// printImportGroup prints one category of import-clones results
+func printImportGroup(title string, paths []string) {
+	if len(paths) == 0 {
+		return
+	}
+	fmt.Printf("%s: %d\n", title, len(paths))
+	for _, p := range paths {
+		fmt.Printf("    %s\n", p)
+	}
+}
+
+// checkImportable verifies a path can be registered as a clone of remote
+func checkImportable(cfg *config.Config, remote *config.Remote, clonePath string) error {
+	if _, exists := cfg.Clones[clonePath]; exists {
+		return errCloneAlreadyRegistered
+	}
+
+	if !git.IsGitRepo(clonePath) {
+		return fmt.Errorf("not a git repository")
+	}
+
+	url, err := git.GetRemoteURL(clonePath)
+	if err != nil {
+		return fmt.Errorf("no origin remote")
+	}
+	if git.NormalizeRemoteURL(url) != git.NormalizeRemoteURL(remote.URL) {
+		return fmt.Errorf("%w: %s", errCloneOriginMismatch, url)
+	}
+
+	return nil
+}
+
+// importClone registers an existing checkout as a clone of remote, free unless a
+// workspace was already created from its path
+func importClone(cfg *config.Config, remote *config.Remote, clonePath string) error {
+	if err := checkImportable(cfg, remote, clonePath); err != nil {
+		return err
+	}
+
+	users := cfg.WorkspacesUsingRepo(clonePath, "")
+	if err := cfg.AddClone(clonePath, remote.Name); err != nil {
+		return err
+	}
+
+	branch, err := git.GetCurrentBranch(clonePath)
+	if err != nil {
+		branch = "unknown"
+	}
+	cfg.Clones[clonePath].SetBranch(branch, time.Now())
+
+	// A workspace created from this path before it was registered keeps it
+	if len(users) > 0 {
+		cfg.Clones[clonePath].InUseBy = users[0]
+		if len(users) > 1 {
+			fmt.Printf("  ⚠ %s is used by %d workspaces (%s); run claudew doctor\n", clonePath, len(users), strings.Join(users, ", "))
+		}
+	}
+
+	emitEvent(cfg, events.CloneImported, "", map[string]string{"clone_path": clonePath, "remote": remote.Name})
+	return nil
+}
+
+// findGitRepos returns git repositories under root, without descending into them
+func findGitRepos(root string, maxDepth int) ([]string, error) {
+	info, err := os.Stat(root)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read scan directory: %w", err)
+	}
+	if !info.IsDir() {
+		return nil, fmt.Errorf("%s is not a directory", root)
+	}
+
+	var repos []string
+	err = filepath.WalkDir(root, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			// Unreadable directories are skipped rather than aborting the scan
+			if d != nil && d.IsDir() && path != root {
+				return filepath.SkipDir
+			}
+			return err
+		}
+		if !d.IsDir() {
+			return nil
+		}
+
+		if _, err := os.Stat(filepath.Join(path, ".git")); err == nil {
+			repos = append(repos, path)
+			return filepath.SkipDir
+		}
+
+		rel, _ := filepath.Rel(root, path)
+		if rel != "." && (strings.HasPrefix(d.Name(), ".") || strings.Count(rel, string(filepath.Separator))+1 >= maxDepth) {
+			return filepath.SkipDir
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to scan %s: %w", root, err)
+	}
+
+	return repos, nil
+}
+
+func init() {
+	rootCmd.AddCommand(importCloneCmd)
+	rootCmd.AddCommand(importClonesCmd)
+	importCloneCmd.ValidArgsFunction = validRemoteNames
+	importClonesCmd.ValidArgsFunction = validRemoteNames
+	importClonesCmd.Flags().StringVar(&importClonesScanDir, "scan", "", "Directory to scan for clones (required)")
+	importClonesCmd.Flags().IntVar(&importClonesMaxDepth, "max-depth", 3, "How many directory levels below --scan to search")
+	importClonesCmd.Flags().BoolVar(&importClonesDryRun, "dry-run", false, "Show what would be imported without changing the config")
+	importClonesCmd.MarkFlagRequired("scan")
+}
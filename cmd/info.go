@@ -4,6 +4,7 @@ import (
 	"fmt"
 
 	"github.com/pmossman/claudew/internal/config"
+	"github.com/pmossman/claudew/internal/session"
 	"github.com/pmossman/claudew/internal/workspace"
 	"github.com/spf13/cobra"
 )
@@ -22,13 +23,15 @@ var infoCmd = &cobra.Command{
 			return fmt.Errorf("failed to load config: %w", err)
 		}
 
+		refreshLastActive(cfg)
+
 		// Get workspace
 		ws, err := cfg.GetWorkspace(name)
 		if err != nil {
 			return err
 		}
 
-		wsMgr := workspace.NewManager(cfg.Settings.WorkspaceDir)
+		wsMgr := workspace.NewManagerForConfig(cfg)
 
 		// Display workspace info
 		fmt.Println("═══════════════════════════════════════════════════════════")
@@ -36,15 +39,38 @@ var infoCmd = &cobra.Command{
 		fmt.Println("═══════════════════════════════════════════════════════════")
 		fmt.Printf("Status:       %s\n", formatStatus(ws.Status))
 		fmt.Printf("Repository:   %s\n", ws.GetRepoPath())
+		if ws.Subdir != "" {
+			fmt.Printf("Subdir:       %s\n", ws.Subdir)
+		}
 
 		// Show clone info if managed
 		if ws.ClonePath != "" {
 			if clone, err := cfg.GetClone(ws.ClonePath); err == nil {
 				fmt.Printf("Remote:       %s\n", clone.RemoteName)
-				fmt.Printf("Branch:       %s\n", clone.CurrentBranch)
+			}
+			if branch := displayBranch(cfg, name, ws); branch != "" {
+				fmt.Printf("Branch:       %s\n", branch)
 			}
 		}
 
+		if ws.Issue != "" {
+			fmt.Printf("Issue:        %s\n", ws.Issue)
+		}
+		if note := cfg.HandoverNote(ws); note != "" {
+			fmt.Printf("Handover:     %s\n", note)
+		}
+
+		if ports := ws.FormatPorts(); ports != "" {
+			fmt.Printf("Ports:        %s\n", ports)
+		}
+		if ws.Container != nil {
+			fmt.Printf("Container:    %s", ws.Container.Image)
+			if ws.Container.Network != "" {
+				fmt.Printf(" (network: %s)", ws.Container.Network)
+			}
+			fmt.Println()
+		}
+
 		fmt.Printf("Created:      %s\n", ws.CreatedAt.Format("2006-01-02 15:04:05"))
 		fmt.Printf("Last Active:  %s (%s)\n", ws.LastActive.Format("2006-01-02 15:04:05"), formatTimeAgo(ws.LastActive))
 
@@ -57,6 +83,30 @@ var infoCmd = &cobra.Command{
 			fmt.Printf("Session PID:  %d\n", ws.SessionPID)
 		}
 
+		sessionMgr := session.NewManagerForConfig(cfg)
+		sessionRunning, _ := sessionMgr.Exists(sessionMgr.GetSessionName(name))
+		for _, warning := range lintWorkspace(cfg, wsMgr, name, sessionRunning) {
+			fmt.Println(paint(colorYellow, "⚠ "+warning))
+		}
+
+		if len(ws.Agents) > 0 {
+			running := runningAgentWindows(sessionMgr, name)
+			fmt.Println("Agents:")
+			for _, agent := range ws.Agents {
+				state := "running"
+				if !running[agent.Name] {
+					state = "not running"
+				}
+				fmt.Printf("  • %-14s %s (spawned %s)\n", agent.Name, state, formatTimeAgo(agent.CreatedAt))
+				if prompt := agent.Prompt; prompt != "" {
+					if len(prompt) > 70 {
+						prompt = prompt[:67] + "..."
+					}
+					fmt.Printf("    %s\n", prompt)
+				}
+			}
+		}
+
 		// Display continuation
 		continuation := wsMgr.GetContinuation(name)
 		if continuation != "" {
@@ -78,7 +128,11 @@ var infoCmd = &cobra.Command{
 		}
 
 		fmt.Println()
-		fmt.Printf("Workspace directory: %s\n", wsMgr.GetPath(name))
+		if ws.Offloaded {
+			fmt.Printf("Workspace directory: offloaded to %s (claudew restore %s)\n", cfg.Settings.BackupTarget, name)
+		} else {
+			fmt.Printf("Workspace directory: %s\n", wsMgr.GetPath(name))
+		}
 
 		return nil
 	},
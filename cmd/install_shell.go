@@ -5,6 +5,8 @@ import (
 	"fmt"
 	"os"
 	"path/filepath"
+	"runtime"
+	"strconv"
 	"strings"
 
 	"github.com/spf13/cobra"
@@ -23,6 +25,84 @@ var (
 	bashCompletionSetup string
 )
 
+// rcBlockBegin and rcBlockEnd delimit the lines install-shell writes to the rc file.
+// Reinstalling replaces and uninstalling removes exactly the lines between them.
+const (
+	rcBlockBegin = "# >>> claudew >>>"
+	rcBlockEnd   = "# <<< claudew <<<"
+)
+
+// rcManagedSuffix tagged each rc line written by versions before the block markers
+const rcManagedSuffix = "# claudew-managed"
+
+// legacyRCHeaders started sections written by the earliest versions, whose extent
+// isn't marked; they are reported rather than guessed at
+var legacyRCHeaders = []string{
+	"# claude-workspace shell integration",
+	"# claudew shell integration",
+	"# claude-workspace completion",
+	"# claudew completion",
+}
+
+// rcFileOverride is the --rc-file flag shared by install-shell and uninstall-shell
+var rcFileOverride string
+
+// rcTargetPath records a custom --rc-file, so later reinstalls (e.g. by self-update) and
+// uninstalls edit the same file without being told again
+func rcTargetPath(home string) string {
+	return filepath.Join(home, ".claudew", "rc-file")
+}
+
+// shellRCFile returns the rc file to edit: --rc-file, then the one recorded by an earlier
+// install-shell --rc-file, then the file the user's $SHELL reads
+func shellRCFile(home string) (string, error) {
+	if rcFileOverride != "" {
+		return filepath.Abs(rcFileOverride)
+	}
+	if data, err := os.ReadFile(rcTargetPath(home)); err == nil {
+		if path := strings.TrimSpace(string(data)); path != "" {
+			return path, nil
+		}
+	}
+	return detectShellRCFile(home, os.Getenv("SHELL"), runtime.GOOS)
+}
+
+// detectShellRCFile picks the startup file interactive shells read. zsh reads .zshrc
+// (under $ZDOTDIR if set), falling back to .zprofile when only that exists. Terminal
+// emulators on macOS start bash as a login shell, which reads .bash_profile and not
+// .bashrc unless .bash_profile sources it.
+func detectShellRCFile(home, shell, goos string) (string, error) {
+	switch {
+	case strings.Contains(shell, "zsh"):
+		dir := home
+		if zdotdir := os.Getenv("ZDOTDIR"); zdotdir != "" {
+			dir = zdotdir
+		}
+		zshrc, zprofile := filepath.Join(dir, ".zshrc"), filepath.Join(dir, ".zprofile")
+		if !fileExists(zshrc) && fileExists(zprofile) {
+			return zprofile, nil
+		}
+		return zshrc, nil
+	case strings.Contains(shell, "bash"):
+		bashrc := filepath.Join(home, ".bashrc")
+		if goos != "darwin" {
+			return bashrc, nil
+		}
+		profile := filepath.Join(home, ".bash_profile")
+		if content, err := os.ReadFile(profile); err == nil && strings.Contains(string(content), ".bashrc") {
+			return bashrc, nil
+		}
+		return profile, nil
+	}
+	return "", fmt.Errorf("unsupported shell: %s (only bash and zsh supported; use --rc-file for another startup file)", shell)
+}
+
+// fileExists reports whether path exists
+func fileExists(path string) bool {
+	_, err := os.Stat(path)
+	return err == nil
+}
+
 // isShellIntegrationInstalled checks if shell integration is already installed
 func isShellIntegrationInstalled() (bool, string, error) {
 	home, err := os.UserHomeDir()
@@ -30,15 +110,9 @@ func isShellIntegrationInstalled() (bool, string, error) {
 		return false, "", fmt.Errorf("failed to get home directory: %w", err)
 	}
 
-	// Detect shell
-	shell := os.Getenv("SHELL")
-	var rcFile string
-	if strings.Contains(shell, "zsh") {
-		rcFile = filepath.Join(home, ".zshrc")
-	} else if strings.Contains(shell, "bash") {
-		rcFile = filepath.Join(home, ".bashrc")
-	} else {
-		return false, "", fmt.Errorf("unsupported shell: %s (only bash and zsh supported)", shell)
+	rcFile, err := shellRCFile(home)
+	if err != nil {
+		return false, "", err
 	}
 
 	// Check if already installed
@@ -47,15 +121,150 @@ func isShellIntegrationInstalled() (bool, string, error) {
 		return false, "", fmt.Errorf("failed to read %s: %w", rcFile, err)
 	}
 
-	// Check for either old or new shell integration markers
-	hasOld := strings.Contains(string(content), "# claude-workspace shell integration")
-	hasNew := strings.Contains(string(content), "# claudew shell integration")
-	installed := hasOld || hasNew
-	return installed, rcFile, nil
+	return hasShellIntegration(string(content)), rcFile, nil
+}
+
+// hasShellIntegration reports whether rc content holds claudew integration from any version
+func hasShellIntegration(content string) bool {
+	for _, line := range strings.Split(content, "\n") {
+		trimmed := strings.TrimSpace(line)
+		if trimmed == rcBlockBegin || strings.HasSuffix(trimmed, rcManagedSuffix) {
+			return true
+		}
+		for _, header := range legacyRCHeaders {
+			if trimmed == header {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// rcIntegrationBlock renders the marker-delimited block that sources each of paths
+func rcIntegrationBlock(paths ...string) string {
+	var out strings.Builder
+	out.WriteString(rcBlockBegin + "\n")
+	out.WriteString("# Managed by 'claudew install-shell'; 'claudew uninstall-shell' removes this block.\n")
+	for _, path := range paths {
+		fmt.Fprintf(&out, "[ -f %s ] && source %s\n", path, path)
+	}
+	out.WriteString(rcBlockEnd + "\n")
+	return out.String()
+}
+
+// findRCBlock returns the line indexes of the begin and end markers, or -1, -1 if there is no block
+func findRCBlock(lines []string) (int, int, error) {
+	begin := -1
+	for i, line := range lines {
+		switch strings.TrimSpace(line) {
+		case rcBlockBegin:
+			if begin >= 0 {
+				return 0, 0, fmt.Errorf("line %d: %q inside another claudew block", i+1, rcBlockBegin)
+			}
+			begin = i
+		case rcBlockEnd:
+			if begin < 0 {
+				return 0, 0, fmt.Errorf("line %d: %q without %q", i+1, rcBlockEnd, rcBlockBegin)
+			}
+			return begin, i, nil
+		}
+	}
+	if begin >= 0 {
+		return 0, 0, fmt.Errorf("line %d: %q without %q", begin+1, rcBlockBegin, rcBlockEnd)
+	}
+	return -1, -1, nil
+}
+
+// setClaudewBlock writes block into rc content: in place of an existing claudew block,
+// or appended after a blank line. Lines tagged by older versions are dropped.
+func setClaudewBlock(content, block string) (string, error) {
+	lines := strings.Split(removeManagedLines(content), "\n")
+	begin, end, err := findRCBlock(lines)
+	if err != nil {
+		return "", err
+	}
+	blockLines := strings.Split(strings.TrimSuffix(block, "\n"), "\n")
+	if begin >= 0 {
+		replaced := append(append(append([]string{}, lines[:begin]...), blockLines...), lines[end+1:]...)
+		return strings.Join(replaced, "\n"), nil
+	}
+
+	trimmed := strings.TrimRight(strings.Join(lines, "\n"), "\n")
+	if trimmed == "" {
+		return block, nil
+	}
+	return trimmed + "\n\n" + block, nil
+}
+
+// removeClaudewSections removes the claudew block, and the lines older versions tagged,
+// from rc content. Nothing outside those lines is touched; an unterminated block is an
+// error rather than a guess at where it ends.
+func removeClaudewSections(content string) (string, error) {
+	lines := strings.Split(removeManagedLines(content), "\n")
+	begin, end, err := findRCBlock(lines)
+	if err != nil {
+		return "", err
+	}
+	if begin < 0 {
+		return strings.Join(lines, "\n"), nil
+	}
+
+	// Also drop the blank line install-shell put before an appended block
+	rest := lines[end+1:]
+	if begin > 0 && strings.TrimSpace(lines[begin-1]) == "" && (len(rest) == 0 || (len(rest) == 1 && rest[0] == "")) {
+		begin--
+	}
+	return strings.Join(append(append([]string{}, lines[:begin]...), rest...), "\n"), nil
+}
+
+// removeManagedLines drops the individually tagged lines written before the block markers
+func removeManagedLines(content string) string {
+	lines := strings.Split(content, "\n")
+	kept := lines[:0:0]
+	for _, line := range lines {
+		if strings.HasSuffix(strings.TrimSpace(line), rcManagedSuffix) {
+			continue
+		}
+		kept = append(kept, line)
+	}
+	return strings.Join(kept, "\n")
+}
+
+// legacyRCHeaderLines returns the 1-based line numbers of section headers from the earliest
+// versions, which have to be removed by hand
+func legacyRCHeaderLines(content string) []int {
+	var found []int
+	for i, line := range strings.Split(content, "\n") {
+		for _, header := range legacyRCHeaders {
+			if strings.TrimSpace(line) == header {
+				found = append(found, i+1)
+			}
+		}
+	}
+	return found
+}
+
+// warnLegacyRCHeaders points out old integration sections left in rcFile
+func warnLegacyRCHeaders(rcFile, content string) {
+	if lines := legacyRCHeaderLines(content); len(lines) > 0 {
+		fmt.Printf("⚠️  %s has integration from an older claudew/claude-workspace at line(s) %s\n", rcFile, joinInts(lines))
+		fmt.Println("   Its end isn't marked, so remove that section by hand.")
+	}
+}
+
+// joinInts formats numbers as a comma-separated list
+func joinInts(nums []int) string {
+	parts := make([]string, len(nums))
+	for i, n := range nums {
+		parts[i] = strconv.Itoa(n)
+	}
+	return strings.Join(parts, ", ")
 }
 
 var (
-	installShellForce bool
+	installShellForce            bool
+	installShellPrintIntegration bool
+	installShellPrintOnly        bool
 )
 
 var installShellCmd = &cobra.Command{
@@ -71,12 +280,33 @@ binary and adds directory navigation capability.
 You can create a short alias in your shell config if desired:
   alias cw='claudew'
 
-Use --force to reinstall if already installed (useful after updates).`,
+Use --force to reinstall if already installed (useful after updates).
+
+Use --print-integration to print the shell function to stdout instead of installing
+anything, for package managers and dotfiles that manage rc files themselves:
+  eval "$(claudew install-shell --print-integration)"
+Completion is printed separately by 'claudew completion <bash|zsh>'.
+
+The rc file is detected from $SHELL: ~/.zshrc (or ~/.zprofile if only that exists),
+~/.bashrc, or ~/.bash_profile on macOS unless it sources ~/.bashrc. Use --rc-file to
+pick another one; it is remembered for later reinstalls and uninstall-shell.
+Use --print-only to install the files under ~/.claudew but print the rc block instead
+of editing any rc file, e.g. to add it to dotfiles managed by chezmoi or stow.`,
 	RunE: func(cmd *cobra.Command, args []string) error {
+		if installShellPrintIntegration {
+			fmt.Fprint(cmd.OutOrStdout(), shellIntegrationScript)
+			return nil
+		}
+
 		// Check if already installed
-		installed, rcFile, err := isShellIntegrationInstalled()
-		if err != nil {
-			return err
+		var installed bool
+		var rcFile string
+		if !installShellPrintOnly {
+			var err error
+			installed, rcFile, err = isShellIntegrationInstalled()
+			if err != nil {
+				return err
+			}
 		}
 
 		if installed && !installShellForce {
@@ -168,36 +398,33 @@ Use --force to reinstall if already installed (useful after updates).`,
 			return fmt.Errorf("failed to write completion setup: %w", err)
 		}
 
-		// If force installing and already installed, remove old sections first
-		if installShellForce && installed {
-			content, err := os.ReadFile(rcFile)
-			if err != nil {
-				return fmt.Errorf("failed to read %s: %w", rcFile, err)
-			}
-
-			// Remove existing claudew sections
-			newContent := removeClaudewSections(string(content))
-
-			// Write back the cleaned content
-			if err := os.WriteFile(rcFile, []byte(newContent), 0644); err != nil {
-				return fmt.Errorf("failed to write %s: %w", rcFile, err)
-			}
+		block := rcIntegrationBlock(shellIntegrationPath, completionSetupPath)
+		if installShellPrintOnly {
+			// Only the block goes to stdout, so it can be redirected into a dotfile
+			fmt.Fprint(cmd.OutOrStdout(), block)
+			fmt.Fprintf(cmd.ErrOrStderr(), "✓ Installed %s and %s\n", shellIntegrationPath, completionPath)
+			fmt.Fprintln(cmd.ErrOrStderr(), "  Add the block above to your shell config")
+			return nil
 		}
 
-		// Append source statements to rc file
-		f, err := os.OpenFile(rcFile, os.O_APPEND|os.O_WRONLY|os.O_CREATE, 0644)
+		// Write the claudew block to the rc file, replacing any previous one
+		content, err := os.ReadFile(rcFile)
+		if err != nil && !os.IsNotExist(err) {
+			return fmt.Errorf("failed to read %s: %w", rcFile, err)
+		}
+		newContent, err := setClaudewBlock(string(content), block)
 		if err != nil {
-			return fmt.Errorf("failed to open %s: %w", rcFile, err)
+			return fmt.Errorf("failed to update %s: %w", rcFile, err)
 		}
-		defer f.Close()
-
-		rcAdditions := fmt.Sprintf(`
-[ -f %s ] && source %s # claudew-managed
-[ -f %s ] && source %s # claudew-managed
-`, shellIntegrationPath, shellIntegrationPath, completionSetupPath, completionSetupPath)
+		if err := os.WriteFile(rcFile, []byte(newContent), 0644); err != nil {
+			return fmt.Errorf("failed to write %s: %w", rcFile, err)
+		}
+		warnLegacyRCHeaders(rcFile, newContent)
 
-		if _, err := f.WriteString(rcAdditions); err != nil {
-			return fmt.Errorf("failed to write to %s: %w", rcFile, err)
+		if rcFileOverride != "" {
+			if err := os.WriteFile(rcTargetPath(home), []byte(rcFile+"\n"), 0644); err != nil {
+				return fmt.Errorf("failed to record rc file: %w", err)
+			}
 		}
 
 		fmt.Println("✓ Shell integration installed")
@@ -235,123 +462,19 @@ This will remove:
 - Completion setup
 - Old claude-workspace integration (if present)
 
+The rc file is the one install-shell used; pass --rc-file to clean up another one.
+
 After uninstalling, you can reinstall with: claudew install-shell`,
 	RunE: func(cmd *cobra.Command, args []string) error {
-		home, err := os.UserHomeDir()
+		rcFile, found, err := removeShellIntegration()
 		if err != nil {
-			return fmt.Errorf("failed to get home directory: %w", err)
-		}
-
-		// Detect shell
-		shell := os.Getenv("SHELL")
-		var rcFile string
-		if strings.Contains(shell, "zsh") {
-			rcFile = filepath.Join(home, ".zshrc")
-		} else if strings.Contains(shell, "bash") {
-			rcFile = filepath.Join(home, ".bashrc")
-		} else {
-			return fmt.Errorf("unsupported shell: %s (only bash and zsh supported)", shell)
-		}
-
-		// Read current rc file
-		content, err := os.ReadFile(rcFile)
-		if err != nil {
-			return fmt.Errorf("failed to read %s: %w", rcFile, err)
+			return err
 		}
-
-		originalContent := string(content)
-		hasOldIntegration := strings.Contains(originalContent, "# claude-workspace shell integration")
-		hasNewIntegration := strings.Contains(originalContent, "# claudew shell integration")
-
-		if !hasOldIntegration && !hasNewIntegration {
+		if !found {
 			fmt.Println("✓ No shell integration found - nothing to uninstall")
 			return nil
 		}
 
-		// Remove old integration markers
-		markers := []string{
-			"# claude-workspace shell integration",
-			"# claudew shell integration",
-			"# claude-workspace completion",
-			"# claudew completion",
-		}
-
-		lines := strings.Split(originalContent, "\n")
-		var newLines []string
-		skipUntilBlank := false
-
-		for i, line := range lines {
-			// Check if this line is a marker
-			isMarker := false
-			for _, marker := range markers {
-				if strings.TrimSpace(line) == marker {
-					isMarker = true
-					skipUntilBlank = true
-					break
-				}
-			}
-
-			if isMarker {
-				// Skip this line and start looking for the end of the section
-				continue
-			}
-
-			if skipUntilBlank {
-				// Skip until we hit a blank line or a non-integration line
-				trimmed := strings.TrimSpace(line)
-
-				// Check if we've reached the end of the integration section
-				// Integration ends at: blank line, or a line that starts with # but isn't part of completion
-				if trimmed == "" {
-					// Found blank line - check if next line is also integration-related
-					if i+1 < len(lines) {
-						nextLine := strings.TrimSpace(lines[i+1])
-						// If next line is a known integration marker, keep skipping
-						isNextMarker := false
-						for _, marker := range markers {
-							if nextLine == marker {
-								isNextMarker = true
-								break
-							}
-						}
-						if isNextMarker {
-							continue // Keep skipping
-						}
-					}
-					skipUntilBlank = false
-					newLines = append(newLines, line) // Keep the blank line
-				}
-				// Skip lines that look like integration content
-				continue
-			}
-
-			// Keep this line
-			newLines = append(newLines, line)
-		}
-
-		// Write back
-		newContent := strings.Join(newLines, "\n")
-		if err := os.WriteFile(rcFile, []byte(newContent), 0644); err != nil {
-			return fmt.Errorf("failed to write %s: %w", rcFile, err)
-		}
-
-		// Clean up completion files
-		if strings.Contains(shell, "zsh") {
-			oldCompPath := filepath.Join(home, ".zsh", "completion", "_claude-workspace")
-			newCompPath := filepath.Join(home, ".zsh", "completion", "_claudew")
-			os.Remove(oldCompPath) // Ignore errors
-			os.Remove(newCompPath) // Ignore errors
-		} else {
-			oldCompPath := filepath.Join(home, ".claude-workspace-completion.bash")
-			newCompPath := filepath.Join(home, ".claudew-completion.bash")
-			os.Remove(oldCompPath) // Ignore errors
-			os.Remove(newCompPath) // Ignore errors
-		}
-
-		// Clean up ~/.claudew directory
-		claudewDir := filepath.Join(home, ".claudew")
-		os.RemoveAll(claudewDir) // Remove directory and all contents
-
 		fmt.Println("✓ Shell integration uninstalled")
 		fmt.Printf("  Cleaned up: %s\n", rcFile)
 		fmt.Println("\nTo reinstall:")
@@ -363,23 +486,69 @@ After uninstalling, you can reinstall with: claudew install-shell`,
 	},
 }
 
-// removeClaudewSections removes all claudew-managed lines from rc file content
-func removeClaudewSections(content string) string {
-	lines := strings.Split(content, "\n")
-	var newLines []string
+// removeShellIntegration strips the claudew sections from the shell rc file and removes
+// the completion files and ~/.claudew. It reports false if the rc file had no integration.
+func removeShellIntegration() (string, bool, error) {
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return "", false, fmt.Errorf("failed to get home directory: %w", err)
+	}
 
-	for _, line := range lines {
-		// Skip any line with the claudew-managed marker
-		if strings.Contains(line, "# claudew-managed") {
+	rcFile, err := shellRCFile(home)
+	if err != nil {
+		return "", false, err
+	}
+
+	// Read current rc file
+	content, err := os.ReadFile(rcFile)
+	if err != nil {
+		return "", false, fmt.Errorf("failed to read %s: %w", rcFile, err)
+	}
+
+	if !hasShellIntegration(string(content)) {
+		return rcFile, false, nil
+	}
+
+	newContent, err := removeClaudewSections(string(content))
+	if err != nil {
+		return "", false, fmt.Errorf("failed to update %s: %w", rcFile, err)
+	}
+	if err := os.WriteFile(rcFile, []byte(newContent), 0644); err != nil {
+		return "", false, fmt.Errorf("failed to write %s: %w", rcFile, err)
+	}
+	warnLegacyRCHeaders(rcFile, newContent)
+
+	removeShellFiles(home)
+
+	return rcFile, true, nil
+}
+
+// removeShellFiles deletes the zsh and bash completion files and ~/.claudew, returning the paths that existed
+func removeShellFiles(home string) []string {
+	paths := []string{
+		filepath.Join(home, ".zsh", "completion", "_claude-workspace"),
+		filepath.Join(home, ".zsh", "completion", "_claudew"),
+		filepath.Join(home, ".claude-workspace-completion.bash"),
+		filepath.Join(home, ".claudew-completion.bash"),
+		filepath.Join(home, ".claudew"),
+	}
+
+	var removed []string
+	for _, path := range paths {
+		if _, err := os.Stat(path); err != nil {
 			continue
 		}
-		// Keep all other lines
-		newLines = append(newLines, line)
+		if err := os.RemoveAll(path); err == nil {
+			removed = append(removed, path)
+		}
 	}
-
-	return strings.Join(newLines, "\n")
+	return removed
 }
 
 func init() {
 	installShellCmd.Flags().BoolVarP(&installShellForce, "force", "f", false, "Force reinstall even if already installed")
+	installShellCmd.Flags().BoolVar(&installShellPrintIntegration, "print-integration", false, "Print the shell function to stdout instead of installing it")
+	installShellCmd.Flags().BoolVar(&installShellPrintOnly, "print-only", false, "Install the integration files but print the rc block instead of editing the rc file")
+	installShellCmd.Flags().StringVar(&rcFileOverride, "rc-file", "", "Shell startup file to edit instead of the detected one")
+	uninstallShellCmd.Flags().StringVar(&rcFileOverride, "rc-file", "", "Shell startup file to remove the integration from")
 }
@@ -0,0 +1,121 @@
+package cmd
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSetClaudewBlock_AppendsAndIsIdempotent(t *testing.T) {
+	block := rcIntegrationBlock("/home/me/.claudew/shell-integration.sh")
+	rc := "export PATH=$HOME/bin:$PATH\n"
+
+	once, err := setClaudewBlock(rc, block)
+	require.NoError(t, err)
+	assert.Equal(t, "export PATH=$HOME/bin:$PATH\n\n"+block, once)
+
+	twice, err := setClaudewBlock(once, block)
+	require.NoError(t, err)
+	assert.Equal(t, once, twice)
+}
+
+func TestSetClaudewBlock_ReplacesInPlace(t *testing.T) {
+	rc := "alias ll='ls -l'\n" +
+		rcBlockBegin + "\n[ -f /old ] && source /old\n" + rcBlockEnd + "\n" +
+		"eval \"$(direnv hook zsh)\"\n"
+
+	updated, err := setClaudewBlock(rc, rcIntegrationBlock("/new"))
+	require.NoError(t, err)
+	assert.Equal(t, "alias ll='ls -l'\n"+rcIntegrationBlock("/new")+"eval \"$(direnv hook zsh)\"\n", updated)
+}
+
+func TestSetClaudewBlock_MigratesManagedLines(t *testing.T) {
+	rc := "alias ll='ls -l'\n\n[ -f /a ] && source /a # claudew-managed\n[ -f /b ] && source /b # claudew-managed\n"
+
+	updated, err := setClaudewBlock(rc, rcIntegrationBlock("/a", "/b"))
+	require.NoError(t, err)
+	assert.Equal(t, "alias ll='ls -l'\n\n"+rcIntegrationBlock("/a", "/b"), updated)
+}
+
+func TestRemoveClaudewSections_KeepsUnrelatedLines(t *testing.T) {
+	// The old heuristic deleted everything up to the next blank line
+	rc := "alias ll='ls -l'\n\n" + rcIntegrationBlock("/a") + "export EDITOR=vim\nsource ~/.fzf.zsh\n"
+
+	cleaned, err := removeClaudewSections(rc)
+	require.NoError(t, err)
+	assert.Equal(t, "alias ll='ls -l'\n\nexport EDITOR=vim\nsource ~/.fzf.zsh\n", cleaned)
+}
+
+func TestRemoveClaudewSections_RoundTrip(t *testing.T) {
+	rc := "alias ll='ls -l'\n"
+
+	installed, err := setClaudewBlock(rc, rcIntegrationBlock("/a"))
+	require.NoError(t, err)
+	removed, err := removeClaudewSections(installed)
+	require.NoError(t, err)
+	assert.Equal(t, rc, removed)
+}
+
+func TestRemoveClaudewSections_UnterminatedBlock(t *testing.T) {
+	rc := "alias ll='ls -l'\n" + rcBlockBegin + "\n[ -f /a ] && source /a\nexport EDITOR=vim\n"
+
+	_, err := removeClaudewSections(rc)
+	assert.Error(t, err)
+	_, err = setClaudewBlock(rc, rcIntegrationBlock("/a"))
+	assert.Error(t, err)
+}
+
+func TestHasShellIntegration(t *testing.T) {
+	assert.False(t, hasShellIntegration("alias ll='ls -l'\n# claudew is great\n"))
+	assert.True(t, hasShellIntegration(rcIntegrationBlock("/a")))
+	assert.True(t, hasShellIntegration("[ -f /a ] && source /a # claudew-managed\n"))
+	assert.True(t, hasShellIntegration("# claude-workspace shell integration\nfoo\n"))
+}
+
+func TestLegacyRCHeaderLines(t *testing.T) {
+	rc := "alias ll='ls -l'\n# claudew shell integration\nclaudew() { ... }\n"
+	assert.Equal(t, []int{2}, legacyRCHeaderLines(rc))
+	assert.Empty(t, legacyRCHeaderLines(rcIntegrationBlock("/a")))
+}
+
+func TestDetectShellRCFile(t *testing.T) {
+	t.Setenv("ZDOTDIR", "")
+	home := t.TempDir()
+	write := func(name, content string) {
+		require.NoError(t, os.WriteFile(filepath.Join(home, name), []byte(content), 0644))
+	}
+	detect := func(shell, goos string) string {
+		path, err := detectShellRCFile(home, shell, goos)
+		require.NoError(t, err)
+		return filepath.Base(path)
+	}
+
+	assert.Equal(t, ".zshrc", detect("/bin/zsh", "darwin"))
+	assert.Equal(t, ".bashrc", detect("/bin/bash", "linux"))
+	assert.Equal(t, ".bash_profile", detect("/bin/bash", "darwin"))
+
+	write(".bash_profile", "[ -f ~/.bashrc ] && . ~/.bashrc\n")
+	assert.Equal(t, ".bashrc", detect("/bin/bash", "darwin"))
+
+	write(".zprofile", "eval \"$(/opt/homebrew/bin/brew shellenv)\"\n")
+	assert.Equal(t, ".zprofile", detect("/bin/zsh", "darwin"))
+	write(".zshrc", "")
+	assert.Equal(t, ".zshrc", detect("/bin/zsh", "darwin"))
+
+	_, err := detectShellRCFile(home, "/usr/bin/fish", "linux")
+	assert.Error(t, err)
+}
+
+func TestShellRCFile_RecordedTarget(t *testing.T) {
+	home := t.TempDir()
+	custom := filepath.Join(home, "dotfiles", "zshrc.local")
+	require.NoError(t, os.MkdirAll(filepath.Join(home, ".claudew"), 0755))
+	require.NoError(t, os.WriteFile(rcTargetPath(home), []byte(custom+"\n"), 0644))
+
+	path, err := shellRCFile(home)
+	require.NoError(t, err)
+	assert.Equal(t, custom, path)
+}
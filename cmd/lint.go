@@ -0,0 +1,36 @@
+package cmd
+
+import (
+	"time"
+
+	"github.com/pmossman/claudew/internal/config"
+	"github.com/pmossman/claudew/internal/workspace"
+)
+
+// Defaults for the workspace file hygiene checks, matching the CLAUDE.md protocol
+const (
+	defaultContextMaxWords         = 500
+	defaultContinuationMaxAgeHours = 24
+)
+
+// workspaceLintLimits returns the configured hygiene thresholds (negative settings disable a check)
+func workspaceLintLimits(cfg *config.Config) workspace.LintLimits {
+	words := cfg.Settings.ContextMaxWords
+	if words == 0 {
+		words = defaultContextMaxWords
+	}
+	hours := cfg.Settings.ContinuationMaxAgeHours
+	if hours == 0 {
+		hours = defaultContinuationMaxAgeHours
+	}
+	return workspace.LintLimits{
+		ContextWords:    max(words, 0),
+		ContinuationAge: time.Duration(max(hours, 0)) * time.Hour,
+	}
+}
+
+// lintWorkspace returns hygiene warnings for a workspace's files. The continuation's age
+// only matters while Claude is running in the workspace.
+func lintWorkspace(cfg *config.Config, wsMgr *workspace.Manager, name string, running bool) []string {
+	return wsMgr.Lint(name, workspaceLintLimits(cfg), running, time.Now())
+}
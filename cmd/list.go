@@ -2,23 +2,71 @@ package cmd
 
 import (
 	"fmt"
+	"os"
+	"slices"
 	"sort"
+	"strings"
 	"time"
 
+	"github.com/pmossman/claudew/internal/app"
 	"github.com/pmossman/claudew/internal/config"
+	"github.com/pmossman/claudew/internal/session"
+	"github.com/pmossman/claudew/internal/stats"
+	"github.com/pmossman/claudew/internal/table"
+	"github.com/pmossman/claudew/internal/transcript"
 	"github.com/pmossman/claudew/internal/workspace"
 	"github.com/spf13/cobra"
 )
 
 var (
 	listArchived bool
+	listStatus   string
+	listState    string
+	listRemote   string
+	listSort     string
+	listReverse  bool
+	listLimit    int
+	listWide     bool
+	listActivity bool
 )
 
 var listCmd = &cobra.Command{
 	Use:   "list",
 	Short: "List all workspaces",
-	Long:  `Lists all workspaces with their status and last active time.`,
+	Long: `Lists all workspaces with their status, live session state and last active time.
+
+SESSION shows whether the workspace's tmux session is attached (with the number of
+attached terminals), detached, or missing; "none" is highlighted for workspaces the
+config still marks active.
+
+--activity adds a LAST 7 DAYS column with a sparkline of Claude's activity in each
+workspace per day, oldest first, read from its Claude transcripts: "·" is a day
+without any, and blocks grow with the day's messages and tool calls relative to the
+busiest day listed. A row of dots is a dormant workstream worth archiving.
+
+Example:
+  claudew list --status idle --remote backend           # Idle backend workspaces
+  claudew list --state blocked                          # What is waiting on others
+  claudew list --sort last-active --reverse --limit 10  # 10 least recently used
+  claudew list --sort name                              # Alphabetical
+  claudew list --activity --sort last-active --reverse  # Spot dormant workspaces`,
 	RunE: func(cmd *cobra.Command, args []string) error {
+		switch listStatus {
+		case "", config.StatusActive, config.StatusIdle, config.StatusArchived:
+		default:
+			return fmt.Errorf("invalid --status '%s' (must be active, idle, or archived)", listStatus)
+		}
+		if listState != "" && listState != "none" {
+			if err := config.ValidateState(listState); err != nil {
+				return fmt.Errorf("invalid --state: %w", err)
+			}
+		}
+		switch listSort {
+		case "name", "last-active", "created":
+		default:
+			return fmt.Errorf("invalid --sort '%s' (must be name, last-active, or created)", listSort)
+		}
+
 		// Load config
 		cfg, err := config.Load()
 		if err != nil {
@@ -31,76 +79,217 @@ var listCmd = &cobra.Command{
 			return nil
 		}
 
-		wsMgr := workspace.NewManager(cfg.Settings.WorkspaceDir)
+		wsMgr := workspace.NewManagerForConfig(cfg)
+
+		// Use real tmux activity for "last active" and ordering
+		refreshLastActive(cfg)
+
+		if listRemote != "" {
+			if _, err := cfg.GetRemote(listRemote); err != nil {
+				return err
+			}
+		}
 
-		// Sort workspaces by last active (most recent first)
 		type wsEntry struct {
 			name string
 			ws   *config.Workspace
 		}
 		var entries []wsEntry
 		for name, ws := range cfg.Workspaces {
-			// Skip archived workspaces unless explicitly requested
-			if !listArchived && ws.Status == config.StatusArchived {
+			if !matchesListFilters(cfg, ws) {
 				continue
 			}
 			entries = append(entries, wsEntry{name: name, ws: ws})
 		}
+
 		sort.Slice(entries, func(i, j int) bool {
-			return entries[i].ws.LastActive.After(entries[j].ws.LastActive)
+			a, b := entries[i], entries[j]
+			if listReverse {
+				a, b = b, a
+			}
+			switch listSort {
+			case "name":
+				return a.name < b.name
+			case "created":
+				return a.ws.CreatedAt.After(b.ws.CreatedAt)
+			default:
+				return a.ws.LastActive.After(b.ws.LastActive)
+			}
 		})
 
-		// Print header
-		fmt.Printf("%-20s %-10s %-50s %s\n", "NAME", "STATUS", "REPO PATH", "LAST ACTIVE")
-		fmt.Println("────────────────────────────────────────────────────────────────────────────────────────────────────────")
+		if listLimit > 0 && len(entries) > listLimit {
+			entries = entries[:listLimit]
+		}
+
+		if len(entries) == 0 {
+			fmt.Println("No workspaces match the given filters.")
+			return nil
+		}
+
+		columns := []table.Column{
+			{Header: "NAME", Max: 30},
+			{Header: "STATUS"},
+			{Header: "SESSION"},
+			{Header: "STATE"},
+			{Header: "REPO PATH", Max: 50, KeepEnd: true},
+			{Header: "LAST ACTIVE"},
+		}
+
+		// Activity is read for every row first, so all sparklines share one scale
+		var activity map[string][]int
+		peak := 0
+		if listActivity {
+			columns = append(columns, table.Column{Header: fmt.Sprintf("LAST %d DAYS", activityDays)})
+			activity = map[string][]int{}
+			now := time.Now()
+			for _, entry := range entries {
+				if counts, ok := workspaceActivity(entry.ws, now); ok {
+					activity[entry.name] = counts
+					peak = max(peak, slices.Max(counts))
+				}
+			}
+		}
+
+		tbl := table.New(columns...)
+		tbl.Wide = listWide
+
+		// Live session state for every workspace in one tmux call
+		sessionMgr := session.NewManagerForConfig(cfg)
+		attached, err := sessionMgr.ListAttached()
+		if err != nil {
+			attached = map[string]int{}
+		}
 
-		// Print workspaces
 		for _, entry := range entries {
 			ws := entry.ws
 			summary := wsMgr.GetSummary(entry.name)
 
-			// Format last active time
-			lastActive := formatTimeAgo(ws.LastActive)
-
-			// Status with color codes
-			statusStr := formatStatus(ws.Status)
-
-			// Truncate repo path if too long
-			repoPath := ws.GetRepoPath()
-			if len(repoPath) > 50 {
-				repoPath = "..." + repoPath[len(repoPath)-47:]
+			statusStr := paint(statusColor(ws.Status), formatStatus(ws.Status))
+			clients, running := attached[sessionMgr.GetSessionName(entry.name)]
+			row := []string{paint(workspaceColor(ws), entry.name), statusStr, formatSessionState(cfg, entry.name, running, clients), formatListState(ws), ws.GetRepoPath(), formatTimeAgo(ws.LastActive)}
+			if listActivity {
+				row = append(row, formatActivity(activity[entry.name], peak))
 			}
+			tbl.AddRow(row...)
 
-			fmt.Printf("%-20s %-10s %-50s %s\n", entry.name, statusStr, repoPath, lastActive)
-
-			// Print summary and clone info
-			if summary != "(no summary)" {
-				fmt.Printf("  └─ %s", summary)
-
-				// Add clone info if managed
-				if ws.ClonePath != "" {
-					if clone, err := cfg.GetClone(ws.ClonePath); err == nil {
-						fmt.Printf(" (%s, %s)", clone.RemoteName, clone.CurrentBranch)
-					}
-				} else {
-					fmt.Printf(" [unmanaged]")
-				}
-				fmt.Println()
-			} else if ws.ClonePath != "" {
-				// Show clone info even without summary
+			// Summary and clone info go on a detail line under the row
+			var detail string
+			if ws.ClonePath != "" {
 				if clone, err := cfg.GetClone(ws.ClonePath); err == nil {
-					fmt.Printf("  └─ (%s, %s)\n", clone.RemoteName, clone.CurrentBranch)
+					detail = fmt.Sprintf("(%s, %s)", clone.RemoteName, displayBranch(cfg, entry.name, ws))
+				} else if branch := displayBranch(cfg, entry.name, ws); branch != "" {
+					detail = "(" + branch + ")"
 				}
 			} else {
-				// No summary and no clone - show unmanaged
-				fmt.Printf("  └─ [unmanaged]\n")
+				detail = "[unmanaged]"
+			}
+			if summary != "(no summary)" {
+				detail = strings.TrimSpace(summary + " " + detail)
+			}
+			if detail != "" {
+				tbl.AddLine("  └─ " + detail)
+			}
+			if ws.StateReason != "" || !ws.BlockedUntil.IsZero() {
+				tbl.AddLine("  " + formatWorkspaceState(ws))
+			}
+			for _, warning := range lintWorkspace(cfg, wsMgr, entry.name, running) {
+				tbl.AddLine("  " + paint(colorYellow, "⚠ "+warning))
 			}
 		}
+		tbl.Render(os.Stdout)
 
 		return nil
 	},
 }
 
+// activityDays is how many days list --activity shows
+const activityDays = 7
+
+// workspaceActivity counts Claude's activity in a workspace on each of the last
+// activityDays days, from the transcripts of its work directory. Like stats, it only
+// counts sessions from the workspace's lifetime, since other workspaces may have used
+// the clone before it.
+func workspaceActivity(ws *config.Workspace, now time.Time) ([]int, bool) {
+	workDir := ws.GetWorkDir()
+	if workDir == "" {
+		return nil, false
+	}
+	var until time.Time
+	if ws.Status == config.StatusArchived {
+		until = ws.LastActive
+	}
+	counts, err := stats.Activity(transcript.ProjectDir(claudeHomeDir(), workDir), ws.CreatedAt, until, now, activityDays)
+	if err != nil {
+		return nil, false
+	}
+	return counts, true
+}
+
+// sparkBlocks draw activity levels, lowest first
+var sparkBlocks = []rune("▁▂▃▄▅▆▇█")
+
+// formatActivity draws one character per day: "·" for a day without activity, else
+// a block scaled against peak, the busiest day across the listed workspaces. Without
+// transcripts it shows "-".
+func formatActivity(counts []int, peak int) string {
+	if counts == nil {
+		return paint(colorGray, "-")
+	}
+	var b strings.Builder
+	for _, n := range counts {
+		if n == 0 || peak == 0 {
+			b.WriteRune('·')
+			continue
+		}
+		level := (n*len(sparkBlocks) - 1) / peak
+		b.WriteRune(sparkBlocks[level])
+	}
+	if slices.Max(counts) == 0 {
+		return paint(colorGray, b.String())
+	}
+	return b.String()
+}
+
+// matchesListFilters reports whether a workspace passes the list command's filters
+func matchesListFilters(cfg *config.Config, ws *config.Workspace) bool {
+	if listStatus != "" {
+		if ws.Status != listStatus {
+			return false
+		}
+	} else if !listArchived && ws.Status == config.StatusArchived {
+		// Skip archived workspaces unless explicitly requested
+		return false
+	}
+
+	if listState == "none" {
+		if ws.State != "" {
+			return false
+		}
+	} else if listState != "" && ws.State != listState {
+		return false
+	}
+
+	if listRemote != "" {
+		if ws.ClonePath == "" {
+			return false
+		}
+		clone, err := cfg.GetClone(ws.ClonePath)
+		if err != nil || clone.RemoteName != listRemote {
+			return false
+		}
+	}
+
+	return true
+}
+
+// formatListState returns the workspace's state for the list's STATE column
+func formatListState(ws *config.Workspace) string {
+	if ws.State == "" {
+		return paint(colorGray, "-")
+	}
+	return paint(stateColor(ws.State), ws.State)
+}
+
 func formatStatus(status string) string {
 	switch status {
 	case config.StatusActive:
@@ -114,6 +303,39 @@ func formatStatus(status string) string {
 	}
 }
 
+// formatSessionState describes a workspace's session: attached (with the tmux client
+// count), detached, foreground or none. A missing session is flagged when the config
+// says active.
+func formatSessionState(cfg *config.Config, name string, running bool, clients int) string {
+	if !running {
+		if _, ok := app.ForegroundPID(cfg, name); ok {
+			return paint(colorGreen, "foreground")
+		}
+	}
+	switch {
+	case !running && cfg.Workspaces[name].Status == config.StatusActive:
+		return paint(colorRed, "none")
+	case !running:
+		return paint(colorGray, "none")
+	case clients == 0:
+		return paint(colorYellow, "detached")
+	default:
+		return paint(colorGreen, fmt.Sprintf("attached (%d)", clients))
+	}
+}
+
+// statusColor returns the color used to display a workspace status
+func statusColor(status string) string {
+	switch status {
+	case config.StatusActive:
+		return colorGreen
+	case config.StatusIdle:
+		return colorYellow
+	default:
+		return colorGray
+	}
+}
+
 func formatTimeAgo(t time.Time) string {
 	duration := time.Since(t)
 
@@ -142,4 +364,16 @@ func formatTimeAgo(t time.Time) string {
 
 func init() {
 	listCmd.Flags().BoolVar(&listArchived, "archived", false, "Include archived workspaces in the list")
+	listCmd.Flags().StringVar(&listStatus, "status", "", "Only show workspaces with this status (active, idle, archived)")
+	listCmd.Flags().StringVar(&listState, "state", "", "Only show workspaces in this workflow state (todo, in-progress, blocked, review, done, none)")
+	listCmd.Flags().StringVar(&listRemote, "remote", "", "Only show workspaces using a clone of this remote")
+	listCmd.Flags().StringVar(&listSort, "sort", "last-active", "Sort by name, last-active, or created")
+	listCmd.Flags().BoolVar(&listReverse, "reverse", false, "Reverse the sort order")
+	listCmd.Flags().IntVar(&listLimit, "limit", 0, "Show at most N workspaces")
+	listCmd.Flags().BoolVarP(&listWide, "wide", "w", false, "Don't truncate long names or paths")
+	listCmd.Flags().BoolVar(&listActivity, "activity", false, "Show a sparkline of each workspace's activity over the last 7 days")
+	listCmd.RegisterFlagCompletionFunc("remote", validRemoteNames)
+	listCmd.RegisterFlagCompletionFunc("status", cobra.FixedCompletions([]string{config.StatusActive, config.StatusIdle, config.StatusArchived}, cobra.ShellCompDirectiveNoFileComp))
+	listCmd.RegisterFlagCompletionFunc("state", cobra.FixedCompletions(append(append([]string(nil), config.WorkspaceStates...), "none"), cobra.ShellCompDirectiveNoFileComp))
+	listCmd.RegisterFlagCompletionFunc("sort", cobra.FixedCompletions([]string{"name", "last-active", "created"}, cobra.ShellCompDirectiveNoFileComp))
 }
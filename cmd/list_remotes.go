@@ -2,12 +2,16 @@ package cmd
 
 import (
 	"fmt"
+	"os"
 	"sort"
 
 	"github.com/pmossman/claudew/internal/config"
+	"github.com/pmossman/claudew/internal/table"
 	"github.com/spf13/cobra"
 )
 
+var listRemotesWide bool
+
 var listRemotesCmd = &cobra.Command{
 	Use:   "list-remotes",
 	Short: "List all registered remotes",
@@ -32,11 +36,13 @@ var listRemotesCmd = &cobra.Command{
 		}
 		sort.Strings(names)
 
-		// Print header
-		fmt.Printf("%-15s %-50s %s\n", "NAME", "URL", "CLONE DIRECTORY")
-		fmt.Println("─────────────────────────────────────────────────────────────────────────────────────────────────────────")
+		tbl := table.New(
+			table.Column{Header: "NAME", Max: 20},
+			table.Column{Header: "URL", Max: 50},
+			table.Column{Header: "CLONE DIRECTORY", Max: 50, KeepEnd: true},
+		)
+		tbl.Wide = listRemotesWide
 
-		// Print remotes
 		for _, name := range names {
 			remote := cfg.Remotes[name]
 
@@ -49,19 +55,25 @@ var listRemotesCmd = &cobra.Command{
 				}
 			}
 
-			url := remote.URL
-			if len(url) > 50 {
-				url = url[:47] + "..."
+			tbl.AddRow(name, remote.URL, remote.CloneBaseDir)
+			if remote.Shared {
+				tbl.AddLine("  ├─ Shared: " + cfg.Settings.SharedRemotesFile)
+			}
+			if remote.CloneStrategy != "" {
+				tbl.AddLine("  ├─ Clone strategy: " + remote.CloneStrategy)
 			}
-
-			fmt.Printf("%-15s %-50s %s\n", name, url, remote.CloneBaseDir)
 			if len(clones) > 0 {
-				fmt.Printf("  └─ %d clones (%d free, %d in use)\n", len(clones), freeCount, len(clones)-freeCount)
+				tbl.AddLine(fmt.Sprintf("  └─ %d clones (%d free, %d in use)", len(clones), freeCount, len(clones)-freeCount))
 			} else {
-				fmt.Printf("  └─ No clones yet\n")
+				tbl.AddLine("  └─ No clones yet")
 			}
 		}
+		tbl.Render(os.Stdout)
 
 		return nil
 	},
 }
+
+func init() {
+	listRemotesCmd.Flags().BoolVarP(&listRemotesWide, "wide", "w", false, "Don't truncate long URLs or paths")
+}
@@ -0,0 +1,84 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"sort"
+
+	"github.com/pmossman/claudew/internal/config"
+	"github.com/pmossman/claudew/internal/session"
+	"github.com/pmossman/claudew/internal/workspace"
+)
+
+// staleLockReason returns why a workspace's lock file is stale, or "" if it is valid or absent
+func staleLockReason(wsMgr *workspace.Manager, sessionMgr *session.Manager, name string) string {
+	state, err := wsMgr.GetLockState(name)
+	if err != nil || !state.Exists {
+		return ""
+	}
+
+	// A released flock lock leaves its file behind for the next holder; removing it
+	// would race with a process taking the lock
+	if state.Managed && !state.Held {
+		return ""
+	}
+	if !state.Held {
+		if state.PID == 0 {
+			return "unreadable lock file"
+		}
+		return fmt.Sprintf("PID %d is not running", state.PID)
+	}
+
+	// A live flock holder releases the lock itself when it exits
+	if state.Managed {
+		return ""
+	}
+
+	// Legacy PID lock whose PID is alive (possibly reused) but whose session is gone
+	exists, err := sessionMgr.Exists(sessionMgr.GetSessionName(name))
+	if err == nil && !exists {
+		return fmt.Sprintf("no tmux session (PID %d)", state.PID)
+	}
+	return ""
+}
+
+// removeStaleLock removes a workspace's lock file if staleLockReason finds it stale,
+// returning the reason, or "" if the lock was left alone. Unheld flock-managed locks
+// are never removed, so this can't race with another process taking the lock.
+func removeStaleLock(wsMgr *workspace.Manager, sessionMgr *session.Manager, name string) (string, error) {
+	reason := staleLockReason(wsMgr, sessionMgr, name)
+	if reason == "" {
+		return "", nil
+	}
+	if err := wsMgr.RemoveLock(name); err != nil {
+		return "", err
+	}
+	return reason, nil
+}
+
+// cleanStaleLocks removes stale lock files for all workspaces, logging each one to stderr.
+// Returns the number of locks removed.
+func cleanStaleLocks(cfg *config.Config, wsMgr *workspace.Manager, sessionMgr *session.Manager) int {
+	var names []string
+	for name, ws := range cfg.Workspaces {
+		if ws.Status != config.StatusArchived {
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names)
+
+	removed := 0
+	for _, name := range names {
+		reason, err := removeStaleLock(wsMgr, sessionMgr, name)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Warning: failed to remove stale lock for '%s': %v\n", name, err)
+			continue
+		}
+		if reason == "" {
+			continue
+		}
+		fmt.Fprintf(os.Stderr, "🧹 Removed stale lock for '%s' (%s)\n", name, reason)
+		removed++
+	}
+	return removed
+}
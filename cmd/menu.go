@@ -0,0 +1,154 @@
+package cmd
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"strings"
+
+	"github.com/pmossman/claudew/internal/execx"
+	"github.com/pmossman/claudew/internal/menu"
+	"github.com/pmossman/claudew/internal/table"
+)
+
+// menuOptions configures an interactive selector
+type menuOptions struct {
+	header        string
+	prompt        string
+	height        string // fzf --height, e.g. "50%"
+	preview       string // fzf preview command, ignored by the numbered fallback
+	previewWindow string
+	reverse       bool
+	noSort        bool
+	tmux          string // fzf --tmux popup options, only used inside tmux
+	query         string // initial filter, e.g. from the command line
+	withIDs       bool   // lines are made with menu.Line; only their display text is shown
+}
+
+// fzfInstalled reports whether fzf is available on PATH
+func fzfInstalled() bool {
+	_, err := exec.LookPath("fzf")
+	return err == nil
+}
+
+// runMenu lets the user pick one of lines with fzf, falling back to a numbered
+// list on the terminal when fzf isn't installed. Returns "" if the user cancelled.
+// Callers apply the user's fzf_* settings to opts with applyFzfSettings.
+func runMenu(lines []string, opts menuOptions) (string, error) {
+	if !fzfInstalled() {
+		return runNumberedMenu(lines, opts)
+	}
+
+	fzfCmd := execx.Command("fzf", "--ansi")
+	if opts.noSort {
+		fzfCmd.Args = append(fzfCmd.Args, "--no-sort")
+	}
+	if opts.reverse {
+		fzfCmd.Args = append(fzfCmd.Args, "--layout=reverse")
+	}
+	if opts.height != "" {
+		fzfCmd.Args = append(fzfCmd.Args, "--height="+opts.height)
+	}
+	if opts.preview != "" {
+		fzfCmd.Args = append(fzfCmd.Args, "--preview="+opts.preview)
+		if opts.previewWindow != "" {
+			fzfCmd.Args = append(fzfCmd.Args, "--preview-window="+opts.previewWindow)
+		}
+	}
+	if opts.tmux != "" {
+		fzfCmd.Args = append(fzfCmd.Args, "--tmux="+opts.tmux)
+	}
+	if opts.query != "" {
+		fzfCmd.Args = append(fzfCmd.Args, "--query="+opts.query)
+	}
+	if opts.withIDs {
+		// Show and search only the display text; fzf still prints the whole line
+		fzfCmd.Args = append(fzfCmd.Args, "--delimiter="+menu.IDSeparator, "--with-nth=2..")
+		lines = withEmptyIDs(lines)
+	}
+	fzfCmd.Args = append(fzfCmd.Args, "--header="+opts.header, "--prompt="+opts.prompt)
+	fzfCmd.Args = append(fzfCmd.Args, fzfColorArgs()...)
+
+	// Set up pipes
+	fzfCmd.Stdin = strings.NewReader(strings.Join(lines, "\n"))
+	fzfCmd.Stderr = os.Stderr
+
+	var outBuf bytes.Buffer
+	fzfCmd.Stdout = &outBuf
+
+	if err := fzfCmd.Run(); err != nil {
+		// User cancelled (Ctrl-C)
+		var exitErr *execx.ExitError
+		if errors.As(err, &exitErr) && exitErr.ExitCode() == 130 {
+			return "", nil
+		}
+		return "", fmt.Errorf("fzf failed: %w", err)
+	}
+
+	if opts.withIDs {
+		selected := strings.TrimRight(outBuf.String(), "\r\n")
+		if strings.TrimSpace(selected) == "" {
+			return "", nil // A blank separator line
+		}
+		return selected, nil
+	}
+	return strings.TrimSpace(outBuf.String()), nil
+}
+
+// withEmptyIDs gives lines without an ID, such as headers, an empty one, so fzf
+// shows their text in a menu of menu.Line lines
+func withEmptyIDs(lines []string) []string {
+	result := make([]string, len(lines))
+	for i, line := range lines {
+		if !strings.Contains(line, menu.IDSeparator) {
+			line = menu.Line("", line)
+		}
+		result[i] = line
+	}
+	return result
+}
+
+// padMenuName pads a name with spaces to width terminal cells, so the columns after
+// it line up even when names contain wide characters such as CJK or emoji
+func padMenuName(name string, width int) string {
+	return name + strings.Repeat(" ", max(width-table.Width(name), 0))
+}
+
+// menuNameWidth returns the display width of the widest of names
+func menuNameWidth(names []string) int {
+	width := 0
+	for _, name := range names {
+		width = max(width, table.Width(name))
+	}
+	return width
+}
+
+// runNumberedMenu shows lines as a numbered list on the terminal, for systems without fzf
+func runNumberedMenu(lines []string, opts menuOptions) (string, error) {
+	// Use the terminal so the menu works even when stdout is captured by the shell wrapper
+	tty := openTerminal()
+	defer tty.Close()
+
+	// Without fzf to filter as the user types, apply the query up front
+	if opts.query != "" {
+		var matching []string
+		for _, line := range lines {
+			display := menu.Display(line)
+			if !isSelectableMenuLine(display) || menu.FuzzyMatch(opts.query, table.StripANSI(display)) {
+				matching = append(matching, line)
+			}
+		}
+		lines = matching
+	}
+
+	header := strings.TrimSuffix(opts.header, " (Ctrl-C to cancel)")
+	return menu.Numbered(tty, tty, header, lines, isSelectableMenuLine)
+}
+
+// isSelectableMenuLine reports whether a menu line is an item rather than a separator or section header
+func isSelectableMenuLine(line string) bool {
+	plain := strings.TrimSpace(table.StripANSI(line))
+	return plain != "" && !strings.HasPrefix(plain, "────")
+}
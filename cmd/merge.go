@@ -0,0 +1,78 @@
+package cmd
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/pmossman/claudew/internal/config"
+	"github.com/spf13/cobra"
+)
+
+var mergeCmd = &cobra.Command{
+	Use:   "merge <source> <target>",
+	Short: "Merge a workspace's notes into another and archive it",
+	Long: `Appends the source workspace's context.md and decisions.md to the target's,
+under a header naming the source, and copies its research files. The source's
+clone is then freed and the source is archived.
+
+Use this when two exploratory workspaces turn out to be the same task.
+
+Example:
+  claudew merge cache-spike perf-work`,
+	Args: cobra.ExactArgs(2),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		source, target := args[0], args[1]
+		if source == target {
+			return fmt.Errorf("cannot merge a workspace into itself")
+		}
+
+		// Load config
+		cfg, err := config.Load()
+		if err != nil {
+			return fmt.Errorf("failed to load config: %w", err)
+		}
+
+		sourceWs, err := cfg.GetWorkspace(source)
+		if err != nil {
+			return err
+		}
+		targetWs, err := cfg.GetWorkspace(target)
+		if err != nil {
+			return err
+		}
+		if targetWs.Status == config.StatusArchived {
+			return fmt.Errorf("target workspace '%s' is archived", target)
+		}
+		if sourceWs.Status == config.StatusArchived {
+			return fmt.Errorf("source workspace '%s' is already archived", source)
+		}
+		if sourceWs.Status == config.StatusActive {
+			return fmt.Errorf("cannot merge active workspace '%s'. Stop the session first.", source)
+		}
+
+		svc := newService(cfg)
+		wsMgr := svc.Workspaces
+		if err := wsMgr.MergeInto(source, target, time.Now()); err != nil {
+			return err
+		}
+		fmt.Printf("✓ Merged notes from '%s' into '%s'\n", source, target)
+
+		if err := archiveWorkspace(svc, source, sourceWs, false); err != nil {
+			return err
+		}
+
+		if err := cfg.Save(); err != nil {
+			return fmt.Errorf("failed to save config: %w", err)
+		}
+
+		emitArchiveEvents(cfg, source, sourceWs)
+
+		fmt.Printf("✓ Archived workspace '%s'\n", source)
+		return nil
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(mergeCmd)
+	mergeCmd.ValidArgsFunction = validWorkspaceNamesExcludeArchived
+}
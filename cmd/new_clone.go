@@ -2,20 +2,38 @@ package cmd
 
 import (
 	"fmt"
+	"os"
 	"path/filepath"
+	"sync"
+	"time"
 
 	"github.com/pmossman/claudew/internal/config"
+	"github.com/pmossman/claudew/internal/events"
 	"github.com/pmossman/claudew/internal/git"
 	"github.com/spf13/cobra"
 )
 
+var newCloneCount int
+
 var newCloneCmd = &cobra.Command{
-	Use:   "new-clone <remote-name>",
+	Use:   "new-clone [remote-name]",
 	Short: "Create a new clone of a remote repository",
-	Long:  `Clones the remote repository to a new numbered directory in the clone base directory.`,
-	Args:  cobra.ExactArgs(1),
+	Long: `Clones the remote repository to a new numbered directory in the clone base directory,
+named by the remote's clone naming pattern (1, 2, 3 unless set with edit-remote
+--clone-naming).
+
+Without a remote name, the remote is picked interactively. Use --count to create
+several clones at once; they are cloned in parallel, with a progress line for each.
+
+Example:
+  claudew new-clone airbyte             # One clone
+  claudew new-clone airbyte --count 3   # Three clones in parallel
+  claudew new-clone                     # Pick the remote interactively`,
+	Args: cobra.MaximumNArgs(1),
 	RunE: func(cmd *cobra.Command, args []string) error {
-		remoteName := args[0]
+		if newCloneCount < 1 {
+			return fmt.Errorf("--count must be at least 1")
+		}
 
 		// Load config
 		cfg, err := config.Load()
@@ -23,49 +41,154 @@ var newCloneCmd = &cobra.Command{
 			return fmt.Errorf("failed to load config: %w", err)
 		}
 
+		var remoteName string
+		if len(args) > 0 {
+			remoteName = args[0]
+		} else {
+			remoteName, err = selectRemoteInteractive(cfg, "Select remote to clone")
+			if err != nil {
+				return err
+			}
+			if remoteName == "" {
+				return nil // User cancelled
+			}
+		}
+
 		// Get remote
 		remote, err := cfg.GetRemote(remoteName)
 		if err != nil {
 			return err
 		}
 
-		// Get next clone number
-		cloneNum := cfg.GetNextCloneNumber(remoteName)
-		clonePath := filepath.Join(remote.CloneBaseDir, fmt.Sprintf("%d", cloneNum))
+		if newCloneCount == 1 {
+			return createClone(cfg, remoteName, remote)
+		}
+		return createClones(cfg, remoteName, remote, newCloneCount)
+	},
+}
+
+// createClone clones a remote into its next clone directory, streaming git's progress
+func createClone(cfg *config.Config, remoteName string, remote *config.Remote) error {
+	// Get next clone path, named by the remote's naming pattern
+	clonePath, err := cfg.NextClonePath(remoteName, "")
+	if err != nil {
+		return err
+	}
 
-		fmt.Printf("Creating clone %d of '%s'...\n", cloneNum, remoteName)
-		fmt.Printf("  Cloning from: %s\n", remote.URL)
-		fmt.Printf("  To: %s\n", clonePath)
-		fmt.Println()
+	fmt.Printf("Creating clone %s of '%s'...\n", filepath.Base(clonePath), remoteName)
+	fmt.Printf("  Cloning from: %s\n", remote.URL)
+	fmt.Printf("  To: %s\n", clonePath)
+	fmt.Println()
 
-		// Clone the repository
-		if err := git.Clone(remote.URL, clonePath); err != nil {
-			return err
-		}
+	// Clone the repository
+	if err := git.Clone(remote.URL, clonePath); err != nil {
+		return err
+	}
 
-		// Add clone to config
-		if err := cfg.AddClone(clonePath, remoteName); err != nil {
-			return err
+	branch, err := registerNewClone(cfg, remoteName, clonePath)
+	if err != nil {
+		return err
+	}
+
+	// Save config
+	if err := cfg.Save(); err != nil {
+		return fmt.Errorf("failed to save config: %w", err)
+	}
+
+	emitEvent(cfg, events.CloneCreated, "", map[string]string{"clone_path": clonePath, "remote": remoteName})
+
+	fmt.Printf("✓ Created clone at %s\n", clonePath)
+	fmt.Printf("  Branch: %s\n", branch)
+	fmt.Printf("  Status: Free (available for workspaces)\n")
+
+	return nil
+}
+
+// createClones clones a remote count times in parallel into unused clone directories
+func createClones(cfg *config.Config, remoteName string, remote *config.Remote, count int) error {
+	// Pick every path up front so parallel clones can't collide
+	paths := make([]string, 0, count)
+	reserved := map[string]bool{}
+	for num := cfg.GetNextCloneNumber(remoteName); len(paths) < count; num++ {
+		path := filepath.Join(remote.CloneBaseDir, remote.CloneDirName(num))
+		if _, err := os.Stat(path); err == nil || cfg.Clones[path] != nil || reserved[path] {
+			continue
 		}
+		reserved[path] = true
+		paths = append(paths, path)
+	}
 
-		// Get current branch
-		branch, err := git.GetCurrentBranch(clonePath)
+	fmt.Printf("Creating %d clones of '%s' from %s...\n\n", count, remoteName, remote.URL)
+
+	progress := newCloneProgress(paths)
+	errs := make([]error, count)
+	var wg sync.WaitGroup
+	for i, path := range paths {
+		wg.Add(1)
+		go func(i int, path string) {
+			defer wg.Done()
+			errs[i] = git.CloneWithProgress(remote.URL, path, func(p git.Progress) {
+				progress.update(i, p)
+			})
+			progress.finish(i, errs[i])
+		}(i, path)
+	}
+	wg.Wait()
+	if progress.live {
+		fmt.Println()
+	}
+
+	// Register the successful clones; the config is only touched from here
+	var created []string
+	for i, path := range paths {
+		if errs[i] != nil {
+			fmt.Printf("  ✗ %s: %v\n", path, errs[i])
+			continue
+		}
+		branch, err := registerNewClone(cfg, remoteName, path)
 		if err != nil {
-			branch = "unknown"
+			fmt.Printf("  ✗ %s: %v\n", path, err)
+			continue
 		}
+		created = append(created, path)
+		fmt.Printf("  ✓ %s (%s)\n", path, branch)
+	}
 
-		clone, _ := cfg.GetClone(clonePath)
-		clone.CurrentBranch = branch
-
-		// Save config
+	if len(created) > 0 {
 		if err := cfg.Save(); err != nil {
 			return fmt.Errorf("failed to save config: %w", err)
 		}
+		for _, path := range created {
+			emitEvent(cfg, events.CloneCreated, "", map[string]string{"clone_path": path, "remote": remoteName})
+		}
+	}
 
-		fmt.Printf("✓ Created clone at %s\n", clonePath)
-		fmt.Printf("  Branch: %s\n", branch)
-		fmt.Printf("  Status: Free (available for workspaces)\n")
+	fmt.Println()
+	if len(created) < count {
+		return fmt.Errorf("created %d of %d clones", len(created), count)
+	}
+	fmt.Printf("✓ Created %d clones (free for workspaces)\n", len(created))
+	return nil
+}
 
-		return nil
-	},
+// registerNewClone adds a freshly cloned directory to the config and records its branch
+func registerNewClone(cfg *config.Config, remoteName, clonePath string) (string, error) {
+	if err := cfg.AddClone(clonePath, remoteName); err != nil {
+		return "", err
+	}
+
+	// Get current branch
+	branch, err := git.GetCurrentBranch(clonePath)
+	if err != nil {
+		branch = "unknown"
+	}
+
+	clone, _ := cfg.GetClone(clonePath)
+	clone.SetBranch(branch, time.Now())
+	return branch, nil
+}
+
+func init() {
+	newCloneCmd.ValidArgsFunction = validRemoteNames
+	newCloneCmd.Flags().IntVar(&newCloneCount, "count", 1, "Number of clones to create (in parallel)")
 }
@@ -2,10 +2,10 @@ package cmd
 
 import (
 	"fmt"
-	"os/exec"
 	"runtime"
 
 	"github.com/pmossman/claudew/internal/config"
+	"github.com/pmossman/claudew/internal/execx"
 	"github.com/pmossman/claudew/internal/workspace"
 	"github.com/spf13/cobra"
 )
@@ -48,18 +48,18 @@ On Windows: Uses explorer`,
 		}
 
 		// Get workspace directory
-		wsMgr := workspace.NewManager(cfg.Settings.WorkspaceDir)
+		wsMgr := workspace.NewManagerForConfig(cfg)
 		workspaceDir := wsMgr.GetPath(workspaceName)
 
 		// Open in file browser based on OS
-		var openCmd *exec.Cmd
+		var openCmd *execx.Cmd
 		switch runtime.GOOS {
 		case "darwin":
-			openCmd = exec.Command("open", workspaceDir)
+			openCmd = execx.Command("open", workspaceDir)
 		case "linux":
-			openCmd = exec.Command("xdg-open", workspaceDir)
+			openCmd = execx.Command("xdg-open", workspaceDir)
 		case "windows":
-			openCmd = exec.Command("explorer", workspaceDir)
+			openCmd = execx.Command("explorer", workspaceDir)
 		default:
 			return fmt.Errorf("unsupported operating system: %s", runtime.GOOS)
 		}
@@ -0,0 +1,68 @@
+package cmd
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/pmossman/claudew/internal/config"
+	"github.com/pmossman/claudew/internal/git"
+)
+
+// Limits on how much of the outside-changes summary is shown
+const (
+	outsideChangesMaxCommits = 10
+	outsideChangesMaxFiles   = 15
+)
+
+// outsideChanges are the commits made in a workspace's repo since it was last active,
+// e.g. by hand or from another tool, which neither the user nor Claude may know about
+type outsideChanges struct {
+	since   time.Time
+	commits []string
+	files   []string
+}
+
+// collectOutsideChanges returns the commits made since the workspace was last active, if any
+func collectOutsideChanges(ws *config.Workspace) (outsideChanges, bool) {
+	if ws.LastActive.IsZero() || ws.RepoPath == "" || !git.IsGitRepo(ws.RepoPath) {
+		return outsideChanges{}, false
+	}
+
+	commits, err := git.LogSince(ws.RepoPath, ws.LastActive)
+	if err != nil || len(commits) == 0 {
+		return outsideChanges{}, false
+	}
+	files, _ := git.FilesChangedSince(ws.RepoPath, ws.LastActive)
+	return outsideChanges{since: ws.LastActive, commits: commits, files: files}, true
+}
+
+// print shows the changes in the start header
+func (c outsideChanges) print() {
+	fmt.Printf("  Since last active (%s): %d commit(s), %d file(s) changed\n", formatTimeAgo(c.since), len(c.commits), len(c.files))
+	for _, line := range limitLines(c.commits, outsideChangesMaxCommits) {
+		fmt.Printf("    %s\n", line)
+	}
+}
+
+// note describes the changes for Claude, to be sent with its first prompt
+func (c outsideChanges) note() string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "Note: these commits were made in this repo since the workspace was last active (%s):\n", c.since.Format("2006-01-02 15:04"))
+	for _, line := range limitLines(c.commits, outsideChangesMaxCommits) {
+		fmt.Fprintf(&b, "- %s\n", line)
+	}
+	if len(c.files) > 0 {
+		b.WriteString("Files changed: " + strings.Join(limitLines(c.files, outsideChangesMaxFiles), ", ") + "\n")
+	}
+	b.WriteString("Take them into account before continuing.")
+	return b.String()
+}
+
+// limitLines returns at most n lines, replacing the rest with a "... and N more" line
+func limitLines(lines []string, n int) []string {
+	if len(lines) <= n {
+		return lines
+	}
+	return append(append([]string{}, lines[:n]...), fmt.Sprintf("... and %d more", len(lines)-n))
+}
@@ -0,0 +1,80 @@
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/pmossman/claudew/internal/config"
+	"github.com/spf13/cobra"
+)
+
+var pinCmd = &cobra.Command{
+	Use:   "pin <workspace-name>",
+	Short: "Pin a workspace to the top of the select menu",
+	Long: `Pins a workspace so it is always listed first in the interactive menus,
+regardless of when it was last active.
+
+Example:
+  claudew pin feature-auth`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return setPinned(args[0], true)
+	},
+}
+
+var unpinCmd = &cobra.Command{
+	Use:   "unpin <workspace-name>",
+	Short: "Unpin a workspace",
+	Args:  cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return setPinned(args[0], false)
+	},
+}
+
+// setPinned updates a workspace's pinned flag and saves the config
+func setPinned(name string, pinned bool) error {
+	// Load config
+	cfg, err := config.Load()
+	if err != nil {
+		return fmt.Errorf("failed to load config: %w", err)
+	}
+
+	ws, err := cfg.GetWorkspace(name)
+	if err != nil {
+		return err
+	}
+
+	ws.Pinned = pinned
+	if err := cfg.Save(); err != nil {
+		return fmt.Errorf("failed to save config: %w", err)
+	}
+
+	if pinned {
+		fmt.Printf("📌 Pinned workspace '%s'\n", name)
+	} else {
+		fmt.Printf("✓ Unpinned workspace '%s'\n", name)
+	}
+	return nil
+}
+
+// menuOrderLess orders workspaces for menus: pinned first, then most recently active
+func menuOrderLess(a, b *config.Workspace) bool {
+	if a.Pinned != b.Pinned {
+		return a.Pinned
+	}
+	return a.LastActive.After(b.LastActive)
+}
+
+// pinMarker returns the menu marker for pinned workspaces
+func pinMarker(ws *config.Workspace) string {
+	if ws.Pinned {
+		return "📌 "
+	}
+	return ""
+}
+
+func init() {
+	rootCmd.AddCommand(pinCmd)
+	rootCmd.AddCommand(unpinCmd)
+	pinCmd.ValidArgsFunction = validWorkspaceNamesExcludeArchived
+	unpinCmd.ValidArgsFunction = validWorkspaceNamesExcludeArchived
+}
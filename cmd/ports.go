@@ -0,0 +1,15 @@
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/pmossman/claudew/internal/config"
+)
+
+// ensurePorts reserves a port block for a workspace that doesn't have one yet,
+// e.g. workspaces created before port allocation existed
+func ensurePorts(cfg *config.Config, name string) {
+	if _, err := cfg.AllocatePorts(name); err != nil {
+		fmt.Printf("Warning: failed to allocate ports: %v\n", err)
+	}
+}
@@ -0,0 +1,67 @@
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/pmossman/claudew/internal/config"
+	"github.com/pmossman/claudew/internal/session"
+	"github.com/spf13/cobra"
+)
+
+var lastCmd = &cobra.Command{
+	Use:   "last",
+	Short: "Reattach to the most recently opened workspace",
+	Long: `Starts or reattaches to the workspace you opened most recently, without the menu.
+
+Example:
+  claudew last`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return startRecent(cmd, 0)
+	},
+}
+
+var prevCmd = &cobra.Command{
+	Use:   "prev",
+	Short: "Switch to the workspace opened before the most recent one",
+	Long: `Starts or reattaches to the second most recently opened workspace. Run from
+inside a workspace session, it flips back to the one you were in before, so
+running it repeatedly alternates between two workstreams, like alt-tab.
+
+Example:
+  claudew prev`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return startRecent(cmd, 1)
+	},
+}
+
+// startRecent starts the workspace at position index in the most-recently-opened order
+func startRecent(cmd *cobra.Command, index int) error {
+	// Load config
+	cfg, err := config.Load()
+	if err != nil {
+		return fmt.Errorf("failed to load config: %w", err)
+	}
+
+	recent := cfg.RecentWorkspaces()
+	if len(recent) == 0 {
+		return fmt.Errorf("no workspaces yet. Create one with: claudew create")
+	}
+	if len(recent) <= index {
+		return fmt.Errorf("no previous workspace: '%s' is the only one", recent[0])
+	}
+	name := recent[index]
+
+	sessionMgr := session.NewManagerForConfig(cfg)
+	if sessionMgr.WorkspaceName(sessionMgr.CurrentSession()) == name {
+		fmt.Printf("Already in '%s'\n", name)
+		return nil
+	}
+	return startCmd.RunE(cmd, []string{name})
+}
+
+func init() {
+	rootCmd.AddCommand(lastCmd)
+	rootCmd.AddCommand(prevCmd)
+}
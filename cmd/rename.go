@@ -3,11 +3,13 @@ package cmd
 import (
 	"fmt"
 	"os"
-	"os/exec"
 	"path/filepath"
+	"strings"
 
 	"github.com/pmossman/claudew/internal/config"
+	"github.com/pmossman/claudew/internal/events"
 	"github.com/pmossman/claudew/internal/session"
+	"github.com/pmossman/claudew/internal/workspace"
 	"github.com/spf13/cobra"
 )
 
@@ -44,32 +46,45 @@ This will also update any clones that are assigned to this workspace.`,
 		}
 
 		// Check if tmux session exists and rename it
-		sessionMgr := session.NewManager()
+		sessionMgr := session.NewManagerForConfig(cfg)
 		oldSessionName := sessionMgr.GetSessionName(oldName)
 		newSessionName := sessionMgr.GetSessionName(newName)
+		if oldWs.SessionName != "" {
+			// Keep the session's suffix, e.g. its summary slug
+			oldWs.SessionName = session.SessionPrefix + newName + strings.TrimPrefix(oldWs.SessionName, session.SessionPrefix+oldName)
+			newSessionName = oldWs.SessionName
+		}
 
 		if exists, _ := sessionMgr.Exists(oldSessionName); exists {
 			fmt.Printf("Renaming tmux session: %s -> %s\n", oldSessionName, newSessionName)
-			cmd := exec.Command("tmux", "rename-session", "-t", oldSessionName, newSessionName)
-			if err := cmd.Run(); err != nil {
-				return fmt.Errorf("failed to rename tmux session: %w", err)
+			if err := sessionMgr.Rename(oldSessionName, newSessionName); err != nil {
+				return err
 			}
 		}
 
-		// Rename workspace directory
-		oldDir := filepath.Join(cfg.Settings.WorkspaceDir, oldName)
+		// Rename workspace directory, unless it's configured to live elsewhere
+		wsMgr := workspace.NewManagerForConfig(cfg)
+		oldDir := wsMgr.GetPath(oldName)
 		newDir := filepath.Join(cfg.Settings.WorkspaceDir, newName)
 
-		if _, err := os.Stat(oldDir); err == nil {
+		if oldWs.Dir != "" {
+			fmt.Printf("Keeping workspace directory: %s\n", oldDir)
+		} else if _, err := os.Stat(oldDir); err == nil {
 			fmt.Printf("Renaming workspace directory: %s -> %s\n", oldDir, newDir)
 			if err := os.Rename(oldDir, newDir); err != nil {
 				return fmt.Errorf("failed to rename workspace directory: %w", err)
 			}
+			if err := wsMgr.RecordRename(oldName, newName); err != nil {
+				fmt.Printf("Warning: failed to record notes history: %v\n", err)
+			}
 		} else {
 			fmt.Printf("Note: Workspace directory not found at %s\n", oldDir)
 		}
 
-		// Update workspace in config
+		// Update workspace in config, keeping its color even if it was derived from the old name
+		if oldWs.Color == "" {
+			oldWs.Color = oldWs.DisplayColor()
+		}
 		oldWs.Name = newName
 		cfg.Workspaces[newName] = oldWs
 		delete(cfg.Workspaces, oldName)
@@ -87,6 +102,8 @@ This will also update any clones that are assigned to this workspace.`,
 			return fmt.Errorf("failed to save config: %w", err)
 		}
 
+		emitEvent(cfg, events.WorkspaceRenamed, newName, map[string]string{"old_name": oldName})
+
 		fmt.Printf("\n✓ Renamed workspace '%s' to '%s'\n", oldName, newName)
 		return nil
 	},
@@ -3,17 +3,27 @@ package cmd
 import (
 	"bufio"
 	"fmt"
+	"io"
 	"os"
-	"os/exec"
-	"runtime"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strconv"
 	"strings"
+	"time"
 
 	"github.com/pmossman/claudew/internal/config"
+	"github.com/pmossman/claudew/internal/events"
 	"github.com/pmossman/claudew/internal/session"
 	"github.com/pmossman/claudew/internal/workspace"
 	"github.com/spf13/cobra"
 )
 
+var (
+	restartAll      bool
+	restartNoPrompt bool
+)
+
 var restartCmd = &cobra.Command{
 	Use:   "restart <workspace-name>",
 	Short: "Restart Claude session in a workspace",
@@ -31,10 +41,19 @@ What this does:
 - Keeps the tmux session and workspace context intact
 
 Example:
-  claudew restart feature-auth    # Restart specific workspace
-  claudew restart                 # Interactive: select workspace to restart`,
+  claudew restart feature-auth              # Restart specific workspace
+  claudew restart                           # Interactive: select workspace to restart
+  claudew restart feature-auth --no-prompt  # Skip the continuation prompt
+  claudew restart --all --no-prompt         # Restart every running session (e.g. after upgrading claude)`,
 	Args: cobra.MaximumNArgs(1),
 	RunE: func(cmd *cobra.Command, args []string) error {
+		if restartAll {
+			if len(args) > 0 {
+				return fmt.Errorf("cannot specify a workspace name with --all")
+			}
+			return restartAllSessions()
+		}
+
 		// Output immediately at start of command execution
 		fmt.Fprintf(os.Stdout, "\n")
 		os.Stdout.Sync()
@@ -85,12 +104,14 @@ Example:
 		os.Stdout.Sync()
 
 		// Prompt to save continuation before restarting
-		wsMgr := workspace.NewManager(cfg.Settings.WorkspaceDir)
-		if err := promptSaveContinuation(wsMgr, workspaceName); err != nil {
-			return err
+		wsMgr := workspace.NewManagerForConfig(cfg)
+		if !restartNoPrompt {
+			if err := promptSaveContinuation(wsMgr, workspaceName); err != nil {
+				return err
+			}
 		}
 
-		sessionMgr := session.NewManager()
+		sessionMgr := session.NewManagerForConfig(cfg)
 		sessionName := sessionMgr.GetSessionName(workspaceName)
 
 		// Check if session exists
@@ -107,55 +128,13 @@ Example:
 		fmt.Printf("🔄 Restarting Claude session in workspace '%s'...\n", workspaceName)
 		fmt.Println()
 
-		// Kill the Claude process directly by finding its PID
-		fmt.Println("  [1/4] Finding Claude process...")
-
-		// Find the PID of the tmux pane
-		getPaneCmd := exec.Command("tmux", "list-panes", "-t", sessionName, "-F", "#{pane_pid}")
-		output, err := getPaneCmd.Output()
-		if err != nil {
-			return fmt.Errorf("failed to get pane PID: %w", err)
-		}
-		panePID := strings.TrimSpace(string(output))
-
-		if panePID != "" {
-			fmt.Printf("  [2/4] Terminating Claude process (PID: %s)...\n", panePID)
-
-			// Kill all child processes of the tmux pane
-			// Use pkill to find and kill any 'claude' processes under this pane
-			killCmd := exec.Command("pkill", "-TERM", "-P", panePID, "claude")
-			_ = killCmd.Run() // Ignore errors if no claude process found
-
-			// Give it a moment to terminate gracefully
-			fmt.Println("        Waiting for graceful shutdown...")
-			if err := exec.Command("sleep", "0.5").Run(); err != nil {
-				// Not critical if sleep fails
-			}
-
-			// Force kill if still alive
-			killCmd = exec.Command("pkill", "-KILL", "-P", panePID, "claude")
-			_ = killCmd.Run() // Ignore errors
-			fmt.Println("        ✓ Process terminated")
-		} else {
-			fmt.Println("  [2/4] No active Claude process found (skipping)")
-		}
-
-		// Clear the command line
-		fmt.Println("  [3/4] Clearing tmux command line...")
-		if err := sessionMgr.SendKeysLiteral(sessionName, "C-c"); err != nil {
-			return fmt.Errorf("failed to send Ctrl-C: %w", err)
-		}
-		if err := sessionMgr.SendKeysLiteral(sessionName, "C-u"); err != nil {
-			return fmt.Errorf("failed to clear line: %w", err)
+		recordNotesHistory(wsMgr, workspaceName, "snapshot before restart")
+		if err := restartClaude(cfg, sessionMgr, workspaceName, os.Stdout); err != nil {
+			return err
 		}
-		fmt.Println("        ✓ Command line cleared")
 
-		// Start new Claude session
-		fmt.Println("  [4/4] Starting new Claude session...")
-		if err := sessionMgr.SendKeys(sessionName, cfg.Settings.ClaudeCommand); err != nil {
-			return fmt.Errorf("failed to start Claude: %w", err)
-		}
-		fmt.Println("        ✓ Claude session started")
+		recordRestart(cfg, workspaceName)
+		emitEvent(cfg, events.SessionRestarted, workspaceName, map[string]string{"session": sessionName})
 
 		// Display continuation prompt
 		continuation := wsMgr.GetContinuation(workspaceName)
@@ -169,21 +148,7 @@ Example:
 			fmt.Println("───────────────────────────────────────────────────────────")
 			fmt.Println()
 
-			// Copy to clipboard if available
-			if runtime.GOOS == "darwin" {
-				cmd := exec.Command("pbcopy")
-				cmd.Stdin = nil
-				stdin, err := cmd.StdinPipe()
-				if err == nil {
-					if err := cmd.Start(); err == nil {
-						_, _ = stdin.Write([]byte(continuation))
-						_ = stdin.Close()
-						_ = cmd.Wait()
-						fmt.Println("✓ Copied continuation prompt to clipboard")
-					}
-				}
-			}
-			fmt.Println()
+			copyToClipboard(cfg, continuation)
 		}
 
 		fmt.Println()
@@ -198,13 +163,278 @@ Example:
 	},
 }
 
-// promptSaveContinuation prompts the user to save continuation before restarting
-func promptSaveContinuation(wsMgr *workspace.Manager, workspaceName string) error {
-	// Reopen /dev/tty for both reading and writing to ensure output is visible after fzf
-	tty, err := os.OpenFile("/dev/tty", os.O_RDWR, 0)
+// recordRestart counts a restart of Claude in a workspace, for claudew stats
+func recordRestart(cfg *config.Config, name string) {
+	ws, err := cfg.GetWorkspace(name)
+	if err != nil {
+		return
+	}
+	ws.Restarts++
+	if err := cfg.Save(); err != nil {
+		fmt.Printf("Warning: failed to save config: %v\n", err)
+	}
+}
+
+// restartAllSessions restarts Claude in every non-archived workspace with a running session
+func restartAllSessions() error {
+	cfg, err := config.Load()
+	if err != nil {
+		return fmt.Errorf("failed to load config: %w", err)
+	}
+
+	sessionMgr := session.NewManagerForConfig(cfg)
+	wsMgr := workspace.NewManagerForConfig(cfg)
+
+	var names []string
+	for name, ws := range cfg.Workspaces {
+		if ws.Status == config.StatusArchived {
+			continue
+		}
+		if exists, err := sessionMgr.Exists(sessionMgr.GetSessionName(name)); err == nil && exists {
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names)
+
+	if len(names) == 0 {
+		fmt.Println("No running sessions to restart.")
+		return nil
+	}
+
+	var failed []string
+	for _, name := range names {
+		if !restartNoPrompt {
+			fmt.Printf("\n── %s ──\n", name)
+			if err := promptSaveContinuation(wsMgr, name); err != nil {
+				return err
+			}
+		}
+
+		recordNotesHistory(wsMgr, name, "snapshot before restart")
+		sessionName := sessionMgr.GetSessionName(name)
+		if err := restartClaude(cfg, sessionMgr, name, io.Discard); err != nil {
+			fmt.Printf("✗ %s: %v\n", name, err)
+			failed = append(failed, name)
+			continue
+		}
+
+		recordRestart(cfg, name)
+		emitEvent(cfg, events.SessionRestarted, name, map[string]string{"session": sessionName, "reason": "all"})
+		fmt.Printf("✓ Restarted Claude in '%s'\n", name)
+	}
+
+	if len(failed) > 0 {
+		return fmt.Errorf("failed to restart %d of %d session(s): %v", len(failed), len(names), failed)
+	}
+	fmt.Printf("\n✅ Restarted %d session(s)\n", len(names))
+	return nil
+}
+
+// restartClaude kills the Claude process in a session's pane and launches a new one,
+// writing progress to out
+func restartClaude(cfg *config.Config, sessionMgr *session.Manager, workspaceName string, out io.Writer) error {
+	sessionName := sessionMgr.GetSessionName(workspaceName)
+	ws, err := cfg.GetWorkspace(workspaceName)
+	if err != nil {
+		return err
+	}
+
+	// Leave the running Claude alone if its replacement can't be launched
+	if err := checkClaudeCommand(cfg, ws); err != nil {
+		return err
+	}
+
+	// Find Claude by the pane's terminal, which also catches it behind wrappers
+	fmt.Fprintln(out, "  [1/4] Finding Claude process...")
+	pattern := claudeProcessPattern(cfg)
+	pids, err := sessionMgr.PaneProcesses(sessionName, pattern)
+	if err != nil {
+		return err
+	}
+
+	if len(pids) > 0 || ws.Container != nil {
+		if len(pids) > 0 {
+			fmt.Fprintf(out, "  [2/4] Terminating Claude process (PID: %s)...\n", formatPIDs(pids))
+		} else {
+			fmt.Fprintln(out, "  [2/4] Terminating Claude in the container...")
+		}
+
+		// Claude in a container isn't on the pane's terminal, so signal it there
+		if ws.Container != nil {
+			killContainerClaude(workspaceName)
+		}
+
+		if err := stopPaneProcesses(sessionMgr, sessionName, pattern, pids, out); err != nil {
+			return err
+		}
+		fmt.Fprintln(out, "        ✓ Process terminated")
+	} else {
+		fmt.Fprintln(out, "  [2/4] No running Claude process found (skipping)")
+	}
+
+	// Clear the command line
+	fmt.Fprintln(out, "  [3/4] Clearing tmux command line...")
+	if err := sessionMgr.SendKeysLiteral(sessionName, "C-c"); err != nil {
+		return fmt.Errorf("failed to send Ctrl-C: %w", err)
+	}
+	if err := sessionMgr.SendKeysLiteral(sessionName, "C-u"); err != nil {
+		return fmt.Errorf("failed to clear line: %w", err)
+	}
+	fmt.Fprintln(out, "        ✓ Command line cleared")
+
+	// Start new Claude session
+	fmt.Fprintln(out, "  [4/4] Starting new Claude session...")
+	wsMgr := workspace.NewManagerForConfig(cfg)
+	launch := claudeLaunchCommand(cfg, wsMgr, workspaceName, ws, withHandoverNote(cfg, ws, ""))
+	if err := sessionMgr.SendKeysWhenReady(sessionName, launch, shellReadyTimeout); err != nil {
+		return fmt.Errorf("failed to start Claude: %w", err)
+	}
+	pids, err = waitForClaudeStart(sessionMgr, sessionName, pattern, launch, ws.Container != nil)
 	if err != nil {
-		return fmt.Errorf("failed to open terminal: %w", err)
+		return err
+	}
+	if len(pids) > 0 {
+		fmt.Fprintf(out, "        ✓ Claude session started (PID: %s)\n", formatPIDs(pids))
+	} else {
+		fmt.Fprintln(out, "        ✓ Claude session started")
 	}
+
+	return nil
+}
+
+// claudeStopTimeout is how long Claude has to exit after SIGTERM before it is killed
+const claudeStopTimeout = 5 * time.Second
+
+// claudeStartTimeout is how long a relaunched Claude has to show up in its pane
+const claudeStartTimeout = 10 * time.Second
+
+// claudeLaunchFailures are shell messages that mean the launch command didn't run
+var claudeLaunchFailures = []string{"command not found", ": not found", "No such file or directory", "Permission denied"}
+
+// claudeProcessPattern returns the pgrep pattern that matches Claude's process name:
+// "claude", or the program claude_command runs
+func claudeProcessPattern(cfg *config.Config) string {
+	pattern := "claude"
+	if binary := filepath.Base(claudeBinary(cfg)); binary != "." && binary != "claude" {
+		// Process names are cut to 15 characters
+		if len(binary) > 15 {
+			binary = binary[:15]
+		}
+		pattern += "|" + regexp.QuoteMeta(binary)
+	}
+	return pattern
+}
+
+// stopPaneProcesses sends SIGTERM to pids, then SIGKILL to any process matching
+// pattern still on the session's pane after claudeStopTimeout, and fails if one
+// survives that too
+func stopPaneProcesses(sessionMgr *session.Manager, sessionName, pattern string, pids []int, out io.Writer) error {
+	sessionMgr.SignalProcesses(pids, "TERM")
+	fmt.Fprintln(out, "        Waiting for graceful shutdown...")
+	remaining, err := waitForPaneExit(sessionMgr, sessionName, pattern, claudeStopTimeout)
+	if err != nil || len(remaining) == 0 {
+		return err
+	}
+
+	fmt.Fprintf(out, "        Still running after %s, killing PID %s...\n", claudeStopTimeout, formatPIDs(remaining))
+	sessionMgr.SignalProcesses(remaining, "KILL")
+	remaining, err = waitForPaneExit(sessionMgr, sessionName, pattern, 2*time.Second)
+	if err != nil {
+		return err
+	}
+	if len(remaining) > 0 {
+		return fmt.Errorf("claude (PID %s) is still running in %s after SIGKILL; stop it by hand before restarting", formatPIDs(remaining), sessionName)
+	}
+	return nil
+}
+
+// waitForPaneExit polls the processes matching pattern on a session's pane until
+// none are left or timeout passes, returning those still running
+func waitForPaneExit(sessionMgr *session.Manager, sessionName, pattern string, timeout time.Duration) ([]int, error) {
+	deadline := time.Now().Add(timeout)
+	for {
+		pids, err := sessionMgr.PaneProcesses(sessionName, pattern)
+		if err != nil || len(pids) == 0 || time.Now().After(deadline) {
+			return pids, err
+		}
+		time.Sleep(200 * time.Millisecond)
+	}
+}
+
+// waitForClaudeStart confirms that a relaunched Claude is running in a session's
+// pane, returning its PIDs. It fails early if the shell printed an error after the
+// launch command, and otherwise once claudeStartTimeout passes without Claude
+// appearing. Claude in a container isn't on the pane's terminal, so for it the
+// pane only has to stay free of errors for a few seconds.
+func waitForClaudeStart(sessionMgr *session.Manager, sessionName, pattern, launch string, inContainer bool) ([]int, error) {
+	attach := fmt.Sprintf("attach to see what happened: claudew start %s", sessionMgr.WorkspaceName(sessionName))
+	timeout := claudeStartTimeout
+	if inContainer {
+		timeout = 3 * time.Second
+	}
+	deadline := time.Now().Add(timeout)
+	for {
+		if content, err := sessionMgr.CapturePane(sessionName); err == nil {
+			if failure := launchFailure(content, launch); failure != "" {
+				return nil, fmt.Errorf("claude failed to start: %s (%s)", failure, attach)
+			}
+		}
+		if !inContainer {
+			pids, err := sessionMgr.PaneProcesses(sessionName, pattern)
+			if err != nil || len(pids) > 0 {
+				return pids, err
+			}
+		}
+		if time.Now().After(deadline) {
+			if inContainer {
+				return nil, nil
+			}
+			return nil, fmt.Errorf("claude did not start within %s; %s", claudeStartTimeout, attach)
+		}
+		time.Sleep(200 * time.Millisecond)
+	}
+}
+
+// launchFailure returns the first shell error in pane content printed after the
+// launch command was echoed, or "" if there is none
+func launchFailure(content, launch string) string {
+	lines := strings.Split(content, "\n")
+	echo, _, _ := strings.Cut(launch, "\n")
+	if len(echo) > 20 {
+		echo = echo[:20]
+	}
+	after := -1
+	for i, line := range lines {
+		if strings.Contains(line, echo) {
+			after = i
+		}
+	}
+	if after < 0 {
+		return ""
+	}
+	for _, line := range lines[after+1:] {
+		for _, failure := range claudeLaunchFailures {
+			if strings.Contains(line, failure) {
+				return strings.TrimSpace(line)
+			}
+		}
+	}
+	return ""
+}
+
+// formatPIDs joins PIDs for display, e.g. "123, 456"
+func formatPIDs(pids []int) string {
+	parts := make([]string, len(pids))
+	for i, pid := range pids {
+		parts[i] = strconv.Itoa(pid)
+	}
+	return strings.Join(parts, ", ")
+}
+
+// promptSaveContinuation prompts the user to save continuation before restarting
+func promptSaveContinuation(wsMgr *workspace.Manager, workspaceName string) error {
+	// Prompt on the terminal even after fzf or when stdout is captured
+	tty := openTerminal()
 	defer tty.Close()
 
 	fmt.Fprintln(tty)
@@ -270,4 +500,6 @@ func promptSaveContinuation(wsMgr *workspace.Manager, workspaceName string) erro
 func init() {
 	rootCmd.AddCommand(restartCmd)
 	restartCmd.ValidArgsFunction = validWorkspaceNamesExcludeArchived
+	restartCmd.Flags().BoolVar(&restartAll, "all", false, "Restart Claude in every running workspace session")
+	restartCmd.Flags().BoolVar(&restartNoPrompt, "no-prompt", false, "Skip the prompt to update the continuation before restarting")
 }
@@ -0,0 +1,142 @@
+package cmd
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/pmossman/claudew/internal/app"
+	"github.com/pmossman/claudew/internal/config"
+	"github.com/spf13/cobra"
+)
+
+var (
+	resurrectIncludeIdle bool
+	resurrectDryRun      bool
+)
+
+var resurrectCmd = &cobra.Command{
+	Use:   "resurrect",
+	Short: "Recreate tmux sessions lost after a reboot",
+	Long: `Recreates tmux sessions for workspaces that were active when their sessions
+disappeared (for example after a reboot or a tmux server crash).
+
+For each workspace still marked active that has no tmux session, this will:
+- Create a new detached tmux session in the workspace's clone
+- Restore the workspace status line
+- Restart Claude (if auto_start_claude is enabled), re-sending the continuation
+- Clean up the stale lock file
+- Set the workspace status to idle (attach again with 'claudew start')
+
+If any session can't be recreated, the others are still resurrected and claudew
+exits with status 1.
+
+Example:
+  claudew resurrect                 # Recreate sessions for active workspaces
+  claudew resurrect --include-idle  # Also include idle workspaces holding a clone
+  claudew resurrect --dry-run       # Show what would be recreated`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		svc, err := loadService()
+		if err != nil {
+			return err
+		}
+		cfg, wsMgr, sessionMgr := svc.Config, svc.Workspaces, svc.Sessions
+		if err := sessionMgr.CheckTmuxInstalled(); err != nil {
+			return err
+		}
+
+		// Collect workspaces whose session went away
+		var names []string
+		for name, ws := range cfg.Workspaces {
+			if !shouldResurrect(cfg, ws) {
+				continue
+			}
+			exists, err := sessionMgr.Exists(sessionMgr.GetSessionName(name))
+			if err != nil {
+				return fmt.Errorf("failed to check session: %w", err)
+			}
+			if !exists {
+				names = append(names, name)
+			}
+		}
+		sort.Strings(names)
+
+		if len(names) == 0 {
+			fmt.Println("✓ No sessions to resurrect")
+			return nil
+		}
+
+		if resurrectDryRun {
+			fmt.Println("Would resurrect sessions for:")
+			for _, name := range names {
+				fmt.Printf("  • %s (%s)\n", name, cfg.Workspaces[name].GetRepoPath())
+			}
+			return nil
+		}
+
+		var resurrected, failed []string
+		for _, name := range names {
+			ws := cfg.Workspaces[name]
+			fmt.Printf("Resurrecting session for '%s'...\n", name)
+
+			opts := app.StartOptions{InitialPrompt: wsMgr.GetContinuation(name), Reason: "resurrect"}
+			if _, err := svc.StartSession(name, opts); err != nil {
+				fmt.Printf("  ✗ %v\n", err)
+				failed = append(failed, name)
+				continue
+			}
+
+			// The process that held the lock did not survive the reboot
+			_, _ = removeStaleLock(wsMgr, sessionMgr, name)
+
+			// Session is running but nobody is attached yet
+			ws.Status = config.StatusIdle
+			ws.SessionPID = 0
+			resurrected = append(resurrected, name)
+		}
+
+		if err := cfg.Save(); err != nil {
+			return fmt.Errorf("failed to save config: %w", err)
+		}
+
+		fmt.Println()
+		fmt.Printf("✓ Resurrected %d session(s)\n", len(resurrected))
+		for _, name := range resurrected {
+			fmt.Printf("  • %s\n", name)
+		}
+		if len(failed) > 0 {
+			fmt.Printf("✗ Failed to resurrect %d session(s): %v\n", len(failed), failed)
+		}
+		fmt.Println("\nAttach with: claudew start <name>")
+
+		// The failures were reported above; the status tells scripts about them
+		if len(failed) > 0 {
+			cmd.SilenceErrors = true
+			cmd.SilenceUsage = true
+			return &ExitCodeError{Code: 1}
+		}
+		return nil
+	},
+}
+
+// shouldResurrect reports whether a workspace's session should be recreated
+func shouldResurrect(cfg *config.Config, ws *config.Workspace) bool {
+	switch ws.Status {
+	case config.StatusActive:
+		return true
+	case config.StatusIdle:
+		if !resurrectIncludeIdle || ws.ClonePath == "" {
+			return false
+		}
+		clone, err := cfg.GetClone(ws.ClonePath)
+		return err == nil && clone.InUseBy == ws.Name
+	default:
+		return false
+	}
+}
+
+func init() {
+	rootCmd.AddCommand(resurrectCmd)
+	resurrectCmd.Flags().BoolVar(&resurrectIncludeIdle, "include-idle", false, "Also resurrect idle workspaces that still hold a clone")
+	resurrectCmd.Flags().BoolVar(&resurrectDryRun, "dry-run", false, "Show which sessions would be recreated without creating them")
+}
@@ -1,9 +1,17 @@
 package cmd
 
 import (
+	"fmt"
+	"log/slog"
+	"os"
+
 	"github.com/spf13/cobra"
 )
 
+// Version is the claudew release version, set at build time with
+// -ldflags "-X github.com/pmossman/claudew/cmd.Version=v1.2.3"
+var Version = "dev"
+
 var rootCmd = &cobra.Command{
 	Use:   "claudew",
 	Short: "Manage Claude Code workspaces with context preservation",
@@ -12,17 +20,40 @@ different repository clones, with automatic context preservation and session man
 
 The shell function 'claudew' wraps this binary and adds directory navigation features.
 Install it with: claudew install-shell`,
-	RunE: selectCmd.RunE, // Default to interactive selector
+	Version: Version,
+	RunE:    selectCmd.RunE, // Default to interactive selector
+	PersistentPreRun: func(cmd *cobra.Command, args []string) {
+		if err := setupDebugLog(os.Args[1:]); err != nil {
+			fmt.Fprintf(os.Stderr, "Warning: %v\n", err)
+		}
+	},
+}
+
+// ExitCodeError makes claudew exit with Code without printing an error, for commands
+// that pass on the exit status of a program they ran
+type ExitCodeError struct {
+	Code int
+}
+
+func (e *ExitCodeError) Error() string {
+	return fmt.Sprintf("exit status %d", e.Code)
 }
 
 func Execute() error {
-	return rootCmd.Execute()
+	err := rootCmd.Execute()
+	if err != nil {
+		slog.Debug("failed", "error", err)
+	}
+	return err
 }
 
 func init() {
-	// Disable standalone completion command (integrated into install-shell)
+	// Cobra's default completion command is replaced by our own (see completion.go)
 	rootCmd.CompletionOptions.DisableDefaultCmd = true
 
+	rootCmd.PersistentFlags().BoolVar(&noColor, "no-color", false, "Disable colored output (also honors the NO_COLOR environment variable)")
+	rootCmd.PersistentFlags().BoolVar(&debug, "debug", false, "Log external commands (tmux, git, fzf...) with exit statuses and timings to ~/.claude-workspaces/debug.log")
+
 	// Register subcommands
 	rootCmd.AddCommand(initCmd)
 	rootCmd.AddCommand(installShellCmd)
@@ -3,6 +3,7 @@ package cmd
 import (
 	"bufio"
 	"fmt"
+	"io"
 	"os"
 	"strings"
 
@@ -11,6 +12,11 @@ import (
 	"github.com/spf13/cobra"
 )
 
+var (
+	saveContextFile  string
+	saveContextStdin bool
+)
+
 var saveContextCmd = &cobra.Command{
 	Use:   "save-context <workspace-name>",
 	Short: "Save context and continuation for a workspace",
@@ -27,9 +33,14 @@ The command will prompt you to describe:
 - What has been completed
 - What should be done next
 
+Use --file or --stdin to replace continuation.md without prompting, so other
+tools (or Claude itself, via a hook) can update it programmatically.
+
 Example:
   claudew save-context feature-auth    # Save context for specific workspace
-  claudew save-context                 # Interactive: select workspace`,
+  claudew save-context                 # Interactive: select workspace
+  claudew save-context feature-auth --file notes.md
+  echo "Next: wire up the retry" | claudew save-context feature-auth --stdin`,
 	Args: cobra.MaximumNArgs(1),
 	RunE: func(cmd *cobra.Command, args []string) error {
 		// Load config
@@ -38,6 +49,11 @@ Example:
 			return fmt.Errorf("failed to load config: %w", err)
 		}
 
+		nonInteractive := saveContextFile != "" || saveContextStdin
+		if nonInteractive && len(args) == 0 {
+			return fmt.Errorf("--file and --stdin require a workspace name")
+		}
+
 		var workspaceName string
 
 		// If no args, show interactive selector
@@ -59,13 +75,14 @@ Example:
 			return fmt.Errorf("workspace '%s' not found", workspaceName)
 		}
 
-		wsMgr := workspace.NewManager(cfg.Settings.WorkspaceDir)
+		wsMgr := workspace.NewManagerForConfig(cfg)
 
-		// Reopen /dev/tty for both reading and writing to ensure output is visible after fzf
-		tty, err := os.OpenFile("/dev/tty", os.O_RDWR, 0)
-		if err != nil {
-			return fmt.Errorf("failed to open terminal: %w", err)
+		if nonInteractive {
+			return saveContinuationFrom(wsMgr, workspaceName, saveContextFile, os.Stdin, os.Stdout)
 		}
+
+		// Prompt on the terminal even after fzf or when stdout is captured
+		tty := openTerminal()
 		defer tty.Close()
 
 		fmt.Fprintln(tty)
@@ -124,7 +141,36 @@ Example:
 	},
 }
 
+// saveContinuationFrom replaces a workspace's continuation with the contents of
+// path, or of stdin if path is empty. Empty input is rejected rather than saved.
+func saveContinuationFrom(wsMgr *workspace.Manager, name, path string, stdin io.Reader, out io.Writer) error {
+	var data []byte
+	var err error
+	if path != "" {
+		data, err = os.ReadFile(path)
+	} else {
+		data, err = io.ReadAll(stdin)
+	}
+	if err != nil {
+		return fmt.Errorf("failed to read continuation: %w", err)
+	}
+
+	continuation := strings.TrimSpace(string(data))
+	if continuation == "" {
+		return fmt.Errorf("continuation is empty; keeping the existing one")
+	}
+
+	if err := wsMgr.SaveContinuation(name, continuation); err != nil {
+		return fmt.Errorf("failed to save continuation: %w", err)
+	}
+	fmt.Fprintf(out, "✓ Saved continuation for workspace '%s'\n", name)
+	return nil
+}
+
 func init() {
 	rootCmd.AddCommand(saveContextCmd)
 	saveContextCmd.ValidArgsFunction = validWorkspaceNamesExcludeArchived
+	saveContextCmd.Flags().StringVar(&saveContextFile, "file", "", "Read the continuation from this file instead of prompting")
+	saveContextCmd.Flags().BoolVar(&saveContextStdin, "stdin", false, "Read the continuation from standard input instead of prompting")
+	saveContextCmd.MarkFlagsMutuallyExclusive("file", "stdin")
 }
@@ -0,0 +1,53 @@
+package cmd
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/pmossman/claudew/internal/workspace"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSaveContinuationFrom(t *testing.T) {
+	notes := filepath.Join(t.TempDir(), "notes.md")
+	require.NoError(t, os.WriteFile(notes, []byte("\nNext: wire up the retry\n\n"), 0644))
+	blank := filepath.Join(t.TempDir(), "blank.md")
+	require.NoError(t, os.WriteFile(blank, []byte(" \n\t\n"), 0644))
+
+	tests := []struct {
+		name    string
+		path    string
+		stdin   string
+		want    string // continuation.md afterwards
+		wantErr string
+	}{
+		{name: "file", path: notes, want: "Next: wire up the retry"},
+		{name: "stdin", stdin: "Done: parser\nNext: tests\n", want: "Done: parser\nNext: tests"},
+		{name: "empty stdin keeps existing", stdin: "", want: "previous", wantErr: "continuation is empty"},
+		{name: "blank file keeps existing", path: blank, want: "previous", wantErr: "continuation is empty"},
+		{name: "missing file", path: filepath.Join(t.TempDir(), "missing.md"), want: "previous", wantErr: "failed to read continuation"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			wsMgr := workspace.NewManager(t.TempDir())
+			require.NoError(t, wsMgr.Create("test-ws"))
+			require.NoError(t, wsMgr.SaveContinuation("test-ws", "previous"))
+
+			var out bytes.Buffer
+			err := saveContinuationFrom(wsMgr, "test-ws", tt.path, strings.NewReader(tt.stdin), &out)
+			if tt.wantErr != "" {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tt.wantErr)
+				assert.Empty(t, out.String())
+			} else {
+				require.NoError(t, err)
+				assert.Contains(t, out.String(), "Saved continuation for workspace 'test-ws'")
+			}
+			assert.Equal(t, tt.want, wsMgr.GetContinuation("test-ws"))
+		})
+	}
+}
@@ -1,68 +1,86 @@
 package cmd
 
 import (
-	"bytes"
 	"fmt"
 	"os"
-	"os/exec"
 	"sort"
+	"strconv"
 	"strings"
+	"time"
 
 	"github.com/pmossman/claudew/internal/config"
+	"github.com/pmossman/claudew/internal/markdown"
+	"github.com/pmossman/claudew/internal/menu"
 	"github.com/pmossman/claudew/internal/session"
 	"github.com/pmossman/claudew/internal/workspace"
 	"github.com/spf13/cobra"
 )
 
-// ANSI color codes for terminal output
-const (
-	colorReset  = "\033[0m"
-	colorGray   = "\033[90m"
-	colorCyan   = "\033[36m"
-	colorGreen  = "\033[32m"
-	colorYellow = "\033[33m"
-	colorBlue   = "\033[34m"
-)
-
 // buildWorkspaceMenuItems creates the workspace list section of the menu
-func buildWorkspaceMenuItems(cfg *config.Config, wsMgr *workspace.Manager, sessionMgr *session.Manager, includeArchived bool) []string {
+func buildWorkspaceMenuItems(cfg *config.Config, wsMgr *workspace.Manager, sessionMgr *session.Manager, includeArchived, includeHidden bool) []string {
 	var lines []string
 
 	if len(cfg.Workspaces) == 0 {
 		return lines
 	}
 
-	// Add section header
-	lines = append(lines, colorGray+"──── WORKSPACES ────"+colorReset)
-
 	// Build workspace list sorted by last active
 	type wsEntry struct {
 		name string
 		ws   *config.Workspace
 	}
 	var entries []wsEntry
+	hidden := 0
 	for name, ws := range cfg.Workspaces {
 		// Skip archived workspaces unless explicitly requested
 		if !includeArchived && ws.Status == config.StatusArchived {
 			continue
 		}
+		if !includeHidden && ws.Hidden {
+			hidden++
+			continue
+		}
 		entries = append(entries, wsEntry{name: name, ws: ws})
 	}
+
+	// Add section header, noting hidden workspaces so they aren't mistaken for lost ones
+	header := "──── WORKSPACES ────"
+	if hidden > 0 {
+		header = fmt.Sprintf("──── WORKSPACES (%d hidden, see --all) ────", hidden)
+	}
+	lines = append(lines, colorize(colorGray, header))
 	sort.Slice(entries, func(i, j int) bool {
-		return entries[i].ws.LastActive.After(entries[j].ws.LastActive)
+		return menuOrderLess(entries[i].ws, entries[j].ws)
 	})
 
-	// Add workspace items
+	// Unchanged summaries come from the cache, and session states from one tmux call,
+	// so the menu opens quickly with many workspaces
+	wsMgr.EnableCache()
+	defer wsMgr.SaveCache()
+	attached, attachedErr := sessionMgr.ListAttached()
+
+	var names []string
+	for _, entry := range entries {
+		names = append(names, entry.name)
+	}
+	nameWidth := menuNameWidth(names)
+
+	// Add workspace items, identified by name so any characters in it are safe
 	for _, entry := range entries {
 		ws := entry.ws
 		summary := wsMgr.GetSummary(entry.name)
 		lastActive := formatTimeAgo(ws.LastActive)
 
 		// Get tmux session state
-		sessionName := sessionMgr.GetSessionName(entry.name)
-		sessionState, err := sessionMgr.GetSessionState(sessionName)
-		if err != nil {
+		clients, running := attached[sessionMgr.GetSessionName(entry.name)]
+		sessionState := "none"
+		switch {
+		case attachedErr != nil:
 			sessionState = "unknown"
+		case running && clients > 0:
+			sessionState = "attached"
+		case running:
+			sessionState = "detached"
 		}
 
 		// Color code status based on session state
@@ -73,18 +91,17 @@ func buildWorkspaceMenuItems(cfg *config.Config, wsMgr *workspace.Manager, sessi
 			statusColor = colorYellow
 		}
 
-		// Format: name [status] summary (time)
-		line := fmt.Sprintf("%s %s[%s]%s %s %s(%s)%s",
-			colorCyan+entry.name+colorReset,
-			statusColor,
-			sessionState,
-			colorReset,
+		// Format: name [status] STATE summary (time)
+		line := fmt.Sprintf("%s %s %s%s%s%s %s",
+			colorize(workspaceColor(ws), padMenuName(entry.name, nameWidth)),
+			colorize(statusColor, "["+sessionState+"]"),
+			pinMarker(ws),
+			hiddenMarker(ws),
+			stateMarker(ws),
 			summary,
-			colorGray,
-			lastActive,
-			colorReset,
+			colorize(colorGray, "("+lastActive+")"),
 		)
-		lines = append(lines, line)
+		lines = append(lines, menu.Line(menuItemID(menuItemWorkspace, entry.name), line))
 	}
 
 	return lines
@@ -95,122 +112,126 @@ func buildActionMenuItems(cfg *config.Config) []string {
 	var lines []string
 
 	// Add section header
-	lines = append(lines, colorGray+"──── ACTIONS ────"+colorReset)
+	lines = append(lines, colorize(colorGray, "──── ACTIONS ────"))
 
 	// Add create workspace action only if there are remotes
 	if len(cfg.Remotes) > 0 {
-		lines = append(lines, colorBlue+"→"+colorReset+" Create new workspace")
+		lines = append(lines, actionMenuLine(menuItemAction, actionCreateWorkspace, "Create new workspace"))
 	}
 
 	// Add workspace management actions if there are workspaces
 	if len(cfg.Workspaces) > 0 {
-		lines = append(lines, colorBlue+"→"+colorReset+" CD to workspace clone")
-		lines = append(lines, colorBlue+"→"+colorReset+" Open workspace folder")
-		lines = append(lines, colorBlue+"→"+colorReset+" Save context")
-		lines = append(lines, colorBlue+"→"+colorReset+" Restart Claude session")
-		lines = append(lines, colorBlue+"→"+colorReset+" Stop workspace")
-		lines = append(lines, colorBlue+"→"+colorReset+" Archive workspace")
+		lines = append(lines, actionMenuLine(menuItemAction, actionCD, "CD to workspace clone"))
+		lines = append(lines, actionMenuLine(menuItemAction, actionOpen, "Open workspace folder"))
+		lines = append(lines, actionMenuLine(menuItemAction, actionSaveContext, "Save context"))
+		lines = append(lines, actionMenuLine(menuItemAction, actionRestart, "Restart Claude session"))
+		lines = append(lines, actionMenuLine(menuItemAction, actionStop, "Stop workspace"))
+		lines = append(lines, actionMenuLine(menuItemAction, actionArchive, "Archive workspace"))
 	}
 
 	// Add clone-related actions if clones exist
 	if len(cfg.Clones) > 0 {
-		lines = append(lines, fmt.Sprintf(colorBlue+"→"+colorReset+" Browse clones "+colorGray+"(%d available)"+colorReset, len(cfg.Clones)))
+		lines = append(lines, actionMenuLine(menuItemAction, actionBrowseClones,
+			"Browse clones "+colorize(colorGray, fmt.Sprintf("(%d available)", len(cfg.Clones)))))
 	}
 
 	// Add remote-related actions
 	if len(cfg.Remotes) > 0 {
-		lines = append(lines, fmt.Sprintf(colorBlue+"→"+colorReset+" Create new clone "+colorGray+"(%d remotes)"+colorReset, len(cfg.Remotes)))
-		lines = append(lines, fmt.Sprintf(colorBlue+"→"+colorReset+" List remotes "+colorGray+"(%d)"+colorReset, len(cfg.Remotes)))
+		lines = append(lines, actionMenuLine(menuItemAction, actionNewClone,
+			"Create new clone "+colorize(colorGray, fmt.Sprintf("(%d remotes)", len(cfg.Remotes)))))
+		lines = append(lines, actionMenuLine(menuItemAction, actionListRemotes,
+			"List remotes "+colorize(colorGray, fmt.Sprintf("(%d)", len(cfg.Remotes)))))
 	}
 
 	// Always show "Add remote" action
-	lines = append(lines, colorBlue+"→"+colorReset+" Add remote")
+	lines = append(lines, actionMenuLine(menuItemAction, actionAddRemote, "Add remote"))
+
+	// User-defined actions from settings, identified by their label
+	for _, action := range cfg.Settings.CustomActions {
+		lines = append(lines, actionMenuLine(menuItemCustom, action.Label, action.Label))
+	}
 
 	return lines
 }
 
-// runFzfMenu runs fzf with the given input and returns the selected item
-func runFzfMenu(input string) (string, error) {
+// actionMenuLine formats an action for the main menu as "→ label"
+func actionMenuLine(kind, action, label string) string {
+	return menu.Line(menuItemID(kind, action), colorize(colorBlue, "→")+" "+label)
+}
+
+// applyFzfSettings overrides a menu's layout with the user's fzf_* settings
+func applyFzfSettings(opts menuOptions, settings config.Settings) menuOptions {
+	if settings.FzfHeight != "" {
+		opts.height = settings.FzfHeight
+	}
+	if settings.FzfPreviewWindow != "" && opts.preview != "" {
+		opts.previewWindow = settings.FzfPreviewWindow
+	}
+	if settings.FzfTmux != "" && os.Getenv("TMUX") != "" {
+		opts.tmux = settings.FzfTmux
+	}
+	return opts
+}
+
+// runMainMenu shows the main menu and returns the selected item
+func runMainMenu(lines []string, settings config.Settings) (string, error) {
 	// Get path to self for preview command
 	self, err := os.Executable()
 	if err != nil {
 		return "", fmt.Errorf("failed to get executable path: %w", err)
 	}
 
-	// Build fzf command with preview
 	previewCmd := fmt.Sprintf("sh -c '%s preview-menu \"$1\"' _ {}", self)
-	fzfCmd := exec.Command("fzf",
-		"--ansi",
-		"--no-sort",
-		"--layout=reverse",
-		"--height=100%",
-		"--preview="+previewCmd,
-		"--preview-window=right:50%:wrap",
-		"--header=Select an option (Ctrl-C to cancel)",
-		"--prompt=claude-workspace> ",
-	)
-
-	// Set up pipes
-	fzfCmd.Stdin = strings.NewReader(input)
-	fzfCmd.Stderr = os.Stderr
-
-	var outBuf bytes.Buffer
-	fzfCmd.Stdout = &outBuf
-
-	// Run fzf
-	if err := fzfCmd.Run(); err != nil {
-		// User cancelled (Ctrl-C)
-		if exitErr, ok := err.(*exec.ExitError); ok {
-			if exitErr.ExitCode() == 130 {
-				return "", nil
-			}
-		}
-		return "", fmt.Errorf("fzf failed: %w", err)
-	}
-
-	// Extract selection
-	selected := strings.TrimSpace(outBuf.String())
-	return selected, nil
-}
-
-// parseWorkspaceSelection extracts the workspace name from a menu selection
-func parseWorkspaceSelection(selected string) (string, error) {
-	// Parse workspace name (everything before '[')
-	bracketIdx := strings.Index(selected, "[")
-	if bracketIdx == -1 {
-		return "", fmt.Errorf("invalid selection format")
-	}
-	return strings.TrimSpace(selected[:bracketIdx]), nil
+	return runMenu(lines, applyFzfSettings(menuOptions{
+		header:        "Select an option (Ctrl-C to cancel)",
+		prompt:        "claude-workspace> ",
+		height:        "100%",
+		preview:       previewCmd,
+		previewWindow: "right:50%:wrap",
+		reverse:       true,
+		noSort:        true,
+		withIDs:       true,
+	}, settings))
 }
 
 var (
 	selectArchived bool
+	selectAll      bool
 )
 
 var selectCmd = &cobra.Command{
 	Use:   "select",
 	Short: "Interactive super-prompt for all workspace operations",
-	Long:  `Opens an interactive fzf menu to choose workspaces, create new ones, browse clones, etc. This is the default command.`,
-	RunE: func(cmd *cobra.Command, args []string) error {
-		// Check if fzf is installed
-		if err := checkFzfInstalled(); err != nil {
-			return err
-		}
+	Long: `Opens an interactive fzf menu to choose workspaces, create new ones, browse clones, etc. This is the default command.
 
+Problems such as dead sessions still marked active, missing clone directories and
+stale locks are listed in an ATTENTION section; select one to fix it.`,
+	RunE: func(cmd *cobra.Command, args []string) error {
 		// Load config
 		cfg, err := config.Load()
 		if err != nil {
 			return fmt.Errorf("failed to load config: %w", err)
 		}
 
-		wsMgr := workspace.NewManager(cfg.Settings.WorkspaceDir)
-		sessionMgr := session.NewManager()
+		wsMgr := workspace.NewManagerForConfig(cfg)
+		sessionMgr := session.NewManagerForConfig(cfg)
+
+		// Use real tmux activity for "time ago" and ordering
+		if syncSessionActivity(cfg, sessionMgr) {
+			cfg.Save()
+		}
 
 		// Build menu options
 		var inputLines []string
 
+		// Problems that need fixing come first; stale locks are listed here rather than removed silently
+		if attentionLines := buildAttentionMenuItems(collectAttentionItems(cfg, wsMgr, sessionMgr)); len(attentionLines) > 0 {
+			inputLines = append(inputLines, attentionLines...)
+			inputLines = append(inputLines, "")
+		}
+
 		// Add workspace items
-		workspaceLines := buildWorkspaceMenuItems(cfg, wsMgr, sessionMgr, selectArchived)
+		workspaceLines := buildWorkspaceMenuItems(cfg, wsMgr, sessionMgr, selectArchived, selectAll)
 		inputLines = append(inputLines, workspaceLines...)
 
 		// Add separator if there are workspaces
@@ -222,9 +243,8 @@ var selectCmd = &cobra.Command{
 		actionLines := buildActionMenuItems(cfg)
 		inputLines = append(inputLines, actionLines...)
 
-		// Run fzf menu
-		input := strings.Join(inputLines, "\n")
-		selected, err := runFzfMenu(input)
+		// Run menu
+		selected, err := runMainMenu(inputLines, cfg.Settings)
 		if err != nil {
 			return err
 		}
@@ -234,65 +254,104 @@ var selectCmd = &cobra.Command{
 			return nil
 		}
 
-		// Strip ANSI color codes from selection
-		selected = stripANSI(selected)
+		// Every item is identified by its menu ID; only section headers have none
+		kind, value := parseMenuItemID(menu.ID(selected))
+		switch kind {
+		case menuItemWorkspace:
+			return startCmd.RunE(cmd, []string{value})
 
-		// Handle actions
-		if strings.HasPrefix(selected, "→") {
-			return handleAction(cfg, selected)
-		}
+		case menuItemAction:
+			return handleAction(cfg, value)
 
-		// Handle section headers
-		if strings.HasPrefix(selected, "────") {
-			fmt.Println("Please select a workspace or action, not a section header")
-			return nil
-		}
+		case menuItemCustom:
+			custom, err := cfg.GetCustomAction(value)
+			if err != nil {
+				return err
+			}
+			return runCustomAction(cfg, custom)
 
-		// Parse workspace name
-		workspaceName, err := parseWorkspaceSelection(selected)
-		if err != nil {
-			return err
+		case menuItemAttention:
+			item, err := parseAttentionID(value)
+			if err != nil {
+				return err
+			}
+			return fixAttentionItem(cfg, item)
 		}
 
-		// Call start command for the selected workspace
-		return startCmd.RunE(cmd, []string{workspaceName})
+		fmt.Println("Please select a workspace or action, not a section header")
+		return nil
 	},
 }
 
+// Kinds of main menu item; an item's menu ID is "kind:value"
+const (
+	menuItemWorkspace = "workspace" // value is the workspace name
+	menuItemAction    = "action"    // value is one of the action constants
+	menuItemCustom    = "custom"    // value is the custom action's label
+	menuItemAttention = "attention" // value is written by attentionItem.id
+)
+
+// Built-in actions of the main menu
+const (
+	actionCreateWorkspace = "create-workspace"
+	actionCD              = "cd"
+	actionOpen            = "open"
+	actionSaveContext     = "save-context"
+	actionRestart         = "restart"
+	actionStop            = "stop"
+	actionArchive         = "archive"
+	actionBrowseClones    = "browse-clones"
+	actionNewClone        = "new-clone"
+	actionListRemotes     = "list-remotes"
+	actionAddRemote       = "add-remote"
+)
+
+// menuItemID returns the menu ID of a main menu item
+func menuItemID(kind, value string) string {
+	return kind + ":" + value
+}
+
+// parseMenuItemID splits a main menu ID into its kind and value. Section headers
+// have no ID, so both are empty.
+func parseMenuItemID(id string) (kind, value string) {
+	kind, value, _ = strings.Cut(id, ":")
+	return kind, value
+}
+
 // handleAction handles the action items from the menu
 func handleAction(cfg *config.Config, action string) error {
-	switch {
-	case strings.HasPrefix(action, "→ Create new workspace"):
+	switch action {
+	case actionCreateWorkspace:
 		return createCmd.RunE(nil, []string{})
 
-	case strings.HasPrefix(action, "→ CD to workspace clone"):
+	case actionCD:
 		return cdCmd.RunE(nil, []string{})
 
-	case strings.HasPrefix(action, "→ Open workspace folder"):
+	case actionOpen:
 		return openCmd.RunE(nil, []string{})
 
-	case strings.HasPrefix(action, "→ Save context"):
+	case actionSaveContext:
 		return saveContextCmd.RunE(nil, []string{})
 
-	case strings.HasPrefix(action, "→ Restart Claude session"):
+	case actionRestart:
 		return restartCmd.RunE(nil, []string{})
 
-	case strings.HasPrefix(action, "→ Stop workspace"):
+	case actionStop:
 		return stopCmd.RunE(nil, []string{})
 
-	case strings.HasPrefix(action, "→ Archive workspace"):
+	case actionArchive:
 		return interactiveArchive(cfg)
 
-	case strings.HasPrefix(action, "→ Browse clones"):
+	case actionBrowseClones:
 		return browseClones(cfg)
 
-	case strings.HasPrefix(action, "→ Create new clone"):
+	case actionNewClone:
 		return interactiveNewClone(cfg)
 
-	case strings.HasPrefix(action, "→ List remotes"):
+	case actionListRemotes:
 		return listRemotesCmd.RunE(nil, []string{})
 
-	case strings.HasPrefix(action, "→ Add remote"):
+	case actionAddRemote:
 		return addRemoteCmd.RunE(nil, []string{})
 
 	default:
@@ -307,7 +366,9 @@ func selectWorkspaceInteractive(cfg *config.Config) (string, error) {
 		return "", nil
 	}
 
-	wsMgr := workspace.NewManager(cfg.Settings.WorkspaceDir)
+	refreshLastActive(cfg)
+
+	wsMgr := workspace.NewManagerForConfig(cfg)
 
 	// Build workspace list
 	type wsEntry struct {
@@ -323,61 +384,44 @@ func selectWorkspaceInteractive(cfg *config.Config) (string, error) {
 		entries = append(entries, wsEntry{name: name, ws: ws})
 	}
 	sort.Slice(entries, func(i, j int) bool {
-		return entries[i].ws.LastActive.After(entries[j].ws.LastActive)
+		return menuOrderLess(entries[i].ws, entries[j].ws)
 	})
 
+	wsMgr.EnableCache()
+	defer wsMgr.SaveCache()
+
+	var names []string
+	for _, entry := range entries {
+		names = append(names, entry.name)
+	}
+	nameWidth := menuNameWidth(names)
+
 	var inputLines []string
 	for _, entry := range entries {
 		ws := entry.ws
 		summary := wsMgr.GetSummary(entry.name)
 		lastActive := formatTimeAgo(ws.LastActive)
 
-		line := fmt.Sprintf("%s [%s] %s (%s)",
-			entry.name,
+		line := fmt.Sprintf("%s [%s] %s%s (%s)",
+			padMenuName(entry.name, nameWidth),
 			ws.Status,
+			pinMarker(ws),
 			summary,
 			lastActive,
 		)
-		inputLines = append(inputLines, line)
-	}
-
-	input := strings.Join(inputLines, "\n")
-
-	fzfCmd := exec.Command("fzf",
-		"--ansi",
-		"--height=50%",
-		"--header=Select workspace (Ctrl-C to cancel)",
-		"--prompt=Workspace> ",
-	)
-
-	fzfCmd.Stdin = strings.NewReader(input)
-	fzfCmd.Stderr = os.Stderr
-
-	var outBuf bytes.Buffer
-	fzfCmd.Stdout = &outBuf
-
-	if err := fzfCmd.Run(); err != nil {
-		if exitErr, ok := err.(*exec.ExitError); ok {
-			if exitErr.ExitCode() == 130 {
-				return "", nil
-			}
-		}
-		return "", fmt.Errorf("fzf failed: %w", err)
-	}
-
-	selected := strings.TrimSpace(outBuf.String())
-	if selected == "" {
-		return "", nil
+		inputLines = append(inputLines, menu.Line(entry.name, line))
 	}
 
-	// Parse workspace name (everything before '[')
-	bracketIdx := strings.Index(selected, "[")
-	if bracketIdx == -1 {
-		return "", fmt.Errorf("invalid selection format")
+	selected, err := runMenu(inputLines, applyFzfSettings(menuOptions{
+		header:  "Select workspace (Ctrl-C to cancel)",
+		prompt:  "Workspace> ",
+		height:  "50%",
+		withIDs: true,
+	}, cfg.Settings))
+	if err != nil {
+		return "", err
 	}
-	workspaceName := strings.TrimSpace(selected[:bracketIdx])
-
-	return workspaceName, nil
+	return menu.ID(selected), nil
 }
 
 // interactiveArchive shows an interactive workspace archive selector
@@ -410,44 +454,22 @@ func browseClones(cfg *config.Config) error {
 			status = fmt.Sprintf("in use by: %s", clone.InUseBy)
 		}
 		line := fmt.Sprintf("%s [%s] %s", clone.Path, clone.RemoteName, status)
-		inputLines = append(inputLines, line)
-	}
-
-	input := strings.Join(inputLines, "\n")
-
-	fzfCmd := exec.Command("fzf",
-		"--ansi",
-		"--height=100%",
-		"--header=Clone paths (use 'cwc' to cd interactively, or copy path below)",
-		"--prompt=Clone> ",
-	)
-
-	fzfCmd.Stdin = strings.NewReader(input)
-	fzfCmd.Stderr = os.Stderr
-
-	var outBuf bytes.Buffer
-	fzfCmd.Stdout = &outBuf
-
-	if err := fzfCmd.Run(); err != nil {
-		if exitErr, ok := err.(*exec.ExitError); ok {
-			if exitErr.ExitCode() == 130 {
-				return nil
-			}
-		}
-		return fmt.Errorf("fzf failed: %w", err)
+		inputLines = append(inputLines, menu.Line(clone.Path, line))
 	}
 
-	selected := strings.TrimSpace(outBuf.String())
-	if selected == "" {
-		return nil
+	selected, err := runMenu(inputLines, applyFzfSettings(menuOptions{
+		header:  "Clone paths (use 'cwc' to cd interactively, or copy path below)",
+		prompt:  "Clone> ",
+		height:  "100%",
+		withIDs: true,
+	}, cfg.Settings))
+	if err != nil {
+		return err
 	}
-
-	// Extract clone path (everything before '[')
-	bracketIdx := strings.Index(selected, "[")
-	if bracketIdx == -1 {
+	clonePath := menu.ID(selected)
+	if clonePath == "" {
 		return nil
 	}
-	clonePath := strings.TrimSpace(selected[:bracketIdx])
 
 	// Output CD marker for shell function to detect
 	// Use CD::: delimiter to handle paths with colons
@@ -458,10 +480,25 @@ func browseClones(cfg *config.Config) error {
 
 // interactiveNewClone prompts for remote and creates a new clone
 func interactiveNewClone(cfg *config.Config) error {
+	remoteName, err := selectRemoteInteractive(cfg, "Select remote to clone")
+	if err != nil || remoteName == "" {
+		return err
+	}
+
+	remote, err := cfg.GetRemote(remoteName)
+	if err != nil {
+		return err
+	}
+	return createClone(cfg, remoteName, remote)
+}
+
+// selectRemoteInteractive lets the user pick a registered remote. Returns "" if there are
+// no remotes or the user cancelled.
+func selectRemoteInteractive(cfg *config.Config, header string) (string, error) {
 	if len(cfg.Remotes) == 0 {
 		fmt.Println("No remotes registered.")
 		fmt.Println("Add one with: claudew add-remote <name> <url> --clone-dir <path>")
-		return nil
+		return "", nil
 	}
 
 	// Build remote list
@@ -476,73 +513,19 @@ func interactiveNewClone(cfg *config.Config) error {
 		remote := cfg.Remotes[name]
 		cloneCount := len(cfg.GetClonesForRemote(name))
 		line := fmt.Sprintf("%s (%d clones) - %s", name, cloneCount, remote.URL)
-		inputLines = append(inputLines, line)
+		inputLines = append(inputLines, menu.Line(name, line))
 	}
 
-	input := strings.Join(inputLines, "\n")
-
-	fzfCmd := exec.Command("fzf",
-		"--ansi",
-		"--height=50%",
-		"--header=Select remote to clone",
-		"--prompt=Remote> ",
-	)
-
-	fzfCmd.Stdin = strings.NewReader(input)
-	fzfCmd.Stderr = os.Stderr
-
-	var outBuf bytes.Buffer
-	fzfCmd.Stdout = &outBuf
-
-	if err := fzfCmd.Run(); err != nil {
-		if exitErr, ok := err.(*exec.ExitError); ok {
-			if exitErr.ExitCode() == 130 {
-				return nil
-			}
-		}
-		return fmt.Errorf("fzf failed: %w", err)
-	}
-
-	selected := strings.TrimSpace(outBuf.String())
-	if selected == "" {
-		return nil
-	}
-
-	// Extract remote name (before first space)
-	parts := strings.Fields(selected)
-	if len(parts) == 0 {
-		return fmt.Errorf("invalid selection")
-	}
-	remoteName := parts[0]
-
-	// Call new-clone command
-	return newCloneCmd.RunE(nil, []string{remoteName})
-}
-
-// stripANSI removes ANSI color codes from a string
-func stripANSI(s string) string {
-	// Use a more sophisticated approach that handles UTF-8 properly
-	// Match ANSI escape sequences: \033[ followed by any chars until 'm'
-	result := strings.Builder{}
-	i := 0
-	for i < len(s) {
-		// Check for ANSI escape sequence start
-		if i < len(s)-1 && (s[i] == '\033' || s[i] == '\x1b') && i+1 < len(s) && s[i+1] == '[' {
-			// Skip until we find 'm'
-			i += 2
-			for i < len(s) && s[i] != 'm' {
-				i++
-			}
-			if i < len(s) {
-				i++ // skip the 'm'
-			}
-		} else {
-			// Regular character - write it as-is (preserves UTF-8)
-			result.WriteByte(s[i])
-			i++
-		}
+	selected, err := runMenu(inputLines, applyFzfSettings(menuOptions{
+		header:  header,
+		prompt:  "Remote> ",
+		height:  "50%",
+		withIDs: true,
+	}, cfg.Settings))
+	if err != nil {
+		return "", err
 	}
-	return result.String()
+	return menu.ID(selected), nil
 }
 
 // previewMenuCmd handles previews for the super-prompt menu
@@ -553,194 +536,189 @@ var previewMenuCmd = &cobra.Command{
 	RunE: func(cmd *cobra.Command, args []string) error {
 		selection := strings.Join(args, " ")
 
-		// Strip ANSI color codes from selection
-		selection = stripANSI(selection)
-
 		// Load config
 		cfg, err := config.Load()
 		if err != nil {
 			return fmt.Errorf("failed to load config: %w", err)
 		}
 
-		// Handle different selection types
-		if strings.HasPrefix(selection, "→ Create new workspace") {
-			fmt.Println("Create a new workspace with a fresh clone or existing repo.")
-			fmt.Println()
-			fmt.Println("This will:")
-			fmt.Println("  • Prompt for workspace name")
-			fmt.Println("  • Let you choose a remote")
-			fmt.Println("  • Auto-find or create a clone")
-			fmt.Println("  • Set up workspace tracking files")
-			return nil
-		}
+		kind, value := parseMenuItemID(menu.ID(selection))
+		switch kind {
+		case menuItemWorkspace:
+			return showWorkspacePreview(cfg, value)
 
-		if strings.HasPrefix(selection, "→ CD to workspace clone") {
-			fmt.Println("Change directory to a workspace's clone.")
-			fmt.Println()
-			fmt.Printf("Total workspaces: %d\n", len(cfg.Workspaces))
-			fmt.Println()
-			fmt.Println("This will:")
-			fmt.Println("  • Select a workspace")
-			fmt.Println("  • CD your shell to the workspace's clone directory")
-			fmt.Println("  • Let you work directly in the repository")
-			fmt.Println()
-			fmt.Println("Note: Requires shell integration (cw install-shell)")
-			return nil
-		}
+		case menuItemAction:
+			previewAction(cfg, value)
 
-		if strings.HasPrefix(selection, "→ Open workspace folder") {
-			fmt.Println("Open a workspace directory in your file browser.")
-			fmt.Println()
-			fmt.Printf("Total workspaces: %d\n", len(cfg.Workspaces))
-			fmt.Println()
-			fmt.Println("This will:")
-			fmt.Println("  • Select a workspace")
-			fmt.Println("  • Open its folder in Finder/Explorer")
-			fmt.Println("  • Let you view/edit markdown files directly:")
-			fmt.Println("    - context.md")
-			fmt.Println("    - decisions.md")
-			fmt.Println("    - continuation.md")
-			fmt.Println("    - summary.txt")
-			fmt.Println("    - research/ folder")
-			return nil
-		}
-
-		if strings.HasPrefix(selection, "→ Save context") {
-			fmt.Println("Save context and continuation for a workspace.")
-			fmt.Println()
-			fmt.Printf("Total workspaces: %d\n", len(cfg.Workspaces))
-			fmt.Println()
-			fmt.Println("Useful for:")
-			fmt.Println("  • Preserving progress before restarting Claude")
-			fmt.Println("  • Manual checkpoints during long tasks")
-			fmt.Println("  • Ensuring continuation.md is up to date")
-			fmt.Println()
-			fmt.Println("This will:")
-			fmt.Println("  • Show current continuation (if any)")
-			fmt.Println("  • Prompt for updated continuation text")
-			fmt.Println("  • Save to continuation.md for next session")
-			return nil
-		}
-
-		if strings.HasPrefix(selection, "→ Restart Claude session") {
-			fmt.Println("Restart the Claude Code session in a workspace.")
-			fmt.Println()
-			fmt.Printf("Total workspaces: %d\n", len(cfg.Workspaces))
-			fmt.Println()
-			fmt.Println("Useful when:")
-			fmt.Println("  • Claude becomes unresponsive or stuck")
-			fmt.Println("  • You want to start fresh with a new session")
-			fmt.Println("  • You need to reload with the continuation prompt")
-			fmt.Println()
-			fmt.Println("This will:")
-			fmt.Println("  • Prompt to save continuation first")
-			fmt.Println("  • Kill the current Claude process (Ctrl-C)")
-			fmt.Println("  • Start a new Claude session")
-			fmt.Println("  • Display and copy the continuation prompt")
-			fmt.Println("  • Keep tmux session and context intact")
-			return nil
-		}
-
-		if strings.HasPrefix(selection, "→ Stop workspace") {
-			fmt.Println("Stop a workspace temporarily and free its clone.")
-			fmt.Println()
-			fmt.Printf("Total workspaces: %d\n", len(cfg.Workspaces))
-			fmt.Println()
-			fmt.Println("This will:")
-			fmt.Println("  • Select a workspace to stop")
-			fmt.Println("  • Kill the tmux session (if running)")
-			fmt.Println("  • Free the clone for other workspaces to use")
-			fmt.Println("  • Set status to 'idle'")
-			fmt.Println()
-			fmt.Println("The workspace can be restarted with 'claudew start'")
-			fmt.Println("All context files are preserved")
-			return nil
-		}
-
-		if strings.HasPrefix(selection, "→ Archive workspace") {
-			fmt.Println("Archive an existing workspace.")
-			fmt.Println()
-			fmt.Printf("Total workspaces: %d\n", len(cfg.Workspaces))
-			fmt.Println()
-			fmt.Println("This will:")
-			fmt.Println("  • Select a workspace to archive")
-			fmt.Println("  • Move it to archived/ directory")
-			fmt.Println("  • Free up the clone if managed")
-			fmt.Println("  • Preserve all workspace files")
-			return nil
-		}
-
-		if strings.HasPrefix(selection, "→ Browse clones") {
-			fmt.Println("Browse all available clones.")
-			fmt.Println()
-			fmt.Printf("Total clones: %d\n", len(cfg.Clones))
-			freeCount := 0
-			for _, clone := range cfg.Clones {
-				if clone.InUseBy == "" {
-					freeCount++
+		case menuItemCustom:
+			if custom, err := cfg.GetCustomAction(value); err == nil {
+				fmt.Printf("Custom action: %s\n", custom.Label)
+				fmt.Println()
+				fmt.Println("Runs:")
+				fmt.Printf("  %s\n", custom.Command)
+				if custom.NeedsWorkspace() {
+					fmt.Println()
+					fmt.Println("You will be asked to select a workspace.")
 				}
 			}
-			fmt.Printf("Free clones: %d\n", freeCount)
-			fmt.Printf("In use: %d\n", len(cfg.Clones)-freeCount)
-			fmt.Println()
-			fmt.Println("Select a clone to cd into it.")
-			return nil
-		}
 
-		if strings.HasPrefix(selection, "→ Create new clone") {
-			fmt.Println("Create a new numbered clone from a remote.")
-			fmt.Println()
-			fmt.Printf("Available remotes: %d\n", len(cfg.Remotes))
-			fmt.Println()
-			fmt.Println("This will:")
-			fmt.Println("  • Prompt to select a remote")
-			fmt.Println("  • Clone to next available number")
-			fmt.Println("  • Track the clone for future use")
-			return nil
+		case menuItemAttention:
+			item, err := parseAttentionID(value)
+			if err != nil {
+				return err
+			}
+			attentionPreview(cfg, item)
 		}
 
-		if strings.HasPrefix(selection, "→ List remotes") {
-			fmt.Println("View all registered remotes.")
-			fmt.Println()
-			fmt.Printf("Total remotes: %d\n", len(cfg.Remotes))
-			fmt.Println()
-			fmt.Println("Shows:")
-			fmt.Println("  • Remote name")
-			fmt.Println("  • Git URL")
-			fmt.Println("  • Clone base directory")
-			fmt.Println("  • Number of clones")
-			return nil
-		}
+		// Section headers have no preview
+		return nil
+	},
+}
 
-		if strings.HasPrefix(selection, "→ Add remote") {
-			fmt.Println("Register a new remote repository.")
-			fmt.Println()
-			fmt.Println("This will prompt for:")
-			fmt.Println("  • Remote name (e.g., 'my-app')")
-			fmt.Println("  • Git URL (e.g., 'git@github.com:org/repo.git')")
-			fmt.Println("  • Clone directory (where to store clones)")
-			fmt.Println()
-			fmt.Println("After registering, you can:")
-			fmt.Println("  • Create workspaces for this remote")
-			fmt.Println("  • Create additional clones as needed")
-			return nil
-		}
+// previewAction describes one of the main menu's built-in actions
+func previewAction(cfg *config.Config, action string) {
+	switch action {
+	case actionCreateWorkspace:
+		fmt.Println("Create a new workspace with a fresh clone or existing repo.")
+		fmt.Println()
+		fmt.Println("This will:")
+		fmt.Println("  • Prompt for workspace name")
+		fmt.Println("  • Let you choose a remote")
+		fmt.Println("  • Auto-find or create a clone")
+		fmt.Println("  • Set up workspace tracking files")
+
+	case actionCD:
+		fmt.Println("Change directory to a workspace's clone.")
+		fmt.Println()
+		fmt.Printf("Total workspaces: %d\n", len(cfg.Workspaces))
+		fmt.Println()
+		fmt.Println("This will:")
+		fmt.Println("  • Select a workspace")
+		fmt.Println("  • CD your shell to the workspace's clone directory")
+		fmt.Println("  • Let you work directly in the repository")
+		fmt.Println()
+		fmt.Println("Note: Requires shell integration (cw install-shell)")
 
-		if strings.HasPrefix(selection, "────") {
-			// Section header - no preview
-			return nil
-		}
+	case actionOpen:
+		fmt.Println("Open a workspace directory in your file browser.")
+		fmt.Println()
+		fmt.Printf("Total workspaces: %d\n", len(cfg.Workspaces))
+		fmt.Println()
+		fmt.Println("This will:")
+		fmt.Println("  • Select a workspace")
+		fmt.Println("  • Open its folder in Finder/Explorer")
+		fmt.Println("  • Let you view/edit markdown files directly:")
+		fmt.Println("    - context.md")
+		fmt.Println("    - decisions.md")
+		fmt.Println("    - continuation.md")
+		fmt.Println("    - summary.txt")
+		fmt.Println("    - research/ folder")
+
+	case actionSaveContext:
+		fmt.Println("Save context and continuation for a workspace.")
+		fmt.Println()
+		fmt.Printf("Total workspaces: %d\n", len(cfg.Workspaces))
+		fmt.Println()
+		fmt.Println("Useful for:")
+		fmt.Println("  • Preserving progress before restarting Claude")
+		fmt.Println("  • Manual checkpoints during long tasks")
+		fmt.Println("  • Ensuring continuation.md is up to date")
+		fmt.Println()
+		fmt.Println("This will:")
+		fmt.Println("  • Show current continuation (if any)")
+		fmt.Println("  • Prompt for updated continuation text")
+		fmt.Println("  • Save to continuation.md for next session")
 
-		// Must be a workspace - show workspace preview
-		bracketIdx := strings.Index(selection, "[")
-		if bracketIdx == -1 {
-			// Not a workspace, no preview
-			return nil
+	case actionRestart:
+		fmt.Println("Restart the Claude Code session in a workspace.")
+		fmt.Println()
+		fmt.Printf("Total workspaces: %d\n", len(cfg.Workspaces))
+		fmt.Println()
+		fmt.Println("Useful when:")
+		fmt.Println("  • Claude becomes unresponsive or stuck")
+		fmt.Println("  • You want to start fresh with a new session")
+		fmt.Println("  • You need to reload with the continuation prompt")
+		fmt.Println()
+		fmt.Println("This will:")
+		fmt.Println("  • Prompt to save continuation first")
+		fmt.Println("  • Kill the current Claude process (Ctrl-C)")
+		fmt.Println("  • Start a new Claude session")
+		fmt.Println("  • Display and copy the continuation prompt")
+		fmt.Println("  • Keep tmux session and context intact")
+
+	case actionStop:
+		fmt.Println("Stop a workspace temporarily and free its clone.")
+		fmt.Println()
+		fmt.Printf("Total workspaces: %d\n", len(cfg.Workspaces))
+		fmt.Println()
+		fmt.Println("This will:")
+		fmt.Println("  • Select a workspace to stop")
+		fmt.Println("  • Kill the tmux session (if running)")
+		fmt.Println("  • Free the clone for other workspaces to use")
+		fmt.Println("  • Set status to 'idle'")
+		fmt.Println()
+		fmt.Println("The workspace can be restarted with 'claudew start'")
+		fmt.Println("All context files are preserved")
+
+	case actionArchive:
+		fmt.Println("Archive an existing workspace.")
+		fmt.Println()
+		fmt.Printf("Total workspaces: %d\n", len(cfg.Workspaces))
+		fmt.Println()
+		fmt.Println("This will:")
+		fmt.Println("  • Select a workspace to archive")
+		fmt.Println("  • Move it to archived/ directory")
+		fmt.Println("  • Free up the clone if managed")
+		fmt.Println("  • Preserve all workspace files")
+
+	case actionBrowseClones:
+		fmt.Println("Browse all available clones.")
+		fmt.Println()
+		fmt.Printf("Total clones: %d\n", len(cfg.Clones))
+		freeCount := 0
+		for _, clone := range cfg.Clones {
+			if clone.InUseBy == "" {
+				freeCount++
+			}
 		}
-		workspaceName := strings.TrimSpace(selection[:bracketIdx])
+		fmt.Printf("Free clones: %d\n", freeCount)
+		fmt.Printf("In use: %d\n", len(cfg.Clones)-freeCount)
+		fmt.Println()
+		fmt.Println("Select a clone to cd into it.")
 
-		return showWorkspacePreview(cfg, workspaceName)
-	},
+	case actionNewClone:
+		fmt.Println("Create a new numbered clone from a remote.")
+		fmt.Println()
+		fmt.Printf("Available remotes: %d\n", len(cfg.Remotes))
+		fmt.Println()
+		fmt.Println("This will:")
+		fmt.Println("  • Prompt to select a remote")
+		fmt.Println("  • Clone to next available number")
+		fmt.Println("  • Track the clone for future use")
+
+	case actionListRemotes:
+		fmt.Println("View all registered remotes.")
+		fmt.Println()
+		fmt.Printf("Total remotes: %d\n", len(cfg.Remotes))
+		fmt.Println()
+		fmt.Println("Shows:")
+		fmt.Println("  • Remote name")
+		fmt.Println("  • Git URL")
+		fmt.Println("  • Clone base directory")
+		fmt.Println("  • Number of clones")
+
+	case actionAddRemote:
+		fmt.Println("Register a new remote repository.")
+		fmt.Println()
+		fmt.Println("This will prompt for:")
+		fmt.Println("  • Remote name (e.g., 'my-app')")
+		fmt.Println("  • Git URL (e.g., 'git@github.com:org/repo.git')")
+		fmt.Println("  • Clone directory (where to store clones)")
+		fmt.Println()
+		fmt.Println("After registering, you can:")
+		fmt.Println("  • Create workspaces for this remote")
+		fmt.Println("  • Create additional clones as needed")
+	}
 }
 
 // showWorkspacePreview shows detailed workspace information
@@ -750,7 +728,7 @@ func showWorkspacePreview(cfg *config.Config, name string) error {
 		return err
 	}
 
-	wsMgr := workspace.NewManager(cfg.Settings.WorkspaceDir)
+	wsMgr := workspace.NewManagerForConfig(cfg)
 
 	fmt.Printf("WORKSPACE: %s\n", name)
 	fmt.Printf("STATUS: %s", formatStatus(ws.Status))
@@ -758,13 +736,28 @@ func showWorkspacePreview(cfg *config.Config, name string) error {
 		fmt.Printf(" (PID %d)", ws.SessionPID)
 	}
 	fmt.Println()
+	if ws.State != "" {
+		fmt.Printf("STATE: %s", colorize(stateColor(ws.State), ws.State))
+		if ws.StateReason != "" {
+			fmt.Printf(": %s", ws.StateReason)
+		}
+		fmt.Printf(" (since %s)\n", formatTimeAgo(ws.StateChanged))
+		if until := formatBlockedUntil(ws, time.Now()); until != "" {
+			fmt.Printf("REMINDER: %s\n", until)
+		}
+	}
 	fmt.Printf("REPO: %s\n", ws.GetRepoPath())
+	if ws.Subdir != "" {
+		fmt.Printf("SUBDIR: %s\n", ws.Subdir)
+	}
 
 	// Show clone info if managed
 	if ws.ClonePath != "" {
 		if clone, err := cfg.GetClone(ws.ClonePath); err == nil {
 			fmt.Printf("REMOTE: %s\n", clone.RemoteName)
-			fmt.Printf("BRANCH: %s\n", clone.CurrentBranch)
+		}
+		if branch := displayBranch(cfg, name, ws); branch != "" {
+			fmt.Printf("BRANCH: %s\n", branch)
 		}
 	}
 
@@ -775,17 +768,22 @@ func showWorkspacePreview(cfg *config.Config, name string) error {
 		fmt.Printf("SUMMARY: %s\n", summary)
 	}
 
+	sessionMgr := session.NewManagerForConfig(cfg)
+	running, _ := sessionMgr.Exists(sessionMgr.GetSessionName(name))
+	for _, warning := range lintWorkspace(cfg, wsMgr, name, running) {
+		fmt.Println(colorize(colorYellow, "⚠ "+warning))
+	}
+
 	// Show continuation
 	continuation := wsMgr.GetContinuation(name)
 	if continuation != "" {
 		fmt.Println()
 		fmt.Println("─── CONTINUATION ───")
-		// Truncate if too long
-		if len(continuation) > 500 {
-			fmt.Println(continuation[:500] + "...")
-		} else {
-			fmt.Println(continuation)
+		// Truncate if too long, without splitting a multi-byte character
+		if runes := []rune(continuation); len(runes) > 500 {
+			continuation = string(runes[:500]) + "..."
 		}
+		fmt.Print(renderPreviewMarkdown(continuation))
 	}
 
 	// Show context preview
@@ -793,12 +791,18 @@ func showWorkspacePreview(cfg *config.Config, name string) error {
 	if context != "(no context yet)" {
 		fmt.Println()
 		fmt.Println("─── RECENT CONTEXT ───")
-		fmt.Println(context)
+		fmt.Print(renderPreviewMarkdown(context))
 	}
 
 	return nil
 }
 
+// renderPreviewMarkdown formats markdown for fzf's preview window, wrapped to its width
+func renderPreviewMarkdown(text string) string {
+	width, _ := strconv.Atoi(os.Getenv("FZF_PREVIEW_COLUMNS"))
+	return markdown.Render(text, width, colorsAllowed())
+}
+
 // preview is a hidden command used by fzf to generate previews (for claudew start)
 var previewCmd = &cobra.Command{
 	Use:    "preview <name>",
@@ -822,13 +826,5 @@ func init() {
 	rootCmd.AddCommand(previewCmd)
 	rootCmd.AddCommand(previewMenuCmd)
 	selectCmd.Flags().BoolVar(&selectArchived, "archived", false, "Include archived workspaces in the list")
-}
-
-func checkFzfInstalled() error {
-	cmd := exec.Command("fzf", "--version")
-	if err := cmd.Run(); err != nil {
-		return fmt.Errorf("fzf is not installed. Please install fzf to use the interactive selector.\n" +
-			"Install with: brew install fzf (macOS) or see https://github.com/junegunn/fzf")
-	}
-	return nil
+	selectCmd.Flags().BoolVar(&selectAll, "all", false, "Include workspaces hidden with \"claudew hide\"")
 }
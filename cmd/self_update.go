@@ -0,0 +1,128 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"runtime"
+
+	"github.com/pmossman/claudew/internal/execx"
+	"github.com/pmossman/claudew/internal/update"
+	"github.com/spf13/cobra"
+)
+
+var (
+	selfUpdateCheck bool
+	selfUpdateForce bool
+)
+
+var selfUpdateCmd = &cobra.Command{
+	Use:   "self-update",
+	Short: "Update claudew to the latest GitHub release",
+	Long: `Checks GitHub for the latest claudew release, downloads the binary for this
+platform, verifies it against the release's checksums.txt, and replaces the
+running binary in place. Afterwards the shell integration and completions are
+refreshed with 'install-shell --force'.
+
+Example:
+  claudew self-update          # Update if a newer release exists
+  claudew self-update --check  # Only report whether an update is available`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		client := update.NewClient()
+
+		fmt.Println("Checking for updates...")
+		release, err := client.Latest()
+		if err != nil {
+			return err
+		}
+
+		upToDate := Version != "dev" && update.CompareVersions(Version, release.TagName) >= 0
+		fmt.Printf("  Current: %s\n", Version)
+		fmt.Printf("  Latest:  %s\n", release.TagName)
+
+		if upToDate && !selfUpdateForce {
+			fmt.Println("✓ claudew is up to date")
+			return nil
+		}
+		if selfUpdateCheck {
+			fmt.Println("Update available. Run 'claudew self-update' to install it.")
+			return nil
+		}
+
+		assetName := update.AssetName(runtime.GOOS, runtime.GOARCH)
+		asset, err := release.Asset(assetName)
+		if err != nil {
+			return fmt.Errorf("no release binary for %s/%s: %w", runtime.GOOS, runtime.GOARCH, err)
+		}
+		checksumsAsset, err := release.Asset(update.ChecksumsAssetName)
+		if err != nil {
+			return fmt.Errorf("refusing to update without checksums: %w", err)
+		}
+
+		self, err := os.Executable()
+		if err != nil {
+			return fmt.Errorf("failed to get executable path: %w", err)
+		}
+		self, err = filepath.EvalSymlinks(self)
+		if err != nil {
+			return fmt.Errorf("failed to resolve executable path: %w", err)
+		}
+
+		tmpDir, err := os.MkdirTemp("", "claudew-update-")
+		if err != nil {
+			return fmt.Errorf("failed to create temp directory: %w", err)
+		}
+		defer os.RemoveAll(tmpDir)
+
+		fmt.Printf("Downloading %s...\n", assetName)
+		binPath := filepath.Join(tmpDir, assetName)
+		if err := client.Download(asset.DownloadURL, binPath); err != nil {
+			return err
+		}
+		checksumsPath := filepath.Join(tmpDir, update.ChecksumsAssetName)
+		if err := client.Download(checksumsAsset.DownloadURL, checksumsPath); err != nil {
+			return err
+		}
+
+		// Verify checksum before touching the installed binary
+		checksums, err := os.ReadFile(checksumsPath)
+		if err != nil {
+			return fmt.Errorf("failed to read checksums: %w", err)
+		}
+		expected, ok := update.ParseChecksums(string(checksums))[assetName]
+		if !ok {
+			return fmt.Errorf("checksums.txt has no entry for %s", assetName)
+		}
+		actual, err := update.FileSHA256(binPath)
+		if err != nil {
+			return err
+		}
+		if actual != expected {
+			return fmt.Errorf("checksum mismatch for %s: expected %s, got %s", assetName, expected, actual)
+		}
+		fmt.Println("✓ Checksum verified")
+
+		if err := update.ReplaceExecutable(self, binPath); err != nil {
+			return err
+		}
+		fmt.Printf("✓ Updated %s to %s\n", self, release.TagName)
+
+		// Refresh shell integration and completions using the new binary
+		refresh := execx.Command(self, "install-shell", "--force")
+		refresh.Stdout = os.Stdout
+		refresh.Stderr = os.Stderr
+		if err := refresh.Run(); err != nil {
+			fmt.Printf("Warning: failed to refresh shell integration: %v\n", err)
+			fmt.Println("Run 'claudew install-shell --force' manually.")
+		}
+
+		return nil
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(selfUpdateCmd)
+	selfUpdateCmd.Flags().BoolVar(&selfUpdateCheck, "check", false, "Only check whether an update is available")
+	selfUpdateCmd.Flags().BoolVar(&selfUpdateForce, "force", false, "Reinstall even if already up to date")
+}
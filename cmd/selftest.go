@@ -0,0 +1,286 @@
+package cmd
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"github.com/pmossman/claudew/internal/config"
+	"github.com/pmossman/claudew/internal/execx"
+	"github.com/pmossman/claudew/internal/session"
+	"github.com/pmossman/claudew/internal/workspace"
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+)
+
+// selftestWorkspace is the name of the workspace the self-test creates
+const selftestWorkspace = "selftest"
+
+var selftestCmd = &cobra.Command{
+	Use:    "_selftest",
+	Short:  "Run a create/start/stop/archive loop in a sandbox",
+	Hidden: true, // Diagnostic for development and bug reports
+	Long: `Runs the real create, start, stop and archive commands against a throwaway
+git repository, with HOME pointed at a temporary directory and tmux replaced by an
+in-memory fake. Your config, workspaces and tmux server are never touched.
+
+Command output is written to selftest.log in the sandbox; use --keep to inspect it.`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		keep, err := cmd.Flags().GetBool("keep")
+		if err != nil {
+			return err
+		}
+		return runSelftest(cmd.OutOrStdout(), keep)
+	},
+}
+
+// selftestStep is one stage of the self-test loop
+type selftestStep struct {
+	name string
+	run  func() error
+}
+
+// runSelftest runs the self-test loop, reporting each step to out
+func runSelftest(out io.Writer, keep bool) error {
+	sandbox, err := os.MkdirTemp("", "claudew-selftest-")
+	if err != nil {
+		return fmt.Errorf("failed to create sandbox: %w", err)
+	}
+	if keep {
+		defer fmt.Fprintf(out, "Sandbox kept at %s\n", sandbox)
+	} else {
+		defer os.RemoveAll(sandbox)
+	}
+
+	logPath := filepath.Join(sandbox, "selftest.log")
+	logFile, err := os.Create(logPath)
+	if err != nil {
+		return fmt.Errorf("failed to create log: %w", err)
+	}
+	defer logFile.Close()
+
+	// Commands print with fmt.Printf, so their output is captured by swapping os.Stdout
+	stdout := os.Stdout
+	os.Stdout = logFile
+	defer func() { os.Stdout = stdout }()
+
+	defer selftestSetenv(map[string]string{
+		"HOME":      filepath.Join(sandbox, "home"),
+		"TMUX":      "",
+		"TMUX_PANE": "",
+	})()
+
+	tmux := session.NewFakeTmux()
+	fake := execx.NewFake(execx.OS{})
+	fake.Handle("tmux", tmux.Handle)
+	defer execx.Use(fake)()
+
+	repoPath := filepath.Join(sandbox, "repo")
+	sessionName := session.NewManager().GetSessionName(selftestWorkspace)
+
+	steps := []selftestStep{
+		{"create sandbox repository", func() error {
+			return selftestInitRepo(repoPath)
+		}},
+		{"create workspace", func() error {
+			if err := selftestRun(logFile, "create", selftestWorkspace, repoPath, "--summary", "claudew self-test"); err != nil {
+				return err
+			}
+			if _, err := os.Stat(filepath.Join(repoPath, ".claude", "CLAUDE.md")); err != nil {
+				return fmt.Errorf("CLAUDE.md was not generated: %w", err)
+			}
+			return selftestExpectStatus(config.StatusIdle)
+		}},
+		{"start session", func() error {
+			if err := selftestRun(logFile, "start", selftestWorkspace); err != nil {
+				return err
+			}
+			if !slices.Contains(tmux.Sessions(), sessionName) {
+				return fmt.Errorf("tmux session %s was not created", sessionName)
+			}
+			if tmux.Attaches(sessionName) != 1 {
+				return fmt.Errorf("expected 1 attach to %s, got %d", sessionName, tmux.Attaches(sessionName))
+			}
+			launched := false
+			for _, input := range tmux.Input(sessionName) {
+				if strings.Contains(input, "claude") {
+					launched = true
+				}
+			}
+			if !launched {
+				return fmt.Errorf("claude was not launched in %s", sessionName)
+			}
+			return selftestExpectStatus(config.StatusIdle)
+		}},
+		{"stop session", func() error {
+			if err := selftestRun(logFile, "stop", selftestWorkspace); err != nil {
+				return err
+			}
+			if slices.Contains(tmux.Sessions(), sessionName) {
+				return fmt.Errorf("tmux session %s is still running", sessionName)
+			}
+			return selftestExpectStatus(config.StatusIdle)
+		}},
+		{"archive workspace", func() error {
+			if err := selftestRun(logFile, "archive", selftestWorkspace); err != nil {
+				return err
+			}
+			cfg, err := config.Load()
+			if err != nil {
+				return err
+			}
+			if workspace.NewManagerForConfig(cfg).Exists(selftestWorkspace) {
+				return fmt.Errorf("workspace directory was not archived")
+			}
+			if _, err := os.Stat(filepath.Join(repoPath, ".claude", "CLAUDE.md")); !os.IsNotExist(err) {
+				return fmt.Errorf("CLAUDE.md was not removed")
+			}
+			return selftestExpectStatus(config.StatusArchived)
+		}},
+	}
+
+	for _, step := range steps {
+		if err := step.run(); err != nil {
+			fmt.Fprintf(out, "  ✗ %s: %v\n", step.name, err)
+			if keep {
+				fmt.Fprintf(out, "See %s\n", logPath)
+			} else {
+				fmt.Fprintln(out, "Rerun with --keep to inspect the command output")
+			}
+			return fmt.Errorf("self-test failed")
+		}
+		fmt.Fprintf(out, "  ✓ %s\n", step.name)
+	}
+
+	tmuxCalls := 0
+	for _, call := range fake.Calls() {
+		if strings.HasPrefix(call, "tmux ") {
+			tmuxCalls++
+		}
+	}
+	fmt.Fprintf(out, "\n✓ Self-test passed (%d tmux commands faked)\n", tmuxCalls)
+	return nil
+}
+
+// selftestRun runs a claudew command line in-process, sending cobra's own output to log.
+// Commands keep their flags in package variables that cobra never resets, so every flag
+// is put back to its default first; otherwise flags given to an earlier step, or to the
+// claudew invocation running the self-test, would leak into this one.
+func selftestRun(log io.Writer, args ...string) error {
+	if err := resetFlags(rootCmd); err != nil {
+		return fmt.Errorf("failed to reset flags: %w", err)
+	}
+	rootCmd.SetArgs(args)
+	rootCmd.SetOut(log)
+	rootCmd.SetErr(log)
+	defer func() {
+		rootCmd.SetArgs(nil)
+		rootCmd.SetOut(nil)
+		rootCmd.SetErr(nil)
+	}()
+
+	fmt.Fprintf(log, "\n$ claudew %s\n", strings.Join(args, " "))
+	if _, err := rootCmd.ExecuteC(); err != nil {
+		return fmt.Errorf("claudew %s: %w", args[0], err)
+	}
+	return nil
+}
+
+// resetFlags sets every flag of c and its subcommands back to its default value
+func resetFlags(c *cobra.Command) error {
+	var err error
+	reset := func(f *pflag.Flag) {
+		if !f.Changed || err != nil {
+			return
+		}
+		if slice, ok := f.Value.(pflag.SliceValue); ok {
+			var values []string
+			if def := strings.Trim(f.DefValue, "[]"); def != "" {
+				values = strings.Split(def, ",")
+			}
+			err = slice.Replace(values)
+		} else {
+			err = f.Value.Set(f.DefValue)
+		}
+		f.Changed = false
+	}
+	c.Flags().VisitAll(reset)
+	c.PersistentFlags().VisitAll(reset)
+	for _, sub := range c.Commands() {
+		if subErr := resetFlags(sub); subErr != nil && err == nil {
+			err = subErr
+		}
+	}
+	return err
+}
+
+// selftestInitRepo creates a git repository with one commit
+func selftestInitRepo(repoPath string) error {
+	if err := os.MkdirAll(repoPath, 0755); err != nil {
+		return err
+	}
+	if err := os.WriteFile(filepath.Join(repoPath, "README.md"), []byte("# claudew self-test\n"), 0644); err != nil {
+		return err
+	}
+
+	commands := [][]string{
+		{"init", "--quiet"},
+		{"add", "README.md"},
+		{"-c", "user.name=claudew", "-c", "user.email=selftest@claudew.invalid", "commit", "--quiet", "-m", "Initial commit"},
+	}
+	for _, args := range commands {
+		cmd := execx.Command("git", append([]string{"-C", repoPath}, args...)...)
+		if output, err := cmd.CombinedOutput(); err != nil {
+			return fmt.Errorf("git %s: %w (%s)", args[0], err, strings.TrimSpace(string(output)))
+		}
+	}
+	return nil
+}
+
+// selftestExpectStatus checks the self-test workspace's status in the saved config
+func selftestExpectStatus(want string) error {
+	cfg, err := config.Load()
+	if err != nil {
+		return err
+	}
+	ws, err := cfg.GetWorkspace(selftestWorkspace)
+	if err != nil {
+		return err
+	}
+	if ws.Status != want {
+		return fmt.Errorf("expected status %s, got %s", want, ws.Status)
+	}
+	return nil
+}
+
+// selftestSetenv sets environment variables and returns a function that restores them
+func selftestSetenv(env map[string]string) (restore func()) {
+	type saved struct {
+		value string
+		ok    bool
+	}
+	previous := make(map[string]saved, len(env))
+	for key, value := range env {
+		old, ok := os.LookupEnv(key)
+		previous[key] = saved{old, ok}
+		os.Setenv(key, value)
+	}
+	return func() {
+		for key, p := range previous {
+			if p.ok {
+				os.Setenv(key, p.value)
+			} else {
+				os.Unsetenv(key)
+			}
+		}
+	}
+}
+
+func init() {
+	rootCmd.AddCommand(selftestCmd)
+	selftestCmd.Flags().Bool("keep", false, "Keep the sandbox directory and its log afterwards")
+}
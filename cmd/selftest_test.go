@@ -0,0 +1,42 @@
+package cmd
+
+import (
+	"bytes"
+	"os/exec"
+	"testing"
+
+	"github.com/spf13/cobra"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSelftest(t *testing.T) {
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git not installed")
+	}
+
+	var out bytes.Buffer
+	require.NoError(t, runSelftest(&out, false), out.String())
+	assert.Contains(t, out.String(), "✓ archive workspace")
+	assert.Contains(t, out.String(), "Self-test passed")
+}
+
+func TestResetFlags(t *testing.T) {
+	var summary string
+	var tags []string
+	root := &cobra.Command{Use: "root"}
+	sub := &cobra.Command{Use: "sub", Run: func(*cobra.Command, []string) {}}
+	sub.Flags().StringVar(&summary, "summary", "none", "")
+	sub.Flags().StringSliceVar(&tags, "tag", []string{"a"}, "")
+	root.AddCommand(sub)
+
+	root.SetArgs([]string{"sub", "--summary", "first", "--tag", "x", "--tag", "y"})
+	require.NoError(t, root.Execute())
+	assert.Equal(t, "first", summary)
+	assert.Equal(t, []string{"x", "y"}, tags)
+
+	require.NoError(t, resetFlags(root))
+	assert.Equal(t, "none", summary)
+	assert.Equal(t, []string{"a"}, tags)
+	assert.False(t, sub.Flags().Changed("summary"))
+}
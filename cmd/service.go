@@ -0,0 +1,27 @@
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/pmossman/claudew/internal/app"
+	"github.com/pmossman/claudew/internal/config"
+)
+
+// newService returns the service commands run workspace operations through, with
+// sessions launched by createWorkspaceSession
+func newService(cfg *config.Config) *app.Service {
+	svc := app.New(cfg)
+	svc.Launch = func(name string, ws *config.Workspace, initialPrompt string) error {
+		return createWorkspaceSession(cfg, svc.Workspaces, svc.Sessions, name, ws, initialPrompt)
+	}
+	return svc
+}
+
+// loadService loads the config and returns a service for it
+func loadService() (*app.Service, error) {
+	cfg, err := config.Load()
+	if err != nil {
+		return nil, fmt.Errorf("failed to load config: %w", err)
+	}
+	return newService(cfg), nil
+}
@@ -0,0 +1,175 @@
+package cmd
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/pmossman/claudew/internal/config"
+	"github.com/pmossman/claudew/internal/session"
+	"github.com/pmossman/claudew/internal/workspace"
+	"github.com/spf13/cobra"
+)
+
+var (
+	spawnName   string
+	spawnPrompt string
+	spawnClose  string
+)
+
+var spawnCmd = &cobra.Command{
+	Use:   "spawn <workspace>",
+	Short: "Run another Claude instance in a new window of a workspace session",
+	Long: `Opens a new tmux window in a running workspace session with a second Claude
+instance, e.g. a reviewer or a test writer working alongside the main session.
+Give it a first prompt with --prompt to set its task or persona.
+
+Spawned agents are listed by 'claudew info' and end with the session.
+Close one early with --close <name>.
+
+Example:
+  claudew spawn feature-auth --name reviewer --prompt "Review the changes on this branch"
+  claudew spawn feature-auth                      # Unnamed: agent-1, agent-2, ...
+  claudew spawn feature-auth --close reviewer`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		name := args[0]
+
+		// Load config
+		cfg, err := config.Load()
+		if err != nil {
+			return fmt.Errorf("failed to load config: %w", err)
+		}
+
+		ws, err := cfg.GetWorkspace(name)
+		if err != nil {
+			return err
+		}
+
+		sessionMgr := session.NewManagerForConfig(cfg)
+		sessionName := sessionMgr.GetSessionName(name)
+		exists, err := sessionMgr.Exists(sessionName)
+		if err != nil {
+			return fmt.Errorf("failed to check session: %w", err)
+		}
+
+		if spawnClose != "" {
+			if exists {
+				_ = sessionMgr.KillWindow(sessionName, spawnClose)
+			}
+			if err := cfg.RemoveAgent(name, spawnClose); err != nil {
+				return err
+			}
+			if err := cfg.Save(); err != nil {
+				return fmt.Errorf("failed to save config: %w", err)
+			}
+			fmt.Printf("✓ Closed agent '%s' in '%s'\n", spawnClose, name)
+			return nil
+		}
+
+		if !exists {
+			return fmt.Errorf("workspace '%s' has no running session. Start it first with: claudew start %s", name, name)
+		}
+
+		// Forget agents whose windows were closed by hand
+		pruneAgents(cfg, sessionMgr, name, ws)
+
+		agentName := spawnName
+		if agentName == "" {
+			agentName = ws.NextAgentName()
+		}
+		if err := cfg.AddAgent(name, config.Agent{Name: agentName, Prompt: spawnPrompt}); err != nil {
+			return err
+		}
+
+		wsMgr := workspace.NewManagerForConfig(cfg)
+		if ws.Container != nil {
+			if err := ensureWorkspaceContainer(wsMgr, name, ws); err != nil {
+				return err
+			}
+		}
+
+		if err := sessionMgr.NewWindow(sessionName, agentName, ws.GetWorkDir()); err != nil {
+			return err
+		}
+		if err := sessionMgr.SendKeysWhenReady(sessionName+":"+agentName, claudeCommand(cfg, wsMgr, name, ws, spawnPrompt), shellReadyTimeout); err != nil {
+			return fmt.Errorf("failed to start Claude: %w", err)
+		}
+
+		if err := cfg.Save(); err != nil {
+			return fmt.Errorf("failed to save config: %w", err)
+		}
+
+		fmt.Printf("✓ Spawned agent '%s' in '%s'\n", agentName, name)
+		fmt.Printf("  Switch to it with Ctrl-b w, or: tmux select-window -t %s:%s\n", sessionName, agentName)
+		return nil
+	},
+}
+
+// pruneAgents drops agents whose tmux windows no longer exist
+func pruneAgents(cfg *config.Config, sessionMgr *session.Manager, name string, ws *config.Workspace) {
+	running := runningAgentWindows(sessionMgr, name)
+	for _, agent := range append([]config.Agent{}, ws.Agents...) {
+		if !running[agent.Name] {
+			_ = cfg.RemoveAgent(name, agent.Name)
+		}
+	}
+}
+
+// runningAgentWindows returns the names of the windows in a workspace's session
+func runningAgentWindows(sessionMgr *session.Manager, name string) map[string]bool {
+	running := map[string]bool{}
+	windows, err := sessionMgr.ListWindows(sessionMgr.GetSessionName(name))
+	if err != nil {
+		return running
+	}
+	for _, window := range windows {
+		running[window] = true
+	}
+	return running
+}
+
+// validAgentNames provides completion for --close with the workspace's agents
+func validAgentNames(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+	if len(args) == 0 {
+		return nil, cobra.ShellCompDirectiveNoFileComp
+	}
+	cfg, err := config.Load()
+	if err != nil {
+		return nil, cobra.ShellCompDirectiveNoFileComp
+	}
+	ws, err := cfg.GetWorkspace(args[0])
+	if err != nil {
+		return nil, cobra.ShellCompDirectiveNoFileComp
+	}
+	var names []string
+	for _, agent := range ws.Agents {
+		names = append(names, agent.Name)
+	}
+	return names, cobra.ShellCompDirectiveNoFileComp
+}
+
+// validWindowNames provides completion for --window with the windows of the workspace's running session
+func validWindowNames(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+	if len(args) == 0 {
+		return nil, cobra.ShellCompDirectiveNoFileComp
+	}
+	cfg, err := config.Load()
+	if err != nil {
+		return nil, cobra.ShellCompDirectiveNoFileComp
+	}
+	var names []string
+	for window := range runningAgentWindows(session.NewManagerForConfig(cfg), args[0]) {
+		names = append(names, window)
+	}
+	sort.Strings(names)
+	return names, cobra.ShellCompDirectiveNoFileComp
+}
+
+func init() {
+	rootCmd.AddCommand(spawnCmd)
+	spawnCmd.ValidArgsFunction = validWorkspaceNamesExcludeArchived
+	spawnCmd.Flags().StringVar(&spawnName, "name", "", "Window name for the agent (default agent-N)")
+	spawnCmd.Flags().StringVar(&spawnPrompt, "prompt", "", "First prompt for the agent, e.g. its task or persona")
+	spawnCmd.Flags().StringVar(&spawnClose, "close", "", "Close a spawned agent's window instead")
+	spawnCmd.RegisterFlagCompletionFunc("close", validAgentNames)
+}
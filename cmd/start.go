@@ -1,15 +1,21 @@
 package cmd
 
 import (
-	"bytes"
+	"errors"
 	"fmt"
 	"os"
-	"os/exec"
 	"sort"
 	"strings"
+	"sync"
+	"time"
 
+	"github.com/pmossman/claudew/internal/app"
+	"github.com/pmossman/claudew/internal/clipboard"
 	"github.com/pmossman/claudew/internal/config"
+	"github.com/pmossman/claudew/internal/events"
+	"github.com/pmossman/claudew/internal/menu"
 	"github.com/pmossman/claudew/internal/session"
+	"github.com/pmossman/claudew/internal/table"
 	"github.com/pmossman/claudew/internal/workspace"
 	"github.com/spf13/cobra"
 )
@@ -43,8 +49,16 @@ func escapeShellArg(arg string) string {
 	return fmt.Sprintf("'%s'", escaped)
 }
 
+var (
+	startDetach    bool
+	startExclusive bool
+	startWindow    string
+	startFuzzy     bool
+	startSteal     bool
+)
+
 var startCmd = &cobra.Command{
-	Use:   "start [name]",
+	Use:   "start [name...]",
 	Short: "Start a workspace session (interactive)",
 	Long: `Starts or attaches to a tmux session for the workspace.
 
@@ -52,20 +66,75 @@ Interactive mode:
   claudew start
 
 Direct mode:
-  claudew start <workspace-name>`,
-	Args: cobra.MaximumNArgs(1),
+  claudew start <workspace-name>
+
+Background mode (create sessions without attaching, in parallel):
+  claudew start ws-a ws-b ws-c --detach
+
+Focus mode (detach every other terminal attached to a workspace session first,
+e.g. before screen sharing):
+  claudew start <workspace-name> --exclusive
+
+Jump straight to a window of the session, e.g. a notes window or a spawned agent:
+  claudew start <workspace-name> --window notes
+
+Fuzzy mode (start the one workspace whose name matches, or pick from the
+matches; "claudew s <query>" is short for this):
+  claudew start --fuzzy auth
+
+If the workspace's clone has disappeared or is no longer a git repository, start
+offers to assign a free clone of the same remote or to clone the remote again.
+
+Commits made in the repo since the workspace was last active are listed, and a
+new Claude session is told about them in its first prompt.
+
+If another terminal holds the workspace lock, start asks whether to attach anyway,
+steal the lock (detaching that terminal from the session) or abort. --steal takes
+the lock without asking, for scripts:
+  claudew start <workspace-name> --steal
+
+With "session_mode": "wt" in settings, Claude opens in a new Windows Terminal
+tab instead of a tmux session. With "session_mode": "foreground", Claude runs
+in this terminal until it exits, for machines without tmux.`,
+	Args: cobra.ArbitraryArgs,
 	RunE: func(cmd *cobra.Command, args []string) error {
+		if startFuzzy && startDetach {
+			return fmt.Errorf("--fuzzy can't be combined with --detach")
+		}
+		if len(args) > 1 && !startDetach && !startFuzzy {
+			return fmt.Errorf("starting multiple workspaces requires --detach")
+		}
+
 		// Load config
 		cfg, err := config.Load()
 		if err != nil {
 			return fmt.Errorf("failed to load config: %w", err)
 		}
 
+		if startDetach {
+			if mode := cfg.Settings.SessionMode; mode != "" && mode != config.SessionModeTmux {
+				return fmt.Errorf("--detach requires session_mode \"tmux\"")
+			}
+			if len(args) == 0 {
+				return fmt.Errorf("--detach requires at least one workspace name")
+			}
+			return startDetached(cfg, args)
+		}
+
 		var name string
 
-		// Interactive mode if no args
+		// Interactive mode if no args; fuzzy mode only asks when the query is ambiguous
+		query := ""
+		if startFuzzy {
+			query = strings.Join(args, " ")
+			if matches := fuzzyWorkspaceMatches(cfg, query); len(matches) == 1 {
+				args = matches
+			} else {
+				args = nil
+			}
+		}
 		if len(args) == 0 {
-			selectedName, err := interactiveWorkspaceSelect(cfg)
+			selectedName, err := interactiveWorkspaceSelect(cfg, query)
 			if err != nil {
 				return err
 			}
@@ -84,8 +153,27 @@ Direct mode:
 			return err
 		}
 
-		wsMgr := workspace.NewManager(cfg.Settings.WorkspaceDir)
-		sessionMgr := session.NewManager()
+		if mode := cfg.Settings.SessionMode; startWindow != "" && mode != "" && mode != config.SessionModeTmux {
+			return fmt.Errorf("--window requires session_mode \"tmux\"")
+		}
+
+		// Never start or attach in a clone that has disappeared
+		if err := ensureCloneReady(cfg, name); err != nil {
+			return err
+		}
+
+		switch cfg.Settings.SessionMode {
+		case config.SessionModeWindowsTerminal:
+			return startInWindowsTerminal(cfg, name, ws)
+		case config.SessionModeForeground:
+			return startInForeground(cfg, name, ws)
+		}
+
+		wsMgr := workspace.NewManagerForConfig(cfg)
+		sessionMgr := session.NewManagerForConfig(cfg)
+
+		// Remove locks left behind by dead processes or vanished sessions
+		cleanStaleLocks(cfg, wsMgr, sessionMgr)
 
 		// Get session name
 		sessionName := sessionMgr.GetSessionName(name)
@@ -96,83 +184,70 @@ Direct mode:
 			return err
 		}
 
-		// Check for existing lock (but allow reattaching to existing sessions)
-		if cfg.Settings.RequireSessionLock && !exists {
-			locked, pid, err := wsMgr.CheckLock(name)
-			if err != nil {
-				return fmt.Errorf("failed to check lock: %w", err)
-			}
-			if locked {
-				return fmt.Errorf("workspace '%s' has an active session (PID %d)", name, pid)
-			}
+		// A new session has no windows but its first and the notes window, so any other
+		// --window fails now, before anything is started only to be left behind
+		if !exists && startWindow != "" && !(cfg.Settings.NotesWindow && startWindow == "notes") {
+			return fmt.Errorf("workspace '%s' has no running session, so it has no window '%s' yet", name, startWindow)
 		}
 
-		// If session exists, clean up any stale locks
-		if exists && cfg.Settings.RequireSessionLock {
-			locked, _, err := wsMgr.CheckLock(name)
+		// Another live process holding the lock is resolved before anything is created:
+		// attach without the lock, steal it or abort
+		lockConflict := ""
+		if cfg.Settings.RequireSessionLock {
+			locked, pid, err := wsMgr.CheckLock(name)
 			if err != nil {
 				return fmt.Errorf("failed to check lock: %w", err)
 			}
-			if !locked {
-				// Lock exists but process is dead - clean it up
-				_ = wsMgr.RemoveLock(name)
+			switch {
+			case !locked:
+				// Stale locks were removed by cleanStaleLocks above; a released flock
+				// lock file stays for AcquireLock to take again
+			case startSteal:
+				lockConflict = lockConflictSteal
+			default:
+				lockConflict = resolveLockConflict(name, pid, exists)
+				if lockConflict == lockConflictAbort {
+					return fmt.Errorf("workspace '%s' is locked by PID %d; use --steal to take it over", name, pid)
+				}
 			}
 		}
 
-		// Create session if it doesn't exist
+		// A new session starts on the branch the workspace was last on, if the user agrees
 		if !exists {
-			fmt.Printf("Creating new session for '%s'...\n", name)
-			if err := sessionMgr.Create(sessionName, ws.GetRepoPath()); err != nil {
-				return err
-			}
-
-			// Read workspace summary
-			summary := wsMgr.GetSummary(name)
-			if summary == "(no summary)" {
-				summary = ""
-			}
-			// Truncate summary if too long
-			if len(summary) > 30 {
-				summary = summary[:27] + "..."
-			}
-
-			// Customize tmux status line for this workspace
-			var statusLeft string
-			repoPath := ws.GetRepoPath()
-
-			// Shorten path for display (show last 2-3 components or use ~)
-			displayPath := shortenPath(repoPath)
-
-			// Escape repo path for safe use in shell command (prevents command injection)
-			escapedRepoPath := escapeShellArg(repoPath)
-			gitBranch := fmt.Sprintf("#(cd %s && git rev-parse --abbrev-ref HEAD 2>/dev/null || echo 'no-branch')", escapedRepoPath)
-
-			if summary != "" {
-				statusLeft = fmt.Sprintf("[%s] %s @ %s | %s", name, displayPath, gitBranch, summary)
-			} else {
-				statusLeft = fmt.Sprintf("[%s] %s @ %s", name, displayPath, gitBranch)
-			}
-
-			// Add tmux shortcuts to status-right
-			statusRight := "^b d:detach ^b s:switch ^b [:scroll"
+			offerBranchCheckout(cfg, name, ws)
+		}
 
-			if err := sessionMgr.SetStatusLine(sessionName, statusLeft, statusRight); err != nil {
-				fmt.Printf("Warning: failed to set status line: %v\n", err)
-			}
+		// Commits made while the workspace was inactive are shown below and told to a new Claude session
+		changes, changed := collectOutsideChanges(ws)
 
-			// If auto-start is enabled, send claude command to tmux (only for new sessions)
+		// Create session if it doesn't exist
+		if !exists {
 			if cfg.Settings.AutoStartClaude {
-				fmt.Println("Starting Claude Code...")
-				fmt.Println()
-				// Send the claude command to the tmux session
-				if err := sessionMgr.SendKeys(sessionName, cfg.Settings.ClaudeCommand); err != nil {
-					fmt.Printf("Warning: failed to auto-start Claude: %v\n", err)
+				if err := checkClaudeCommand(cfg, ws); err != nil {
+					return err
 				}
 			}
+			fmt.Printf("Creating new session for '%s'...\n", name)
+			initialPrompt := ""
+			if changed {
+				initialPrompt = changes.note()
+			}
+			initialPrompt = withHandoverNote(cfg, ws, initialPrompt)
+			sessionName, err = newService(cfg).StartSession(name, app.StartOptions{InitialPrompt: initialPrompt})
+			if err != nil {
+				return err
+			}
 		} else {
 			fmt.Printf("Attaching to existing session '%s'...\n", name)
 		}
 
+		if startWindow != "" {
+			if err := sessionMgr.SelectWindow(sessionName, startWindow); err != nil {
+				windows, _ := sessionMgr.ListWindows(sessionName)
+				return fmt.Errorf("workspace '%s' has no window '%s' (windows: %s)", name, startWindow, strings.Join(windows, ", "))
+			}
+		}
+
 		// Display header
 		fmt.Println()
 		fmt.Println("═══════════════════════════════════════════════════════════")
@@ -184,6 +259,9 @@ Direct mode:
 		if summary != "(no summary)" {
 			fmt.Printf("  Summary: %s\n", summary)
 		}
+		if changed {
+			changes.print()
+		}
 
 		// Display continuation prompt
 		continuation := wsMgr.GetContinuation(name)
@@ -196,8 +274,7 @@ Direct mode:
 			fmt.Println("───────────────────────────────────────────────────────────")
 			fmt.Println()
 
-			// Copy to clipboard if pbcopy is available (macOS)
-			copyToClipboard(continuation)
+			copyToClipboard(cfg, continuation)
 		} else {
 			fmt.Println("═══════════════════════════════════════════════════════════")
 			fmt.Println()
@@ -205,10 +282,27 @@ Direct mode:
 			fmt.Println()
 		}
 
-		// Create lock file
-		if cfg.Settings.RequireSessionLock {
-			if err := wsMgr.CreateLock(name, os.Getpid()); err != nil {
-				return fmt.Errorf("failed to create lock: %w", err)
+		// Hold the workspace lock for as long as we're attached. The lock is
+		// released by the kernel if this process dies, so it can never go stale.
+		var lock *workspace.Lock
+		switch {
+		case lockConflict == lockConflictSteal:
+			lock, err = stealWorkspaceLock(wsMgr, sessionMgr, name, sessionName, exists)
+			if errors.Is(err, workspace.ErrStealUnsupported) {
+				return fmt.Errorf("failed to steal lock: %w. Close the other terminal holding '%s' and try again", err, name)
+			}
+			if err != nil {
+				return fmt.Errorf("failed to steal lock: %w", err)
+			}
+		case cfg.Settings.RequireSessionLock && lockConflict != lockConflictAttach:
+			lock, err = wsMgr.AcquireLock(name, os.Getpid())
+			if err != nil {
+				var lockedErr *workspace.ErrLocked
+				if !errors.As(err, &lockedErr) || !exists {
+					return fmt.Errorf("failed to create lock: %w", err)
+				}
+				// Another terminal is attached to the existing session; reattaching is allowed
+				lock = nil
 			}
 		}
 
@@ -216,10 +310,15 @@ Direct mode:
 		if err := cfg.UpdateWorkspaceStatus(name, config.StatusActive, os.Getpid()); err != nil {
 			return err
 		}
+		ws.LastOpened = time.Now()
 		if err := cfg.Save(); err != nil {
 			return err
 		}
 
+		if startExclusive {
+			detachOtherWorkspaceClients(cfg, sessionMgr, sessionName)
+		}
+
 		// Show tmux tips
 		fmt.Println("━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━")
 		fmt.Println("Tmux Quick Reference:")
@@ -232,57 +331,386 @@ Direct mode:
 		// Attach to session (this will block until detach or window close)
 		err = sessionMgr.Attach(sessionName)
 
-		// Clean up lock file after detaching
-		if cfg.Settings.RequireSessionLock {
-			_ = wsMgr.RemoveLock(name)
-		}
+		// Release lock after detaching
+		_ = lock.Release()
 
 		// Update workspace status to idle
 		_ = cfg.UpdateWorkspaceStatus(name, config.StatusIdle, 0)
+		app.RecordBranch(cfg, ws)
 		_ = cfg.Save()
 
+		emitEvent(cfg, events.SessionDetached, name, map[string]string{"session": sessionName})
+
 		return err
 	},
 }
 
-func copyToClipboard(text string) {
-	// Try pbcopy (macOS)
-	cmd := exec.Command("pbcopy")
-	cmd.Stdin = strings.NewReader(text)
-	if err := cmd.Run(); err == nil {
-		fmt.Println("✓ Continuation prompt copied to clipboard")
+// startResult is the outcome of starting one workspace in the background
+type startResult struct {
+	name   string
+	status string
+	detail string
+}
+
+// startDetached creates sessions for several workspaces in parallel without attaching.
+// Claude is started with each workspace's continuation as its initial prompt.
+func startDetached(cfg *config.Config, names []string) error {
+	sessionMgr := session.NewManagerForConfig(cfg)
+	if err := sessionMgr.CheckTmuxInstalled(); err != nil {
+		return err
+	}
+	svc := newService(cfg)
+	wsMgr := svc.Workspaces
+
+	// Allocate and name up front: the config must not be modified from the goroutines
+	for _, name := range names {
+		if ws, err := cfg.GetWorkspace(name); err == nil {
+			ensurePorts(cfg, name)
+			if exists, err := sessionMgr.Exists(sessionMgr.GetSessionName(name)); err == nil && !exists {
+				svc.LinkRepo(name)
+				ws.SessionName = svc.NewSessionName(name, ws)
+			}
+		}
+	}
+
+	results := make([]startResult, len(names))
+	var wg sync.WaitGroup
+	for i, name := range names {
+		wg.Add(1)
+		go func(i int, name string) {
+			defer wg.Done()
+			results[i] = startWorkspaceDetached(cfg, wsMgr, sessionMgr, name)
+		}(i, name)
+	}
+	wg.Wait()
+
+	// Sessions are running but nobody is attached yet
+	failed := 0
+	for _, r := range results {
+		switch r.status {
+		case "started":
+			_ = cfg.UpdateWorkspaceStatus(r.name, config.StatusIdle, 0)
+			emitEvent(cfg, events.SessionStarted, r.name, map[string]string{"session": sessionMgr.GetSessionName(r.name), "reason": "detach"})
+		case "failed":
+			failed++
+		}
+	}
+	if err := cfg.Save(); err != nil {
+		return fmt.Errorf("failed to save config: %w", err)
+	}
+
+	fmt.Println()
+	tbl := table.New(table.Column{Header: "WORKSPACE"}, table.Column{Header: "RESULT"}, table.Column{Header: "DETAIL"})
+	for _, r := range results {
+		tbl.AddRow(r.name, r.status, r.detail)
+	}
+	tbl.Render(os.Stdout)
+	fmt.Println()
+	fmt.Println("Attach with: claudew start <name>")
+
+	if failed > 0 {
+		return fmt.Errorf("failed to start %d of %d workspace(s)", failed, len(names))
+	}
+	return nil
+}
+
+// startWorkspaceDetached creates a single workspace's session if it isn't already running
+func startWorkspaceDetached(cfg *config.Config, wsMgr *workspace.Manager, sessionMgr *session.Manager, name string) startResult {
+	ws, err := cfg.GetWorkspace(name)
+	if err != nil {
+		return startResult{name: name, status: "failed", detail: err.Error()}
+	}
+	if ws.Status == config.StatusArchived {
+		return startResult{name: name, status: "failed", detail: "workspace is archived"}
+	}
+
+	sessionName := sessionMgr.GetSessionName(name)
+	exists, err := sessionMgr.Exists(sessionName)
+	if err != nil {
+		return startResult{name: name, status: "failed", detail: err.Error()}
+	}
+	if exists {
+		return startResult{name: name, status: "running", detail: "session already exists"}
+	}
+	if err := app.CheckClone(cfg, name); err != nil {
+		return startResult{name: name, status: "failed", detail: err.Error()}
+	}
+	if cfg.Settings.AutoStartClaude {
+		if err := checkClaudeCommand(cfg, ws); err != nil {
+			return startResult{name: name, status: "failed", detail: err.Error()}
+		}
+	}
+
+	continuation := wsMgr.GetContinuation(name)
+	initialPrompt := continuation
+	if changes, ok := collectOutsideChanges(ws); ok {
+		initialPrompt = strings.TrimSpace(continuation + "\n\n" + changes.note())
+	}
+	initialPrompt = withHandoverNote(cfg, ws, initialPrompt)
+	if err := createWorkspaceSession(cfg, wsMgr, sessionMgr, name, ws, initialPrompt); err != nil {
+		return startResult{name: name, status: "failed", detail: err.Error()}
+	}
+
+	detail := sessionMgr.GetSessionName(name)
+	if continuation != "" && cfg.Settings.AutoStartClaude {
+		detail += " (continuation sent)"
+	}
+	return startResult{name: name, status: "started", detail: detail}
+}
+
+// shellReadyTimeout is how long to wait for a new pane's shell to show its prompt
+// before typing into it anyway
+const shellReadyTimeout = 10 * time.Second
+
+// createWorkspaceSession creates the tmux session for a workspace, customizes its
+// status line and, if auto-start is enabled, launches Claude. A non-empty
+// initialPrompt is passed to Claude as its first message. Callers name the session
+// with Service.NewSessionName first and save the config, so the name is remembered.
+func createWorkspaceSession(cfg *config.Config, wsMgr *workspace.Manager, sessionMgr *session.Manager, name string, ws *config.Workspace, initialPrompt string) error {
+	repoPath := ws.GetRepoPath()
+
+	if repoPath == "" {
+		return fmt.Errorf("workspace '%s' has no clone assigned. Assign one with: claudew assign-clone %s <clone-path>", name, name)
+	}
+
+	sessionName := sessionMgr.GetSessionName(name)
+
+	// Start the workspace's container first so Claude never falls back to the host
+	if err := ensureWorkspaceContainer(wsMgr, name, ws); err != nil {
+		return fmt.Errorf("failed to start container: %w", err)
+	}
+
+	if err := sessionMgr.Create(sessionName, ws.GetWorkDir()); err != nil {
+		return err
+	}
+	ensureCommitHook(cfg, ws)
+
+	// Export workspace environment before anything else runs in the session
+	env, err := workspaceEnv(wsMgr, name, ws)
+	if err != nil {
+		fmt.Printf("Warning: %v\n", err)
+	} else if len(env) > 0 {
+		if err := sessionMgr.SetEnvironment(sessionName, env); err != nil {
+			fmt.Printf("Warning: failed to set session environment: %v\n", err)
+		}
+		// set-environment only reaches new panes, so also export into the shell already running
+		if err := sessionMgr.SendKeysWhenReady(sessionName, envExportCommand(env), shellReadyTimeout); err != nil {
+			fmt.Printf("Warning: failed to export environment: %v\n", err)
+		}
+	}
+
+	// Read workspace summary
+	summary := wsMgr.GetSummary(name)
+	if summary == "(no summary)" {
+		summary = ""
+	}
+	// Truncate summary if too long
+	summary = table.Truncate(summary, 30, false)
+
+	// Customize tmux status line for this workspace
+	var statusLeft string
+
+	// Shorten path for display (show last 2-3 components or use ~)
+	displayPath := shortenPath(repoPath)
+	if ws.Subdir != "" {
+		displayPath += ":" + ws.Subdir
+	}
+
+	// Escape repo path for safe use in shell command (prevents command injection)
+	escapedRepoPath := escapeShellArg(repoPath)
+	gitBranch := fmt.Sprintf("#(cd %s && git rev-parse --abbrev-ref HEAD 2>/dev/null || echo 'no-branch')", escapedRepoPath)
+
+	if summary != "" {
+		statusLeft = fmt.Sprintf("[%s] %s @ %s | %s", name, displayPath, gitBranch, summary)
+	} else {
+		statusLeft = fmt.Sprintf("[%s] %s @ %s", name, displayPath, gitBranch)
+	}
+
+	// Add tmux shortcuts to status-right
+	statusRight := "^b d:detach ^b s:switch ^b [:scroll"
+
+	// Show Claude's context usage so it's clear when to restart
+	// (not in containers, where the claudew binary isn't available to Claude)
+	if cfg.Settings.ContextStatusLine && ws.Container == nil {
+		contextSegment, err := installContextStatusLine(wsMgr, name, ws)
+		if err != nil {
+			fmt.Printf("Warning: failed to install context statusline: %v\n", err)
+		} else {
+			statusRight = contextSegment + statusRight
+		}
+	}
+
+	if err := sessionMgr.SetStatusLine(sessionName, statusLeft, statusRight); err != nil {
+		fmt.Printf("Warning: failed to set status line: %v\n", err)
+	}
+	if err := sessionMgr.SetStatusColor(sessionName, ws.DisplayColor()); err != nil {
+		fmt.Printf("Warning: failed to set status color: %v\n", err)
+	}
+
+	// prefix+D appends a decision to the workspace's decisions.md
+	if err := bindDecisionKey(cfg, sessionMgr); err != nil {
+		fmt.Printf("Warning: failed to bind decision key: %v\n", err)
+	}
+
+	// Open a second window in the workspace directory for editing context files
+	if cfg.Settings.NotesWindow {
+		if err := sessionMgr.NewWindow(sessionName, "notes", wsMgr.GetPath(name)); err != nil {
+			fmt.Printf("Warning: failed to create notes window: %v\n", err)
+		}
+	}
+
+	// If auto-start is enabled, send claude command to tmux (only for new sessions)
+	if cfg.Settings.AutoStartClaude {
+		fmt.Println("Starting Claude Code...")
 		fmt.Println()
+		if err := sessionMgr.SendKeysWhenReady(sessionName, claudeLaunchCommand(cfg, wsMgr, name, ws, initialPrompt), shellReadyTimeout); err != nil {
+			fmt.Printf("Warning: failed to auto-start Claude: %v\n", err)
+		}
+	}
+
+	return nil
+}
+
+// workspaceEnv merges a workspace's port variables, .claudew.env file and config env map (config wins)
+func workspaceEnv(wsMgr *workspace.Manager, name string, ws *config.Workspace) (map[string]string, error) {
+	fileEnv, err := wsMgr.LoadEnv(name)
+	if err != nil {
+		return nil, err
+	}
+
+	// Later sources win: allocated ports, then the env file, then config
+	env := ws.PortEnv()
+	for key, value := range fileEnv {
+		env[key] = value
+	}
+	for key, value := range ws.Env {
+		if !workspace.IsValidEnvKey(key) {
+			return nil, fmt.Errorf("invalid environment variable name '%s' in config for workspace '%s'", key, name)
+		}
+		env[key] = value
+	}
+	return env, nil
+}
+
+// envExportCommand builds a shell export statement for the given variables
+func envExportCommand(env map[string]string) string {
+	keys := make([]string, 0, len(env))
+	for key := range env {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	parts := make([]string, 0, len(keys))
+	for _, key := range keys {
+		parts = append(parts, key+"="+escapeShellArg(env[key]))
+	}
+	return "export " + strings.Join(parts, " ")
+}
+
+// claudeLaunchCommand returns the shell command used to launch a workspace's main
+// Claude, with an optional initial prompt passed as its first argument. Workspaces
+// with a container run it there via docker exec.
+func claudeLaunchCommand(cfg *config.Config, wsMgr *workspace.Manager, name string, ws *config.Workspace, initialPrompt string) string {
+	command := claudeCommand(cfg, wsMgr, name, ws, initialPrompt)
+	if cfg.Settings.SuperviseClaude {
+		// Record how Claude exited so "claudew supervise" can tell a crash from a normal exit
+		exitPath := escapeShellArg(wsMgr.ClaudeExitPath(name))
+		command = fmt.Sprintf("rm -f %s; %s; echo $? > %s", exitPath, command, exitPath)
+	}
+	return command
+}
+
+// withHandoverNote puts the workspace's handover note, if it has one, ahead of the
+// rest of a new Claude session's first message
+func withHandoverNote(cfg *config.Config, ws *config.Workspace, prompt string) string {
+	return strings.TrimSpace(cfg.HandoverNote(ws) + "\n\n" + prompt)
+}
+
+// claudeCommand returns the command that runs Claude for a workspace, in its container if it has one
+func claudeCommand(cfg *config.Config, wsMgr *workspace.Manager, name string, ws *config.Workspace, initialPrompt string) string {
+	command := cfg.Settings.ClaudeCommand
+	if initialPrompt != "" {
+		command += " " + escapeShellArg(initialPrompt)
+	}
+	if ws.Container != nil {
+		command = containerizeCommand(wsMgr, name, ws, command)
+	}
+	return command
+}
+
+// detachOtherWorkspaceClients detaches every terminal attached to another workspace's
+// session, except the one this process runs in, and marks those workspaces idle
+func detachOtherWorkspaceClients(cfg *config.Config, sessionMgr *session.Manager, keepSession string) {
+	clients, err := sessionMgr.ListClients()
+	if err != nil {
+		fmt.Printf("⚠ Could not list tmux clients: %v\n", err)
 		return
 	}
 
-	// Try xclip (Linux)
-	cmd = exec.Command("xclip", "-selection", "clipboard")
-	cmd.Stdin = strings.NewReader(text)
-	if err := cmd.Run(); err == nil {
-		fmt.Println("✓ Continuation prompt copied to clipboard")
-		fmt.Println()
+	workspaceBySession := map[string]string{}
+	for name := range cfg.Workspaces {
+		workspaceBySession[sessionMgr.GetSessionName(name)] = name
+	}
+
+	self := sessionMgr.CurrentClientTTY()
+	detached := map[string]bool{}
+	for _, client := range clients {
+		name, ok := workspaceBySession[client.Session]
+		if !ok || client.Session == keepSession || client.TTY == self {
+			continue
+		}
+		if err := sessionMgr.DetachClient(client.TTY); err != nil {
+			fmt.Printf("⚠ Could not detach %s from '%s': %v\n", client.TTY, name, err)
+			continue
+		}
+		detached[name] = true
+	}
+	if len(detached) == 0 {
 		return
 	}
 
-	// Clipboard copy not available
-	fmt.Println("(Could not copy to clipboard - pbcopy/xclip not available)")
+	var names []string
+	for name := range detached {
+		_ = cfg.UpdateWorkspaceStatus(name, config.StatusIdle, 0)
+		emitEvent(cfg, events.SessionDetached, name, map[string]string{"session": sessionMgr.GetSessionName(name), "reason": "exclusive"})
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	if err := cfg.Save(); err != nil {
+		fmt.Printf("⚠ Could not save config: %v\n", err)
+	}
+	fmt.Printf("✓ Detached other clients from: %s\n", strings.Join(names, ", "))
 	fmt.Println()
 }
 
-// interactiveWorkspaceSelect shows fzf selector and returns selected workspace name
-func interactiveWorkspaceSelect(cfg *config.Config) (string, error) {
-	// Check if fzf is installed
-	if err := checkFzfInstalled(); err != nil {
-		return "", err
+// copyToClipboard copies the continuation prompt to the clipboard and reports how
+func copyToClipboard(cfg *config.Config, text string) {
+	method, err := clipboard.Copy(text, cfg.Settings.ClipboardCommand)
+	if err != nil {
+		fmt.Printf("(Could not copy to clipboard: %v)\n", err)
+		fmt.Println()
+		return
 	}
 
+	if method == clipboard.MethodOSC52 {
+		fmt.Println("✓ Continuation prompt sent to terminal clipboard (OSC52)")
+	} else {
+		fmt.Println("✓ Continuation prompt copied to clipboard")
+	}
+	fmt.Println()
+}
+
+// interactiveWorkspaceSelect shows an interactive selector and returns selected workspace name
+func interactiveWorkspaceSelect(cfg *config.Config, query string) (string, error) {
+	refreshLastActive(cfg)
+
 	if len(cfg.Workspaces) == 0 {
 		fmt.Println("No workspaces found.")
 		fmt.Println("Create one with: claudew create")
 		return "", nil
 	}
 
-	wsMgr := workspace.NewManager(cfg.Settings.WorkspaceDir)
+	wsMgr := workspace.NewManagerForConfig(cfg)
 
 	// Build workspace list sorted by last active
 	type wsEntry struct {
@@ -294,9 +722,15 @@ func interactiveWorkspaceSelect(cfg *config.Config) (string, error) {
 		entries = append(entries, wsEntry{name: name, ws: ws})
 	}
 	sort.Slice(entries, func(i, j int) bool {
-		return entries[i].ws.LastActive.After(entries[j].ws.LastActive)
+		return menuOrderLess(entries[i].ws, entries[j].ws)
 	})
 
+	var names []string
+	for _, entry := range entries {
+		names = append(names, entry.name)
+	}
+	nameWidth := menuNameWidth(names)
+
 	// Build fzf input
 	var inputLines []string
 	for _, entry := range entries {
@@ -305,70 +739,87 @@ func interactiveWorkspaceSelect(cfg *config.Config) (string, error) {
 		lastActive := formatTimeAgo(ws.LastActive)
 
 		// Format: name [status] summary (time)
-		line := fmt.Sprintf("%s [%s] %s (%s)",
-			entry.name,
+		line := fmt.Sprintf("%s [%s] %s%s (%s)",
+			padMenuName(entry.name, nameWidth),
 			ws.Status,
+			pinMarker(ws),
 			summary,
 			lastActive,
 		)
-		inputLines = append(inputLines, line)
+		inputLines = append(inputLines, menu.Line(entry.name, line))
 	}
 
-	input := strings.Join(inputLines, "\n")
-
 	// Get path to self for preview command
 	self, err := os.Executable()
 	if err != nil {
 		return "", fmt.Errorf("failed to get executable path: %w", err)
 	}
 
-	// Build fzf command with preview
-	// Use awk to extract workspace name (everything before '[')
-	previewCmd := fmt.Sprintf("echo {} | awk -F'\\\\[' '{print $1}' | xargs %s preview", self)
-	fzfCmd := exec.Command("fzf",
-		"--ansi",
-		"--no-sort",
-		"--height=100%",
-		"--preview="+previewCmd,
-		"--preview-window=right:50%:wrap",
-		"--header=Select a workspace (Ctrl-C to cancel)",
-		"--prompt=Workspace> ",
-	)
-
-	// Set up pipes
-	fzfCmd.Stdin = strings.NewReader(input)
-	fzfCmd.Stderr = os.Stderr
-
-	var outBuf bytes.Buffer
-	fzfCmd.Stdout = &outBuf
-
-	// Run fzf
-	if err := fzfCmd.Run(); err != nil {
-		// User cancelled (Ctrl-C)
-		if exitErr, ok := err.(*exec.ExitError); ok {
-			if exitErr.ExitCode() == 130 {
-				return "", nil
-			}
-		}
-		return "", fmt.Errorf("fzf failed: %w", err)
+	// Build menu with preview; {1} is the hidden workspace name field
+	previewCmd := fmt.Sprintf("%s preview {1}", self)
+	selected, err := runMenu(inputLines, applyFzfSettings(menuOptions{
+		header:        "Select a workspace (Ctrl-C to cancel)",
+		prompt:        "Workspace> ",
+		height:        "100%",
+		preview:       previewCmd,
+		previewWindow: "right:50%:wrap",
+		noSort:        true,
+		query:         query,
+		withIDs:       true,
+	}, cfg.Settings))
+	if err != nil {
+		return "", err
 	}
+	return menu.ID(selected), nil
+}
 
-	// Extract selected workspace name
-	selected := strings.TrimSpace(outBuf.String())
-	if selected == "" {
-		return "", nil
+// fuzzyWorkspaceMatches returns the names of the non-archived workspaces that query
+// fuzzy-matches, most recently active first. A workspace named exactly query is the
+// only match, so a short name can always be started even if others contain it.
+func fuzzyWorkspaceMatches(cfg *config.Config, query string) []string {
+	if ws, ok := cfg.Workspaces[query]; ok && ws.Status != config.StatusArchived {
+		return []string{query}
 	}
-
-	// Parse workspace name (everything before '[')
-	bracketIdx := strings.Index(selected, "[")
-	if bracketIdx == -1 {
-		return "", fmt.Errorf("invalid selection format")
+	var matches []string
+	for name, ws := range cfg.Workspaces {
+		if ws.Status != config.StatusArchived && menu.FuzzyMatch(query, name) {
+			matches = append(matches, name)
+		}
 	}
-	workspaceName := strings.TrimSpace(selected[:bracketIdx])
+	sort.Slice(matches, func(i, j int) bool {
+		return menuOrderLess(cfg.Workspaces[matches[i]], cfg.Workspaces[matches[j]])
+	})
+	return matches
+}
 
-	return workspaceName, nil
+var fuzzyStartCmd = &cobra.Command{
+	Use:   "s <query...>",
+	Short: "Start the workspace matching a fuzzy query",
+	Long: `Short for "claudew start --fuzzy": starts the workspace whose name fuzzy-matches
+the query (its letters in order, like fzf), or opens the workspace picker filtered
+by the query when several match or none do.
+
+Example:
+  claudew s auth      # starts feature-auth if it's the only match
+  claudew s api v2    # every term must match`,
+	Args: cobra.MinimumNArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		startFuzzy = true
+		return startCmd.RunE(cmd, args)
+	},
 }
 
 func init() {
+	rootCmd.AddCommand(fuzzyStartCmd)
+	fuzzyStartCmd.ValidArgsFunction = validWorkspaceNamesExcludeArchived
 	startCmd.ValidArgsFunction = validWorkspaceNamesExcludeArchived
+	startCmd.Flags().BoolVarP(&startDetach, "detach", "d", false, "Create sessions in the background without attaching (allows multiple workspaces)")
+	startCmd.Flags().BoolVar(&startExclusive, "exclusive", false, "Detach terminals attached to other workspace sessions before attaching")
+	startCmd.Flags().StringVar(&startWindow, "window", "", "Window of the session to show when attaching, e.g. notes or a spawned agent")
+	startCmd.Flags().BoolVar(&startFuzzy, "fuzzy", false, "Treat the arguments as a fuzzy query: start the only matching workspace, or pick from the matches")
+	startCmd.Flags().BoolVar(&startSteal, "steal", false, "Take over the workspace lock from another terminal without asking")
+	startCmd.RegisterFlagCompletionFunc("window", validWindowNames)
+	startCmd.MarkFlagsMutuallyExclusive("detach", "exclusive")
+	startCmd.MarkFlagsMutuallyExclusive("detach", "window")
+	startCmd.MarkFlagsMutuallyExclusive("detach", "steal")
 }
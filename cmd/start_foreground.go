@@ -0,0 +1,139 @@
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"os/signal"
+	"runtime"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/pmossman/claudew/internal/app"
+	"github.com/pmossman/claudew/internal/config"
+	"github.com/pmossman/claudew/internal/events"
+	"github.com/pmossman/claudew/internal/execx"
+	"github.com/pmossman/claudew/internal/workspace"
+)
+
+// startInForeground runs a workspace's Claude as a child of this process, for
+// machines without tmux. There is nothing to detach from: the session ends when
+// Claude exits. The workspace lock is held throughout, so other commands can tell
+// the session is alive, and each start and exit is recorded in session.log.
+func startInForeground(cfg *config.Config, name string, ws *config.Workspace) error {
+	if ws.GetRepoPath() == "" {
+		return fmt.Errorf("workspace '%s' has no clone assigned. Assign one with: claudew assign-clone %s <clone-path>", name, name)
+	}
+
+	if err := checkClaudeCommand(cfg, ws); err != nil {
+		return err
+	}
+
+	wsMgr := workspace.NewManagerForConfig(cfg)
+	lock, err := wsMgr.AcquireLock(name, os.Getpid())
+	if err != nil {
+		var lockedErr *workspace.ErrLocked
+		if errors.As(err, &lockedErr) {
+			return fmt.Errorf("workspace '%s' is already running in the foreground (PID %d)", name, lockedErr.PID)
+		}
+		return fmt.Errorf("failed to create lock: %w", err)
+	}
+	defer lock.Release()
+
+	ensurePorts(cfg, name)
+	if err := ensureWorkspaceContainer(wsMgr, name, ws); err != nil {
+		return fmt.Errorf("failed to start container: %w", err)
+	}
+	env, err := workspaceEnv(wsMgr, name, ws)
+	if err != nil {
+		fmt.Printf("Warning: %v\n", err)
+	}
+
+	offerBranchCheckout(cfg, name, ws)
+	ensureCommitHook(cfg, ws)
+	initialPrompt := ""
+	if changes, changed := collectOutsideChanges(ws); changed {
+		changes.print()
+		initialPrompt = changes.note()
+	}
+	initialPrompt = withHandoverNote(cfg, ws, initialPrompt)
+	if continuation := wsMgr.GetContinuation(name); continuation != "" {
+		copyToClipboard(cfg, continuation)
+	}
+
+	args := foregroundClaudeArgs(cfg, wsMgr, name, ws, initialPrompt)
+	if len(args) == 0 {
+		return fmt.Errorf("claude command is not configured")
+	}
+	claude := exec.Command(args[0], args[1:]...)
+	claude.Dir = ws.GetWorkDir()
+	claude.Env = os.Environ()
+	for key, value := range env {
+		claude.Env = append(claude.Env, key+"="+value)
+	}
+	claude.Stdin = os.Stdin
+	claude.Stdout = os.Stdout
+	claude.Stderr = os.Stderr
+
+	// Ctrl-C is meant for Claude, which shares this terminal; this process must outlive it
+	signal.Ignore(os.Interrupt)
+	defer signal.Reset(os.Interrupt)
+
+	fmt.Println("Starting Claude Code in the foreground (no detach; exit Claude to end the session)...")
+	fmt.Println()
+	// Started with os/exec rather than execx to learn Claude's PID; logged the same way
+	started := time.Now()
+	if err := claude.Start(); err != nil {
+		execx.LogProcess(claude, err, time.Since(started))
+		_ = wsMgr.AppendSessionLog(name, fmt.Sprintf("failed to start claude: %v", err), time.Now())
+		return fmt.Errorf("failed to start claude: %w", err)
+	}
+	pid := claude.Process.Pid
+	_ = wsMgr.AppendSessionLog(name, fmt.Sprintf("started claude (PID %d)", pid), time.Now())
+
+	if err := cfg.UpdateWorkspaceStatus(name, config.StatusActive, pid); err != nil {
+		return err
+	}
+	ws.LastOpened = time.Now()
+	if err := cfg.Save(); err != nil {
+		fmt.Printf("Warning: failed to save config: %v\n", err)
+	}
+	emitEvent(cfg, events.SessionStarted, name, map[string]string{"pid": strconv.Itoa(pid), "mode": config.SessionModeForeground})
+
+	waitErr := claude.Wait()
+	execx.LogProcess(claude, waitErr, time.Since(started))
+	code := claude.ProcessState.ExitCode()
+	_ = wsMgr.AppendSessionLog(name, fmt.Sprintf("claude exited with status %d", code), time.Now())
+
+	_ = cfg.UpdateWorkspaceStatus(name, config.StatusIdle, 0)
+	app.RecordBranch(cfg, ws)
+	if err := cfg.Save(); err != nil {
+		fmt.Printf("Warning: failed to save config: %v\n", err)
+	}
+	emitEvent(cfg, events.SessionStopped, name, map[string]string{"exit_code": strconv.Itoa(code), "mode": config.SessionModeForeground})
+
+	var exitErr *exec.ExitError
+	if waitErr != nil && !errors.As(waitErr, &exitErr) {
+		return fmt.Errorf("failed waiting for claude: %w", waitErr)
+	}
+	return nil
+}
+
+// foregroundClaudeArgs returns the argv that runs a workspace's Claude in the foreground.
+// The configured command is run through sh, as in a tmux pane, except on Windows where
+// it is split on spaces and the initial prompt is passed as its own argument.
+func foregroundClaudeArgs(cfg *config.Config, wsMgr *workspace.Manager, name string, ws *config.Workspace, initialPrompt string) []string {
+	if runtime.GOOS == "windows" && ws.Container == nil {
+		args := strings.Fields(cfg.Settings.ClaudeCommand)
+		if len(args) > 0 && initialPrompt != "" {
+			args = append(args, initialPrompt)
+		}
+		return args
+	}
+	if strings.TrimSpace(cfg.Settings.ClaudeCommand) == "" {
+		return nil
+	}
+	return []string{"sh", "-c", claudeCommand(cfg, wsMgr, name, ws, initialPrompt)}
+}
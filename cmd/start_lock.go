@@ -0,0 +1,81 @@
+package cmd
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/pmossman/claudew/internal/session"
+	"github.com/pmossman/claudew/internal/workspace"
+)
+
+// How start resolves a workspace lock held by another live process
+const (
+	lockConflictAbort  = "abort"
+	lockConflictAttach = "attach" // go ahead without the lock
+	lockConflictSteal  = "steal"  // take the lock over
+)
+
+// stealReleaseTimeout is how long to wait for detached terminals to release the lock
+// on their own before it is taken from them
+const stealReleaseTimeout = 2 * time.Second
+
+// resolveLockConflict asks what to do when another live process holds a workspace's
+// lock: attach anyway, steal the lock or abort. Without an answer, e.g. when there is
+// no terminal, it aborts.
+func resolveLockConflict(name string, pid int, sessionExists bool) string {
+	tty := openTerminal()
+	defer tty.Close()
+
+	fmt.Fprintln(tty)
+	if sessionExists {
+		fmt.Fprintf(tty, "'%s' is locked by another terminal (PID %d) attached to its session.\n", name, pid)
+		fmt.Fprintln(tty, "  1. Attach anyway, sharing the session (the other terminal keeps the lock)")
+		fmt.Fprintln(tty, "  2. Steal the lock, detaching the other terminal")
+	} else {
+		fmt.Fprintf(tty, "'%s' is locked by PID %d, but its tmux session is gone.\n", name, pid)
+		fmt.Fprintln(tty, "It may be running in the foreground in another terminal, or stuck.")
+		fmt.Fprintln(tty, "  1. Start a session anyway, without the lock")
+		fmt.Fprintln(tty, "  2. Steal the lock and start a session")
+	}
+	fmt.Fprintln(tty, "  3. Abort")
+	fmt.Fprint(tty, "Choice [3]: ")
+
+	answer, _ := bufio.NewReader(tty).ReadString('\n')
+	switch strings.TrimSpace(answer) {
+	case "1":
+		return lockConflictAttach
+	case "2":
+		return lockConflictSteal
+	}
+	return lockConflictAbort
+}
+
+// stealWorkspaceLock takes a workspace's lock from the process holding it. Other
+// terminals attached to the session are detached first, so their start commands
+// release the lock as they return; a holder that doesn't let go in time loses it anyway.
+func stealWorkspaceLock(wsMgr *workspace.Manager, sessionMgr *session.Manager, name, sessionName string, sessionExists bool) (*workspace.Lock, error) {
+	deadline := time.Now()
+	if sessionExists {
+		if err := sessionMgr.Detach(sessionName); err != nil {
+			fmt.Printf("Warning: failed to detach other terminals: %v\n", err)
+		}
+		deadline = deadline.Add(stealReleaseTimeout)
+	}
+
+	for {
+		lock, err := wsMgr.AcquireLock(name, os.Getpid())
+		var lockedErr *workspace.ErrLocked
+		if !errors.As(err, &lockedErr) {
+			return lock, err
+		}
+		if time.Now().After(deadline) {
+			fmt.Printf("Taking the lock from PID %d\n", lockedErr.PID)
+			return wsMgr.StealLock(name, os.Getpid())
+		}
+		time.Sleep(100 * time.Millisecond)
+	}
+}
@@ -0,0 +1,100 @@
+package cmd
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/pmossman/claudew/internal/app"
+	"github.com/pmossman/claudew/internal/config"
+)
+
+// ensureCloneReady checks a workspace's clone before its session is started or
+// attached. If the clone is gone or no longer a git repository, it offers to assign a
+// free clone of the same remote or to clone the remote again, rather than starting
+// Claude in a directory that doesn't exist. A session already running in the broken
+// clone is killed before the workspace is repaired.
+func ensureCloneReady(cfg *config.Config, name string) error {
+	err := app.CheckClone(cfg, name)
+	var missing *app.ErrCloneMissing
+	if !errors.As(err, &missing) {
+		return err
+	}
+	ws, err := cfg.GetWorkspace(name)
+	if err != nil {
+		return err
+	}
+	if missing.Remote == "" {
+		return fmt.Errorf("%w. Assign another with: claudew assign-clone %s <clone-path>", missing, name)
+	}
+
+	type repair struct {
+		label string
+		run   func(svc *app.Service) error
+	}
+	var repairs []repair
+	if free := pickFreeClone(cfg, missing.Remote, ws.Subdir); free != nil {
+		repairs = append(repairs, repair{
+			label: fmt.Sprintf("Assign free clone %s", shortenPath(free.Path)),
+			run: func(svc *app.Service) error {
+				return svc.ReplaceClone(name, free.Path)
+			},
+		})
+	}
+	if missing.Gone {
+		repairs = append(repairs, repair{
+			label: fmt.Sprintf("Re-clone %s into %s", missing.Remote, shortenPath(missing.Path)),
+			run: func(svc *app.Service) error {
+				return svc.RestoreClone(name)
+			},
+		})
+	} else {
+		repairs = append(repairs, repair{
+			label: fmt.Sprintf("Re-clone %s into a new clone", missing.Remote),
+			run: func(svc *app.Service) error {
+				clonePath, err := createNewClone(cfg, missing.Remote)
+				if err != nil {
+					return err
+				}
+				return svc.ReplaceClone(name, clonePath)
+			},
+		})
+	}
+
+	svc := newService(cfg)
+	sessionName := svc.Sessions.GetSessionName(name)
+	sessionExists, _ := svc.Sessions.Exists(sessionName)
+
+	tty := openTerminal()
+	defer tty.Close()
+
+	fmt.Fprintln(tty)
+	fmt.Fprintf(tty, "✗ The %s.\n", missing)
+	if sessionExists {
+		fmt.Fprintln(tty, "Its running session is stuck in that directory and will be restarted.")
+	}
+	for i, r := range repairs {
+		fmt.Fprintf(tty, "  %d. %s\n", i+1, r.label)
+	}
+	abort := len(repairs) + 1
+	fmt.Fprintf(tty, "  %d. Abort\n", abort)
+	fmt.Fprintf(tty, "Choice [%d]: ", abort)
+
+	answer, _ := bufio.NewReader(tty).ReadString('\n')
+	var choice int
+	if _, err := fmt.Sscanf(strings.TrimSpace(answer), "%d", &choice); err != nil || choice < 1 || choice >= abort {
+		return missing
+	}
+
+	if sessionExists {
+		if err := svc.Sessions.Kill(sessionName); err != nil {
+			return fmt.Errorf("failed to kill session: %w", err)
+		}
+	}
+	if err := repairs[choice-1].run(svc); err != nil {
+		return err
+	}
+	fmt.Fprintf(tty, "✓ '%s' now uses %s\n", name, ws.GetRepoPath())
+	return nil
+}
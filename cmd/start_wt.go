@@ -0,0 +1,82 @@
+package cmd
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/pmossman/claudew/internal/config"
+	"github.com/pmossman/claudew/internal/events"
+	"github.com/pmossman/claudew/internal/execx"
+	"github.com/pmossman/claudew/internal/workspace"
+)
+
+// startInWindowsTerminal opens a workspace's Claude in a new Windows Terminal tab.
+// Windows Terminal has no detached sessions, so the tab is not tracked after it
+// opens: closing it ends Claude, and the continuation prompt is copied to the
+// clipboard for pasting instead of being passed on the command line.
+func startInWindowsTerminal(cfg *config.Config, name string, ws *config.Workspace) error {
+	if ws.GetRepoPath() == "" {
+		return fmt.Errorf("workspace '%s' has no clone assigned. Assign one with: claudew assign-clone %s <clone-path>", name, name)
+	}
+	if ws.Container != nil {
+		return fmt.Errorf("workspace '%s' runs in a container, which requires session_mode \"tmux\"", name)
+	}
+
+	if err := checkClaudeCommand(cfg, ws); err != nil {
+		return err
+	}
+
+	wsMgr := workspace.NewManagerForConfig(cfg)
+	ensurePorts(cfg, name)
+	ensureCommitHook(cfg, ws)
+
+	env, err := workspaceEnv(wsMgr, name, ws)
+	if err != nil {
+		fmt.Printf("Warning: %v\n", err)
+	}
+
+	title := "claude-ws-" + name
+	cmd := execx.Command("wt.exe", windowsTerminalArgs(title, ws.GetWorkDir(), cfg.Settings.ClaudeCommand, env)...)
+	if output, err := cmd.CombinedOutput(); err != nil {
+		return fmt.Errorf("failed to open Windows Terminal tab: %w (%s)", err, strings.TrimSpace(string(output)))
+	}
+	fmt.Printf("✓ Opened '%s' in a new Windows Terminal tab\n", name)
+
+	if changes, changed := collectOutsideChanges(ws); changed {
+		changes.print()
+	}
+	if continuation := wsMgr.GetContinuation(name); continuation != "" {
+		copyToClipboard(cfg, continuation)
+	}
+
+	// Nothing holds the session open, so the workspace stays idle
+	if err := cfg.UpdateWorkspaceStatus(name, config.StatusIdle, 0); err != nil {
+		return err
+	}
+	if err := cfg.Save(); err != nil {
+		return err
+	}
+	emitEvent(cfg, events.SessionStarted, name, map[string]string{"session": title, "mode": config.SessionModeWindowsTerminal})
+	return nil
+}
+
+// windowsTerminalArgs returns the wt.exe arguments that open a tab titled title in dir,
+// running claudeCommand through cmd.exe after setting env
+func windowsTerminalArgs(title, dir, claudeCommand string, env map[string]string) []string {
+	keys := make([]string, 0, len(env))
+	for key := range env {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	var steps []string
+	for _, key := range keys {
+		steps = append(steps, fmt.Sprintf(`set "%s=%s"`, key, env[key]))
+	}
+	steps = append(steps, claudeCommand)
+
+	// wt treats ";" as a separator between its own subcommands
+	script := strings.ReplaceAll(strings.Join(steps, " && "), ";", `\;`)
+	return []string{"-w", "0", "new-tab", "--title", title, "-d", dir, "cmd", "/k", script}
+}
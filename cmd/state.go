@@ -0,0 +1,187 @@
+package cmd
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/pmossman/claudew/internal/config"
+	"github.com/spf13/cobra"
+)
+
+var (
+	stateReason string
+	stateUntil  string
+)
+
+var stateCmd = &cobra.Command{
+	Use:   "state <workspace-name> [state|none]",
+	Short: "Show or set a workspace's workflow state",
+	Long: `Tracks where a workspace's task is, like a kanban column, independent of
+whether its session is running. The state is shown in list, the select menu and
+the digest.
+
+A blocked workspace can be given a time to check back with --until. Once it has
+passed, the workspace gets a ⏰ in the select menu and is listed under reminders
+in the digest.
+
+States: ` + strings.Join(config.WorkspaceStates, ", ") + `
+
+Example:
+  claudew state feature-auth                                          # Show the state
+  claudew state feature-auth in-progress                              # Set it
+  claudew state feature-auth blocked --reason "waiting on API review" # Say why
+  claudew state feature-auth blocked -r "API review" --until 2025-10-24 # Remind me
+  claudew state feature-auth blocked --until 3d                       # ...in 3 days
+  claudew state feature-auth none                                     # Stop tracking it`,
+	Args: cobra.RangeArgs(1, 2),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		name := args[0]
+
+		// Load config
+		cfg, err := config.Load()
+		if err != nil {
+			return fmt.Errorf("failed to load config: %w", err)
+		}
+
+		ws, err := cfg.GetWorkspace(name)
+		if err != nil {
+			return err
+		}
+
+		if len(args) == 1 {
+			if stateReason != "" || stateUntil != "" {
+				return fmt.Errorf("--reason and --until need a state, e.g.: claudew state %s blocked --reason \"...\"", name)
+			}
+			if ws.State == "" {
+				fmt.Printf("Workspace '%s' has no state. Set one with: claudew state %s <state>\n", name, name)
+				return nil
+			}
+			fmt.Printf("%s (since %s)\n", formatWorkspaceState(ws), formatTimeAgo(ws.StateChanged))
+			return nil
+		}
+
+		state := strings.ToLower(args[1])
+		if state == "none" {
+			if stateReason != "" || stateUntil != "" {
+				return fmt.Errorf("--reason and --until can't be given when clearing the state")
+			}
+			state = ""
+		} else if err := config.ValidateState(state); err != nil {
+			return err
+		}
+
+		var until time.Time
+		if stateUntil != "" {
+			if state != config.StateBlocked {
+				return fmt.Errorf("--until only applies to the blocked state")
+			}
+			until, err = config.ParseUntil(stateUntil, time.Now())
+			if err != nil {
+				return err
+			}
+		}
+
+		// Setting the same state again only changes what was given: --until alone
+		// keeps the reason, and --reason alone keeps the reminder
+		reason := stateReason
+		if state == ws.State && !cmd.Flags().Changed("reason") {
+			reason = ws.StateReason
+		}
+		if state == ws.State && !cmd.Flags().Changed("until") {
+			until = ws.BlockedUntil
+		}
+
+		ws.SetState(state, reason, until, time.Now())
+		if err := cfg.Save(); err != nil {
+			return fmt.Errorf("failed to save config: %w", err)
+		}
+
+		if state == "" {
+			fmt.Printf("✓ Cleared the state of '%s'\n", name)
+		} else {
+			fmt.Printf("✓ Workspace '%s' is now %s\n", name, formatWorkspaceState(ws))
+		}
+		return nil
+	},
+}
+
+// formatWorkspaceState returns a workspace's colored state with its reason, e.g.
+// "blocked: waiting on API review", or "" when it has none
+func formatWorkspaceState(ws *config.Workspace) string {
+	if ws.State == "" {
+		return ""
+	}
+	text := paint(stateColor(ws.State), ws.State)
+	if ws.StateReason != "" {
+		text += ": " + ws.StateReason
+	}
+	if until := formatBlockedUntil(ws, time.Now()); until != "" {
+		text += " " + paint(colorGray, "("+until+")")
+	}
+	return text
+}
+
+// formatBlockedUntil describes a blocked workspace's reminder, e.g. "until Fri Oct 24"
+// or "⏰ due since Fri Oct 24", or returns "" when it has none
+func formatBlockedUntil(ws *config.Workspace, now time.Time) string {
+	if ws.State != config.StateBlocked || ws.BlockedUntil.IsZero() {
+		return ""
+	}
+	until := ws.BlockedUntil.Local()
+	when := until.Format("Mon Jan 2")
+	if until.Hour() != 0 || until.Minute() != 0 {
+		when += until.Format(" 15:04")
+	}
+	if ws.ReminderDue(now) {
+		return "⏰ due since " + when
+	}
+	return "until " + when
+}
+
+// stateMarker prefixes a workspace's select menu summary with its state, and a ⏰
+// once a blocked workspace's reminder is due
+func stateMarker(ws *config.Workspace) string {
+	if ws.State == "" {
+		return ""
+	}
+	marker := colorize(stateColor(ws.State), strings.ToUpper(ws.State)) + " "
+	if ws.ReminderDue(time.Now()) {
+		marker = "⏰ " + marker
+	}
+	return marker
+}
+
+// stateColor returns the color used to display a workflow state
+func stateColor(state string) string {
+	switch state {
+	case config.StateInProgress:
+		return colorCyan
+	case config.StateBlocked:
+		return colorRed
+	case config.StateReview:
+		return colorMagenta
+	case config.StateDone:
+		return colorGreen
+	default:
+		return colorGray
+	}
+}
+
+// validStateArgs completes the workspace name, then the state
+func validStateArgs(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+	if len(args) == 0 {
+		return validWorkspaceNamesExcludeArchived(cmd, args, toComplete)
+	}
+	if len(args) == 1 {
+		return append(append([]string(nil), config.WorkspaceStates...), "none"), cobra.ShellCompDirectiveNoFileComp
+	}
+	return nil, cobra.ShellCompDirectiveNoFileComp
+}
+
+func init() {
+	rootCmd.AddCommand(stateCmd)
+	stateCmd.ValidArgsFunction = validStateArgs
+	stateCmd.Flags().StringVar(&stateUntil, "until", "", "Remind about a blocked workspace from this date (2025-10-24, \"2025-10-24 14:00\") or after this span (3d, 2w, 12h)")
+	stateCmd.Flags().StringVarP(&stateReason, "reason", "r", "", "Why the workspace is in this state, e.g. what it is blocked on")
+}
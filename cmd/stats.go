@@ -0,0 +1,181 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"time"
+
+	"github.com/pmossman/claudew/internal/config"
+	"github.com/pmossman/claudew/internal/stats"
+	"github.com/pmossman/claudew/internal/table"
+	"github.com/pmossman/claudew/internal/transcript"
+	"github.com/pmossman/claudew/internal/workspace"
+	"github.com/spf13/cobra"
+)
+
+var (
+	statsWeeks int
+	statsAll   bool
+)
+
+var statsCmd = &cobra.Command{
+	Use:   "stats",
+	Short: "Summarize how workspaces, clones and sessions are used",
+	Long: `Shows how the workflow is used:
+
+  - workspaces created and archived per week, and how long they live
+  - how many of each remote's clones are in use
+  - per workspace, how often Claude was restarted (by restart or supervise) and
+    how often Claude updated continuation.md, read from its session transcripts
+
+Archived workspaces are left out of the per-workspace table unless --all is given.
+
+Example:
+  claudew stats
+  claudew stats --weeks 12 --all`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		// Load config
+		cfg, err := config.Load()
+		if err != nil {
+			return fmt.Errorf("failed to load config: %w", err)
+		}
+		if statsWeeks < 1 {
+			return fmt.Errorf("--weeks must be at least 1")
+		}
+
+		now := time.Now()
+		printWeeklyStats(cfg, now)
+		fmt.Println()
+		printCloneStats(cfg)
+		fmt.Println()
+		printWorkspaceStats(cfg)
+		return nil
+	},
+}
+
+// printWeeklyStats shows workspaces created and archived per week, and their lifetimes
+func printWeeklyStats(cfg *config.Config, now time.Time) {
+	fmt.Printf("Workspaces, last %d week(s)\n", statsWeeks)
+	t := table.New(
+		table.Column{Header: "WEEK OF"},
+		table.Column{Header: "CREATED"},
+		table.Column{Header: "ARCHIVED"},
+	)
+	for _, week := range stats.Weekly(cfg, now, statsWeeks) {
+		t.AddRow(week.Start.Format("Mon Jan 2"), fmt.Sprint(week.Created), fmt.Sprint(week.Archived))
+	}
+	t.Render(os.Stdout)
+
+	archived, active := stats.Lifetimes(cfg, now)
+	fmt.Println()
+	if archived.Count > 0 {
+		fmt.Printf("Average lifetime: %s (%d archived workspace(s))\n", formatStatsDuration(archived.Average), archived.Count)
+	} else {
+		fmt.Println("Average lifetime: " + paint(colorGray, "no archived workspaces yet"))
+	}
+	if active.Count > 0 {
+		fmt.Printf("Average age:      %s (%d active workspace(s))\n", formatStatsDuration(active.Average), active.Count)
+	}
+}
+
+// printCloneStats shows the share of each remote's clones assigned to a workspace
+func printCloneStats(cfg *config.Config) {
+	usage := stats.CloneUtilization(cfg)
+	if len(usage) == 0 {
+		fmt.Println("No remotes registered.")
+		return
+	}
+
+	fmt.Println("Clones")
+	t := table.New(
+		table.Column{Header: "REMOTE"},
+		table.Column{Header: "CLONES"},
+		table.Column{Header: "IN USE"},
+		table.Column{Header: "UTILIZATION"},
+	)
+	for _, u := range usage {
+		utilization := paint(colorGray, "-")
+		if u.Clones > 0 {
+			utilization = fmt.Sprintf("%d%%", u.Percent())
+		}
+		t.AddRow(u.Remote, fmt.Sprint(u.Clones), fmt.Sprint(u.InUse), utilization)
+	}
+	t.Render(os.Stdout)
+}
+
+// printWorkspaceStats shows restarts and continuation updates per workspace
+func printWorkspaceStats(cfg *config.Config) {
+	wsMgr := workspace.NewManagerForConfig(cfg)
+
+	var names []string
+	for name, ws := range cfg.Workspaces {
+		if statsAll || ws.Status != config.StatusArchived {
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names)
+	if len(names) == 0 {
+		fmt.Println("No workspaces.")
+		return
+	}
+
+	fmt.Println("Per workspace")
+	t := table.New(
+		table.Column{Header: "WORKSPACE"},
+		table.Column{Header: "RESTARTS"},
+		table.Column{Header: "SESSIONS"},
+		table.Column{Header: "CONTINUATION UPDATES"},
+		table.Column{Header: "LAST UPDATE"},
+	)
+	for _, name := range names {
+		ws := cfg.Workspaces[name]
+		sessions, updates, last := paint(colorGray, "-"), paint(colorGray, "-"), paint(colorGray, "-")
+
+		if workDir := ws.GetWorkDir(); workDir != "" {
+			var until time.Time
+			if ws.Status == config.StatusArchived {
+				until = ws.LastActive
+			}
+			c, err := stats.ContinuationUpdates(transcript.ProjectDir(claudeHomeDir(), workDir),
+				filepath.Join(wsMgr.GetPath(name), "continuation.md"), ws.CreatedAt, until)
+			if err == nil {
+				sessions = fmt.Sprint(c.Sessions)
+				updates = formatContinuationUpdates(c)
+				if !c.Last.IsZero() {
+					last = formatTimeAgo(c.Last)
+				}
+			}
+		}
+		t.AddRow(name, fmt.Sprint(ws.Restarts), sessions, updates, last)
+	}
+	t.Render(os.Stdout)
+}
+
+// formatContinuationUpdates describes how often continuation.md was updated, e.g. "6, every 45m"
+func formatContinuationUpdates(c stats.Continuation) string {
+	if c.Updates == 0 {
+		return "0"
+	}
+	return fmt.Sprintf("%d, every %s", c.Updates, formatStatsDuration(c.Interval()))
+}
+
+// formatStatsDuration returns a rounded duration, e.g. "40m", "5.5h" or "3.2d"
+func formatStatsDuration(d time.Duration) string {
+	switch {
+	case d >= 24*time.Hour:
+		return fmt.Sprintf("%.1fd", d.Hours()/24)
+	case d >= time.Hour:
+		return fmt.Sprintf("%.1fh", d.Hours())
+	default:
+		return fmt.Sprintf("%dm", int(d.Minutes()))
+	}
+}
+
+func init() {
+	rootCmd.AddCommand(statsCmd)
+	statsCmd.Flags().IntVar(&statsWeeks, "weeks", 8, "Number of weeks to show")
+	statsCmd.Flags().BoolVar(&statsAll, "all", false, "Include archived workspaces in the per-workspace table")
+}
@@ -0,0 +1,98 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/pmossman/claudew/internal/config"
+	"github.com/pmossman/claudew/internal/statusline"
+	"github.com/pmossman/claudew/internal/template"
+	"github.com/pmossman/claudew/internal/workspace"
+	"github.com/spf13/cobra"
+)
+
+// defaultContextWarnPercent is used when context_warn_percent is unset
+const defaultContextWarnPercent = 80
+
+var statuslineTmux bool
+
+var statuslineCmd = &cobra.Command{
+	Use:    "statusline <name>",
+	Short:  "Report Claude context usage for a workspace (used by the Claude statusline)",
+	Hidden: true, // Invoked by Claude Code and tmux, not by users
+	Long: `Claude Code runs this as its statusline command with session JSON on stdin.
+It records the context-usage percentage in the workspace directory and prints it.
+
+With --tmux, prints the last recorded usage for the tmux status line instead.`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		name := args[0]
+
+		cfg, err := config.Load()
+		if err != nil {
+			return fmt.Errorf("failed to load config: %w", err)
+		}
+		wsMgr := workspace.NewManagerForConfig(cfg)
+
+		if statuslineTmux {
+			if percent, ok := wsMgr.GetContextUsage(name); ok {
+				fmt.Printf("%s | ", statusline.Format(percent, contextWarnPercent(cfg)))
+			}
+			return nil
+		}
+
+		in, err := statusline.ParseInput(os.Stdin)
+		if err != nil {
+			return err
+		}
+
+		tokens, err := statusline.ContextTokens(in.TranscriptPath)
+		if err != nil {
+			// No transcript yet at the start of a session
+			tokens = 0
+		}
+		percent := statusline.Percent(tokens, in.WindowSize())
+
+		if err := wsMgr.SaveContextUsage(name, percent); err != nil {
+			return fmt.Errorf("failed to save context usage: %w", err)
+		}
+
+		fmt.Printf("[%s] %s\n", name, statusline.Format(percent, contextWarnPercent(cfg)))
+		return nil
+	},
+}
+
+// contextWarnPercent returns the configured context warning threshold
+func contextWarnPercent(cfg *config.Config) int {
+	if cfg.Settings.ContextWarnPercent > 0 {
+		return cfg.Settings.ContextWarnPercent
+	}
+	return defaultContextWarnPercent
+}
+
+// installContextStatusLine points the workspace's Claude statusline at claudew and
+// returns the tmux status-right segment that displays the recorded usage
+func installContextStatusLine(wsMgr *workspace.Manager, name string, ws *config.Workspace) (string, error) {
+	self, err := os.Executable()
+	if err != nil {
+		return "", fmt.Errorf("failed to get executable path: %w", err)
+	}
+
+	// A new session starts with an empty context
+	if err := wsMgr.ClearContextUsage(name); err != nil {
+		return "", fmt.Errorf("failed to reset context usage: %w", err)
+	}
+
+	command := fmt.Sprintf("%s statusline %s", escapeShellArg(self), escapeShellArg(name))
+	if err := template.WriteStatusLine(ws.GetWorkDir(), command); err != nil {
+		return "", err
+	}
+
+	return fmt.Sprintf("#(%s --tmux)", command), nil
+}
+
+func init() {
+	rootCmd.AddCommand(statuslineCmd)
+	statuslineCmd.Flags().BoolVar(&statuslineTmux, "tmux", false, "Print the last recorded usage for the tmux status line")
+	statuslineCmd.ValidArgsFunction = validWorkspaceNamesExcludeArchived
+}
@@ -3,14 +3,18 @@ package cmd
 import (
 	"fmt"
 
-	"github.com/pmossman/claudew/internal/config"
-	"github.com/pmossman/claudew/internal/session"
+	"github.com/pmossman/claudew/internal/app"
 	"github.com/spf13/cobra"
 )
 
+var (
+	stopKeepClone bool
+	stopWip       bool
+)
+
 var stopCmd = &cobra.Command{
 	Use:   "stop <workspace-name>",
-	Short: "Stop a workspace and free its clone",
+	Short: "Stop a workspace and free its clone (unless --keep-clone)",
 	Long: `Stops a workspace by killing its tmux session and freeing the associated clone.
 
 This is useful when you want to pause work on a workspace temporarily but don't want
@@ -22,22 +26,29 @@ What this does:
 - Sets workspace status to 'idle'
 - Preserves all workspace context files
 
+Use --keep-clone to keep the clone reserved for this workspace, so its checkout
+and branch are still there when you resume.
+
+Use --wip (or the wip_commit setting) to first commit any uncommitted changes to
+a local wip/<workspace> branch, so they can be recovered after the clone is reused.
+The checkout itself is left as is and the branch is never pushed.
+
 Example:
   claudew stop feature-auth       # Stop specific workspace
+  claudew stop feature-auth --keep-clone  # Pause without giving up the clone
   claudew stop                    # Interactive: select workspace to stop`,
 	Args: cobra.MaximumNArgs(1),
 	RunE: func(cmd *cobra.Command, args []string) error {
-		// Load config
-		cfg, err := config.Load()
+		svc, err := loadService()
 		if err != nil {
-			return fmt.Errorf("failed to load config: %w", err)
+			return err
 		}
 
 		var workspaceName string
 
 		// If no args, show interactive selector
 		if len(args) == 0 {
-			workspaceName, err = selectWorkspaceInteractive(cfg)
+			workspaceName, err = selectWorkspaceInteractive(svc.Config)
 			if err != nil {
 				return err
 			}
@@ -48,54 +59,27 @@ Example:
 			workspaceName = args[0]
 		}
 
-		// Get workspace
-		ws, err := cfg.GetWorkspace(workspaceName)
-		if err != nil {
-			return fmt.Errorf("workspace '%s' not found", workspaceName)
-		}
-
-		sessionMgr := session.NewManager()
-		sessionName := sessionMgr.GetSessionName(workspaceName)
-
-		// Check if session exists
-		exists, err := sessionMgr.Exists(sessionName)
+		result, err := svc.StopWorkspace(workspaceName, app.StopOptions{KeepClone: stopKeepClone, WIP: stopWip})
 		if err != nil {
-			return fmt.Errorf("failed to check session: %w", err)
+			return err
 		}
 
-		// Kill the tmux session if it exists
-		if exists {
-			fmt.Printf("Killing tmux session: %s\n", sessionName)
-			if err := sessionMgr.Kill(sessionName); err != nil {
-				return fmt.Errorf("failed to kill session: %w", err)
-			}
+		if result.Killed {
+			fmt.Printf("Killed tmux session: %s\n", result.Session)
 		} else {
 			fmt.Printf("No active tmux session for workspace '%s'\n", workspaceName)
 		}
-
-		// Free the clone if workspace is using one
-		if ws.ClonePath != "" {
-			if _, err := cfg.GetClone(ws.ClonePath); err == nil {
-				fmt.Printf("Freeing clone: %s\n", ws.ClonePath)
-				if err := cfg.FreeClone(ws.ClonePath); err != nil {
-					return fmt.Errorf("failed to free clone: %w", err)
-				}
-			}
-		}
-
-		// Update workspace status to idle
-		if err := cfg.UpdateWorkspaceStatus(workspaceName, config.StatusIdle, 0); err != nil {
-			return fmt.Errorf("failed to update workspace status: %w", err)
-		}
-
-		// Save config
-		if err := cfg.Save(); err != nil {
-			return fmt.Errorf("failed to save config: %w", err)
+		if result.WIPCommit != "" {
+			fmt.Printf("Saved uncommitted changes to %s (%s)\n", app.WIPBranch(workspaceName), result.WIPCommit[:7])
 		}
 
 		fmt.Printf("\n✓ Stopped workspace '%s'\n", workspaceName)
 		fmt.Println("  • Tmux session killed")
-		fmt.Println("  • Clone freed for other workspaces")
+		if result.FreedClone != "" {
+			fmt.Printf("  • Clone freed for other workspaces: %s\n", result.FreedClone)
+		} else if result.KeptClone != "" {
+			fmt.Printf("  • Clone kept for this workspace: %s\n", result.KeptClone)
+		}
 		fmt.Println("  • Workspace status set to idle")
 		fmt.Printf("\nResume with: claudew start %s\n", workspaceName)
 
@@ -105,5 +89,7 @@ Example:
 
 func init() {
 	rootCmd.AddCommand(stopCmd)
+	stopCmd.Flags().BoolVar(&stopKeepClone, "keep-clone", false, "Keep the clone assigned to this workspace instead of freeing it")
+	stopCmd.Flags().BoolVar(&stopWip, "wip", false, "Commit uncommitted changes to a local wip/<workspace> branch first")
 	stopCmd.ValidArgsFunction = validWorkspaceNamesExcludeArchived
 }
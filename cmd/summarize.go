@@ -0,0 +1,204 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+
+	"github.com/pmossman/claudew/internal/config"
+	"github.com/pmossman/claudew/internal/git"
+	"github.com/pmossman/claudew/internal/summarize"
+	"github.com/pmossman/claudew/internal/transcript"
+	"github.com/pmossman/claudew/internal/workspace"
+	"github.com/spf13/cobra"
+)
+
+// summarizeGitLogCount is how many recent commits are included in the prompt
+const summarizeGitLogCount = 20
+
+// transcriptExcerptChars caps how much of the conversation is sent when writing a continuation
+const transcriptExcerptChars = 20000
+
+var (
+	summarizeAll     bool
+	summarizeContext bool
+)
+
+var summarizeCmd = &cobra.Command{
+	Use:   "summarize [workspace-name]",
+	Short: "Regenerate a workspace summary with Claude",
+	Long: `Runs 'claude -p' against a workspace's context.md, continuation.md and recent
+git log to regenerate its one-line summary.txt.
+
+With --context, Claude also rewrites context.md to reflect the current state of
+the work. With --all, every non-archived workspace is refreshed in turn, which is
+handy as a nightly cron job.
+
+Example:
+  claudew summarize feature-auth            # Refresh one summary
+  claudew summarize feature-auth --context  # Also refresh context.md
+  claudew summarize --all                   # Refresh every workspace
+  claudew summarize                         # Interactive: select workspace`,
+	Args: cobra.MaximumNArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		// Load config
+		cfg, err := config.Load()
+		if err != nil {
+			return fmt.Errorf("failed to load config: %w", err)
+		}
+
+		if summarizeAll && len(args) > 0 {
+			return fmt.Errorf("cannot specify a workspace name with --all")
+		}
+
+		var names []string
+		switch {
+		case summarizeAll:
+			for name, ws := range cfg.Workspaces {
+				if ws.Status != config.StatusArchived {
+					names = append(names, name)
+				}
+			}
+			sort.Strings(names)
+		case len(args) == 1:
+			if _, err := cfg.GetWorkspace(args[0]); err != nil {
+				return fmt.Errorf("workspace '%s' not found", args[0])
+			}
+			names = []string{args[0]}
+		default:
+			name, err := selectWorkspaceInteractive(cfg)
+			if err != nil {
+				return err
+			}
+			if name == "" {
+				return nil // User cancelled
+			}
+			names = []string{name}
+		}
+
+		if len(names) == 0 {
+			fmt.Println("No workspaces to summarize.")
+			return nil
+		}
+
+		wsMgr := workspace.NewManagerForConfig(cfg)
+
+		var failed []string
+		for _, name := range names {
+			summary, err := summarizeWorkspace(cfg, wsMgr, name, summarizeContext)
+			if err != nil {
+				fmt.Printf("✗ %s: %v\n", name, err)
+				failed = append(failed, name)
+				continue
+			}
+			fmt.Printf("✓ %s: %s\n", name, summary)
+		}
+
+		if len(failed) > 0 {
+			return fmt.Errorf("failed to summarize %d workspace(s): %v", len(failed), failed)
+		}
+		return nil
+	},
+}
+
+// summarizeWorkspace asks Claude for a fresh summary (and optionally context.md) and saves it
+func summarizeWorkspace(cfg *config.Config, wsMgr *workspace.Manager, name string, refreshContext bool) (string, error) {
+	ws, err := cfg.GetWorkspace(name)
+	if err != nil {
+		return "", err
+	}
+
+	input := summarize.Input{
+		Context:      wsMgr.ReadContext(name),
+		Continuation: wsMgr.GetContinuation(name),
+	}
+	if repoPath := ws.GetRepoPath(); repoPath != "" && git.IsGitRepo(repoPath) {
+		if log, err := git.RecentLog(repoPath, summarizeGitLogCount); err == nil {
+			input.GitLog = log
+		}
+	}
+
+	if input.Context == "" && input.Continuation == "" && input.GitLog == "" {
+		return "", fmt.Errorf("no context, continuation or git history to summarize")
+	}
+
+	if refreshContext {
+		newContext, err := summarize.Run(cfg.Settings.ClaudeCommand, summarize.BuildContextPrompt(input))
+		if err != nil {
+			return "", err
+		}
+		if newContext != "" {
+			if err := wsMgr.SaveContext(name, newContext+"\n"); err != nil {
+				return "", fmt.Errorf("failed to save context: %w", err)
+			}
+			input.Context = newContext
+		}
+	}
+
+	output, err := summarize.Run(cfg.Settings.ClaudeCommand, summarize.BuildSummaryPrompt(input))
+	if err != nil {
+		return "", err
+	}
+
+	summary := summarize.CleanSummary(output)
+	if summary == "" {
+		return "", fmt.Errorf("claude returned an empty summary")
+	}
+
+	if err := wsMgr.SaveSummary(name, summary); err != nil {
+		return "", fmt.Errorf("failed to save summary: %w", err)
+	}
+	return summary, nil
+}
+
+// writeContinuationFromTranscript asks Claude to write continuation.md from the end of the
+// workspace's latest Claude transcript, so a stopped session can be resumed later
+func writeContinuationFromTranscript(cfg *config.Config, wsMgr *workspace.Manager, name string, ws *config.Workspace) error {
+	dir := transcript.ProjectDir(claudeHomeDir(), ws.GetWorkDir())
+	path, err := transcript.Latest(dir)
+	if err != nil {
+		return err
+	}
+	messages, err := transcript.Read(path)
+	if err != nil {
+		return err
+	}
+	if len(messages) == 0 {
+		return fmt.Errorf("transcript %s has no messages", path)
+	}
+
+	input := summarize.Input{
+		Context:      wsMgr.ReadContext(name),
+		Continuation: wsMgr.GetContinuation(name),
+		Transcript:   transcript.Excerpt(messages, transcriptExcerptChars),
+	}
+	continuation, err := summarize.Run(cfg.Settings.ClaudeCommand, summarize.BuildContinuationPrompt(input))
+	if err != nil {
+		return err
+	}
+	if continuation == "" {
+		return fmt.Errorf("claude returned an empty continuation")
+	}
+
+	if err := wsMgr.SaveContinuation(name, continuation+"\n"); err != nil {
+		return fmt.Errorf("failed to save continuation: %w", err)
+	}
+	return nil
+}
+
+// claudeHomeDir returns Claude Code's config directory (CLAUDE_CONFIG_DIR or ~/.claude)
+func claudeHomeDir() string {
+	if dir := os.Getenv("CLAUDE_CONFIG_DIR"); dir != "" {
+		return dir
+	}
+	home, _ := os.UserHomeDir()
+	return filepath.Join(home, ".claude")
+}
+
+func init() {
+	rootCmd.AddCommand(summarizeCmd)
+	summarizeCmd.ValidArgsFunction = validWorkspaceNamesExcludeArchived
+	summarizeCmd.Flags().BoolVar(&summarizeAll, "all", false, "Summarize every non-archived workspace")
+	summarizeCmd.Flags().BoolVar(&summarizeContext, "context", false, "Also regenerate context.md")
+}
@@ -0,0 +1,153 @@
+package cmd
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"time"
+
+	"github.com/pmossman/claudew/internal/config"
+	"github.com/pmossman/claudew/internal/events"
+	"github.com/pmossman/claudew/internal/session"
+	"github.com/pmossman/claudew/internal/workspace"
+	"github.com/spf13/cobra"
+)
+
+var (
+	superviseInterval    time.Duration
+	superviseOnce        bool
+	superviseMaxRestarts int
+)
+
+var superviseCmd = &cobra.Command{
+	Use:   "supervise",
+	Short: "Restart Claude in sessions where it crashed",
+	Long: `Watches running workspace sessions and restarts Claude, with the workspace's
+continuation prompt, when it exits unexpectedly (a crash or being killed for
+memory), so a session never sits at a shell prompt looking active.
+
+Requires "supervise_claude": true in settings, which makes each Claude launch
+record its exit status. Exits with status 0, Ctrl-C (130) or SIGTERM (143, as
+sent by 'claudew restart') are treated as intentional and left alone.
+
+Each crash emits a claude.exited event and each restart a session.restarted
+event, so the event webhook or command can notify you.
+
+Example:
+  claudew supervise                   # Check every 30s until interrupted
+  claudew supervise --once            # Single check, e.g. from cron`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		// Load config
+		cfg, err := config.Load()
+		if err != nil {
+			return fmt.Errorf("failed to load config: %w", err)
+		}
+		if !cfg.Settings.SuperviseClaude {
+			return fmt.Errorf("supervision is off: set \"supervise_claude\": true in settings and restart your sessions")
+		}
+
+		restarts := map[string]int{}
+		for {
+			superviseOnePass(restarts)
+			if superviseOnce {
+				return nil
+			}
+			time.Sleep(superviseInterval)
+		}
+	},
+}
+
+// superviseOnePass restarts Claude in every running session whose last launch crashed.
+// restarts counts restarts per workspace across passes.
+func superviseOnePass(restarts map[string]int) {
+	// Reload each pass so new workspaces and settings are picked up
+	cfg, err := config.Load()
+	if err != nil {
+		fmt.Printf("%s failed to load config: %v\n", superviseTimestamp(), err)
+		return
+	}
+
+	sessionMgr := session.NewManagerForConfig(cfg)
+	wsMgr := workspace.NewManagerForConfig(cfg)
+
+	var names []string
+	for name, ws := range cfg.Workspaces {
+		if ws.Status != config.StatusArchived {
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names)
+
+	for _, name := range names {
+		code, exited := wsMgr.GetClaudeExit(name)
+		if !exited || intentionalClaudeExit(code) {
+			continue
+		}
+		sessionName := sessionMgr.GetSessionName(name)
+		if exists, _ := sessionMgr.Exists(sessionName); !exists {
+			continue
+		}
+
+		fmt.Printf("%s Claude in '%s' exited with status %d\n", superviseTimestamp(), name, code)
+		emitEvent(cfg, events.ClaudeExited, name, map[string]string{"session": sessionName, "exit_code": strconv.Itoa(code)})
+
+		// Clear the status so this crash is only handled once
+		if err := wsMgr.ClearClaudeExit(name); err != nil {
+			fmt.Printf("  ✗ failed to clear exit status: %v\n", err)
+			continue
+		}
+
+		if restarts[name] >= superviseMaxRestarts {
+			fmt.Printf("  ✗ not restarting: already restarted %d time(s)\n", restarts[name])
+			continue
+		}
+		if err := relaunchClaude(cfg, wsMgr, sessionMgr, name); err != nil {
+			fmt.Printf("  ✗ restart failed: %v\n", err)
+			continue
+		}
+		restarts[name]++
+		recordRestart(cfg, name)
+		fmt.Printf("  ✓ restarted with continuation\n")
+		emitEvent(cfg, events.SessionRestarted, name, map[string]string{"session": sessionName, "reason": "crash"})
+	}
+}
+
+// relaunchClaude starts Claude with the workspace's continuation in a session whose pane is at a shell prompt
+func relaunchClaude(cfg *config.Config, wsMgr *workspace.Manager, sessionMgr *session.Manager, name string) error {
+	ws, err := cfg.GetWorkspace(name)
+	if err != nil {
+		return err
+	}
+	if ws.Container != nil {
+		if err := ensureWorkspaceContainer(wsMgr, name, ws); err != nil {
+			return err
+		}
+	}
+
+	sessionName := sessionMgr.GetSessionName(name)
+	if err := sessionMgr.SendKeysLiteral(sessionName, "C-u"); err != nil {
+		return fmt.Errorf("failed to clear line: %w", err)
+	}
+	return sessionMgr.SendKeys(sessionName, claudeLaunchCommand(cfg, wsMgr, name, ws, wsMgr.GetContinuation(name)))
+}
+
+// intentionalClaudeExit reports whether an exit status means Claude was quit on purpose
+func intentionalClaudeExit(code int) bool {
+	switch code {
+	case 0, 130, 143: // normal exit, Ctrl-C, SIGTERM from claudew restart
+		return true
+	}
+	return false
+}
+
+func superviseTimestamp() string {
+	return time.Now().Format("2006-01-02 15:04:05")
+}
+
+func init() {
+	rootCmd.AddCommand(superviseCmd)
+	superviseCmd.Flags().DurationVar(&superviseInterval, "interval", 30*time.Second, "How often to check sessions")
+	superviseCmd.Flags().BoolVar(&superviseOnce, "once", false, "Check once and exit")
+	superviseCmd.Flags().IntVar(&superviseMaxRestarts, "max-restarts", 3, "Give up on a workspace after this many restarts")
+}
@@ -0,0 +1,105 @@
+package cmd
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/pmossman/claudew/internal/config"
+	"github.com/pmossman/claudew/internal/template"
+	"github.com/pmossman/claudew/internal/workspace"
+	"github.com/spf13/cobra"
+)
+
+var templateUpgradeAll bool
+
+var templateCmd = &cobra.Command{
+	Use:   "template",
+	Short: "Manage the CLAUDE.md generated in workspace clones",
+}
+
+var templateUpgradeCmd = &cobra.Command{
+	Use:   "upgrade [workspace-name]",
+	Short: "Re-render CLAUDE.md files written by older claudew versions",
+	Long: `Each generated .claude/CLAUDE.md records the version of the template it was
+rendered from. This re-renders the ones older than this claudew's template, so
+improvements to the context protocol reach existing workspaces. Only the block
+between the claudew markers is replaced; anything added around it is kept.
+
+Example:
+  claudew template upgrade feature-auth
+  claudew template upgrade --all`,
+	Args: func(cmd *cobra.Command, args []string) error {
+		if templateUpgradeAll == (len(args) == 1) || len(args) > 1 {
+			return fmt.Errorf("specify a workspace name or --all")
+		}
+		return nil
+	},
+	RunE: func(cmd *cobra.Command, args []string) error {
+		// Load config
+		cfg, err := config.Load()
+		if err != nil {
+			return fmt.Errorf("failed to load config: %w", err)
+		}
+
+		var names []string
+		if templateUpgradeAll {
+			for name, ws := range cfg.Workspaces {
+				if ws.Status != config.StatusArchived && ws.GetRepoPath() != "" {
+					names = append(names, name)
+				}
+			}
+			sort.Strings(names)
+		} else {
+			ws, err := cfg.GetWorkspace(args[0])
+			if err != nil {
+				return err
+			}
+			if ws.GetRepoPath() == "" {
+				return fmt.Errorf("workspace '%s' has no clone assigned. Assign one with: claudew assign-clone %s <clone-path>", args[0], args[0])
+			}
+			names = []string{args[0]}
+		}
+
+		wsMgr := workspace.NewManagerForConfig(cfg)
+		upgraded, failed := 0, 0
+		for _, name := range names {
+			ws := cfg.Workspaces[name]
+			version, err := template.ClaudeMdVersionAt(ws.GetWorkDir())
+			switch {
+			case err != nil:
+				fmt.Printf("%s %s: %v\n", paint(colorRed, "✗"), name, err)
+				failed++
+				continue
+			case version == 0:
+				fmt.Printf("- %s: no generated CLAUDE.md in %s, skipping\n", name, ws.GetWorkDir())
+				continue
+			case version >= template.ClaudeMdVersion:
+				fmt.Printf("✓ %s: up to date (v%d)\n", name, version)
+				continue
+			}
+
+			if err := template.GenerateClaudeMd(name, wsMgr.GetPath(name), ws.GetWorkDir()); err != nil {
+				fmt.Printf("%s %s: %v\n", paint(colorRed, "✗"), name, err)
+				failed++
+				continue
+			}
+			fmt.Printf("✓ %s: upgraded v%d → v%d\n", name, version, template.ClaudeMdVersion)
+			upgraded++
+		}
+
+		if upgraded > 0 {
+			fmt.Println("\nRunning sessions pick up the new instructions after a restart: claudew restart <name>")
+		}
+		if failed > 0 {
+			return fmt.Errorf("failed to upgrade %d CLAUDE.md file(s)", failed)
+		}
+		return nil
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(templateCmd)
+	templateCmd.AddCommand(templateUpgradeCmd)
+	templateUpgradeCmd.ValidArgsFunction = validWorkspaceNamesExcludeArchived
+	templateUpgradeCmd.Flags().BoolVar(&templateUpgradeAll, "all", false, "Upgrade every workspace with a clone")
+}
@@ -0,0 +1,41 @@
+package cmd
+
+import (
+	"io"
+	"os"
+)
+
+// terminal is where interactive prompts are read from and written to
+type terminal struct {
+	in   io.Reader
+	out  io.Writer
+	file *os.File // the opened /dev/tty, nil when falling back
+}
+
+// openTerminal opens /dev/tty, so prompts work after fzf and while stdout is
+// captured by the shell wrapper. Without a controlling terminal (scripts, IDE
+// tasks, some terminal emulators) it falls back to stdin, with prompts on stderr
+// to keep stdout clean.
+func openTerminal() *terminal {
+	tty, err := os.OpenFile("/dev/tty", os.O_RDWR, 0)
+	if err != nil {
+		return &terminal{in: os.Stdin, out: os.Stderr}
+	}
+	return &terminal{in: tty, out: tty, file: tty}
+}
+
+func (t *terminal) Read(p []byte) (int, error) {
+	return t.in.Read(p)
+}
+
+func (t *terminal) Write(p []byte) (int, error) {
+	return t.out.Write(p)
+}
+
+// Close closes /dev/tty if it was opened
+func (t *terminal) Close() error {
+	if t.file == nil {
+		return nil
+	}
+	return t.file.Close()
+}
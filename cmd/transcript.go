@@ -0,0 +1,184 @@
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"os/exec"
+	"strings"
+	"time"
+
+	"github.com/pmossman/claudew/internal/config"
+	"github.com/pmossman/claudew/internal/execx"
+	"github.com/pmossman/claudew/internal/menu"
+	"github.com/pmossman/claudew/internal/table"
+	"github.com/pmossman/claudew/internal/transcript"
+	"github.com/spf13/cobra"
+)
+
+var (
+	transcriptList   bool
+	transcriptLatest bool
+	transcriptAll    bool
+)
+
+var transcriptCmd = &cobra.Command{
+	Use:   "transcript <workspace>",
+	Short: "Browse a workspace's Claude Code transcripts",
+	Long: `Lists the Claude Code sessions recorded for a workspace's clone, with when they
+ran, how many messages they have and the tokens they used, and opens the one you
+pick in a pager ($PAGER, or less) to audit what Claude did: every message and
+the tools it called.
+
+Clones are reused, so only sessions since the workspace was created are shown
+unless --all is given.
+
+Example:
+  claudew transcript feature-auth          # Pick a session to read
+  claudew transcript feature-auth --latest # Read the most recent session
+  claudew transcript feature-auth --list   # Just print the sessions`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		name := args[0]
+
+		// Load config
+		cfg, err := config.Load()
+		if err != nil {
+			return fmt.Errorf("failed to load config: %w", err)
+		}
+
+		ws, err := cfg.GetWorkspace(name)
+		if err != nil {
+			return err
+		}
+		if ws.GetRepoPath() == "" {
+			return fmt.Errorf("workspace '%s' has no clone assigned", name)
+		}
+
+		dir := transcript.ProjectDir(claudeHomeDir(), ws.GetWorkDir())
+		all, err := transcript.List(dir)
+		if err != nil && !errors.Is(err, fs.ErrNotExist) {
+			return err
+		}
+		var sessions []transcript.Session
+		for _, session := range all {
+			if transcriptAll || !session.Ended.Before(ws.CreatedAt) {
+				sessions = append(sessions, session)
+			}
+		}
+		if len(sessions) == 0 {
+			fmt.Printf("No Claude transcripts for '%s' in %s\n", name, dir)
+			return nil
+		}
+
+		if transcriptList {
+			tbl := table.New(
+				table.Column{Header: "STARTED"},
+				table.Column{Header: "DURATION"},
+				table.Column{Header: "MESSAGES"},
+				table.Column{Header: "TOKENS (IN/OUT)"},
+				table.Column{Header: "FIRST PROMPT", Max: 50},
+			)
+			for _, session := range sessions {
+				tbl.AddRow(session.Started.Local().Format("2006-01-02 15:04"), formatSessionDuration(session),
+					fmt.Sprintf("%d", session.Messages), formatTokens(session.InputTokens)+" / "+formatTokens(session.OutputTokens), session.FirstPrompt)
+			}
+			tbl.Render(os.Stdout)
+			return nil
+		}
+
+		selected := sessions[0]
+		if !transcriptLatest {
+			lines := make([]string, len(sessions))
+			for i, session := range sessions {
+				lines[i] = menu.Line(session.ID, transcriptMenuLine(session))
+			}
+			choice, err := runMenu(lines, applyFzfSettings(menuOptions{
+				header:  fmt.Sprintf("Transcripts for %s (Ctrl-C to cancel)", name),
+				prompt:  "Session> ",
+				height:  "50%",
+				noSort:  true,
+				withIDs: true,
+			}, cfg.Settings))
+			if err != nil {
+				return err
+			}
+			id := menu.ID(choice)
+			if id == "" {
+				return nil // User cancelled
+			}
+			for _, session := range sessions {
+				if session.ID == id {
+					selected = session
+				}
+			}
+		}
+
+		text, err := transcript.Render(selected.Path)
+		if err != nil {
+			return err
+		}
+		header := fmt.Sprintf("Transcript %s (%s, %s)\n%s\n\n", selected.ID, selected.Started.Local().Format("2006-01-02 15:04"), formatSessionDuration(selected), selected.Path)
+		return page(header + text)
+	},
+}
+
+// transcriptMenuLine describes a transcript session on one menu line
+func transcriptMenuLine(session transcript.Session) string {
+	prompt := table.Truncate(session.FirstPrompt, 60, false)
+	return fmt.Sprintf("%s  %6s  %4d msgs  %6s tokens  %s",
+		session.Started.Local().Format("2006-01-02 15:04"), formatSessionDuration(session),
+		session.Messages, formatTokens(session.InputTokens+session.OutputTokens), prompt)
+}
+
+// formatSessionDuration returns how long a transcript session ran, e.g. "45m" or "3h10m"
+func formatSessionDuration(session transcript.Session) string {
+	d := session.Ended.Sub(session.Started)
+	if d < 0 {
+		d = 0
+	}
+	if d < time.Hour {
+		return fmt.Sprintf("%dm", int(d.Minutes()))
+	}
+	return fmt.Sprintf("%dh%02dm", int(d.Hours()), int(d.Minutes())%60)
+}
+
+// formatTokens abbreviates a token count, e.g. 950, 12.3k or 1.4M
+func formatTokens(n int) string {
+	switch {
+	case n >= 1_000_000:
+		return fmt.Sprintf("%.1fM", float64(n)/1_000_000)
+	case n >= 1_000:
+		return fmt.Sprintf("%.1fk", float64(n)/1_000)
+	default:
+		return fmt.Sprintf("%d", n)
+	}
+}
+
+// page shows text in $PAGER (or less), or prints it when no pager is available
+func page(text string) error {
+	pager := os.Getenv("PAGER")
+	if pager == "" {
+		if _, err := exec.LookPath("less"); err != nil {
+			fmt.Print(text)
+			return nil
+		}
+		pager = "less -R"
+	}
+
+	cmd := execx.Command("sh", "-c", pager)
+	cmd.Stdin = strings.NewReader(text)
+	cmd.Stdout = os.Stdout
+	cmd.Stderr = os.Stderr
+	return cmd.Run()
+}
+
+func init() {
+	rootCmd.AddCommand(transcriptCmd)
+	transcriptCmd.ValidArgsFunction = validWorkspaceNames
+	transcriptCmd.Flags().BoolVar(&transcriptList, "list", false, "Print the sessions instead of opening one")
+	transcriptCmd.Flags().BoolVar(&transcriptLatest, "latest", false, "Open the most recent session without asking")
+	transcriptCmd.Flags().BoolVar(&transcriptAll, "all", false, "Include sessions from before the workspace was created")
+	transcriptCmd.MarkFlagsMutuallyExclusive("list", "latest")
+}
@@ -0,0 +1,195 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/pmossman/claudew/internal/config"
+	"github.com/pmossman/claudew/internal/session"
+	"github.com/pmossman/claudew/internal/workspace"
+	"github.com/spf13/cobra"
+)
+
+// trayPluginName is the plugin file name; xbar and SwiftBar read the refresh interval from it
+const trayPluginName = "claudew.1m.sh"
+
+var trayInstallDir string
+
+var trayCmd = &cobra.Command{
+	Use:   "tray",
+	Short: "Print workspace status for the xbar/SwiftBar menu bar",
+	Long: `Prints workspace counts and workspaces that need attention in the xbar / SwiftBar
+plugin format, so they show up in the macOS menu bar. Clicking a workspace opens a
+terminal attached to it.
+
+A workspace needs attention when its session died while it was still marked active,
+when Claude crashed in it, or when its context usage is over the warning threshold.
+
+Install the plugin into your xbar or SwiftBar plugin folder with --install.
+
+Example:
+  claudew tray                                                        # Preview the plugin output
+  claudew tray --install ~/Library/Application\ Support/xbar/plugins  # Install for xbar`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if trayInstallDir != "" {
+			return installTrayPlugin(trayInstallDir)
+		}
+
+		// Load config
+		cfg, err := config.Load()
+		if err != nil {
+			return fmt.Errorf("failed to load config: %w", err)
+		}
+
+		printTray(cfg)
+		return nil
+	},
+}
+
+// printTray writes the menu bar plugin output for every non-archived workspace
+func printTray(cfg *config.Config) {
+	refreshLastActive(cfg)
+	wsMgr := workspace.NewManagerForConfig(cfg)
+	sessionMgr := session.NewManagerForConfig(cfg)
+
+	running := map[string]bool{}
+	if sessions, err := sessionMgr.ListWorkspaceSessions(); err == nil {
+		for _, name := range sessions {
+			running[name] = true
+		}
+	}
+
+	var names []string
+	for name, ws := range cfg.Workspaces {
+		if ws.Status != config.StatusArchived {
+			names = append(names, name)
+		}
+	}
+	sort.Slice(names, func(i, j int) bool {
+		return menuOrderLess(cfg.Workspaces[names[i]], cfg.Workspaces[names[j]])
+	})
+
+	active := 0
+	attention := map[string]string{}
+	for _, name := range names {
+		isRunning := running[sessionMgr.GetSessionName(name)]
+		if isRunning {
+			active++
+		}
+		if reason := workspaceAttention(cfg, wsMgr, name, cfg.Workspaces[name], isRunning); reason != "" {
+			attention[name] = reason
+		}
+	}
+
+	// Menu bar title
+	title := fmt.Sprintf("✳ %d/%d", active, len(names))
+	if len(attention) > 0 {
+		title += fmt.Sprintf(" ⚠ %d", len(attention))
+	}
+	fmt.Println(title)
+	fmt.Println("---")
+
+	self := claudewExecutable()
+	if len(attention) > 0 {
+		fmt.Println("Needs attention")
+		for _, name := range names {
+			if reason, ok := attention[name]; ok {
+				fmt.Printf("⚠ %s — %s | %s\n", name, trayText(reason), trayAttachParams(self, name))
+			}
+		}
+		fmt.Println("---")
+	}
+
+	if len(names) == 0 {
+		fmt.Println("No workspaces")
+	}
+	for _, name := range names {
+		ws := cfg.Workspaces[name]
+		marker := "○"
+		if running[sessionMgr.GetSessionName(name)] {
+			marker = "●"
+		}
+		label := fmt.Sprintf("%s %s%s", marker, pinMarker(ws), name)
+		if summary := wsMgr.GetSummary(name); summary != "(no summary)" {
+			label += " — " + trayText(summary)
+		}
+		fmt.Printf("%s | %s\n", label, trayAttachParams(self, name))
+		fmt.Printf("--Last active %s | disabled=true\n", formatTimeAgo(ws.LastActive))
+	}
+
+	fmt.Println("---")
+	fmt.Printf("Open menu | bash=%s param1=select terminal=true\n", trayQuote(self))
+	fmt.Println("Refresh | refresh=true")
+}
+
+// workspaceAttention returns why a workspace needs attention, or "" if it doesn't
+func workspaceAttention(cfg *config.Config, wsMgr *workspace.Manager, name string, ws *config.Workspace, running bool) string {
+	if !running {
+		if ws.Status == config.StatusActive {
+			return "session died"
+		}
+		return ""
+	}
+	if code, ok := wsMgr.GetClaudeExit(name); ok && !intentionalClaudeExit(code) {
+		return fmt.Sprintf("Claude exited (status %d)", code)
+	}
+	if percent, ok := wsMgr.GetContextUsage(name); ok && percent >= contextWarnPercent(cfg) {
+		return fmt.Sprintf("context %d%% full", percent)
+	}
+	return ""
+}
+
+// trayAttachParams returns the plugin parameters that open a terminal attached to a workspace
+func trayAttachParams(self, name string) string {
+	return fmt.Sprintf("bash=%s param1=start param2=%s terminal=true", trayQuote(self), trayQuote(name))
+}
+
+// trayQuote quotes a plugin parameter value, so a path with spaces (such as one under
+// "Application Support") stays one value. The plugin format has no escapes, so a
+// value containing double quotes is single-quoted instead.
+func trayQuote(s string) string {
+	if strings.Contains(s, `"`) {
+		return "'" + s + "'"
+	}
+	return `"` + s + `"`
+}
+
+// trayText strips characters the plugin format treats as syntax
+func trayText(s string) string {
+	return strings.NewReplacer("|", "/", "\n", " ").Replace(s)
+}
+
+// claudewExecutable returns the absolute path of the running claudew binary
+func claudewExecutable() string {
+	if path, err := os.Executable(); err == nil {
+		return path
+	}
+	return "claudew"
+}
+
+// installTrayPlugin writes a plugin script that runs "claudew tray" into dir
+func installTrayPlugin(dir string) error {
+	if info, err := os.Stat(dir); err != nil || !info.IsDir() {
+		return fmt.Errorf("plugin directory %s does not exist", dir)
+	}
+
+	script := fmt.Sprintf("#!/bin/sh\n# Generated by claudew. Shows workspace status in the menu bar.\nexec %s tray\n", escapeShellArg(claudewExecutable()))
+	path := filepath.Join(dir, trayPluginName)
+	if err := os.WriteFile(path, []byte(script), 0755); err != nil {
+		return fmt.Errorf("failed to write plugin: %w", err)
+	}
+
+	fmt.Printf("✓ Installed menu bar plugin at %s\n", path)
+	fmt.Println("  Refresh xbar/SwiftBar to load it")
+	return nil
+}
+
+func init() {
+	rootCmd.AddCommand(trayCmd)
+	trayCmd.Flags().StringVar(&trayInstallDir, "install", "", "Install the plugin into this xbar/SwiftBar plugin directory")
+	trayCmd.MarkFlagDirname("install")
+}
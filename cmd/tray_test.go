@@ -0,0 +1,14 @@
+package cmd
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTrayAttachParams_QuotesPaths(t *testing.T) {
+	assert.Equal(t,
+		`bash="/Users/me/Library/Application Support/claudew" param1=start param2="feature-auth" terminal=true`,
+		trayAttachParams("/Users/me/Library/Application Support/claudew", "feature-auth"))
+	assert.Equal(t, `'/opt/"odd"/claudew'`, trayQuote(`/opt/"odd"/claudew`))
+}
@@ -0,0 +1,166 @@
+package cmd
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/pmossman/claudew/internal/config"
+	"github.com/pmossman/claudew/internal/session"
+	"github.com/pmossman/claudew/internal/template"
+	"github.com/spf13/cobra"
+)
+
+var (
+	uninstallSessions bool
+	uninstallClaudeMd bool
+	uninstallYes      bool
+)
+
+var uninstallCmd = &cobra.Command{
+	Use:   "uninstall",
+	Short: "Remove claudew's shell integration and generated files",
+	Long: `Removes everything claudew installed outside its own data directory:
+
+- The shell integration in ~/.zshrc or ~/.bashrc
+- Completion files for zsh and bash
+- The ~/.claudew directory
+
+--sessions also kills every claude-ws-* tmux session, and --claude-md removes
+the .claude/CLAUDE.md files generated in workspace repos and clones.
+
+Workspace notes and config in ~/.claude-workspaces are kept, as is the claudew
+binary itself; both paths are printed at the end so you can delete them.
+
+Example:
+  claudew uninstall                         # Shell integration and completions
+  claudew uninstall --sessions --claude-md  # Everything`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		home, err := os.UserHomeDir()
+		if err != nil {
+			return fmt.Errorf("failed to get home directory: %w", err)
+		}
+
+		if !uninstallYes {
+			fmt.Println("This will remove the claudew shell integration, completion files and ~/.claudew.")
+			if uninstallSessions {
+				fmt.Println("All claude-ws-* tmux sessions will be killed.")
+			}
+			if uninstallClaudeMd {
+				fmt.Println("Generated .claude/CLAUDE.md files will be deleted.")
+			}
+			fmt.Print("Continue? [y/N]: ")
+			reader := bufio.NewReader(os.Stdin)
+			response, _ := reader.ReadString('\n')
+			response = strings.TrimSpace(strings.ToLower(response))
+			if response != "y" && response != "yes" {
+				fmt.Println("Cancelled.")
+				return nil
+			}
+			fmt.Println()
+		}
+
+		if uninstallSessions {
+			killWorkspaceSessions()
+		}
+
+		if uninstallClaudeMd {
+			removeGeneratedClaudeMds()
+		}
+
+		rcFile, found, err := removeShellIntegration()
+		switch {
+		case err != nil:
+			fmt.Printf("⚠ Shell integration: %v\n", err)
+		case found:
+			fmt.Printf("✓ Removed shell integration from %s\n", rcFile)
+		default:
+			fmt.Printf("  No shell integration in %s\n", rcFile)
+		}
+
+		for _, path := range removeShellFiles(home) {
+			fmt.Printf("✓ Removed %s\n", path)
+		}
+
+		fmt.Println()
+		fmt.Println("Left in place:")
+		if configPath, err := config.GetConfigPath(); err == nil {
+			fmt.Printf("  Workspace data: %s\n", filepath.Dir(configPath))
+		}
+		if exe, err := os.Executable(); err == nil {
+			fmt.Printf("  Binary:         %s\n", exe)
+		}
+		if found {
+			fmt.Println("\nReload your shell:")
+			fmt.Printf("  exec %s\n", filepath.Base(os.Getenv("SHELL")))
+		}
+
+		return nil
+	},
+}
+
+// killWorkspaceSessions kills every claude-ws-* tmux session
+func killWorkspaceSessions() {
+	sessionMgr := session.NewManager()
+	sessions, err := sessionMgr.ListWorkspaceSessions()
+	if err != nil {
+		fmt.Printf("⚠ Could not list tmux sessions: %v\n", err)
+		return
+	}
+	for _, name := range sessions {
+		if err := sessionMgr.Kill(name); err != nil {
+			fmt.Printf("✗ Failed to kill session %s: %v\n", name, err)
+			continue
+		}
+		fmt.Printf("✓ Killed session %s\n", name)
+	}
+}
+
+// removeGeneratedClaudeMds deletes .claude/CLAUDE.md from every workspace's work
+// directory (its subdir, if set) and every registered clone
+func removeGeneratedClaudeMds() {
+	cfg, err := config.Load()
+	if err != nil {
+		fmt.Printf("⚠ Could not load config to find CLAUDE.md files: %v\n", err)
+		return
+	}
+
+	paths := map[string]bool{}
+	for _, ws := range cfg.Workspaces {
+		if ws.GetRepoPath() != "" {
+			paths[ws.GetWorkDir()] = true
+		}
+	}
+	for path := range cfg.Clones {
+		paths[path] = true
+	}
+
+	var dirs []string
+	for path := range paths {
+		dirs = append(dirs, path)
+	}
+	sort.Strings(dirs)
+
+	for _, dir := range dirs {
+		claudeMdPath := filepath.Join(dir, ".claude", "CLAUDE.md")
+		if _, err := os.Stat(claudeMdPath); err != nil {
+			continue
+		}
+		if err := template.RemoveClaudeMd(dir); err != nil {
+			fmt.Printf("✗ %v\n", err)
+			continue
+		}
+		fmt.Printf("✓ Removed %s\n", claudeMdPath)
+	}
+}
+
+func init() {
+	rootCmd.AddCommand(uninstallCmd)
+	uninstallCmd.Flags().BoolVar(&uninstallSessions, "sessions", false, "Also kill all claude-ws-* tmux sessions")
+	uninstallCmd.Flags().BoolVar(&uninstallClaudeMd, "claude-md", false, "Also remove generated .claude/CLAUDE.md files")
+	uninstallCmd.Flags().BoolVarP(&uninstallYes, "yes", "y", false, "Don't ask for confirmation")
+}
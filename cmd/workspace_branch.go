@@ -0,0 +1,61 @@
+package cmd
+
+import (
+	"bufio"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/pmossman/claudew/internal/config"
+	"github.com/pmossman/claudew/internal/git"
+)
+
+// offerBranchCheckout asks to switch the workspace's clone back to the branch the
+// workspace was last on, when the clone is on a different one. Clones with
+// uncommitted changes are left alone.
+func offerBranchCheckout(cfg *config.Config, name string, ws *config.Workspace) {
+	repoPath := ws.GetRepoPath()
+	if ws.Branch == "" || repoPath == "" {
+		return
+	}
+	current, err := git.GetCurrentBranch(repoPath)
+	if err != nil || current == ws.Branch {
+		return
+	}
+
+	if dirty, err := git.HasUncommittedChanges(repoPath); err != nil || dirty {
+		fmt.Printf("Note: '%s' was last on branch %s, but %s is on %s with uncommitted changes\n", name, ws.Branch, repoPath, current)
+		return
+	}
+
+	tty := openTerminal()
+	defer tty.Close()
+	fmt.Fprintf(tty, "'%s' was last on branch %s, but the clone is on %s. Check out %s? [Y/n] ", name, ws.Branch, current, ws.Branch)
+	answer, _ := bufio.NewReader(tty).ReadString('\n')
+	if answer := strings.ToLower(strings.TrimSpace(answer)); answer != "" && answer != "y" && answer != "yes" {
+		return
+	}
+
+	if err := git.Checkout(repoPath, ws.Branch); err != nil {
+		fmt.Fprintf(tty, "Warning: %v\n", err)
+		return
+	}
+	if clone, err := cfg.GetClone(repoPath); err == nil {
+		clone.SetBranch(ws.Branch, time.Now())
+	}
+	fmt.Fprintf(tty, "✓ Checked out %s\n", ws.Branch)
+}
+
+// displayBranch returns the branch to show for a workspace: its clone's while it
+// holds the clone, and otherwise (the clone may belong to another workspace by now)
+// the branch it was last on
+func displayBranch(cfg *config.Config, name string, ws *config.Workspace) string {
+	clone, err := cfg.GetClone(ws.ClonePath)
+	if err == nil && (clone.InUseBy == name || ws.Branch == "") {
+		return clone.CurrentBranch
+	}
+	if ws.Branch != "" {
+		return "last on " + ws.Branch
+	}
+	return ""
+}
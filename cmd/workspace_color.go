@@ -0,0 +1,89 @@
+package cmd
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/pmossman/claudew/internal/config"
+	"github.com/pmossman/claudew/internal/session"
+	"github.com/spf13/cobra"
+)
+
+var colorCmd = &cobra.Command{
+	Use:   "color <workspace-name> [color|auto]",
+	Short: "Show or set a workspace's color",
+	Long: `Every workspace has a color, used for its tmux status line, its name in the
+select menu and in list output, so it is easy to tell which one you are in.
+Unless set here, the color is derived from the workspace name.
+
+Colors: ` + strings.Join(config.WorkspaceColors, ", ") + `
+
+Example:
+  claudew color feature-auth         # Show the color
+  claudew color feature-auth blue    # Set it
+  claudew color feature-auth auto    # Go back to the derived color`,
+	Args: cobra.RangeArgs(1, 2),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		name := args[0]
+
+		// Load config
+		cfg, err := config.Load()
+		if err != nil {
+			return fmt.Errorf("failed to load config: %w", err)
+		}
+
+		ws, err := cfg.GetWorkspace(name)
+		if err != nil {
+			return err
+		}
+
+		if len(args) == 1 {
+			source := "set"
+			if ws.Color == "" {
+				source = "auto"
+			}
+			fmt.Printf("%s (%s)\n", paint(workspaceColor(ws), ws.DisplayColor()), source)
+			return nil
+		}
+
+		color := strings.ToLower(args[1])
+		if color == "auto" {
+			color = ""
+		} else if err := config.ValidateColor(color); err != nil {
+			return err
+		}
+
+		ws.Color = color
+		if err := cfg.Save(); err != nil {
+			return fmt.Errorf("failed to save config: %w", err)
+		}
+
+		// Recolor a running session right away
+		sessionMgr := session.NewManagerForConfig(cfg)
+		sessionName := sessionMgr.GetSessionName(name)
+		if exists, _ := sessionMgr.Exists(sessionName); exists {
+			if err := sessionMgr.SetStatusColor(sessionName, ws.DisplayColor()); err != nil {
+				fmt.Printf("Warning: %v\n", err)
+			}
+		}
+
+		fmt.Printf("✓ Workspace '%s' is now %s\n", name, paint(workspaceColor(ws), ws.DisplayColor()))
+		return nil
+	},
+}
+
+// validColorArgs completes the workspace name, then the color
+func validColorArgs(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+	if len(args) == 0 {
+		return validWorkspaceNamesExcludeArchived(cmd, args, toComplete)
+	}
+	if len(args) == 1 {
+		return append(append([]string(nil), config.WorkspaceColors...), "auto"), cobra.ShellCompDirectiveNoFileComp
+	}
+	return nil, cobra.ShellCompDirectiveNoFileComp
+}
+
+func init() {
+	rootCmd.AddCommand(colorCmd)
+	colorCmd.ValidArgsFunction = validColorArgs
+}
@@ -0,0 +1,174 @@
+package app
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/pmossman/claudew/internal/buildcache"
+	"github.com/pmossman/claudew/internal/config"
+	"github.com/pmossman/claudew/internal/events"
+	"github.com/pmossman/claudew/internal/git"
+	"github.com/pmossman/claudew/internal/template"
+)
+
+// AssignClone points a stopped workspace at a registered clone, freeing the clone
+// it used before and moving CLAUDE.md and settings over. Returns the path of the
+// freed clone, or "" if the workspace had none or keeps the same one.
+func (s *Service) AssignClone(name, clonePath string) (string, error) {
+	ws, err := s.Config.GetWorkspace(name)
+	if err != nil {
+		return "", err
+	}
+	if _, err := s.Config.GetClone(clonePath); err != nil {
+		return "", fmt.Errorf("%w (register it first with: claudew import-clone <remote> %s)", err, clonePath)
+	}
+	if err := s.EnsureNoSession(name); err != nil {
+		return "", err
+	}
+
+	oldWorkDir := ws.GetWorkDir()
+	oldClonePath := ws.GetRepoPath()
+	changed := oldClonePath != clonePath
+
+	// Checked before binding, so a clone the workspace can't use leaves it untouched
+	newWorkDir := clonePath
+	if ws.Subdir != "" {
+		newWorkDir = filepath.Join(clonePath, ws.Subdir)
+	}
+	if _, err := os.Stat(newWorkDir); err != nil {
+		return "", fmt.Errorf("workspace directory does not exist in clone: %s", newWorkDir)
+	}
+	if err := s.Config.BindWorkspaceClone(name, clonePath); err != nil {
+		return "", err
+	}
+
+	if clone, err := s.Config.GetClone(clonePath); err == nil {
+		if branch, err := git.GetCurrentBranch(clonePath); err == nil {
+			clone.SetBranch(branch, time.Now())
+		}
+	}
+
+	// The container mounts the old clone, so recreate it on next start
+	if changed {
+		s.RemoveContainer(name, ws)
+	}
+
+	// Move CLAUDE.md and settings over to the new clone
+	freed := ""
+	if changed && oldClonePath != "" {
+		freed = oldClonePath
+		if err := template.RemoveClaudeMd(oldWorkDir); err != nil {
+			s.Warn(fmt.Errorf("failed to remove CLAUDE.md from old clone: %w", err))
+		}
+	}
+	if err := template.GenerateClaudeMd(name, s.Workspaces.GetPath(name), ws.GetWorkDir()); err != nil {
+		return "", err
+	}
+	if err := template.EnsureGitignore(clonePath); err != nil {
+		return "", err
+	}
+	if err := ApplyPermissions(s.Config, ws); err != nil {
+		return "", err
+	}
+
+	s.LinkRepo(name)
+	if err := s.save(); err != nil {
+		return "", err
+	}
+
+	if freed != "" {
+		s.Emit(events.CloneFreed, name, map[string]string{"clone_path": freed})
+	}
+	s.Emit(events.CloneAssigned, name, map[string]string{"clone_path": clonePath})
+	return freed, nil
+}
+
+// UnassignClone detaches a stopped workspace from its clone and frees the clone,
+// removing CLAUDE.md from it. Returns the path of the freed clone.
+func (s *Service) UnassignClone(name string) (string, error) {
+	ws, err := s.Config.GetWorkspace(name)
+	if err != nil {
+		return "", err
+	}
+	if err := s.EnsureNoSession(name); err != nil {
+		return "", err
+	}
+
+	workDir := ws.GetWorkDir()
+	clonePath, err := s.Config.UnbindWorkspaceClone(name)
+	if err != nil {
+		return "", err
+	}
+
+	if err := template.RemoveClaudeMd(workDir); err != nil {
+		s.Warn(fmt.Errorf("failed to remove CLAUDE.md: %w", err))
+	}
+	s.RemoveContainer(name, ws)
+	s.LinkRepo(name)
+
+	if err := s.save(); err != nil {
+		return "", err
+	}
+
+	s.Emit(events.CloneFreed, name, map[string]string{"clone_path": clonePath})
+	return clonePath, nil
+}
+
+// LinkRepo points the workspace's repo symlink at its current clone, or removes it
+// when the workspace has none. A workspace directory kept inside its repo gets no
+// link, which would loop back into the clone.
+func (s *Service) LinkRepo(name string) {
+	ws, err := s.Config.GetWorkspace(name)
+	if err != nil {
+		return
+	}
+	repoPath := ws.GetRepoPath()
+	if repoPath != "" {
+		if rel, err := filepath.Rel(repoPath, s.Workspaces.GetPath(name)); err == nil && !strings.HasPrefix(rel, "..") {
+			return
+		}
+	}
+	if err := s.Workspaces.LinkRepo(name, repoPath); err != nil {
+		s.Warn(err)
+	}
+}
+
+// RecordCloneUsage notes on the workspace's clone what it was used for, before the
+// workspace gives the clone up. Only marker files are checked, so stopping stays
+// fast; CloneCacheSize measures the caches when they are shown.
+func RecordCloneUsage(cfg *config.Config, ws *config.Workspace) {
+	clone, err := cfg.GetClone(ws.ClonePath)
+	if err != nil {
+		return
+	}
+
+	clone.LastSubdir = ws.Subdir
+	clone.BuildTool = ""
+	if _, tool, found := cloneBuild(clone); found {
+		clone.BuildTool = tool.Name
+	}
+}
+
+// CloneCacheSize returns the approximate size of the build caches of the tool a
+// clone was last used with, walking its cache directories
+func CloneCacheSize(clone *config.Clone) int64 {
+	dir, tool, found := cloneBuild(clone)
+	if !found || tool.Name != clone.BuildTool {
+		return 0
+	}
+	return buildcache.Size(dir, tool)
+}
+
+// cloneBuild finds the build tool of a clone's last subdir (a service in a
+// monorepo) or, failing that, of the repo, and the directory it was found in
+func cloneBuild(clone *config.Clone) (string, buildcache.Tool, bool) {
+	for _, dir := range []string{filepath.Join(clone.Path, clone.LastSubdir), clone.Path} {
+		if tool, found := buildcache.Detect(dir); found {
+			return dir, tool, true
+		}
+	}
+	return "", buildcache.Tool{}, false
+}
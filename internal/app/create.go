@@ -0,0 +1,111 @@
+package app
+
+import (
+	"fmt"
+
+	"github.com/pmossman/claudew/internal/config"
+	"github.com/pmossman/claudew/internal/events"
+	"github.com/pmossman/claudew/internal/template"
+)
+
+// CreateRequest describes a new workspace. Paths are absolute and already
+// validated; picking or cloning the repo is up to the caller.
+type CreateRequest struct {
+	Name        string
+	RepoPath    string            // clone or repo the workspace works in
+	Remote      string            // remote the clone belongs to, "" for a repo given by path
+	Subdir      string            // monorepo sub-path sessions start in
+	Dir         string            // workspace directory override, "" for workspace_dir
+	Permissions string            // permission preset name
+	Issue       string            // issue or ticket URL the workspace was created from
+	Container   *config.Container // run Claude in a container, or nil
+	Summary     string            // initial summary.txt
+	Context     string            // initial context.md
+}
+
+// CreateWorkspace adds a workspace to the config, assigns it its clone, creates its
+// directory and writes CLAUDE.md, permissions and the .gitignore entry into the repo
+func (s *Service) CreateWorkspace(req CreateRequest) (*config.Workspace, error) {
+	if err := s.Config.AddWorkspace(req.Name, req.RepoPath); err != nil {
+		return nil, err
+	}
+
+	ws, _ := s.Config.GetWorkspace(req.Name)
+	ws.ClonePath = req.RepoPath
+	ws.Subdir = req.Subdir
+	ws.Dir = req.Dir
+	ws.Permissions = req.Permissions
+	ws.Issue = req.Issue
+	ws.Container = req.Container
+
+	// Assign the clone to the workspace, also when a registered clone was given by path,
+	// so it isn't handed to another workspace
+	if _, err := s.Config.GetClone(req.RepoPath); err == nil {
+		if err := s.Config.AssignCloneToWorkspace(req.RepoPath, req.Name); err != nil {
+			return nil, err
+		}
+	}
+
+	// Create workspace directory structure, with the repo link to its clone
+	if err := s.Workspaces.Create(req.Name); err != nil {
+		return nil, err
+	}
+	s.LinkRepo(req.Name)
+	if req.Summary != "" {
+		if err := s.Workspaces.SaveSummary(req.Name, req.Summary); err != nil {
+			return nil, fmt.Errorf("failed to write summary: %w", err)
+		}
+	}
+	if req.Context != "" {
+		if err := s.Workspaces.SaveContext(req.Name, req.Context); err != nil {
+			return nil, fmt.Errorf("failed to write context: %w", err)
+		}
+	}
+
+	// Generate CLAUDE.md in repo (or the subtree the workspace focuses on)
+	if err := template.GenerateClaudeMd(req.Name, s.Workspaces.GetPath(req.Name), ws.GetWorkDir()); err != nil {
+		return nil, err
+	}
+
+	// Reserve dev-server ports for this workspace
+	s.allocatePorts(req.Name)
+
+	// Write permission preset to .claude/settings.local.json
+	if err := ApplyPermissions(s.Config, ws); err != nil {
+		return nil, err
+	}
+
+	// Ensure .gitignore has .claude/
+	if err := template.EnsureGitignore(req.RepoPath); err != nil {
+		return nil, err
+	}
+
+	if err := s.save(); err != nil {
+		return nil, err
+	}
+
+	s.Emit(events.WorkspaceCreated, req.Name, map[string]string{"repo_path": req.RepoPath, "remote": req.Remote})
+	if req.Remote != "" {
+		s.Emit(events.CloneAssigned, req.Name, map[string]string{"clone_path": req.RepoPath})
+	}
+	return ws, nil
+}
+
+// ApplyPermissions writes the workspace's resolved permission preset to .claude/settings.local.json
+func ApplyPermissions(cfg *config.Config, ws *config.Workspace) error {
+	perms, err := cfg.ResolvePermissions(ws)
+	if err != nil {
+		return err
+	}
+	if perms == nil {
+		return nil
+	}
+
+	if err := template.WriteSettingsLocal(ws.GetWorkDir(), template.SettingsPermissions{
+		Allow: perms.Allow,
+		Deny:  perms.Deny,
+	}); err != nil {
+		return fmt.Errorf("failed to apply permissions: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,125 @@
+package app
+
+import (
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/pmossman/claudew/internal/config"
+	"github.com/pmossman/claudew/internal/events"
+	"github.com/pmossman/claudew/internal/git"
+	"github.com/pmossman/claudew/internal/template"
+)
+
+// ErrCloneMissing is returned by CheckClone when a workspace's clone directory is
+// gone or no longer a git repository, e.g. after a disk cleanup or a renamed folder
+type ErrCloneMissing struct {
+	Name   string // workspace name
+	Path   string // the clone path the workspace points at
+	Remote string // the clone's registered remote, "" if the clone isn't registered
+	Gone   bool   // the directory doesn't exist at all
+}
+
+func (e *ErrCloneMissing) Error() string {
+	if e.Gone {
+		return fmt.Sprintf("clone of workspace '%s' does not exist: %s", e.Name, e.Path)
+	}
+	return fmt.Sprintf("clone of workspace '%s' is not a git repository: %s", e.Name, e.Path)
+}
+
+// CheckClone verifies that a workspace's clone still exists and is a git repository.
+// A workspace without a clone passes; starting it fails with its own error.
+func CheckClone(cfg *config.Config, name string) error {
+	ws, err := cfg.GetWorkspace(name)
+	if err != nil {
+		return err
+	}
+	repoPath := ws.GetRepoPath()
+	if repoPath == "" {
+		return nil
+	}
+
+	missing := &ErrCloneMissing{Name: name, Path: repoPath}
+	if clone, err := cfg.GetClone(repoPath); err == nil {
+		missing.Remote = clone.RemoteName
+	}
+	if _, err := os.Stat(repoPath); os.IsNotExist(err) {
+		missing.Gone = true
+		return missing
+	}
+	if !git.IsGitRepo(repoPath) {
+		return missing
+	}
+	return nil
+}
+
+// ReplaceClone assigns a stopped workspace another clone in place of a missing one.
+// A registered clone whose directory is gone is forgotten, so it is never handed out
+// again; one that still exists is freed and left for clone-check to report.
+func (s *Service) ReplaceClone(name, clonePath string) error {
+	freed, err := s.AssignClone(name, clonePath)
+	if err != nil {
+		return err
+	}
+	if freed == "" {
+		return nil
+	}
+	if _, err := os.Stat(freed); os.IsNotExist(err) {
+		if err := s.Config.RemoveClone(freed); err != nil {
+			return err
+		}
+		return s.save()
+	}
+	return nil
+}
+
+// RestoreClone clones a workspace's remote again into its clone path, which must no
+// longer exist, and sets the clone up for the workspace as when it was assigned
+func (s *Service) RestoreClone(name string) error {
+	ws, err := s.Config.GetWorkspace(name)
+	if err != nil {
+		return err
+	}
+	clonePath := ws.GetRepoPath()
+	clone, err := s.Config.GetClone(clonePath)
+	if err != nil {
+		return err
+	}
+	remote, err := s.Config.GetRemote(clone.RemoteName)
+	if err != nil {
+		return err
+	}
+	if _, err := os.Stat(clonePath); !os.IsNotExist(err) {
+		return fmt.Errorf("clone path already exists: %s", clonePath)
+	}
+
+	if err := git.Clone(remote.URL, clonePath); err != nil {
+		return err
+	}
+	if branch, err := git.GetCurrentBranch(clonePath); err == nil {
+		clone.SetBranch(branch, time.Now())
+	}
+	clone.BuildTool = ""
+
+	if _, err := os.Stat(ws.GetWorkDir()); err != nil {
+		return fmt.Errorf("workspace directory does not exist in clone: %s", ws.GetWorkDir())
+	}
+	if err := template.GenerateClaudeMd(name, s.Workspaces.GetPath(name), ws.GetWorkDir()); err != nil {
+		return err
+	}
+	if err := template.EnsureGitignore(clonePath); err != nil {
+		return err
+	}
+	if err := ApplyPermissions(s.Config, ws); err != nil {
+		return err
+	}
+
+	// The container mounted the old directory
+	s.RemoveContainer(name, ws)
+
+	if err := s.save(); err != nil {
+		return err
+	}
+	s.Emit(events.CloneCreated, name, map[string]string{"clone_path": clonePath, "remote": remote.Name})
+	return nil
+}
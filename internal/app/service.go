@@ -0,0 +1,78 @@
+package app
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/pmossman/claudew/internal/config"
+	"github.com/pmossman/claudew/internal/events"
+	"github.com/pmossman/claudew/internal/session"
+	"github.com/pmossman/claudew/internal/workspace"
+)
+
+// Service runs workspace operations against a loaded config: each one checks the
+// workspace, does the work across clones, sessions and workspace directories, saves
+// the config and emits its events. Nothing is printed or prompted for, so commands,
+// a daemon or an API can share the same flows and tests can drive them directly.
+type Service struct {
+	Config     *config.Config
+	Workspaces *workspace.Manager
+	Sessions   *session.Manager
+
+	// Warn reports problems that don't fail the operation, e.g. an event that
+	// couldn't be delivered. Warnings go to stderr unless replaced.
+	Warn func(err error)
+
+	// Launch creates the tmux session for a workspace and starts Claude in it.
+	// Sessions are configured by the caller (status line, key bindings, the Claude
+	// command), so StartSession fails until it is set.
+	Launch func(name string, ws *config.Workspace, initialPrompt string) error
+}
+
+// New creates a service for cfg
+func New(cfg *config.Config) *Service {
+	return &Service{
+		Config:     cfg,
+		Workspaces: workspace.NewManagerForConfig(cfg),
+		Sessions:   session.NewManagerForConfig(cfg),
+		Warn: func(err error) {
+			fmt.Fprintf(os.Stderr, "Warning: %v\n", err)
+		},
+	}
+}
+
+// save writes the config to disk
+func (s *Service) save() error {
+	if err := s.Config.Save(); err != nil {
+		return fmt.Errorf("failed to save config: %w", err)
+	}
+	return nil
+}
+
+// Emit sends a lifecycle event to the configured webhook and/or command. Delivery
+// failures are warnings and never fail the operation.
+func (s *Service) Emit(eventType, workspaceName string, data map[string]string) {
+	emitter := events.NewEmitter(s.Config.Settings.EventWebhookURL, s.Config.Settings.EventCommand)
+	if err := emitter.Emit(eventType, workspaceName, data); err != nil {
+		s.Warn(fmt.Errorf("failed to emit %s event: %w", eventType, err))
+	}
+}
+
+// EnsureNoSession returns an error if the workspace has a running tmux session
+func (s *Service) EnsureNoSession(name string) error {
+	exists, err := s.Sessions.Exists(s.Sessions.GetSessionName(name))
+	if err != nil {
+		return fmt.Errorf("failed to check session: %w", err)
+	}
+	if exists {
+		return fmt.Errorf("workspace '%s' has a running session. Stop it first with: claudew stop %s", name, name)
+	}
+	return nil
+}
+
+// allocatePorts reserves a port block for a workspace that doesn't have one yet
+func (s *Service) allocatePorts(name string) {
+	if _, err := s.Config.AllocatePorts(name); err != nil {
+		s.Warn(fmt.Errorf("failed to allocate ports: %w", err))
+	}
+}
@@ -0,0 +1,346 @@
+package app
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/pmossman/claudew/internal/config"
+	"github.com/pmossman/claudew/internal/execx"
+	"github.com/pmossman/claudew/internal/session"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// newTestService returns a service for a fresh config under a temporary HOME, with
+// two registered clones of the remote "repo" and tmux replaced by a FakeTmux
+func newTestService(t *testing.T) (*Service, *session.FakeTmux) {
+	home := t.TempDir()
+	t.Setenv("HOME", home)
+
+	tmux := session.NewFakeTmux()
+	fake := execx.NewFake(execx.OS{})
+	fake.Handle("tmux", tmux.Handle)
+	t.Cleanup(execx.Use(fake))
+
+	cfg := config.NewDefaultConfig()
+	require.NoError(t, cfg.AddRemote("repo", "git@example.com:org/repo.git", filepath.Join(home, "clones")))
+	for _, n := range []string{"1", "2"} {
+		path := filepath.Join(home, "clones", n)
+		require.NoError(t, os.MkdirAll(path, 0755))
+		require.NoError(t, cfg.AddClone(path, "repo"))
+	}
+
+	svc := New(cfg)
+	svc.Warn = func(err error) { t.Errorf("unexpected warning: %v", err) }
+	return svc, tmux
+}
+
+// clonePath returns the path of one of newTestService's clones
+func clonePath(svc *Service, n string) string {
+	return filepath.Join(filepath.Dir(svc.Config.Settings.WorkspaceDir), "clones", n)
+}
+
+// claudeMdPath returns where CLAUDE.md is written in clone n
+func claudeMdPath(svc *Service, n string) string {
+	return filepath.Join(clonePath(svc, n), ".claude", "CLAUDE.md")
+}
+
+// assertRepoLink checks that the workspace's repo link points at target
+func assertRepoLink(t *testing.T, svc *Service, name, target string) {
+	t.Helper()
+	link, err := os.Readlink(svc.Workspaces.RepoLinkPath(name))
+	require.NoError(t, err)
+	assert.Equal(t, target, link)
+}
+
+// createTestWorkspace creates a workspace on clone n
+func createTestWorkspace(t *testing.T, svc *Service, name, n string) *config.Workspace {
+	ws, err := svc.CreateWorkspace(CreateRequest{Name: name, RepoPath: clonePath(svc, n), Remote: "repo"})
+	require.NoError(t, err)
+	return ws
+}
+
+func TestCreateWorkspace(t *testing.T) {
+	svc, _ := newTestService(t)
+
+	ws, err := svc.CreateWorkspace(CreateRequest{
+		Name:     "feature",
+		RepoPath: clonePath(svc, "1"),
+		Remote:   "repo",
+		Summary:  "Fix the login redirect",
+		Context:  "# Context\n",
+	})
+	require.NoError(t, err)
+	assert.Equal(t, clonePath(svc, "1"), ws.ClonePath)
+	assert.NotEmpty(t, ws.Ports)
+
+	clone, err := svc.Config.GetClone(clonePath(svc, "1"))
+	require.NoError(t, err)
+	assert.Equal(t, "feature", clone.InUseBy)
+
+	assert.Equal(t, "Fix the login redirect", svc.Workspaces.GetSummary("feature"))
+	assert.FileExists(t, filepath.Join(svc.Workspaces.GetPath("feature"), "context.md"))
+	assert.FileExists(t, claudeMdPath(svc, "1"))
+	assertRepoLink(t, svc, "feature", clonePath(svc, "1"))
+
+	// The config is saved
+	saved, err := config.Load()
+	require.NoError(t, err)
+	assert.Contains(t, saved.Workspaces, "feature")
+
+	_, err = svc.CreateWorkspace(CreateRequest{Name: "other", RepoPath: clonePath(svc, "1"), Remote: "repo"})
+	assert.Error(t, err, "the clone belongs to another workspace")
+}
+
+func TestStopWorkspace(t *testing.T) {
+	svc, tmux := newTestService(t)
+	createTestWorkspace(t, svc, "feature", "1")
+	require.NoError(t, svc.Sessions.Create(svc.Sessions.GetSessionName("feature"), clonePath(svc, "1")))
+	require.NoError(t, svc.Config.UpdateWorkspaceStatus("feature", config.StatusActive, 0))
+
+	result, err := svc.StopWorkspace("feature", StopOptions{})
+	require.NoError(t, err)
+	assert.True(t, result.Killed)
+	assert.Equal(t, clonePath(svc, "1"), result.FreedClone)
+	assert.Empty(t, tmux.Sessions())
+
+	ws, _ := svc.Config.GetWorkspace("feature")
+	assert.Equal(t, config.StatusIdle, ws.Status)
+	clone, _ := svc.Config.GetClone(clonePath(svc, "1"))
+	assert.Empty(t, clone.InUseBy)
+
+	// Stopping again finds no session
+	result, err = svc.StopWorkspace("feature", StopOptions{})
+	require.NoError(t, err)
+	assert.False(t, result.Killed)
+
+	_, err = svc.StopWorkspace("missing", StopOptions{})
+	assert.Error(t, err)
+}
+
+func TestStopWorkspace_KeepClone(t *testing.T) {
+	svc, _ := newTestService(t)
+	createTestWorkspace(t, svc, "feature", "1")
+
+	result, err := svc.StopWorkspace("feature", StopOptions{KeepClone: true})
+	require.NoError(t, err)
+	assert.Empty(t, result.FreedClone)
+	assert.Equal(t, clonePath(svc, "1"), result.KeptClone)
+
+	clone, _ := svc.Config.GetClone(clonePath(svc, "1"))
+	assert.Equal(t, "feature", clone.InUseBy)
+}
+
+func TestRecordCloneUsage(t *testing.T) {
+	svc, _ := newTestService(t)
+	ws := createTestWorkspace(t, svc, "feature", "1")
+	service := filepath.Join(clonePath(svc, "1"), "services", "api")
+	require.NoError(t, os.MkdirAll(filepath.Join(service, "node_modules"), 0755))
+	require.NoError(t, os.WriteFile(filepath.Join(service, "package.json"), []byte("{}"), 0644))
+	require.NoError(t, os.WriteFile(filepath.Join(service, "node_modules", "dep.js"), make([]byte, 2048), 0644))
+	ws.Subdir = "services/api"
+
+	RecordCloneUsage(svc.Config, ws)
+	clone, _ := svc.Config.GetClone(clonePath(svc, "1"))
+	assert.Equal(t, "services/api", clone.LastSubdir)
+	assert.Equal(t, "npm", clone.BuildTool)
+	assert.Equal(t, int64(2048), CloneCacheSize(clone))
+
+	// A clone whose tool changed since has nothing to report
+	clone.BuildTool = "gradle"
+	assert.Zero(t, CloneCacheSize(clone))
+}
+
+func TestAssignClone(t *testing.T) {
+	svc, _ := newTestService(t)
+	createTestWorkspace(t, svc, "feature", "1")
+
+	freed, err := svc.AssignClone("feature", clonePath(svc, "2"))
+	require.NoError(t, err)
+	assert.Equal(t, clonePath(svc, "1"), freed)
+	assert.NoFileExists(t, claudeMdPath(svc, "1"))
+	assert.FileExists(t, claudeMdPath(svc, "2"))
+	assertRepoLink(t, svc, "feature", clonePath(svc, "2"))
+
+	old, _ := svc.Config.GetClone(clonePath(svc, "1"))
+	assert.Empty(t, old.InUseBy)
+	assigned, _ := svc.Config.GetClone(clonePath(svc, "2"))
+	assert.Equal(t, "feature", assigned.InUseBy)
+
+	// Reassigning the same clone frees nothing
+	freed, err = svc.AssignClone("feature", clonePath(svc, "2"))
+	require.NoError(t, err)
+	assert.Empty(t, freed)
+
+	_, err = svc.AssignClone("feature", "/not/registered")
+	assert.Error(t, err)
+}
+
+func TestAssignClone_MissingWorkDirLeavesWorkspaceBound(t *testing.T) {
+	svc, _ := newTestService(t)
+	createTestWorkspace(t, svc, "feature", "1")
+	ws, _ := svc.Config.GetWorkspace("feature")
+	ws.Subdir = "services/api"
+
+	_, err := svc.AssignClone("feature", clonePath(svc, "2"))
+	assert.ErrorContains(t, err, "does not exist in clone")
+
+	assert.Equal(t, clonePath(svc, "1"), ws.GetRepoPath())
+	current, _ := svc.Config.GetClone(clonePath(svc, "1"))
+	assert.Equal(t, "feature", current.InUseBy)
+	target, _ := svc.Config.GetClone(clonePath(svc, "2"))
+	assert.Empty(t, target.InUseBy)
+}
+
+func TestAssignClone_RefusesRunningSession(t *testing.T) {
+	svc, _ := newTestService(t)
+	createTestWorkspace(t, svc, "feature", "1")
+	require.NoError(t, svc.Sessions.Create(svc.Sessions.GetSessionName("feature"), clonePath(svc, "1")))
+
+	_, err := svc.AssignClone("feature", clonePath(svc, "2"))
+	assert.ErrorContains(t, err, "running session")
+	_, err = svc.UnassignClone("feature")
+	assert.ErrorContains(t, err, "running session")
+}
+
+func TestUnassignClone(t *testing.T) {
+	svc, _ := newTestService(t)
+	createTestWorkspace(t, svc, "feature", "1")
+
+	freed, err := svc.UnassignClone("feature")
+	require.NoError(t, err)
+	assert.Equal(t, clonePath(svc, "1"), freed)
+	assert.NoFileExists(t, claudeMdPath(svc, "1"))
+
+	ws, _ := svc.Config.GetWorkspace("feature")
+	assert.Empty(t, ws.GetRepoPath())
+	_, err = os.Lstat(svc.Workspaces.RepoLinkPath("feature"))
+	assert.True(t, os.IsNotExist(err), "the repo link is removed")
+
+	_, err = svc.UnassignClone("feature")
+	assert.Error(t, err, "nothing left to unassign")
+}
+
+func TestStartSession(t *testing.T) {
+	svc, _ := newTestService(t)
+	svc.Config.Settings.SessionNameSuffix = config.SessionNameSuffixProject
+	createTestWorkspace(t, svc, "feature", "1")
+
+	_, err := svc.StartSession("feature", StartOptions{})
+	assert.Error(t, err, "no launcher")
+
+	var launched, prompt string
+	svc.Launch = func(name string, ws *config.Workspace, initialPrompt string) error {
+		launched, prompt = name, initialPrompt
+		return svc.Sessions.Create(svc.Sessions.GetSessionName(name), ws.GetRepoPath())
+	}
+	sessionName, err := svc.StartSession("feature", StartOptions{InitialPrompt: "continue"})
+	require.NoError(t, err)
+	assert.Equal(t, "claude-ws-feature-repo", sessionName)
+	assert.Equal(t, "feature", launched)
+	assert.Equal(t, "continue", prompt)
+}
+
+func TestNewSessionName(t *testing.T) {
+	svc, _ := newTestService(t)
+	createTestWorkspace(t, svc, "feature", "1")
+	ws, _ := svc.Config.GetWorkspace("feature")
+
+	// Without a suffix setting the plain name is used
+	assert.Empty(t, svc.NewSessionName("feature", ws))
+
+	svc.Config.Settings.SessionNameSuffix = config.SessionNameSuffixSummary
+	require.NoError(t, svc.Workspaces.SaveSummary("feature", "Fix login"))
+	assert.Equal(t, "claude-ws-feature-fix-login", svc.NewSessionName("feature", ws))
+
+	// A name another workspace's session already has falls back to the plain name
+	createTestWorkspace(t, svc, "other", "2")
+	other, _ := svc.Config.GetWorkspace("other")
+	other.SessionName = "claude-ws-feature-fix-login"
+	assert.Empty(t, svc.NewSessionName("feature", ws))
+
+	// So does another workspace's plain name
+	require.NoError(t, svc.Workspaces.SaveSummary("feature", "2"))
+	require.NoError(t, svc.Config.AddWorkspace("feature-2", ""))
+	assert.Empty(t, svc.NewSessionName("feature", ws))
+
+	// A taken plain name gets a number
+	other.SessionName = "claude-ws-feature"
+	assert.Equal(t, "claude-ws-feature-3", svc.NewSessionName("feature", ws))
+	require.NoError(t, svc.Sessions.Create("claude-ws-feature-3", "/tmp"))
+	assert.Equal(t, "claude-ws-feature-4", svc.NewSessionName("feature", ws))
+}
+
+func TestCheckClone(t *testing.T) {
+	svc, _ := newTestService(t)
+	createTestWorkspace(t, svc, "feature", "1")
+
+	var missing *ErrCloneMissing
+	require.ErrorAs(t, CheckClone(svc.Config, "feature"), &missing)
+	assert.False(t, missing.Gone, "the directory exists but isn't a repository")
+	assert.Equal(t, "repo", missing.Remote)
+
+	require.NoError(t, execx.Command("git", "init", "-q", clonePath(svc, "1")).Run())
+	assert.NoError(t, CheckClone(svc.Config, "feature"))
+
+	require.NoError(t, os.RemoveAll(clonePath(svc, "1")))
+	require.ErrorAs(t, CheckClone(svc.Config, "feature"), &missing)
+	assert.True(t, missing.Gone)
+
+	_, err := svc.UnassignClone("feature")
+	require.NoError(t, err)
+	assert.NoError(t, CheckClone(svc.Config, "feature"), "no clone to check")
+}
+
+func TestReplaceClone(t *testing.T) {
+	svc, _ := newTestService(t)
+	createTestWorkspace(t, svc, "feature", "1")
+	require.NoError(t, os.RemoveAll(clonePath(svc, "1")))
+
+	require.NoError(t, svc.ReplaceClone("feature", clonePath(svc, "2")))
+	ws, _ := svc.Config.GetWorkspace("feature")
+	assert.Equal(t, clonePath(svc, "2"), ws.ClonePath)
+	assert.FileExists(t, claudeMdPath(svc, "2"))
+
+	// The vanished clone is forgotten rather than freed
+	_, err := svc.Config.GetClone(clonePath(svc, "1"))
+	assert.Error(t, err)
+}
+
+func TestRestoreClone(t *testing.T) {
+	svc, _ := newTestService(t)
+	origin := filepath.Join(t.TempDir(), "origin")
+	require.NoError(t, execx.Command("git", "init", "-q", origin).Run())
+	require.NoError(t, execx.Command("git", "-C", origin, "-c", "user.name=test", "-c", "user.email=test@example.com",
+		"commit", "-q", "--allow-empty", "-m", "initial").Run())
+	remote, err := svc.Config.GetRemote("repo")
+	require.NoError(t, err)
+	remote.URL = origin
+
+	createTestWorkspace(t, svc, "feature", "1")
+	assert.Error(t, svc.RestoreClone("feature"), "the clone path still exists")
+
+	require.NoError(t, os.RemoveAll(clonePath(svc, "1")))
+	require.NoError(t, svc.RestoreClone("feature"))
+	assert.NoError(t, CheckClone(svc.Config, "feature"))
+	assert.FileExists(t, claudeMdPath(svc, "1"))
+
+	clone, _ := svc.Config.GetClone(clonePath(svc, "1"))
+	assert.Equal(t, "feature", clone.InUseBy)
+}
+
+func TestLinkRepo_WorkspaceDirInRepo(t *testing.T) {
+	svc, _ := newTestService(t)
+	_, err := svc.CreateWorkspace(CreateRequest{
+		Name:     "feature",
+		RepoPath: clonePath(svc, "1"),
+		Remote:   "repo",
+		Dir:      filepath.Join(clonePath(svc, "1"), "notes"),
+	})
+	require.NoError(t, err)
+
+	// A link inside the clone would point back at the clone itself
+	_, err = os.Lstat(svc.Workspaces.RepoLinkPath("feature"))
+	assert.True(t, os.IsNotExist(err))
+}
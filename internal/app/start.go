@@ -0,0 +1,96 @@
+package app
+
+import (
+	"fmt"
+	"path/filepath"
+
+	"github.com/pmossman/claudew/internal/config"
+	"github.com/pmossman/claudew/internal/events"
+	"github.com/pmossman/claudew/internal/session"
+)
+
+// StartOptions describe a new session
+type StartOptions struct {
+	InitialPrompt string // first message to Claude, if any
+	Reason        string // why the session was started, reported with the event
+}
+
+// StartSession reserves ports for a workspace, names its new session and creates it
+// with Launch. Returns the session name. The caller checks no session is running
+// and saves the config, which remembers the name while the session runs.
+func (s *Service) StartSession(name string, opts StartOptions) (string, error) {
+	ws, err := s.Config.GetWorkspace(name)
+	if err != nil {
+		return "", err
+	}
+	if ws.Status == config.StatusArchived {
+		return "", fmt.Errorf("workspace '%s' is archived", name)
+	}
+	if s.Launch == nil {
+		return "", fmt.Errorf("no session launcher configured")
+	}
+
+	s.allocatePorts(name)
+	s.LinkRepo(name) // workspaces created before repo links get theirs here
+	ws.SessionName = s.NewSessionName(name, ws)
+	if err := s.Launch(name, ws, opts.InitialPrompt); err != nil {
+		return "", err
+	}
+
+	sessionName := s.Sessions.GetSessionName(name)
+	data := map[string]string{"session": sessionName}
+	if opts.Reason != "" {
+		data["reason"] = opts.Reason
+	}
+	s.Emit(events.SessionStarted, name, data)
+	return sessionName, nil
+}
+
+// NewSessionName names a workspace's new tmux session, with the suffix chosen by the
+// session_name_suffix setting. Returns "" for the plain claude-ws-<name>. A name
+// another workspace uses or could use, or a tmux session already has, is never
+// returned: the name with a suffix falls back to the plain name, and a taken plain
+// name gets the first free number, skipping e.g. claude-ws-foo-2 if "foo-2" exists.
+func (s *Service) NewSessionName(name string, ws *config.Workspace) string {
+	var suffix string
+	switch s.Config.Settings.SessionNameSuffix {
+	case config.SessionNameSuffixSummary:
+		if summary := s.Workspaces.GetSummary(name); summary != "(no summary)" {
+			suffix = summary
+		}
+	case config.SessionNameSuffixProject:
+		if clone, err := s.Config.GetClone(ws.ClonePath); err == nil {
+			suffix = clone.RemoteName
+		} else if repoPath := ws.GetRepoPath(); repoPath != "" {
+			suffix = filepath.Base(repoPath)
+		}
+	}
+
+	plain := session.SessionPrefix + name
+	if sessionName := session.SessionName(name, suffix); sessionName != plain && !s.sessionNameTaken(name, sessionName) {
+		return sessionName
+	}
+	sessionName := plain
+	for n := 2; s.sessionNameTaken(name, sessionName); n++ {
+		sessionName = fmt.Sprintf("%s-%d", plain, n)
+	}
+	if sessionName == plain {
+		return ""
+	}
+	return sessionName
+}
+
+// sessionNameTaken reports whether a session name belongs to a workspace other than
+// name, as its current session name or its plain one, or to a running tmux session
+func (s *Service) sessionNameTaken(name, sessionName string) bool {
+	for other := range s.Config.Workspaces {
+		if other == name {
+			continue
+		}
+		if s.Sessions.GetSessionName(other) == sessionName || session.SessionPrefix+other == sessionName {
+			return true
+		}
+	}
+	exists, err := s.Sessions.Exists(sessionName)
+	return err == nil && exists
+}
@@ -0,0 +1,163 @@
+package app
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/pmossman/claudew/internal/config"
+	"github.com/pmossman/claudew/internal/container"
+	"github.com/pmossman/claudew/internal/events"
+	"github.com/pmossman/claudew/internal/git"
+	"github.com/pmossman/claudew/internal/workspace"
+)
+
+// StopOptions control what StopWorkspace does with the clone
+type StopOptions struct {
+	KeepClone bool // keep the clone reserved for the workspace instead of freeing it
+	WIP       bool // commit uncommitted changes to the wip branch, as the wip_commit setting does
+}
+
+// StopResult is what StopWorkspace did
+type StopResult struct {
+	Session    string // tmux session name
+	Killed     bool   // whether the session was running
+	WIPCommit  string // commit uncommitted changes were saved to on WIPBranch, if any
+	FreedClone string // clone freed for other workspaces, if any
+	KeptClone  string // clone kept with KeepClone, if any
+}
+
+// StopWorkspace kills a workspace's tmux session and container, snapshots its branch
+// and notes, frees its clone unless opts.KeepClone is set and marks it idle. A
+// session running in the foreground is refused, as killing it would lose Claude's state.
+func (s *Service) StopWorkspace(name string, opts StopOptions) (*StopResult, error) {
+	ws, err := s.Config.GetWorkspace(name)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &StopResult{Session: s.Sessions.GetSessionName(name)}
+	exists, err := s.Sessions.Exists(result.Session)
+	if err != nil {
+		return nil, fmt.Errorf("failed to check session: %w", err)
+	}
+	if exists {
+		if err := s.Sessions.Kill(result.Session); err != nil {
+			return nil, fmt.Errorf("failed to kill session: %w", err)
+		}
+		result.Killed = true
+	} else if pid, ok := ForegroundPID(s.Config, name); ok {
+		return nil, fmt.Errorf("workspace '%s' is running in the foreground (PID %d); exit Claude in that terminal first", name, pid)
+	}
+
+	s.StopContainer(name, ws)
+	if commit, err := SaveWIP(s.Config, name, ws, opts.WIP); err != nil {
+		s.Warn(fmt.Errorf("failed to save uncommitted changes: %w", err))
+	} else {
+		result.WIPCommit = commit
+	}
+	RecordBranch(s.Config, ws)
+	if err := s.Workspaces.RecordHistory(name, "snapshot on stop"); err != nil {
+		s.Warn(fmt.Errorf("failed to record notes history: %w", err))
+	}
+
+	// Free the clone if workspace is using one
+	if ws.ClonePath != "" && opts.KeepClone {
+		result.KeptClone = ws.ClonePath
+	} else if ws.ClonePath != "" {
+		if _, err := s.Config.GetClone(ws.ClonePath); err == nil {
+			RecordCloneUsage(s.Config, ws)
+			if err := s.Config.FreeClone(ws.ClonePath); err != nil {
+				return nil, fmt.Errorf("failed to free clone: %w", err)
+			}
+			result.FreedClone = ws.ClonePath
+		}
+	}
+
+	// Spawned agents ended with the session
+	ws.Agents = nil
+
+	if err := s.Config.UpdateWorkspaceStatus(name, config.StatusIdle, 0); err != nil {
+		return nil, fmt.Errorf("failed to update workspace status: %w", err)
+	}
+	if err := s.save(); err != nil {
+		return nil, err
+	}
+
+	s.Emit(events.SessionStopped, name, map[string]string{"session": result.Session})
+	if result.FreedClone != "" {
+		s.Emit(events.CloneFreed, name, map[string]string{"clone_path": result.FreedClone})
+	}
+	return result, nil
+}
+
+// ForegroundPID returns the PID of Claude if the workspace is running in the
+// foreground, which is the case while its start process still holds the lock
+func ForegroundPID(cfg *config.Config, name string) (int, bool) {
+	ws, err := cfg.GetWorkspace(name)
+	if err != nil || ws.Status != config.StatusActive {
+		return 0, false
+	}
+	state, err := workspace.NewManagerForConfig(cfg).GetLockState(name)
+	if err != nil || !state.Held || !state.Managed {
+		return 0, false
+	}
+	return ws.SessionPID, true
+}
+
+// RecordBranch remembers the branch the workspace's clone is on, so it can be
+// restored after the clone is freed and the workspace moves to another. The
+// clone's cached branch is refreshed along the way.
+func RecordBranch(cfg *config.Config, ws *config.Workspace) {
+	repoPath := ws.GetRepoPath()
+	if repoPath == "" {
+		return
+	}
+	branch, err := git.GetCurrentBranch(repoPath)
+	if err != nil || branch == "HEAD" {
+		return // Detached HEAD or not a repo; keep the last known branch
+	}
+	ws.Branch = branch
+	if clone, err := cfg.GetClone(repoPath); err == nil {
+		clone.SetBranch(branch, time.Now())
+	}
+}
+
+// WIPBranch returns the local branch uncommitted work of a workspace is saved to
+func WIPBranch(name string) string {
+	return "wip/" + name
+}
+
+// SaveWIP snapshots a workspace's uncommitted changes to its wip branch when enabled
+// by force or the wip_commit setting, and returns the commit, or "" if nothing was
+// saved. The branch is local only and never pushed.
+func SaveWIP(cfg *config.Config, name string, ws *config.Workspace, force bool) (string, error) {
+	if !force && !cfg.Settings.WipCommit {
+		return "", nil
+	}
+	if ws.RepoPath == "" || !git.IsGitRepo(ws.RepoPath) {
+		return "", nil
+	}
+	return git.SnapshotToBranch(ws.RepoPath, WIPBranch(name), fmt.Sprintf("WIP: %s (saved by claudew)", name))
+}
+
+// StopContainer stops the workspace's container, if it runs in one
+func (s *Service) StopContainer(name string, ws *config.Workspace) {
+	if ws.Container == nil {
+		return
+	}
+	containerMgr := container.NewManager()
+	if err := containerMgr.Stop(containerMgr.GetContainerName(name)); err != nil {
+		s.Warn(err)
+	}
+}
+
+// RemoveContainer deletes the workspace's container so it is recreated on next start
+func (s *Service) RemoveContainer(name string, ws *config.Workspace) {
+	if ws.Container == nil {
+		return
+	}
+	containerMgr := container.NewManager()
+	if err := containerMgr.Remove(containerMgr.GetContainerName(name)); err != nil {
+		s.Warn(err)
+	}
+}
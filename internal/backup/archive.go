@@ -0,0 +1,188 @@
+package backup
+
+import (
+	"archive/tar"
+	"compress/gzip"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/pmossman/claudew/internal/workspace"
+)
+
+// MetadataFile holds the workspace's config entry at the root of a backup, so a
+// workspace can be restored on a machine that doesn't know it
+const MetadataFile = ".claudew-workspace.json"
+
+// skipped are session state files that make no sense on restore
+var skipped = map[string]bool{
+	".lock":          true,
+	".context-usage": true,
+	".claude-exit":   true,
+}
+
+// Pack writes a workspace directory as a gzipped tar to w, with metadata stored as
+// MetadataFile. Session state files are left out, and so is the environment file,
+// which often holds secrets, unless includeEnv is set.
+func Pack(dir string, metadata []byte, w io.Writer, includeEnv bool) error {
+	gz := gzip.NewWriter(w)
+	tw := tar.NewWriter(gz)
+
+	if err := tw.WriteHeader(&tar.Header{
+		Name:    MetadataFile,
+		Mode:    0644,
+		Size:    int64(len(metadata)),
+		ModTime: time.Now(),
+	}); err != nil {
+		return fmt.Errorf("failed to write backup: %w", err)
+	}
+	if _, err := tw.Write(metadata); err != nil {
+		return fmt.Errorf("failed to write backup: %w", err)
+	}
+
+	err := filepath.WalkDir(dir, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		rel, err := filepath.Rel(dir, path)
+		if err != nil || rel == "." || rel == MetadataFile {
+			return err
+		}
+		if skipped[d.Name()] || (rel == workspace.EnvFileName && !includeEnv) {
+			return nil
+		}
+		if !d.IsDir() && !d.Type().IsRegular() {
+			return nil // Symlinks and devices aren't notes
+		}
+
+		info, err := d.Info()
+		if err != nil {
+			return err
+		}
+		header, err := tar.FileInfoHeader(info, "")
+		if err != nil {
+			return err
+		}
+		header.Name = filepath.ToSlash(rel)
+		if d.IsDir() {
+			header.Name += "/"
+		}
+		if err := tw.WriteHeader(header); err != nil {
+			return err
+		}
+		if d.IsDir() {
+			return nil
+		}
+
+		f, err := os.Open(path)
+		if err != nil {
+			return err
+		}
+		defer f.Close()
+		_, err = io.Copy(tw, f)
+		return err
+	})
+	if err != nil {
+		return fmt.Errorf("failed to write backup: %w", err)
+	}
+
+	if err := tw.Close(); err != nil {
+		return fmt.Errorf("failed to write backup: %w", err)
+	}
+	return gz.Close()
+}
+
+// Unpack extracts a backup written by Pack into dir and returns its metadata.
+// Entries that would land outside dir are rejected.
+func Unpack(r io.Reader, dir string) ([]byte, error) {
+	gz, err := gzip.NewReader(r)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read backup: %w", err)
+	}
+	defer gz.Close()
+
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return nil, fmt.Errorf("failed to create %s: %w", dir, err)
+	}
+
+	var metadata []byte
+	tr := tar.NewReader(gz)
+	for {
+		header, err := tr.Next()
+		if err == io.EOF {
+			return metadata, nil
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to read backup: %w", err)
+		}
+
+		name := filepath.FromSlash(strings.TrimSuffix(header.Name, "/"))
+		if name == MetadataFile {
+			if metadata, err = io.ReadAll(tr); err != nil {
+				return nil, fmt.Errorf("failed to read backup: %w", err)
+			}
+			continue
+		}
+		target := filepath.Join(dir, name)
+		if rel, err := filepath.Rel(dir, target); err != nil || rel == "." || strings.HasPrefix(rel, "..") {
+			return nil, fmt.Errorf("backup contains an invalid path: %s", header.Name)
+		}
+
+		switch header.Typeflag {
+		case tar.TypeDir:
+			if err := os.MkdirAll(target, 0755); err != nil {
+				return nil, fmt.Errorf("failed to create %s: %w", target, err)
+			}
+		case tar.TypeReg:
+			if err := writeFile(target, tr, header); err != nil {
+				return nil, err
+			}
+		}
+	}
+}
+
+// writeFile writes one file of a backup, keeping its modification time
+func writeFile(path string, r io.Reader, header *tar.Header) error {
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return fmt.Errorf("failed to create %s: %w", filepath.Dir(path), err)
+	}
+	f, err := os.OpenFile(path, os.O_WRONLY|os.O_CREATE|os.O_TRUNC, os.FileMode(header.Mode)&0777)
+	if err != nil {
+		return fmt.Errorf("failed to write %s: %w", path, err)
+	}
+	if _, err := io.Copy(f, r); err != nil {
+		f.Close()
+		return fmt.Errorf("failed to write %s: %w", path, err)
+	}
+	if err := f.Close(); err != nil {
+		return fmt.Errorf("failed to write %s: %w", path, err)
+	}
+	return os.Chtimes(path, header.ModTime, header.ModTime)
+}
+
+// LatestChange returns the newest modification time of the files under dir, ignoring
+// session state, so unchanged workspaces can be skipped
+func LatestChange(dir string) (time.Time, error) {
+	var latest time.Time
+	err := filepath.WalkDir(dir, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if skipped[d.Name()] {
+			return nil
+		}
+		info, err := d.Info()
+		if err != nil {
+			return err
+		}
+		if info.ModTime().After(latest) {
+			latest = info.ModTime()
+		}
+		return nil
+	})
+	return latest, err
+}
@@ -0,0 +1,83 @@
+package backup
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/pmossman/claudew/internal/workspace"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPackUnpack(t *testing.T) {
+	src := t.TempDir()
+	require.NoError(t, os.MkdirAll(filepath.Join(src, "research"), 0755))
+	require.NoError(t, os.WriteFile(filepath.Join(src, "context.md"), []byte("Working on login\n"), 0644))
+	require.NoError(t, os.WriteFile(filepath.Join(src, "research", "api.md"), []byte("notes"), 0644))
+	require.NoError(t, os.WriteFile(filepath.Join(src, ".lock"), []byte("123"), 0644))
+	require.NoError(t, os.WriteFile(filepath.Join(src, workspace.EnvFileName), []byte("TOKEN=secret\n"), 0600))
+	old := time.Date(2026, 3, 1, 9, 0, 0, 0, time.UTC)
+	require.NoError(t, os.Chtimes(filepath.Join(src, "context.md"), old, old))
+
+	var buf bytes.Buffer
+	require.NoError(t, Pack(src, []byte(`{"name":"auth"}`), &buf, false))
+
+	dst := filepath.Join(t.TempDir(), "auth")
+	metadata, err := Unpack(&buf, dst)
+	require.NoError(t, err)
+	assert.Equal(t, `{"name":"auth"}`, string(metadata))
+
+	data, err := os.ReadFile(filepath.Join(dst, "context.md"))
+	require.NoError(t, err)
+	assert.Equal(t, "Working on login\n", string(data))
+	info, err := os.Stat(filepath.Join(dst, "context.md"))
+	require.NoError(t, err)
+	assert.True(t, info.ModTime().Equal(old))
+	assert.FileExists(t, filepath.Join(dst, "research", "api.md"))
+	assert.NoFileExists(t, filepath.Join(dst, ".lock"))
+	assert.NoFileExists(t, filepath.Join(dst, MetadataFile))
+	assert.NoFileExists(t, filepath.Join(dst, workspace.EnvFileName))
+
+	// The environment file is only packed when asked for
+	buf.Reset()
+	require.NoError(t, Pack(src, []byte(`{"name":"auth"}`), &buf, true))
+	dst = filepath.Join(t.TempDir(), "auth")
+	_, err = Unpack(&buf, dst)
+	require.NoError(t, err)
+	assert.FileExists(t, filepath.Join(dst, workspace.EnvFileName))
+}
+
+func TestUnpack_RejectsEscapingPaths(t *testing.T) {
+	var buf bytes.Buffer
+	gz := gzip.NewWriter(&buf)
+	tw := tar.NewWriter(gz)
+	require.NoError(t, tw.WriteHeader(&tar.Header{Name: "../escape.md", Mode: 0644, Size: 1, Typeflag: tar.TypeReg}))
+	_, err := tw.Write([]byte("x"))
+	require.NoError(t, err)
+	require.NoError(t, tw.Close())
+	require.NoError(t, gz.Close())
+
+	dir := t.TempDir()
+	_, err = Unpack(&buf, filepath.Join(dir, "ws"))
+	assert.Error(t, err)
+	assert.NoFileExists(t, filepath.Join(dir, "escape.md"))
+}
+
+func TestLatestChange(t *testing.T) {
+	dir := t.TempDir()
+	old := time.Date(2026, 3, 1, 9, 0, 0, 0, time.UTC)
+	newer := old.Add(time.Hour)
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "context.md"), []byte("x"), 0644))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, ".lock"), []byte("1"), 0644))
+	require.NoError(t, os.Chtimes(filepath.Join(dir, "context.md"), newer, newer))
+	require.NoError(t, os.Chtimes(dir, old, old))
+
+	latest, err := LatestChange(dir)
+	require.NoError(t, err)
+	assert.True(t, latest.Equal(newer), latest)
+}
@@ -0,0 +1,206 @@
+package backup
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/pmossman/claudew/internal/execx"
+)
+
+// Target kinds, by the prefix of the backup_target setting
+const (
+	KindS3     = "s3"     // s3://bucket/prefix, via the aws CLI
+	KindGCS    = "gs"     // gs://bucket/prefix, via gcloud storage
+	KindRclone = "rclone" // rclone:remote:path, via rclone
+	KindDir    = "dir"    // an absolute path, e.g. a mounted NAS or synced folder
+)
+
+// Target is where backups are uploaded
+type Target struct {
+	Kind string
+	Base string // location objects are put under, in the form the tool expects
+}
+
+// ParseTarget parses the backup_target setting
+func ParseTarget(s string) (Target, error) {
+	s = strings.TrimRight(strings.TrimSpace(s), "/")
+	switch {
+	case s == "":
+		return Target{}, fmt.Errorf("no backup target configured; set \"backup_target\" to s3://bucket/prefix, gs://bucket/prefix, rclone:remote:path or a directory")
+	case strings.HasPrefix(s, "s3://") && len(s) > len("s3://"):
+		return Target{Kind: KindS3, Base: s}, nil
+	case strings.HasPrefix(s, "gs://") && len(s) > len("gs://"):
+		return Target{Kind: KindGCS, Base: s}, nil
+	case strings.HasPrefix(s, "rclone:") && strings.Contains(strings.TrimPrefix(s, "rclone:"), ":"):
+		return Target{Kind: KindRclone, Base: strings.TrimPrefix(s, "rclone:")}, nil
+	case filepath.IsAbs(s):
+		return Target{Kind: KindDir, Base: s}, nil
+	}
+	return Target{}, fmt.Errorf("unsupported backup target '%s': use s3://bucket/prefix, gs://bucket/prefix, rclone:remote:path or an absolute directory", s)
+}
+
+// String returns the target as configured
+func (t Target) String() string {
+	if t.Kind == KindRclone {
+		return "rclone:" + t.Base
+	}
+	return t.Base
+}
+
+// ObjectName returns the name a workspace's backup is stored under
+func ObjectName(workspace string) string {
+	return workspace + ".tar.gz"
+}
+
+// location returns where an object lives on the target
+func (t Target) location(object string) string {
+	if t.Kind == KindDir {
+		return filepath.Join(t.Base, object)
+	}
+	if t.Kind == KindRclone && strings.HasSuffix(t.Base, ":") {
+		return t.Base + object // remote root, e.g. "drive:"
+	}
+	return t.Base + "/" + path.Clean(object)
+}
+
+// Upload copies a local file to the target, replacing any object of the same name
+func (t Target) Upload(localPath, object string) error {
+	dest := t.location(object)
+	var err error
+	switch t.Kind {
+	case KindDir:
+		err = copyFile(localPath, dest)
+	case KindS3:
+		err = run("aws", "s3", "cp", "--only-show-errors", localPath, dest)
+	case KindGCS:
+		err = run("gcloud", "storage", "cp", localPath, dest)
+	case KindRclone:
+		err = run("rclone", "copyto", localPath, dest)
+	}
+	if err != nil {
+		return fmt.Errorf("failed to upload %s: %w", object, err)
+	}
+	return nil
+}
+
+// Download copies an object from the target to a local file
+func (t Target) Download(object, localPath string) error {
+	src := t.location(object)
+	var err error
+	switch t.Kind {
+	case KindDir:
+		err = copyFile(src, localPath)
+	case KindS3:
+		err = run("aws", "s3", "cp", "--only-show-errors", src, localPath)
+	case KindGCS:
+		err = run("gcloud", "storage", "cp", src, localPath)
+	case KindRclone:
+		err = run("rclone", "copyto", src, localPath)
+	}
+	if err != nil {
+		return fmt.Errorf("failed to download %s: %w", object, err)
+	}
+	return nil
+}
+
+// Size returns the size in bytes of an object on the target, to verify an upload
+func (t Target) Size(object string) (int64, error) {
+	loc := t.location(object)
+	switch t.Kind {
+	case KindDir:
+		info, err := os.Stat(loc)
+		if err != nil {
+			return 0, fmt.Errorf("failed to check %s: %w", object, err)
+		}
+		return info.Size(), nil
+
+	case KindS3:
+		// "2026-03-01 09:30:00       1234 name.tar.gz"
+		out, err := output("aws", "s3", "ls", loc)
+		if err != nil {
+			return 0, fmt.Errorf("failed to check %s: %w", object, err)
+		}
+		for _, line := range strings.Split(out, "\n") {
+			fields := strings.Fields(line)
+			if len(fields) == 4 && fields[3] == path.Base(object) {
+				return strconv.ParseInt(fields[2], 10, 64)
+			}
+		}
+		return 0, fmt.Errorf("%s not found on %s", object, t)
+
+	case KindGCS:
+		// "      1234  2026-03-01T09:30:00Z  gs://bucket/prefix/name.tar.gz", then a TOTAL line
+		out, err := output("gcloud", "storage", "ls", "-l", loc)
+		if err != nil {
+			return 0, fmt.Errorf("failed to check %s: %w", object, err)
+		}
+		for _, line := range strings.Split(out, "\n") {
+			fields := strings.Fields(line)
+			if len(fields) == 3 && fields[2] == loc {
+				return strconv.ParseInt(fields[0], 10, 64)
+			}
+		}
+		return 0, fmt.Errorf("%s not found on %s", object, t)
+
+	case KindRclone:
+		out, err := output("rclone", "size", "--json", loc)
+		if err != nil {
+			return 0, fmt.Errorf("failed to check %s: %w", object, err)
+		}
+		var size struct {
+			Count int   `json:"count"`
+			Bytes int64 `json:"bytes"`
+		}
+		if err := json.Unmarshal([]byte(out), &size); err != nil {
+			return 0, fmt.Errorf("failed to parse rclone size: %w", err)
+		}
+		if size.Count == 0 {
+			return 0, fmt.Errorf("%s not found on %s", object, t)
+		}
+		return size.Bytes, nil
+	}
+	return 0, fmt.Errorf("unsupported backup target kind '%s'", t.Kind)
+}
+
+// run runs a storage CLI, including its output in the error
+func run(name string, args ...string) error {
+	out, err := execx.Command(name, args...).CombinedOutput()
+	if err != nil && len(out) > 0 {
+		return fmt.Errorf("%w: %s", err, strings.TrimSpace(string(out)))
+	}
+	return err
+}
+
+// output runs a storage CLI and returns its standard output
+func output(name string, args ...string) (string, error) {
+	out, err := execx.Command(name, args...).Output()
+	return string(out), err
+}
+
+// copyFile copies src to dst, creating dst's directory
+func copyFile(src, dst string) error {
+	in, err := os.Open(src)
+	if err != nil {
+		return err
+	}
+	defer in.Close()
+
+	if err := os.MkdirAll(filepath.Dir(dst), 0755); err != nil {
+		return err
+	}
+	out, err := os.Create(dst)
+	if err != nil {
+		return err
+	}
+	if _, err := io.Copy(out, in); err != nil {
+		out.Close()
+		return err
+	}
+	return out.Close()
+}
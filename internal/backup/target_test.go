@@ -0,0 +1,107 @@
+package backup
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/pmossman/claudew/internal/execx"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseTarget(t *testing.T) {
+	tests := []struct {
+		in   string
+		want Target
+	}{
+		{"s3://bucket/claudew/", Target{Kind: KindS3, Base: "s3://bucket/claudew"}},
+		{"gs://bucket", Target{Kind: KindGCS, Base: "gs://bucket"}},
+		{"rclone:drive:backups/claudew", Target{Kind: KindRclone, Base: "drive:backups/claudew"}},
+		{"/mnt/nas/claudew", Target{Kind: KindDir, Base: "/mnt/nas/claudew"}},
+	}
+	for _, tt := range tests {
+		got, err := ParseTarget(tt.in)
+		require.NoError(t, err, tt.in)
+		assert.Equal(t, tt.want, got)
+	}
+	assert.Equal(t, "rclone:drive:backups/claudew", tests[2].want.String())
+
+	for _, bad := range []string{"", "s3://", "rclone:nocolon", "relative/dir", "ftp://host"} {
+		_, err := ParseTarget(bad)
+		assert.Error(t, err, bad)
+	}
+}
+
+func TestTarget_Dir(t *testing.T) {
+	local := filepath.Join(t.TempDir(), "auth.tar.gz")
+	require.NoError(t, os.WriteFile(local, []byte("backup"), 0644))
+	target := Target{Kind: KindDir, Base: filepath.Join(t.TempDir(), "nested")}
+
+	require.NoError(t, target.Upload(local, ObjectName("auth")))
+	size, err := target.Size("auth.tar.gz")
+	require.NoError(t, err)
+	assert.EqualValues(t, 6, size)
+
+	restored := filepath.Join(t.TempDir(), "restored.tar.gz")
+	require.NoError(t, target.Download("auth.tar.gz", restored))
+	data, err := os.ReadFile(restored)
+	require.NoError(t, err)
+	assert.Equal(t, "backup", string(data))
+
+	_, err = target.Size("missing.tar.gz")
+	assert.Error(t, err)
+}
+
+func TestTarget_Tools(t *testing.T) {
+	fake := execx.NewFake(nil)
+	fake.Handle("aws", func(c *execx.Cmd) error {
+		if c.Args[1] == "ls" {
+			_, _ = c.Stdout.Write([]byte("2026-03-01 09:30:00       1234 auth.tar.gz\n"))
+		}
+		return nil
+	})
+	fake.Handle("gcloud", func(c *execx.Cmd) error {
+		if c.Args[1] == "ls" {
+			_, _ = c.Stdout.Write([]byte("      5678  2026-03-01T09:30:00Z  gs://bucket/auth.tar.gz\nTOTAL: 1 objects, 5678 bytes (5.54KiB)\n"))
+		}
+		return nil
+	})
+	fake.Handle("rclone", func(c *execx.Cmd) error {
+		if c.Args[0] == "size" {
+			_, _ = c.Stdout.Write([]byte(`{"count":1,"bytes":910}`))
+		}
+		return nil
+	})
+	t.Cleanup(execx.Use(fake))
+
+	s3 := Target{Kind: KindS3, Base: "s3://bucket/claudew"}
+	require.NoError(t, s3.Upload("/tmp/auth.tar.gz", "auth.tar.gz"))
+	require.NoError(t, s3.Download("auth.tar.gz", "/tmp/out.tar.gz"))
+	size, err := s3.Size("auth.tar.gz")
+	require.NoError(t, err)
+	assert.EqualValues(t, 1234, size)
+
+	gcs := Target{Kind: KindGCS, Base: "gs://bucket"}
+	size, err = gcs.Size("auth.tar.gz")
+	require.NoError(t, err)
+	assert.EqualValues(t, 5678, size)
+	_, err = gcs.Size("other.tar.gz")
+	assert.Error(t, err)
+
+	rclone := Target{Kind: KindRclone, Base: "drive:"}
+	require.NoError(t, rclone.Upload("/tmp/auth.tar.gz", "auth.tar.gz"))
+	size, err = rclone.Size("auth.tar.gz")
+	require.NoError(t, err)
+	assert.EqualValues(t, 910, size)
+
+	assert.Equal(t, []string{
+		"aws s3 cp --only-show-errors /tmp/auth.tar.gz s3://bucket/claudew/auth.tar.gz",
+		"aws s3 cp --only-show-errors s3://bucket/claudew/auth.tar.gz /tmp/out.tar.gz",
+		"aws s3 ls s3://bucket/claudew/auth.tar.gz",
+		"gcloud storage ls -l gs://bucket/auth.tar.gz",
+		"gcloud storage ls -l gs://bucket/other.tar.gz",
+		"rclone copyto /tmp/auth.tar.gz drive:auth.tar.gz",
+		"rclone size --json drive:auth.tar.gz",
+	}, fake.Calls())
+}
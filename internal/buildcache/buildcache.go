@@ -0,0 +1,55 @@
+package buildcache
+
+import (
+	"io/fs"
+	"os"
+	"path/filepath"
+)
+
+// Tool is a build tool, recognized by marker files, whose caches live in CacheDirs
+type Tool struct {
+	Name      string
+	Markers   []string
+	CacheDirs []string
+}
+
+// Tools are checked in order, so lock-file-specific tools come before npm
+var Tools = []Tool{
+	{Name: "gradle", Markers: []string{"build.gradle", "build.gradle.kts", "settings.gradle", "settings.gradle.kts"}, CacheDirs: []string{".gradle", "build"}},
+	{Name: "maven", Markers: []string{"pom.xml"}, CacheDirs: []string{"target"}},
+	{Name: "cargo", Markers: []string{"Cargo.toml"}, CacheDirs: []string{"target"}},
+	{Name: "pnpm", Markers: []string{"pnpm-lock.yaml"}, CacheDirs: []string{"node_modules"}},
+	{Name: "yarn", Markers: []string{"yarn.lock"}, CacheDirs: []string{"node_modules"}},
+	{Name: "npm", Markers: []string{"package.json"}, CacheDirs: []string{"node_modules", ".next", "dist"}},
+	{Name: "go", Markers: []string{"go.mod"}}, // caches live in GOCACHE, outside the checkout
+}
+
+// Detect returns the build tool used in dir, if any
+func Detect(dir string) (Tool, bool) {
+	for _, tool := range Tools {
+		for _, marker := range tool.Markers {
+			if _, err := os.Stat(filepath.Join(dir, marker)); err == nil {
+				return tool, true
+			}
+		}
+	}
+	return Tool{}, false
+}
+
+// Size returns the total size in bytes of the tool's cache directories in dir.
+// Unreadable entries are skipped, so the result is an estimate.
+func Size(dir string, tool Tool) int64 {
+	var total int64
+	for _, cacheDir := range tool.CacheDirs {
+		filepath.WalkDir(filepath.Join(dir, cacheDir), func(path string, d fs.DirEntry, err error) error {
+			if err != nil || !d.Type().IsRegular() {
+				return nil
+			}
+			if info, err := d.Info(); err == nil {
+				total += info.Size()
+			}
+			return nil
+		})
+	}
+	return total
+}
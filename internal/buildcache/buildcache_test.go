@@ -0,0 +1,44 @@
+package buildcache
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDetect(t *testing.T) {
+	dir := t.TempDir()
+	_, found := Detect(dir)
+	assert.False(t, found)
+
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "package.json"), []byte("{}"), 0644))
+	tool, found := Detect(dir)
+	require.True(t, found)
+	assert.Equal(t, "npm", tool.Name)
+
+	// Lock files identify the package manager
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "yarn.lock"), nil, 0644))
+	tool, _ = Detect(dir)
+	assert.Equal(t, "yarn", tool.Name)
+}
+
+func TestSize(t *testing.T) {
+	dir := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "build.gradle"), nil, 0644))
+	require.NoError(t, os.MkdirAll(filepath.Join(dir, "build", "classes"), 0755))
+	require.NoError(t, os.MkdirAll(filepath.Join(dir, ".gradle"), 0755))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "build", "classes", "A.class"), make([]byte, 1000), 0644))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, ".gradle", "cache.bin"), make([]byte, 500), 0644))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "src.txt"), make([]byte, 9999), 0644))
+
+	tool, found := Detect(dir)
+	require.True(t, found)
+	assert.Equal(t, int64(1500), Size(dir, tool))
+
+	// Tools without in-repo caches, and missing cache directories, count as empty
+	assert.Equal(t, int64(0), Size(dir, Tool{Name: "go"}))
+	assert.Equal(t, int64(0), Size(t.TempDir(), tool))
+}
@@ -0,0 +1,72 @@
+package clipboard
+
+import (
+	"encoding/base64"
+	"fmt"
+	"os"
+	"os/exec"
+	"strings"
+
+	"github.com/pmossman/claudew/internal/execx"
+)
+
+// Method names reported by Copy
+const (
+	MethodCustom = "custom"
+	MethodOSC52  = "osc52"
+)
+
+// Copy copies text to the clipboard and returns the method that was used.
+// If command is non-empty it is run via sh -c (cmd /C on Windows) with text on
+// stdin and no other method is tried. Otherwise native clipboard tools are tried
+// in order and, if none succeed, an OSC52 escape sequence is written to the
+// terminal, which works over SSH and inside tmux with supporting terminal emulators.
+func Copy(text, command string) (string, error) {
+	if command != "" {
+		cmd := execx.Command(shell[0], append(shell[1:], command)...)
+		cmd.Stdin = strings.NewReader(text)
+		if err := cmd.Run(); err != nil {
+			return "", fmt.Errorf("clipboard command failed: %w", err)
+		}
+		return MethodCustom, nil
+	}
+
+	for _, args := range nativeCommands {
+		if _, err := exec.LookPath(args[0]); err != nil {
+			continue
+		}
+		cmd := execx.Command(args[0], args[1:]...)
+		cmd.Stdin = strings.NewReader(text)
+		if err := cmd.Run(); err == nil {
+			return args[0], nil
+		}
+	}
+
+	if err := copyOSC52(text); err != nil {
+		return "", err
+	}
+	return MethodOSC52, nil
+}
+
+// copyOSC52 writes an OSC52 clipboard sequence directly to the terminal
+func copyOSC52(text string) error {
+	tty, err := os.OpenFile(ttyPath, os.O_WRONLY, 0)
+	if err != nil {
+		return fmt.Errorf("no clipboard tool available and terminal not accessible: %w", err)
+	}
+	defer tty.Close()
+
+	_, err = tty.WriteString(OSC52Sequence(text, os.Getenv("TMUX") != ""))
+	return err
+}
+
+// OSC52Sequence returns the escape sequence that sets the system clipboard to text.
+// Inside tmux the sequence is wrapped in a DCS passthrough so it reaches the outer terminal.
+func OSC52Sequence(text string, inTmux bool) string {
+	seq := "\x1b]52;c;" + base64.StdEncoding.EncodeToString([]byte(text)) + "\x07"
+	if inTmux {
+		// Escape characters inside a passthrough sequence must be doubled
+		seq = "\x1bPtmux;" + strings.ReplaceAll(seq, "\x1b", "\x1b\x1b") + "\x1b\\"
+	}
+	return seq
+}
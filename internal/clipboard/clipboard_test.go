@@ -0,0 +1,39 @@
+package clipboard
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestOSC52Sequence(t *testing.T) {
+	// "hello" base64-encodes to "aGVsbG8="
+	seq := OSC52Sequence("hello", false)
+	assert.Equal(t, "\x1b]52;c;aGVsbG8=\x07", seq)
+}
+
+func TestOSC52Sequence_Tmux(t *testing.T) {
+	seq := OSC52Sequence("hello", true)
+	assert.Equal(t, "\x1bPtmux;\x1b\x1b]52;c;aGVsbG8=\x07\x1b\\", seq)
+}
+
+func TestCopy_CustomCommand(t *testing.T) {
+	outFile := filepath.Join(t.TempDir(), "clipboard.txt")
+
+	method, err := Copy("copied text", "cat > "+outFile)
+	require.NoError(t, err)
+	assert.Equal(t, MethodCustom, method)
+
+	data, err := os.ReadFile(outFile)
+	require.NoError(t, err)
+	assert.Equal(t, "copied text", string(data))
+}
+
+func TestCopy_CustomCommandFailure(t *testing.T) {
+	_, err := Copy("text", "exit 1")
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "clipboard command failed")
+}
@@ -0,0 +1,18 @@
+//go:build !windows
+
+package clipboard
+
+// nativeCommands are the clipboard tools tried in order, before falling back to OSC52
+var nativeCommands = [][]string{
+	{"pbcopy"},                           // macOS
+	{"wl-copy"},                          // Wayland
+	{"xclip", "-selection", "clipboard"}, // X11
+	{"xsel", "--clipboard", "--input"},   // X11
+	{"clip.exe"},                         // WSL
+}
+
+// shell runs a custom clipboard command, which is appended as the last argument
+var shell = []string{"sh", "-c"}
+
+// ttyPath is the controlling terminal OSC52 sequences are written to
+const ttyPath = "/dev/tty"
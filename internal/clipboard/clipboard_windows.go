@@ -0,0 +1,14 @@
+//go:build windows
+
+package clipboard
+
+// nativeCommands are the clipboard tools tried in order, before falling back to OSC52
+var nativeCommands = [][]string{
+	{"clip.exe"},
+}
+
+// shell runs a custom clipboard command, which is appended as the last argument
+var shell = []string{"cmd", "/C"}
+
+// ttyPath is the console OSC52 sequences are written to
+const ttyPath = "CONOUT$"
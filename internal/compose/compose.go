@@ -0,0 +1,106 @@
+package compose
+
+import (
+	"fmt"
+	"strings"
+)
+
+// MaxFiles is the most uncommitted files listed before the rest are summarized
+const MaxFiles = 30
+
+// Input is the workspace state a restart prompt is composed from
+type Input struct {
+	Workspace    string
+	Continuation string
+	Decisions    []string
+	Branch       string
+	Uncommitted  []string // "git status --porcelain" lines
+}
+
+// Build assembles a restart prompt from a workspace's continuation notes, recent
+// decisions and git state. Empty sections are left out.
+func Build(in Input) string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "You are resuming work in the claudew workspace %q.\n", in.Workspace)
+
+	if continuation := strings.TrimSpace(in.Continuation); continuation != "" {
+		b.WriteString("\n## Where things stand\n\n")
+		b.WriteString(continuation)
+		b.WriteString("\n")
+	}
+
+	if len(in.Decisions) > 0 {
+		b.WriteString("\n## Recent decisions (keep following these)\n\n")
+		for _, decision := range in.Decisions {
+			b.WriteString("- ")
+			b.WriteString(strings.ReplaceAll(decision, "\n", "\n  "))
+			b.WriteString("\n")
+		}
+	}
+
+	if in.Branch != "" || in.Uncommitted != nil {
+		b.WriteString("\n## Git state\n\n")
+		if in.Branch != "" {
+			fmt.Fprintf(&b, "Branch: %s\n", in.Branch)
+		}
+		if len(in.Uncommitted) == 0 {
+			b.WriteString("Working tree is clean.\n")
+		} else {
+			b.WriteString("Uncommitted changes:\n")
+			for i, file := range in.Uncommitted {
+				if i == MaxFiles {
+					fmt.Fprintf(&b, "... and %d more\n", len(in.Uncommitted)-MaxFiles)
+					break
+				}
+				fmt.Fprintf(&b, "    %s\n", file)
+			}
+		}
+	}
+
+	b.WriteString("\nRe-read context.md and decisions.md, check the uncommitted changes, then continue with the next steps.\n")
+	return b.String()
+}
+
+// LastDecisions splits decisions.md into entries and returns the last n (all if n <= 0).
+// Entries start at the "## [Timestamp] Topic" headings AppendDecision and the CLAUDE.md
+// protocol write, and run to the next one, so a decision keeps its heading (without
+// the "## ") and every paragraph of its body. Text before the first such heading is
+// an entry too, unless it is only a title; headings without a body are dropped.
+func LastDecisions(text string, n int) []string {
+	var entries []string
+	var heading string
+	var body []string
+	flush := func() {
+		// A title or a heading with nothing under it isn't a decision
+		hasBody := false
+		for _, line := range body {
+			if line != "" && !strings.HasPrefix(line, "#") {
+				hasBody = true
+			}
+		}
+		if hasBody {
+			entry := strings.TrimSpace(strings.Join(body, "\n"))
+			if heading != "" {
+				entry = heading + "\n" + entry
+			}
+			entries = append(entries, entry)
+		}
+		heading, body = "", nil
+	}
+
+	for _, line := range strings.Split(text, "\n") {
+		line = strings.TrimRight(line, " \t\r")
+		if h, ok := strings.CutPrefix(line, "## "); ok {
+			flush()
+			heading = strings.TrimSpace(h)
+			continue
+		}
+		body = append(body, line)
+	}
+	flush()
+
+	if n > 0 && len(entries) > n {
+		entries = entries[len(entries)-n:]
+	}
+	return entries
+}
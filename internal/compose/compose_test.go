@@ -0,0 +1,101 @@
+package compose
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/pmossman/claudew/internal/workspace"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLastDecisions(t *testing.T) {
+	mgr := workspace.NewManager(t.TempDir())
+	require.NoError(t, mgr.Create("ws"))
+	require.NoError(t, mgr.AppendDecision("ws", "Use sqlite for storage", time.Date(2026, 3, 1, 9, 30, 0, 0, time.UTC)))
+	require.NoError(t, mgr.AppendDecision("ws", "Never touch the legacy API.\n\nIt is frozen until Q3.", time.Date(2026, 3, 2, 10, 0, 0, 0, time.UTC)))
+	require.NoError(t, mgr.AppendDecision("ws", "Prefer table-driven tests", time.Date(2026, 3, 3, 11, 15, 0, 0, time.UTC)))
+	text := mgr.ReadDecisions("ws")
+
+	assert.Equal(t, []string{
+		"[2026-03-01 09:30] Decision\nUse sqlite for storage",
+		"[2026-03-02 10:00] Decision\nNever touch the legacy API.\n\nIt is frozen until Q3.",
+		"[2026-03-03 11:15] Decision\nPrefer table-driven tests",
+	}, LastDecisions(text, 0))
+
+	assert.Equal(t, []string{"[2026-03-03 11:15] Decision\nPrefer table-driven tests"}, LastDecisions(text, 1))
+	assert.Empty(t, LastDecisions("", 5))
+}
+
+func TestLastDecisions_TitleAndPreamble(t *testing.T) {
+	text := `# Decisions
+
+Written by hand before the first heading.
+
+## [2026-03-01 09:30] Storage
+User clarified: use sqlite
+Reason: no server to run
+
+## Merged from workspace 'old' (2026-03-04)
+`
+	assert.Equal(t, []string{
+		"# Decisions\n\nWritten by hand before the first heading.",
+		"[2026-03-01 09:30] Storage\nUser clarified: use sqlite\nReason: no server to run",
+	}, LastDecisions(text, 0))
+
+	assert.Equal(t, []string{"[2026-03-01 09:30] Storage\nUser clarified: use sqlite"},
+		LastDecisions("# Decisions\n\n## [2026-03-01 09:30] Storage\nUser clarified: use sqlite\n", 0),
+		"a title on its own is not an entry")
+}
+
+func TestBuild(t *testing.T) {
+	prompt := Build(Input{
+		Workspace:    "auth",
+		Continuation: "Working on token refresh.\n",
+		Decisions:    []string{"Use sqlite", "No new deps\nunless asked"},
+		Branch:       "feat/refresh",
+		Uncommitted:  []string{" M auth.go", "?? notes.txt"},
+	})
+
+	assert.Equal(t, `You are resuming work in the claudew workspace "auth".
+
+## Where things stand
+
+Working on token refresh.
+
+## Recent decisions (keep following these)
+
+- Use sqlite
+- No new deps
+  unless asked
+
+## Git state
+
+Branch: feat/refresh
+Uncommitted changes:
+     M auth.go
+    ?? notes.txt
+
+Re-read context.md and decisions.md, check the uncommitted changes, then continue with the next steps.
+`, prompt)
+}
+
+func TestBuild_OmitsEmptySections(t *testing.T) {
+	prompt := Build(Input{Workspace: "auth", Branch: "main", Uncommitted: []string{}})
+
+	assert.NotContains(t, prompt, "Where things stand")
+	assert.NotContains(t, prompt, "Recent decisions")
+	assert.Contains(t, prompt, "Branch: main\nWorking tree is clean.\n")
+}
+
+func TestBuild_LimitsFiles(t *testing.T) {
+	var files []string
+	for i := 0; i < MaxFiles+5; i++ {
+		files = append(files, "?? file")
+	}
+	prompt := Build(Input{Workspace: "auth", Uncommitted: files})
+
+	assert.Equal(t, MaxFiles, strings.Count(prompt, "?? file"))
+	assert.Contains(t, prompt, "... and 5 more\n")
+}
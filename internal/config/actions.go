@@ -0,0 +1,54 @@
+package config
+
+import (
+	"bytes"
+	"fmt"
+	"regexp"
+	"text/template"
+)
+
+// workspaceFieldPattern matches template placeholders that require a selected workspace
+var workspaceFieldPattern = regexp.MustCompile(`\.(Workspace|ClonePath|RepoPath)\b`)
+
+// CustomAction is a user-defined entry in the select menu's ACTIONS section
+type CustomAction struct {
+	Label   string `json:"label"`
+	Command string `json:"command"` // shell command template, e.g. "cd {{.ClonePath}} && make test"
+}
+
+// ActionVars are the placeholders available to custom action command templates
+type ActionVars struct {
+	Workspace    string
+	ClonePath    string
+	RepoPath     string
+	WorkspaceDir string
+}
+
+// NeedsWorkspace reports whether the command template references workspace placeholders
+func (a CustomAction) NeedsWorkspace() bool {
+	return workspaceFieldPattern.MatchString(a.Command)
+}
+
+// Render expands the command template with the given variables
+func (a CustomAction) Render(vars ActionVars) (string, error) {
+	tmpl, err := template.New(a.Label).Option("missingkey=error").Parse(a.Command)
+	if err != nil {
+		return "", fmt.Errorf("invalid command template for action '%s': %w", a.Label, err)
+	}
+
+	var buf bytes.Buffer
+	if err := tmpl.Execute(&buf, vars); err != nil {
+		return "", fmt.Errorf("failed to render command for action '%s': %w", a.Label, err)
+	}
+	return buf.String(), nil
+}
+
+// GetCustomAction returns the custom action with the given label
+func (c *Config) GetCustomAction(label string) (*CustomAction, error) {
+	for i := range c.Settings.CustomActions {
+		if c.Settings.CustomActions[i].Label == label {
+			return &c.Settings.CustomActions[i], nil
+		}
+	}
+	return nil, fmt.Errorf("custom action '%s' not found", label)
+}
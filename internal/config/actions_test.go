@@ -0,0 +1,56 @@
+package config
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCustomAction_Render(t *testing.T) {
+	action := CustomAction{
+		Label:   "Run tests",
+		Command: "cd {{.ClonePath}} && make test # {{.Workspace}}",
+	}
+
+	cmd, err := action.Render(ActionVars{Workspace: "feature-auth", ClonePath: "/repos/app-1"})
+	require.NoError(t, err)
+	assert.Equal(t, "cd /repos/app-1 && make test # feature-auth", cmd)
+}
+
+func TestCustomAction_RenderInvalidTemplate(t *testing.T) {
+	action := CustomAction{Label: "Broken", Command: "echo {{.Workspace"}
+
+	_, err := action.Render(ActionVars{})
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "Broken")
+}
+
+func TestCustomAction_RenderUnknownField(t *testing.T) {
+	action := CustomAction{Label: "Typo", Command: "echo {{.Workspcae}}"}
+
+	_, err := action.Render(ActionVars{})
+	assert.Error(t, err)
+}
+
+func TestCustomAction_NeedsWorkspace(t *testing.T) {
+	assert.True(t, CustomAction{Command: "open {{.RepoPath}}"}.NeedsWorkspace())
+	assert.True(t, CustomAction{Command: "echo {{ .Workspace }}"}.NeedsWorkspace())
+	assert.False(t, CustomAction{Command: "open https://github.com/pulls"}.NeedsWorkspace())
+	assert.False(t, CustomAction{Command: "ls {{.WorkspaceDir}}"}.NeedsWorkspace())
+}
+
+func TestConfig_GetCustomAction(t *testing.T) {
+	cfg := NewDefaultConfig()
+	cfg.Settings.CustomActions = []CustomAction{
+		{Label: "Run tests", Command: "make test"},
+		{Label: "Open PR page", Command: "gh pr view --web"},
+	}
+
+	action, err := cfg.GetCustomAction("Open PR page")
+	require.NoError(t, err)
+	assert.Equal(t, "gh pr view --web", action.Command)
+
+	_, err = cfg.GetCustomAction("Missing")
+	assert.Error(t, err)
+}
@@ -0,0 +1,70 @@
+package config
+
+import (
+	"fmt"
+	"regexp"
+	"time"
+)
+
+// Agent is an additional Claude instance running in its own window of a workspace's session
+type Agent struct {
+	Name      string    `json:"name"`             // tmux window name
+	Prompt    string    `json:"prompt,omitempty"` // first prompt, e.g. a persona such as "You are a code reviewer"
+	CreatedAt time.Time `json:"created_at"`
+}
+
+var agentNameRe = regexp.MustCompile(`^[A-Za-z0-9][A-Za-z0-9_-]*$`)
+
+// AddAgent records a spawned agent on a workspace. Names must be unique within the workspace.
+func (c *Config) AddAgent(wsName string, agent Agent) error {
+	ws, err := c.GetWorkspace(wsName)
+	if err != nil {
+		return err
+	}
+	if !agentNameRe.MatchString(agent.Name) {
+		return fmt.Errorf("invalid agent name '%s': use letters, digits, '-' and '_'", agent.Name)
+	}
+	if ws.GetAgent(agent.Name) != nil {
+		return fmt.Errorf("workspace '%s' already has an agent named '%s'", wsName, agent.Name)
+	}
+	if agent.CreatedAt.IsZero() {
+		agent.CreatedAt = time.Now()
+	}
+	ws.Agents = append(ws.Agents, agent)
+	return nil
+}
+
+// RemoveAgent forgets a spawned agent
+func (c *Config) RemoveAgent(wsName, agentName string) error {
+	ws, err := c.GetWorkspace(wsName)
+	if err != nil {
+		return err
+	}
+	for i, agent := range ws.Agents {
+		if agent.Name == agentName {
+			ws.Agents = append(ws.Agents[:i], ws.Agents[i+1:]...)
+			return nil
+		}
+	}
+	return fmt.Errorf("workspace '%s' has no agent named '%s'", wsName, agentName)
+}
+
+// GetAgent returns the agent with the given name, or nil
+func (w *Workspace) GetAgent(name string) *Agent {
+	for i := range w.Agents {
+		if w.Agents[i].Name == name {
+			return &w.Agents[i]
+		}
+	}
+	return nil
+}
+
+// NextAgentName returns the first unused name of the form agent-N
+func (w *Workspace) NextAgentName() string {
+	for n := 1; ; n++ {
+		name := fmt.Sprintf("agent-%d", n)
+		if w.GetAgent(name) == nil {
+			return name
+		}
+	}
+}
@@ -0,0 +1,36 @@
+package config
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestConfig_Agents(t *testing.T) {
+	cfg := createTestConfig(t, setupTestDir(t))
+	cfg.AddWorkspace("ws", "/tmp/ws")
+	ws, _ := cfg.GetWorkspace("ws")
+
+	assert.Equal(t, "agent-1", ws.NextAgentName())
+
+	require.NoError(t, cfg.AddAgent("ws", Agent{Name: "agent-1"}))
+	require.NoError(t, cfg.AddAgent("ws", Agent{Name: "reviewer", Prompt: "Review the diff"}))
+	assert.Equal(t, "agent-2", ws.NextAgentName())
+
+	reviewer := ws.GetAgent("reviewer")
+	require.NotNil(t, reviewer)
+	assert.Equal(t, "Review the diff", reviewer.Prompt)
+	assert.False(t, reviewer.CreatedAt.IsZero())
+
+	// Duplicate and invalid names are rejected
+	assert.Error(t, cfg.AddAgent("ws", Agent{Name: "reviewer"}))
+	assert.Error(t, cfg.AddAgent("ws", Agent{Name: "bad name"}))
+	assert.Error(t, cfg.AddAgent("ws", Agent{Name: "a:b"}))
+	assert.Error(t, cfg.AddAgent("missing", Agent{Name: "x"}))
+
+	require.NoError(t, cfg.RemoveAgent("ws", "agent-1"))
+	assert.Nil(t, ws.GetAgent("agent-1"))
+	assert.Len(t, ws.Agents, 1)
+	assert.Error(t, cfg.RemoveAgent("ws", "agent-1"))
+}
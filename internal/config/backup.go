@@ -0,0 +1,193 @@
+package config
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// DefaultConfigBackups is how many rotated backups Save keeps when config_backups is unset
+const DefaultConfigBackups = 10
+
+// configBackupInterval is the minimum time between routine backups, so frequent saves
+// don't rotate older states out within minutes. Saves that drop workspaces are always backed up.
+const configBackupInterval = time.Hour
+
+// backupSuffix and backupTimeFormat name backups config.json.bak-<UTC time>, which sort
+// chronologically. backupConfigFile appends -2, -3... to backups taken within the same second.
+const (
+	backupSuffix     = ".bak-"
+	backupTimeFormat = "20060102T150405Z"
+)
+
+// Backup is a rotated copy of the config file
+type Backup struct {
+	Path       string
+	Time       time.Time
+	Workspaces int // number of workspaces in the backup, -1 if it can't be parsed
+
+	seq int // orders backups taken within the same second
+}
+
+// backupLimit returns how many backups to keep, or 0 if backups are disabled
+func (c *Config) backupLimit() int {
+	switch {
+	case c.Settings.ConfigBackups < 0:
+		return 0
+	case c.Settings.ConfigBackups == 0:
+		return DefaultConfigBackups
+	default:
+		return c.Settings.ConfigBackups
+	}
+}
+
+// rotateConfigBackup copies the config file about to be replaced by data into a timestamped
+// backup when one is due, then removes all but the newest limit backups
+func rotateConfigBackup(configPath string, data []byte, limit int, now time.Time) error {
+	if limit <= 0 {
+		return nil
+	}
+	old, err := os.ReadFile(configPath)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil
+		}
+		return fmt.Errorf("failed to read config for backup: %w", err)
+	}
+	if bytes.Equal(old, data) {
+		return nil
+	}
+
+	backups, err := listBackups(configPath)
+	if err != nil {
+		return err
+	}
+	due := len(backups) == 0 || now.Sub(backups[0].Time) >= configBackupInterval ||
+		countWorkspaces(data) < countWorkspaces(old)
+	if !due {
+		return nil
+	}
+
+	if _, err := backupConfigFile(configPath, old, timedBackupSuffix(now)); err != nil {
+		return err
+	}
+	return pruneBackups(configPath, limit)
+}
+
+// timedBackupSuffix returns the suffix of the rotated backup taken at now
+func timedBackupSuffix(now time.Time) string {
+	return backupSuffix + now.UTC().Format(backupTimeFormat)
+}
+
+// pruneBackups removes all but the newest limit backups
+func pruneBackups(configPath string, limit int) error {
+	backups, err := listBackups(configPath)
+	if err != nil {
+		return err
+	}
+	for i := limit; i < len(backups); i++ {
+		if err := os.Remove(backups[i].Path); err != nil && !os.IsNotExist(err) {
+			return fmt.Errorf("failed to remove old config backup: %w", err)
+		}
+	}
+	return nil
+}
+
+// listBackups returns the rotated backups of configPath, newest first
+func listBackups(configPath string) ([]Backup, error) {
+	matches, err := filepath.Glob(configPath + backupSuffix + "*")
+	if err != nil {
+		return nil, fmt.Errorf("failed to list config backups: %w", err)
+	}
+
+	var backups []Backup
+	for _, path := range matches {
+		stamp, seqStr, hasSeq := strings.Cut(strings.TrimPrefix(path, configPath+backupSuffix), "-")
+		t, err := time.Parse(backupTimeFormat, stamp)
+		if err != nil {
+			continue // not one of ours
+		}
+		seq := 1
+		if hasSeq {
+			if seq, err = strconv.Atoi(seqStr); err != nil || seq < 2 {
+				continue
+			}
+		}
+		backups = append(backups, Backup{Path: path, Time: t, Workspaces: -1, seq: seq})
+	}
+	sort.Slice(backups, func(i, j int) bool {
+		if !backups[i].Time.Equal(backups[j].Time) {
+			return backups[i].Time.After(backups[j].Time)
+		}
+		return backups[i].seq > backups[j].seq
+	})
+	return backups, nil
+}
+
+// countWorkspaces returns the number of workspaces in config file contents, or -1 if they can't be parsed
+func countWorkspaces(data []byte) int {
+	var cfg struct {
+		Workspaces map[string]json.RawMessage `json:"workspaces"`
+	}
+	if err := json.Unmarshal(data, &cfg); err != nil {
+		return -1
+	}
+	return len(cfg.Workspaces)
+}
+
+// ListBackups returns the rotated config backups, newest first, with their workspace counts
+func ListBackups() ([]Backup, error) {
+	configPath, err := GetConfigPath()
+	if err != nil {
+		return nil, err
+	}
+	backups, err := listBackups(configPath)
+	if err != nil {
+		return nil, err
+	}
+	for i := range backups {
+		if data, err := os.ReadFile(backups[i].Path); err == nil {
+			backups[i].Workspaces = countWorkspaces(data)
+		}
+	}
+	return backups, nil
+}
+
+// RestoreBackup replaces the config file with a backup. The current config is backed up
+// first, so a restore can itself be undone. Returns the path of that new backup, or ""
+// if there was no config file.
+func RestoreBackup(backupPath string) (string, error) {
+	configPath, err := GetConfigPath()
+	if err != nil {
+		return "", err
+	}
+
+	data, err := os.ReadFile(backupPath)
+	if err != nil {
+		return "", fmt.Errorf("failed to read backup: %w", err)
+	}
+	var cfg Config
+	if err := json.Unmarshal(data, &cfg); err != nil {
+		return "", fmt.Errorf("backup %s is not a valid config: %w", filepath.Base(backupPath), err)
+	}
+
+	saved := ""
+	if current, err := os.ReadFile(configPath); err == nil {
+		if saved, err = backupConfigFile(configPath, current, timedBackupSuffix(time.Now())); err != nil {
+			return "", err
+		}
+	} else if !os.IsNotExist(err) {
+		return "", fmt.Errorf("failed to read config: %w", err)
+	}
+
+	if err := os.WriteFile(configPath, data, 0644); err != nil {
+		return "", fmt.Errorf("failed to write config: %w", err)
+	}
+	return saved, nil
+}
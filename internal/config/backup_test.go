@@ -0,0 +1,121 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRotateConfigBackup_SameSecond(t *testing.T) {
+	configPath := filepath.Join(t.TempDir(), "config.json")
+	now := time.Date(2025, 10, 20, 9, 0, 0, 0, time.UTC)
+	require.NoError(t, os.WriteFile(configPath, []byte(`{"workspaces":{"a":{},"b":{}}}`), 0644))
+
+	// Two saves dropping workspaces within one second keep both earlier states
+	require.NoError(t, rotateConfigBackup(configPath, []byte(`{"workspaces":{"a":{}}}`), 3, now))
+	require.NoError(t, os.WriteFile(configPath, []byte(`{"workspaces":{"a":{}}}`), 0644))
+	require.NoError(t, rotateConfigBackup(configPath, []byte(`{"workspaces":{}}`), 3, now))
+
+	backups, err := listBackups(configPath)
+	require.NoError(t, err)
+	require.Len(t, backups, 2)
+	assert.Equal(t, configPath+".bak-20251020T090000Z-2", backups[0].Path)
+	assert.Equal(t, configPath+".bak-20251020T090000Z", backups[1].Path)
+	data, _ := os.ReadFile(backups[0].Path)
+	assert.Equal(t, `{"workspaces":{"a":{}}}`, string(data))
+}
+
+func TestRotateConfigBackup(t *testing.T) {
+	configPath := filepath.Join(t.TempDir(), "config.json")
+	now := time.Date(2025, 10, 20, 9, 0, 0, 0, time.UTC)
+
+	// Nothing to back up before the first save
+	require.NoError(t, rotateConfigBackup(configPath, []byte(`{"workspaces":{}}`), 3, now))
+	backups, err := listBackups(configPath)
+	require.NoError(t, err)
+	assert.Empty(t, backups)
+
+	one := []byte(`{"workspaces":{"a":{}}}`)
+	two := []byte(`{"workspaces":{"a":{},"b":{}}}`)
+	require.NoError(t, os.WriteFile(configPath, one, 0644))
+
+	// Unchanged contents aren't backed up
+	require.NoError(t, rotateConfigBackup(configPath, one, 3, now))
+	backups, _ = listBackups(configPath)
+	assert.Empty(t, backups)
+
+	require.NoError(t, rotateConfigBackup(configPath, two, 3, now))
+	require.NoError(t, os.WriteFile(configPath, two, 0644))
+	backups, _ = listBackups(configPath)
+	require.Len(t, backups, 1)
+	assert.Equal(t, configPath+".bak-20251020T090000Z", backups[0].Path)
+	data, _ := os.ReadFile(backups[0].Path)
+	assert.Equal(t, one, data)
+
+	// Within the interval, growing saves are not backed up but dropping workspaces is
+	require.NoError(t, rotateConfigBackup(configPath, []byte(`{"workspaces":{"a":{},"b":{},"c":{}}}`), 3, now.Add(time.Minute)))
+	backups, _ = listBackups(configPath)
+	assert.Len(t, backups, 1)
+	require.NoError(t, rotateConfigBackup(configPath, one, 3, now.Add(2*time.Minute)))
+	backups, _ = listBackups(configPath)
+	assert.Len(t, backups, 2)
+
+	// Only the newest backups are kept
+	for i := 1; i <= 4; i++ {
+		require.NoError(t, rotateConfigBackup(configPath, []byte(`{}`), 3, now.Add(time.Duration(i)*time.Hour)))
+	}
+	backups, _ = listBackups(configPath)
+	require.Len(t, backups, 3)
+	assert.Equal(t, now.Add(4*time.Hour), backups[0].Time)
+
+	// Disabled backups leave the directory alone
+	require.NoError(t, rotateConfigBackup(configPath, []byte(`{"x":1}`), 0, now.Add(10*time.Hour)))
+	backups, _ = listBackups(configPath)
+	assert.Len(t, backups, 3)
+}
+
+func TestBackupLimit(t *testing.T) {
+	cfg := NewDefaultConfig()
+	assert.Equal(t, DefaultConfigBackups, cfg.backupLimit())
+	cfg.Settings.ConfigBackups = 3
+	assert.Equal(t, 3, cfg.backupLimit())
+	cfg.Settings.ConfigBackups = -1
+	assert.Equal(t, 0, cfg.backupLimit())
+}
+
+func TestSaveBackupsAndRestore(t *testing.T) {
+	tmpDir := setupTestDir(t)
+	t.Setenv("HOME", tmpDir)
+
+	cfg := NewDefaultConfig()
+	require.NoError(t, cfg.AddWorkspace("a", "/tmp/a"))
+	require.NoError(t, cfg.AddWorkspace("b", "/tmp/b"))
+	require.NoError(t, cfg.Save())
+
+	// Accidentally dropping workspaces leaves a backup behind
+	require.NoError(t, cfg.RemoveWorkspace("a"))
+	require.NoError(t, cfg.Save())
+
+	backups, err := ListBackups()
+	require.NoError(t, err)
+	require.Len(t, backups, 1)
+	assert.Equal(t, 2, backups[0].Workspaces)
+
+	saved, err := RestoreBackup(backups[0].Path)
+	require.NoError(t, err)
+	assert.NotEmpty(t, saved)
+
+	restored, err := Load()
+	require.NoError(t, err)
+	assert.Len(t, restored.Workspaces, 2)
+
+	// Invalid backups are rejected
+	bad := filepath.Join(tmpDir, "bad.json")
+	require.NoError(t, os.WriteFile(bad, []byte("not json"), 0644))
+	_, err = RestoreBackup(bad)
+	assert.Error(t, err)
+}
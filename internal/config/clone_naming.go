@@ -0,0 +1,72 @@
+package config
+
+import (
+	"fmt"
+	"path/filepath"
+	"strconv"
+	"strings"
+)
+
+// Clone naming patterns name the directories of a remote's clones. {n} is replaced
+// by the clone number and {remote} by the remote's name.
+const (
+	CloneNamingNumber = "{n}"          // 1, 2, 3 (default)
+	CloneNamingRemote = "{remote}-{n}" // airbyte-1, airbyte-2
+)
+
+// ValidateCloneNaming checks that pattern contains {n} exactly once, no other
+// placeholders and no path separators
+func ValidateCloneNaming(pattern string) error {
+	if strings.Count(pattern, "{n}") != 1 {
+		return fmt.Errorf("invalid clone naming '%s': it must contain {n} exactly once", pattern)
+	}
+	rest := strings.NewReplacer("{n}", "", "{remote}", "").Replace(pattern)
+	if strings.ContainsAny(rest, "{}") {
+		return fmt.Errorf("invalid clone naming '%s': only {n} and {remote} are supported", pattern)
+	}
+	if strings.ContainsRune(pattern, filepath.Separator) || strings.Contains(pattern, "/") {
+		return fmt.Errorf("invalid clone naming '%s': it must not contain path separators", pattern)
+	}
+	return nil
+}
+
+// GetCloneNaming returns the remote's clone naming pattern, defaulting to {n}
+func (r *Remote) GetCloneNaming() string {
+	if r.CloneNaming == "" {
+		return CloneNamingNumber
+	}
+	return r.CloneNaming
+}
+
+// CloneDirName returns the directory name of the remote's clone number n
+func (r *Remote) CloneDirName(n int) string {
+	return strings.NewReplacer("{n}", strconv.Itoa(n), "{remote}", r.Name).Replace(r.GetCloneNaming())
+}
+
+// CloneNumber returns the number of a clone of the remote from its directory name.
+// Names from the remote's pattern and plain numbers, as clones were named before
+// patterns existed, are both understood.
+func (r *Remote) CloneNumber(path string) (int, bool) {
+	base := filepath.Base(path)
+	before, after, _ := strings.Cut(r.GetCloneNaming(), "{n}")
+	expand := strings.NewReplacer("{remote}", r.Name)
+	prefix, suffix := expand.Replace(before), expand.Replace(after)
+	if strings.HasPrefix(base, prefix) && strings.HasSuffix(base, suffix) && len(base) > len(prefix)+len(suffix) {
+		if n, err := strconv.Atoi(base[len(prefix) : len(base)-len(suffix)]); err == nil && n > 0 {
+			return n, true
+		}
+	}
+	if n, err := strconv.Atoi(base); err == nil && n > 0 {
+		return n, true
+	}
+	return 0, false
+}
+
+// cloneNumber returns a clone's number under its remote's naming pattern
+func (c *Config) cloneNumber(clone *Clone) (int, bool) {
+	remote, ok := c.Remotes[clone.RemoteName]
+	if !ok {
+		remote = &Remote{Name: clone.RemoteName}
+	}
+	return remote.CloneNumber(clone.Path)
+}
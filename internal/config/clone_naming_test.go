@@ -0,0 +1,89 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestValidateCloneNaming(t *testing.T) {
+	assert.NoError(t, ValidateCloneNaming("{n}"))
+	assert.NoError(t, ValidateCloneNaming("{remote}-{n}"))
+	assert.NoError(t, ValidateCloneNaming("wt{n}.{remote}"))
+
+	assert.Error(t, ValidateCloneNaming("{remote}"), "no number")
+	assert.Error(t, ValidateCloneNaming("{n}-{n}"), "number twice")
+	assert.Error(t, ValidateCloneNaming("{workspace}-{n}"), "unknown placeholder")
+	assert.Error(t, ValidateCloneNaming("clones/{n}"), "path separator")
+}
+
+func TestRemote_CloneNumber(t *testing.T) {
+	remote := &Remote{Name: "airbyte"}
+	assert.Equal(t, "3", remote.CloneDirName(3))
+
+	remote.CloneNaming = CloneNamingRemote
+	assert.Equal(t, "airbyte-3", remote.CloneDirName(3))
+
+	for path, want := range map[string]int{
+		"/clones/airbyte-3":  3,
+		"/clones/airbyte-12": 12,
+		"/clones/7":          7, // named before the pattern was set
+	} {
+		n, ok := remote.CloneNumber(path)
+		assert.True(t, ok, path)
+		assert.Equal(t, want, n, path)
+	}
+	for _, path := range []string{"/clones/airbyte-", "/clones/other-3", "/clones/airbyte-x", "/clones/misc"} {
+		_, ok := remote.CloneNumber(path)
+		assert.False(t, ok, path)
+	}
+}
+
+func TestNextClonePath(t *testing.T) {
+	base := t.TempDir()
+	cfg := &Config{
+		Remotes: map[string]*Remote{"airbyte": {Name: "airbyte", CloneBaseDir: base, CloneNaming: CloneNamingRemote}},
+		Clones: map[string]*Clone{
+			filepath.Join(base, "2"):         {Path: filepath.Join(base, "2"), RemoteName: "airbyte"},
+			filepath.Join(base, "airbyte-3"): {Path: filepath.Join(base, "airbyte-3"), RemoteName: "airbyte"},
+		},
+	}
+	assert.Equal(t, 4, cfg.GetNextCloneNumber("airbyte"))
+
+	path, err := cfg.NextClonePath("airbyte", "")
+	require.NoError(t, err)
+	assert.Equal(t, filepath.Join(base, "airbyte-4"), path)
+
+	// A directory left on disk is skipped
+	require.NoError(t, os.MkdirAll(filepath.Join(base, "airbyte-4"), 0755))
+	path, err = cfg.NextClonePath("airbyte", "")
+	require.NoError(t, err)
+	assert.Equal(t, filepath.Join(base, "airbyte-5"), path)
+
+	path, err = cfg.NextClonePath("airbyte", "/elsewhere")
+	require.NoError(t, err)
+	assert.Equal(t, "/elsewhere/airbyte-4", path)
+
+	_, err = cfg.NextClonePath("missing", "")
+	assert.Error(t, err)
+}
+
+func TestFreeClones_NamedClones(t *testing.T) {
+	cfg := &Config{
+		Remotes: map[string]*Remote{"airbyte": {Name: "airbyte", CloneNaming: CloneNamingRemote}},
+		Clones: map[string]*Clone{
+			"/clones/airbyte-10": {Path: "/clones/airbyte-10", RemoteName: "airbyte"},
+			"/clones/airbyte-2":  {Path: "/clones/airbyte-2", RemoteName: "airbyte"},
+			"/clones/misc":       {Path: "/clones/misc", RemoteName: "airbyte"},
+		},
+	}
+
+	var paths []string
+	for _, clone := range cfg.FreeClones("airbyte") {
+		paths = append(paths, clone.Path)
+	}
+	assert.Equal(t, []string{"/clones/airbyte-2", "/clones/airbyte-10", "/clones/misc"}, paths)
+}
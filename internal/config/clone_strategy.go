@@ -0,0 +1,79 @@
+package config
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// Clone strategies decide which free clone of a remote a new workspace gets
+const (
+	CloneStrategyLowest   = "lowest"   // lowest clone number first (default)
+	CloneStrategyLRU      = "lru"      // the clone freed longest ago first
+	CloneStrategyMRU      = "mru"      // the clone freed most recently first, whose build caches are warmest
+	CloneStrategyCleanest = "cleanest" // no uncommitted changes and fewest commits behind origin; ranked with git by the caller
+)
+
+// CloneStrategies lists the valid values of Remote.CloneStrategy
+var CloneStrategies = []string{CloneStrategyLowest, CloneStrategyLRU, CloneStrategyMRU, CloneStrategyCleanest}
+
+// ValidateCloneStrategy checks that strategy is one of CloneStrategies
+func ValidateCloneStrategy(strategy string) error {
+	for _, s := range CloneStrategies {
+		if s == strategy {
+			return nil
+		}
+	}
+	return fmt.Errorf("invalid clone strategy '%s' (must be one of %s)", strategy, strings.Join(CloneStrategies, ", "))
+}
+
+// GetCloneStrategy returns the remote's clone strategy, defaulting to lowest
+func (r *Remote) GetCloneStrategy() string {
+	if r.CloneStrategy == "" {
+		return CloneStrategyLowest
+	}
+	return r.CloneStrategy
+}
+
+// FreeClones returns a remote's free clones, best first by its clone strategy.
+// The cleanest strategy needs git, so its clones come back in lowest order for
+// the caller to re-rank.
+func (c *Config) FreeClones(remoteName string) []*Clone {
+	var free []*Clone
+	for _, clone := range c.Clones {
+		if clone.RemoteName == remoteName && clone.InUseBy == "" {
+			free = append(free, clone)
+		}
+	}
+
+	strategy := CloneStrategyLowest
+	if remote, err := c.GetRemote(remoteName); err == nil {
+		strategy = remote.GetCloneStrategy()
+	}
+
+	sort.SliceStable(free, func(i, j int) bool {
+		a, b := free[i], free[j]
+		switch {
+		case strategy == CloneStrategyLRU && !a.FreedAt.Equal(b.FreedAt):
+			return a.FreedAt.Before(b.FreedAt)
+		case strategy == CloneStrategyMRU && !a.FreedAt.Equal(b.FreedAt):
+			return a.FreedAt.After(b.FreedAt)
+		}
+		return c.cloneNumberLess(a, b)
+	})
+	return free
+}
+
+// cloneNumberLess orders clones by their number (2 before 10), with clones whose
+// directory name has no number after, by path
+func (c *Config) cloneNumberLess(a, b *Clone) bool {
+	na, okA := c.cloneNumber(a)
+	nb, okB := c.cloneNumber(b)
+	switch {
+	case okA && okB && na != nb:
+		return na < nb
+	case okA != okB:
+		return okA
+	}
+	return a.Path < b.Path
+}
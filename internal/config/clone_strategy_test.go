@@ -0,0 +1,55 @@
+package config
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFreeClones_Strategies(t *testing.T) {
+	now := time.Now()
+	cfg := &Config{
+		Remotes: map[string]*Remote{"origin": {Name: "origin"}},
+		Clones: map[string]*Clone{
+			"/clones/10":   {Path: "/clones/10", RemoteName: "origin", FreedAt: now.Add(-3 * time.Hour)},
+			"/clones/2":    {Path: "/clones/2", RemoteName: "origin", FreedAt: now.Add(-1 * time.Hour)},
+			"/clones/3":    {Path: "/clones/3", RemoteName: "origin", InUseBy: "ws"},
+			"/clones/misc": {Path: "/clones/misc", RemoteName: "origin", FreedAt: now.Add(-2 * time.Hour)},
+			"/other/1":     {Path: "/other/1", RemoteName: "other"},
+		},
+	}
+
+	paths := func() []string {
+		var p []string
+		for _, clone := range cfg.FreeClones("origin") {
+			p = append(p, clone.Path)
+		}
+		return p
+	}
+
+	// Default: lowest number first, non-numeric last
+	assert.Equal(t, []string{"/clones/2", "/clones/10", "/clones/misc"}, paths())
+	assert.Equal(t, "/clones/2", cfg.FindFreeClone("origin").Path)
+
+	cfg.Remotes["origin"].CloneStrategy = CloneStrategyLRU
+	assert.Equal(t, []string{"/clones/10", "/clones/misc", "/clones/2"}, paths())
+
+	cfg.Remotes["origin"].CloneStrategy = CloneStrategyMRU
+	assert.Equal(t, []string{"/clones/2", "/clones/misc", "/clones/10"}, paths())
+
+	// Ranked by the caller, so ordered like lowest here
+	cfg.Remotes["origin"].CloneStrategy = CloneStrategyCleanest
+	assert.Equal(t, []string{"/clones/2", "/clones/10", "/clones/misc"}, paths())
+
+	assert.Nil(t, cfg.FindFreeClone("missing"))
+}
+
+func TestValidateCloneStrategy(t *testing.T) {
+	for _, strategy := range CloneStrategies {
+		require.NoError(t, ValidateCloneStrategy(strategy))
+	}
+	assert.Error(t, ValidateCloneStrategy("random"))
+	assert.Equal(t, CloneStrategyLowest, (&Remote{}).GetCloneStrategy())
+}
@@ -0,0 +1,32 @@
+package config
+
+import (
+	"fmt"
+	"hash/fnv"
+	"strings"
+)
+
+// WorkspaceColors are the colors a workspace can be shown in. The names are
+// understood by tmux and mapped to ANSI colors for terminal output.
+var WorkspaceColors = []string{"red", "green", "yellow", "blue", "magenta", "cyan"}
+
+// ValidateColor checks that color is one of WorkspaceColors
+func ValidateColor(color string) error {
+	for _, c := range WorkspaceColors {
+		if c == color {
+			return nil
+		}
+	}
+	return fmt.Errorf("invalid color '%s' (must be one of %s)", color, strings.Join(WorkspaceColors, ", "))
+}
+
+// DisplayColor returns the workspace's color: the one set with "claudew color", or
+// one derived from its name, so every workspace has a stable color without setup
+func (w *Workspace) DisplayColor() string {
+	if w.Color != "" {
+		return w.Color
+	}
+	h := fnv.New32a()
+	h.Write([]byte(w.Name))
+	return WorkspaceColors[h.Sum32()%uint32(len(WorkspaceColors))]
+}
@@ -0,0 +1,24 @@
+package config
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestValidateColor(t *testing.T) {
+	assert.NoError(t, ValidateColor("blue"))
+	assert.Error(t, ValidateColor("chartreuse"))
+	assert.Error(t, ValidateColor(""))
+}
+
+func TestWorkspace_DisplayColor(t *testing.T) {
+	ws := &Workspace{Name: "feature-auth"}
+	auto := ws.DisplayColor()
+	assert.NoError(t, ValidateColor(auto))
+	// Derived colors are stable
+	assert.Equal(t, auto, (&Workspace{Name: "feature-auth"}).DisplayColor())
+
+	ws.Color = "magenta"
+	assert.Equal(t, "magenta", ws.DisplayColor())
+}
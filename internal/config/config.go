@@ -5,48 +5,147 @@ import (
 	"fmt"
 	"os"
 	"path/filepath"
+	"sort"
 	"strings"
 	"time"
 )
 
+// DefaultCloneGCDays is how long a clone must stay free before clone-gc removes it
+const DefaultCloneGCDays = 14
+
 const (
 	StatusActive   = "active"
 	StatusIdle     = "idle"
 	StatusArchived = "archived"
 )
 
+// Session modes for Settings.SessionMode
+const (
+	SessionModeTmux            = "tmux"
+	SessionModeWindowsTerminal = "wt"
+	SessionModeForeground      = "foreground"
+)
+
+// Suffixes for Settings.SessionNameSuffix
+const (
+	SessionNameSuffixSummary = "summary" // a slug of the workspace's summary
+	SessionNameSuffixProject = "project" // the remote's name, or the repo directory's
+)
+
 type Remote struct {
-	Name         string `json:"name"`
-	URL          string `json:"url"`
-	CloneBaseDir string `json:"clone_base_dir"`
+	Name          string `json:"name"`
+	URL           string `json:"url"`
+	CloneBaseDir  string `json:"clone_base_dir"`
+	Permissions   string `json:"permissions,omitempty"`    // permission preset applied to workspaces on this remote
+	CloneStrategy string `json:"clone_strategy,omitempty"` // which free clone new workspaces get; see CloneStrategies
+	CloneNaming   string `json:"clone_naming,omitempty"`   // clone directory name pattern, e.g. "{remote}-{n}"; default "{n}"
+	Shared        bool   `json:"-"`                        // loaded from Settings.SharedRemotesFile, read-only
 }
 
 type Clone struct {
-	Path         string    `json:"path"`
-	RemoteName   string    `json:"remote_name"`
-	CreatedAt    time.Time `json:"created_at"`
-	InUseBy      string    `json:"in_use_by,omitempty"` // workspace name, empty if free
-	CurrentBranch string   `json:"current_branch,omitempty"`
+	Path          string    `json:"path"`
+	RemoteName    string    `json:"remote_name"`
+	CreatedAt     time.Time `json:"created_at"`
+	InUseBy       string    `json:"in_use_by,omitempty"` // workspace name, empty if free
+	CurrentBranch string    `json:"current_branch,omitempty"`
+	FreedAt       time.Time `json:"freed_at"`       // when the clone last became free
+	BranchChecked time.Time `json:"branch_checked"` // when CurrentBranch was last read from git
+
+	// Recorded when a workspace gives the clone up, so later workspaces doing the
+	// same work can get the clone with warm build caches
+	LastSubdir string `json:"last_subdir,omitempty"` // subdir of the last workspace, "" for the repo root
+	BuildTool  string `json:"build_tool,omitempty"`  // e.g. "gradle" or "npm"
 }
 
 type Workspace struct {
-	Name       string    `json:"name"`
-	RepoPath   string    `json:"repo_path"`            // deprecated, kept for backward compat
-	ClonePath  string    `json:"clone_path,omitempty"` // new field
-	CreatedAt  time.Time `json:"created_at"`
-	LastActive time.Time `json:"last_active"`
-	Status     string    `json:"status"`
-	SessionPID int       `json:"session_pid,omitempty"`
+	Name        string            `json:"name"`
+	RepoPath    string            `json:"repo_path"`            // deprecated, kept for backward compat
+	ClonePath   string            `json:"clone_path,omitempty"` // new field
+	CreatedAt   time.Time         `json:"created_at"`
+	LastActive  time.Time         `json:"last_active"`
+	LastOpened  time.Time         `json:"last_opened,omitzero"` // when a terminal last attached to the session, for "claudew last" and "prev"
+	Status      string            `json:"status"`
+	SessionPID  int               `json:"session_pid,omitempty"`
+	Pinned      bool              `json:"pinned,omitempty"`      // always listed first in the select menu
+	Hidden      bool              `json:"hidden,omitempty"`      // left out of the select menu unless --all is given
+	Subdir      string            `json:"subdir,omitempty"`      // monorepo sub-path the workspace focuses on, relative to the repo
+	Env         map[string]string `json:"env,omitempty"`         // exported into the tmux session, overrides .claudew.env
+	Permissions string            `json:"permissions,omitempty"` // permission preset, overrides the remote's
+	Container   *Container        `json:"container,omitempty"`   // run Claude inside a Docker container instead of on the host
+	Ports       []int             `json:"ports,omitempty"`       // dev-server ports reserved for this workspace, exported as env vars
+	Agents      []Agent           `json:"agents,omitempty"`      // extra Claude instances spawned in their own tmux windows
+	Issue       string            `json:"issue,omitempty"`       // URL of the GitHub issue or Jira ticket the workspace was created from
+	Color       string            `json:"color,omitempty"`       // one of WorkspaceColors; derived from the name when empty
+	Branch      string            `json:"branch,omitempty"`      // branch the workspace was last on, recorded on stop and detach
+	Handover    string            `json:"handover,omitempty"`    // first message typed into Claude in new sessions, overrides handover_note; "none" disables it
+	Restarts    int               `json:"restarts,omitempty"`    // times Claude was restarted in the session, by restart or supervise
+	LastBackup  time.Time         `json:"last_backup,omitzero"`  // when "claudew backup" last uploaded the workspace directory
+	Offloaded   bool              `json:"offloaded,omitempty"`   // archived with --offload: the directory only exists in the backup
+	Dir         string            `json:"dir,omitempty"`         // workspace directory when it isn't <workspace_dir>/<name>, e.g. inside the repo
+
+	// tmux session name when it has a suffix from session_name_suffix, chosen as the
+	// session is created so later summary edits don't lose track of it
+	SessionName string `json:"session_name,omitempty"`
+
+	// Workflow state set with "claudew state", independent of Status
+	State        string    `json:"state,omitempty"`        // one of WorkspaceStates, empty when untracked
+	StateReason  string    `json:"state_reason,omitempty"` // why, e.g. what a blocked workspace waits on
+	StateChanged time.Time `json:"state_changed,omitzero"` // when State was last changed
+	BlockedUntil time.Time `json:"blocked_until,omitzero"` // when to remind about a blocked workspace
+}
+
+// Container configures the Docker container a workspace runs Claude in
+type Container struct {
+	Image   string   `json:"image"`             // image with claude installed
+	Network string   `json:"network,omitempty"` // docker --network, e.g. "none" to block network access
+	Args    []string `json:"args,omitempty"`    // extra docker run arguments, e.g. credential mounts
 }
 
 type Settings struct {
-	WorkspaceDir      string `json:"workspace_dir"`
-	AutoStartClaude   bool   `json:"auto_start_claude"`
-	RequireSessionLock bool   `json:"require_session_lock"`
-	ClaudeCommand     string `json:"claude_command"`
+	WorkspaceDir            string                 `json:"workspace_dir"`
+	AutoStartClaude         bool                   `json:"auto_start_claude"`
+	RequireSessionLock      bool                   `json:"require_session_lock"`
+	ClaudeCommand           string                 `json:"claude_command"`
+	CloneGCDays             int                    `json:"clone_gc_days,omitempty"`              // free clones older than this are removed by clone-gc
+	NotesWindow             bool                   `json:"notes_window,omitempty"`               // add a "notes" window cd'd into the workspace directory
+	ClipboardCommand        string                 `json:"clipboard_command,omitempty"`          // overrides clipboard detection, run via sh -c with text on stdin
+	EventWebhookURL         string                 `json:"event_webhook_url,omitempty"`          // receives lifecycle events as JSON POSTs
+	EventCommand            string                 `json:"event_command,omitempty"`              // run via sh -c with the event JSON on stdin
+	CustomActions           []CustomAction         `json:"custom_actions,omitempty"`             // extra entries in the select menu's ACTIONS section
+	PermissionPresets       map[string]Permissions `json:"permission_presets,omitempty"`         // named allow/deny lists for .claude/settings.local.json
+	ContextStatusLine       bool                   `json:"context_statusline,omitempty"`         // report Claude context usage in the tmux status line
+	ContextWarnPercent      int                    `json:"context_warn_percent,omitempty"`       // flag context usage at or above this percentage (default 80)
+	PortRangeStart          int                    `json:"port_range_start,omitempty"`           // first port handed out to workspaces (default 20000)
+	PortsPerWorkspace       int                    `json:"ports_per_workspace,omitempty"`        // size of each workspace's port block (default 10)
+	RedactPatterns          []string               `json:"redact_patterns,omitempty"`            // extra regexes scrubbed from handoff bundles
+	FzfPreviewWindow        string                 `json:"fzf_preview_window,omitempty"`         // fzf --preview-window for menus with a preview (default right:50%:wrap)
+	FzfHeight               string                 `json:"fzf_height,omitempty"`                 // fzf --height for every menu
+	FzfTmux                 string                 `json:"fzf_tmux,omitempty"`                   // fzf --tmux popup options, used when running inside tmux (fzf 0.53+)
+	WipCommit               bool                   `json:"wip_commit,omitempty"`                 // stop/archive snapshot uncommitted changes to a local wip/<workspace> branch
+	SuperviseClaude         bool                   `json:"supervise_claude,omitempty"`           // record Claude's exit status so "claudew supervise" can restart crashed sessions
+	BranchCacheMinutes      int                    `json:"branch_cache_minutes,omitempty"`       // re-read clone branches from git at most this often (default 5)
+	ContextMaxWords         int                    `json:"context_max_words,omitempty"`          // warn when context.md grows past this many words (default 500, -1 disables)
+	ContinuationMaxAgeHours int                    `json:"continuation_max_age_hours,omitempty"` // warn when a running workspace's continuation.md is older than this (default 24, -1 disables)
+	JiraBaseURL             string                 `json:"jira_base_url,omitempty"`              // Jira site for create --from-jira, e.g. https://acme.atlassian.net
+	JiraEmail               string                 `json:"jira_email,omitempty"`                 // Jira Cloud account email; leave empty to send jira_token as a bearer PAT
+	JiraToken               string                 `json:"jira_token,omitempty"`                 // Jira API token ($JIRA_API_TOKEN takes precedence)
+	JiraAcceptanceField     string                 `json:"jira_acceptance_field,omitempty"`      // custom field with acceptance criteria, e.g. customfield_10035
+	DigestWebhookURL        string                 `json:"digest_webhook_url,omitempty"`         // "claudew digest --send" POSTs {"text": ...} here, e.g. a Slack incoming webhook
+	DigestCommand           string                 `json:"digest_command,omitempty"`             // "claudew digest --send" pipes the digest to this via sh -c, e.g. a mail command
+	ConfigBackups           int                    `json:"config_backups,omitempty"`             // timestamped config.json.bak-* copies Save keeps (default 10, -1 disables)
+	SharedRemotesFile       string                 `json:"shared_remotes_file,omitempty"`        // read-only team remotes file merged into Remotes, e.g. from a dotfiles repo
+	DecisionKey             string                 `json:"decision_key,omitempty"`               // tmux key after the prefix that prompts for a decision to append to decisions.md (default D, "none" disables)
+	SessionMode             string                 `json:"session_mode,omitempty"`               // "tmux" (default), "wt" to open Claude in a Windows Terminal tab, or "foreground" to run it without a multiplexer
+	ClaudeMinVersion        string                 `json:"claude_min_version,omitempty"`         // refuse to launch a claude_command older than this, e.g. "2.0.0"
+	HandoverNote            string                 `json:"handover_note,omitempty"`              // first message typed into Claude in every new session, e.g. "Read continuation.md, then summarize the plan"
+	CommitHook              bool                   `json:"commit_hook,omitempty"`                // install a post-commit hook logging commits to context.md in clones as sessions start
+	BackupTarget            string                 `json:"backup_target,omitempty"`              // where "claudew backup" uploads workspaces: s3://bucket/prefix, gs://bucket/prefix, rclone:remote:path or a directory
+	BackupIncludeEnv        bool                   `json:"backup_include_env,omitempty"`         // also upload each workspace's .claudew.env, which may hold secrets
+	SessionNameSuffix       string                 `json:"session_name_suffix,omitempty"`        // "summary" or "project" appends a slug to new tmux session names, e.g. claude-ws-auth-fix-login-redirect
 }
 
 type Config struct {
+	Version    int                   `json:"version"`
 	Workspaces map[string]*Workspace `json:"workspaces"`
 	Remotes    map[string]*Remote    `json:"remotes"`
 	Clones     map[string]*Clone     `json:"clones"` // keyed by path
@@ -94,6 +193,26 @@ func Load() (*Config, error) {
 		cfg.Workspaces = make(map[string]*Workspace)
 	}
 
+	// Upgrade older config formats, keeping a backup of the original file
+	if cfg.NeedsMigration() {
+		fromVersion := cfg.Version
+		if _, err := cfg.Migrate(); err != nil {
+			return nil, err
+		}
+		if _, err := backupConfigFile(configPath, data, fmt.Sprintf(".v%d.bak", fromVersion)); err != nil {
+			return nil, err
+		}
+		if err := cfg.Save(); err != nil {
+			return nil, fmt.Errorf("failed to save migrated config: %w", err)
+		}
+	} else if cfg.Version > CurrentVersion {
+		return nil, fmt.Errorf("config version %d is newer than supported version %d; please upgrade claudew", cfg.Version, CurrentVersion)
+	}
+
+	if err := cfg.loadSharedRemotes(); err != nil {
+		return nil, err
+	}
+
 	return &cfg, nil
 }
 
@@ -110,11 +229,19 @@ func (c *Config) Save() error {
 		return fmt.Errorf("failed to create config directory: %w", err)
 	}
 
-	data, err := json.MarshalIndent(c, "", "  ")
+	// Shared remotes live in their own file
+	local := *c
+	local.Remotes = c.localRemotes()
+	data, err := json.MarshalIndent(&local, "", "  ")
 	if err != nil {
 		return fmt.Errorf("failed to marshal config: %w", err)
 	}
 
+	// Keep the previous contents so a bad save can be rolled back with "claudew config restore"
+	if err := rotateConfigBackup(configPath, data, c.backupLimit(), time.Now()); err != nil {
+		return err
+	}
+
 	if err := os.WriteFile(configPath, data, 0644); err != nil {
 		return fmt.Errorf("failed to write config: %w", err)
 	}
@@ -126,6 +253,7 @@ func (c *Config) Save() error {
 func NewDefaultConfig() *Config {
 	home, _ := os.UserHomeDir()
 	return &Config{
+		Version:    CurrentVersion,
 		Workspaces: make(map[string]*Workspace),
 		Remotes:    make(map[string]*Remote),
 		Clones:     make(map[string]*Clone),
@@ -134,6 +262,7 @@ func NewDefaultConfig() *Config {
 			AutoStartClaude:    true,
 			RequireSessionLock: true,
 			ClaudeCommand:      "claude",
+			CloneGCDays:        DefaultCloneGCDays,
 		},
 	}
 }
@@ -237,6 +366,29 @@ func (w *Workspace) GetRepoPath() string {
 	return w.RepoPath
 }
 
+// GetWorkDir returns the directory sessions start in: the repo path, or its subdir if set
+func (w *Workspace) GetWorkDir() string {
+	if w.Subdir == "" {
+		return w.GetRepoPath()
+	}
+	return filepath.Join(w.GetRepoPath(), w.Subdir)
+}
+
+// ValidateSubdir checks that a workspace subdir is a relative path inside the repo
+func ValidateSubdir(subdir string) error {
+	if subdir == "" {
+		return nil
+	}
+	if filepath.IsAbs(subdir) {
+		return fmt.Errorf("subdir must be relative to the repository: %s", subdir)
+	}
+	clean := filepath.Clean(subdir)
+	if clean == ".." || strings.HasPrefix(clean, ".."+string(filepath.Separator)) {
+		return fmt.Errorf("subdir must be inside the repository: %s", subdir)
+	}
+	return nil
+}
+
 // Remote management
 
 // AddRemote adds a new remote to the config
@@ -265,17 +417,71 @@ func (c *Config) GetRemote(name string) (*Remote, error) {
 
 // Clone management
 
+// RenameRemote renames a remote and updates the clones that reference it
+func (c *Config) RenameRemote(oldName, newName string) error {
+	remote, err := c.GetRemote(oldName)
+	if err != nil {
+		return err
+	}
+	if newName == "" {
+		return fmt.Errorf("new remote name cannot be empty")
+	}
+	if remote.Shared {
+		return fmt.Errorf("remote '%s' comes from the shared remotes file and can't be renamed here", oldName)
+	}
+	if _, exists := c.Remotes[newName]; exists {
+		return fmt.Errorf("remote '%s' already exists", newName)
+	}
+
+	delete(c.Remotes, oldName)
+	remote.Name = newName
+	c.Remotes[newName] = remote
+
+	for _, clone := range c.Clones {
+		if clone.RemoteName == oldName {
+			clone.RemoteName = newName
+		}
+	}
+
+	return nil
+}
+
+// MoveClone re-registers a clone under a new path and updates the workspace using it
+func (c *Config) MoveClone(oldPath, newPath string) error {
+	clone, err := c.GetClone(oldPath)
+	if err != nil {
+		return err
+	}
+	if _, exists := c.Clones[newPath]; exists {
+		return fmt.Errorf("clone at '%s' already exists", newPath)
+	}
+
+	delete(c.Clones, oldPath)
+	clone.Path = newPath
+	c.Clones[newPath] = clone
+
+	if clone.InUseBy != "" {
+		if ws, err := c.GetWorkspace(clone.InUseBy); err == nil && ws.ClonePath == oldPath {
+			ws.ClonePath = newPath
+		}
+	}
+
+	return nil
+}
+
 // AddClone adds a new clone to the config
 func (c *Config) AddClone(path, remoteName string) error {
 	if _, exists := c.Clones[path]; exists {
 		return fmt.Errorf("clone at '%s' already exists", path)
 	}
 
+	now := time.Now()
 	c.Clones[path] = &Clone{
 		Path:       path,
 		RemoteName: remoteName,
-		CreatedAt:  time.Now(),
+		CreatedAt:  now,
 		InUseBy:    "",
+		FreedAt:    now,
 	}
 
 	return nil
@@ -290,7 +496,18 @@ func (c *Config) GetClone(path string) (*Clone, error) {
 	return clone, nil
 }
 
-// GetClonesForRemote returns all clones for a given remote
+// SetBranch records branch as the clone's current branch, read from git at now
+func (c *Clone) SetBranch(branch string, now time.Time) {
+	c.CurrentBranch = branch
+	c.BranchChecked = now
+}
+
+// BranchStale reports whether the cached branch is older than maxAge
+func (c *Clone) BranchStale(maxAge time.Duration, now time.Time) bool {
+	return now.Sub(c.BranchChecked) >= maxAge
+}
+
+// GetClonesForRemote returns all clones for a given remote, sorted by path
 func (c *Config) GetClonesForRemote(remoteName string) []*Clone {
 	var clones []*Clone
 	for _, clone := range c.Clones {
@@ -298,15 +515,17 @@ func (c *Config) GetClonesForRemote(remoteName string) []*Clone {
 			clones = append(clones, clone)
 		}
 	}
+	sort.Slice(clones, func(i, j int) bool {
+		return clones[i].Path < clones[j].Path
+	})
 	return clones
 }
 
-// FindFreeClone finds an available (not in use) clone for a remote
+// FindFreeClone finds an available (not in use) clone for a remote, the first
+// by the remote's clone strategy
 func (c *Config) FindFreeClone(remoteName string) *Clone {
-	for _, clone := range c.Clones {
-		if clone.RemoteName == remoteName && clone.InUseBy == "" {
-			return clone
-		}
+	if free := c.FreeClones(remoteName); len(free) > 0 {
+		return free[0]
 	}
 	return nil
 }
@@ -340,6 +559,55 @@ func (c *Config) AssignCloneToWorkspace(clonePath, workspaceName string) error {
 	return nil
 }
 
+// BindWorkspaceClone points a workspace at a clone, freeing the clone it used before
+func (c *Config) BindWorkspaceClone(workspaceName, clonePath string) error {
+	ws, err := c.GetWorkspace(workspaceName)
+	if err != nil {
+		return err
+	}
+
+	clone, err := c.GetClone(clonePath)
+	if err != nil {
+		return err
+	}
+	if clone.InUseBy != "" && clone.InUseBy != workspaceName {
+		return fmt.Errorf("clone is already in use by workspace '%s'", clone.InUseBy)
+	}
+
+	if ws.ClonePath != "" && ws.ClonePath != clonePath {
+		if old, err := c.GetClone(ws.ClonePath); err == nil && old.InUseBy == workspaceName {
+			c.FreeClone(old.Path)
+		}
+	}
+
+	clone.InUseBy = workspaceName
+	ws.ClonePath = clonePath
+	ws.RepoPath = clonePath
+	return nil
+}
+
+// UnbindWorkspaceClone detaches a workspace from its clone and frees the clone.
+// Returns the path of the clone the workspace was using.
+func (c *Config) UnbindWorkspaceClone(workspaceName string) (string, error) {
+	ws, err := c.GetWorkspace(workspaceName)
+	if err != nil {
+		return "", err
+	}
+
+	clonePath := ws.GetRepoPath()
+	if clonePath == "" {
+		return "", fmt.Errorf("workspace '%s' has no clone assigned", workspaceName)
+	}
+
+	if clone, err := c.GetClone(clonePath); err == nil && clone.InUseBy == workspaceName {
+		c.FreeClone(clonePath)
+	}
+
+	ws.ClonePath = ""
+	ws.RepoPath = ""
+	return clonePath, nil
+}
+
 // FreeClone marks a clone as available (not in use)
 func (c *Config) FreeClone(clonePath string) error {
 	clone, err := c.GetClone(clonePath)
@@ -347,24 +615,76 @@ func (c *Config) FreeClone(clonePath string) error {
 		return err
 	}
 
+	if clone.InUseBy != "" {
+		clone.FreedAt = time.Now()
+	}
 	clone.InUseBy = ""
 	return nil
 }
 
+// RemoveClone removes a free clone from the config
+func (c *Config) RemoveClone(path string) error {
+	clone, err := c.GetClone(path)
+	if err != nil {
+		return err
+	}
+
+	if clone.InUseBy != "" {
+		return fmt.Errorf("clone is in use by workspace '%s'", clone.InUseBy)
+	}
+
+	delete(c.Clones, path)
+	return nil
+}
+
+// FindStaleFreeClones returns free clones that have not been used since before cutoff, sorted by path
+func (c *Config) FindStaleFreeClones(remoteName string, cutoff time.Time) []*Clone {
+	var stale []*Clone
+	for _, clone := range c.Clones {
+		if clone.InUseBy != "" {
+			continue
+		}
+		if remoteName != "" && clone.RemoteName != remoteName {
+			continue
+		}
+		if clone.FreedAt.Before(cutoff) {
+			stale = append(stale, clone)
+		}
+	}
+	sort.Slice(stale, func(i, j int) bool {
+		return stale[i].Path < stale[j].Path
+	})
+	return stale
+}
+
 // GetNextCloneNumber returns the next available clone number for a remote
 func (c *Config) GetNextCloneNumber(remoteName string) int {
 	maxNum := 0
 	for _, clone := range c.Clones {
 		if clone.RemoteName == remoteName {
-			// Extract number from path
-			base := filepath.Base(clone.Path)
-			var num int
-			if _, err := fmt.Sscanf(base, "%d", &num); err == nil {
-				if num > maxNum {
-					maxNum = num
-				}
+			if num, ok := c.cloneNumber(clone); ok && num > maxNum {
+				maxNum = num
 			}
 		}
 	}
 	return maxNum + 1
 }
+
+// NextClonePath returns where the remote's next clone goes: its next clone number,
+// named by its naming pattern, in baseDir (the remote's clone directory if empty),
+// skipping paths already on disk or registered
+func (c *Config) NextClonePath(remoteName, baseDir string) (string, error) {
+	remote, err := c.GetRemote(remoteName)
+	if err != nil {
+		return "", err
+	}
+	if baseDir == "" {
+		baseDir = remote.CloneBaseDir
+	}
+	for num := c.GetNextCloneNumber(remoteName); ; num++ {
+		path := filepath.Join(baseDir, remote.CloneDirName(num))
+		if _, err := os.Stat(path); os.IsNotExist(err) && c.Clones[path] == nil {
+			return path, nil
+		}
+	}
+}
@@ -430,6 +430,25 @@ func TestWorkspace_GetRepoPath(t *testing.T) {
 	}
 }
 
+func TestWorkspace_GetWorkDir(t *testing.T) {
+	ws := Workspace{ClonePath: "/repos/mono"}
+	assert.Equal(t, "/repos/mono", ws.GetWorkDir())
+
+	ws.Subdir = "services/billing"
+	assert.Equal(t, "/repos/mono/services/billing", ws.GetWorkDir())
+}
+
+func TestValidateSubdir(t *testing.T) {
+	assert.NoError(t, ValidateSubdir(""))
+	assert.NoError(t, ValidateSubdir("services/billing"))
+	assert.NoError(t, ValidateSubdir("./services/../api"))
+
+	assert.Error(t, ValidateSubdir("/abs/path"))
+	assert.Error(t, ValidateSubdir(".."))
+	assert.Error(t, ValidateSubdir("../other-repo"))
+	assert.Error(t, ValidateSubdir("services/../../escape"))
+}
+
 func TestConfig_AddRemote(t *testing.T) {
 	cfg := createTestConfig(t, setupTestDir(t))
 
@@ -657,3 +676,189 @@ func TestConfig_JSONRoundTrip(t *testing.T) {
 	clone, _ := loaded.GetClone("/tmp/clones/1")
 	assert.Equal(t, "test-ws", clone.InUseBy)
 }
+
+func TestClone_BranchCache(t *testing.T) {
+	now := time.Date(2026, 3, 4, 12, 0, 0, 0, time.UTC)
+	clone := &Clone{Path: "/tmp/clones/1"}
+
+	// Never checked
+	assert.True(t, clone.BranchStale(5*time.Minute, now))
+
+	clone.SetBranch("main", now)
+	assert.Equal(t, "main", clone.CurrentBranch)
+	assert.False(t, clone.BranchStale(5*time.Minute, now.Add(4*time.Minute)))
+	assert.True(t, clone.BranchStale(5*time.Minute, now.Add(5*time.Minute)))
+}
+
+func TestConfig_FreeClone_SetsFreedAt(t *testing.T) {
+	cfg := createTestConfig(t, setupTestDir(t))
+	cfg.AddClone("/tmp/clones/1", "origin")
+	cfg.AddWorkspace("ws", "/tmp/clones/1")
+	cfg.AssignCloneToWorkspace("/tmp/clones/1", "ws")
+
+	clone, _ := cfg.GetClone("/tmp/clones/1")
+	clone.FreedAt = time.Now().Add(-48 * time.Hour)
+
+	require.NoError(t, cfg.FreeClone("/tmp/clones/1"))
+	assert.WithinDuration(t, time.Now(), clone.FreedAt, time.Minute)
+
+	// Freeing an already free clone keeps the original timestamp
+	freedAt := clone.FreedAt
+	require.NoError(t, cfg.FreeClone("/tmp/clones/1"))
+	assert.Equal(t, freedAt, clone.FreedAt)
+}
+
+func TestConfig_FindStaleFreeClones(t *testing.T) {
+	cfg := createTestConfig(t, setupTestDir(t))
+	cfg.AddClone("/tmp/clones/1", "origin")
+	cfg.AddClone("/tmp/clones/2", "origin")
+	cfg.AddClone("/tmp/clones/3", "origin")
+	cfg.AddClone("/tmp/other/1", "other")
+
+	old := time.Now().Add(-30 * 24 * time.Hour)
+	cfg.Clones["/tmp/clones/1"].FreedAt = old
+	cfg.Clones["/tmp/clones/2"].FreedAt = old
+	cfg.Clones["/tmp/clones/2"].InUseBy = "ws"
+	cfg.Clones["/tmp/other/1"].FreedAt = old
+
+	cutoff := time.Now().Add(-14 * 24 * time.Hour)
+
+	stale := cfg.FindStaleFreeClones("origin", cutoff)
+	require.Len(t, stale, 1)
+	assert.Equal(t, "/tmp/clones/1", stale[0].Path)
+
+	// Empty remote matches all remotes
+	stale = cfg.FindStaleFreeClones("", cutoff)
+	require.Len(t, stale, 2)
+	assert.Equal(t, "/tmp/clones/1", stale[0].Path)
+	assert.Equal(t, "/tmp/other/1", stale[1].Path)
+}
+
+func TestConfig_RemoveClone(t *testing.T) {
+	cfg := createTestConfig(t, setupTestDir(t))
+	cfg.AddClone("/tmp/clones/1", "origin")
+	cfg.AddClone("/tmp/clones/2", "origin")
+	cfg.Clones["/tmp/clones/2"].InUseBy = "ws"
+
+	require.NoError(t, cfg.RemoveClone("/tmp/clones/1"))
+	_, err := cfg.GetClone("/tmp/clones/1")
+	assert.Error(t, err)
+
+	// Clones in use cannot be removed
+	err = cfg.RemoveClone("/tmp/clones/2")
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "in use")
+
+	// Unknown clones return an error
+	assert.Error(t, cfg.RemoveClone("/tmp/clones/missing"))
+}
+
+func TestConfig_RenameRemote(t *testing.T) {
+	cfg := createTestConfig(t, setupTestDir(t))
+
+	require.NoError(t, cfg.AddRemote("origin", "git@github.com:user/repo.git", "/tmp/clones"))
+	require.NoError(t, cfg.AddRemote("other", "git@github.com:user/other.git", "/tmp/other"))
+	require.NoError(t, cfg.AddClone("/tmp/clones/1", "origin"))
+	require.NoError(t, cfg.AddClone("/tmp/other/1", "other"))
+
+	err := cfg.RenameRemote("origin", "renamed")
+	require.NoError(t, err)
+
+	_, err = cfg.GetRemote("origin")
+	assert.Error(t, err)
+
+	remote, err := cfg.GetRemote("renamed")
+	require.NoError(t, err)
+	assert.Equal(t, "renamed", remote.Name)
+
+	assert.Equal(t, "renamed", cfg.Clones["/tmp/clones/1"].RemoteName)
+	assert.Equal(t, "other", cfg.Clones["/tmp/other/1"].RemoteName)
+
+	// Renaming onto an existing remote fails
+	err = cfg.RenameRemote("renamed", "other")
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "already exists")
+
+	// Renaming a missing remote fails
+	err = cfg.RenameRemote("missing", "new")
+	assert.Error(t, err)
+}
+
+func TestConfig_MoveClone(t *testing.T) {
+	cfg := createTestConfig(t, setupTestDir(t))
+
+	require.NoError(t, cfg.AddRemote("origin", "git@github.com:user/repo.git", "/tmp/clones"))
+	require.NoError(t, cfg.AddClone("/tmp/clones/1", "origin"))
+	require.NoError(t, cfg.AddClone("/tmp/clones/2", "origin"))
+	require.NoError(t, cfg.AddWorkspace("test-ws", ""))
+	cfg.Workspaces["test-ws"].ClonePath = "/tmp/clones/1"
+	require.NoError(t, cfg.AssignCloneToWorkspace("/tmp/clones/1", "test-ws"))
+
+	err := cfg.MoveClone("/tmp/clones/1", "/tmp/new/1")
+	require.NoError(t, err)
+
+	_, err = cfg.GetClone("/tmp/clones/1")
+	assert.Error(t, err)
+
+	clone, err := cfg.GetClone("/tmp/new/1")
+	require.NoError(t, err)
+	assert.Equal(t, "/tmp/new/1", clone.Path)
+	assert.Equal(t, "test-ws", clone.InUseBy)
+	assert.Equal(t, "/tmp/new/1", cfg.Workspaces["test-ws"].ClonePath)
+
+	// Moving onto an existing clone fails
+	err = cfg.MoveClone("/tmp/clones/2", "/tmp/new/1")
+	assert.Error(t, err)
+}
+
+func TestConfig_BindWorkspaceClone(t *testing.T) {
+	cfg := createTestConfig(t, setupTestDir(t))
+
+	cfg.AddRemote("origin", "git@github.com:user/repo.git", "/tmp/clones")
+	cfg.AddClone("/tmp/clones/1", "origin")
+	cfg.AddClone("/tmp/clones/2", "origin")
+	cfg.AddClone("/tmp/clones/3", "origin")
+	cfg.AddWorkspace("test-ws", "/tmp/clones/1")
+	cfg.Workspaces["test-ws"].ClonePath = "/tmp/clones/1"
+	cfg.AssignCloneToWorkspace("/tmp/clones/1", "test-ws")
+	cfg.AssignCloneToWorkspace("/tmp/clones/3", "other-ws")
+
+	// Rebinding frees the old clone
+	err := cfg.BindWorkspaceClone("test-ws", "/tmp/clones/2")
+	require.NoError(t, err)
+
+	ws, _ := cfg.GetWorkspace("test-ws")
+	assert.Equal(t, "/tmp/clones/2", ws.GetRepoPath())
+	assert.Equal(t, "test-ws", cfg.Clones["/tmp/clones/2"].InUseBy)
+	assert.Equal(t, "", cfg.Clones["/tmp/clones/1"].InUseBy)
+
+	// Clones in use by another workspace are refused
+	err = cfg.BindWorkspaceClone("test-ws", "/tmp/clones/3")
+	assert.Error(t, err)
+	assert.Equal(t, "/tmp/clones/2", ws.ClonePath)
+
+	// Unknown clones and workspaces are refused
+	assert.Error(t, cfg.BindWorkspaceClone("test-ws", "/tmp/clones/9"))
+	assert.Error(t, cfg.BindWorkspaceClone("missing", "/tmp/clones/1"))
+}
+
+func TestConfig_UnbindWorkspaceClone(t *testing.T) {
+	cfg := createTestConfig(t, setupTestDir(t))
+
+	cfg.AddRemote("origin", "git@github.com:user/repo.git", "/tmp/clones")
+	cfg.AddClone("/tmp/clones/1", "origin")
+	cfg.AddWorkspace("test-ws", "/tmp/clones/1")
+	require.NoError(t, cfg.BindWorkspaceClone("test-ws", "/tmp/clones/1"))
+
+	path, err := cfg.UnbindWorkspaceClone("test-ws")
+	require.NoError(t, err)
+	assert.Equal(t, "/tmp/clones/1", path)
+
+	ws, _ := cfg.GetWorkspace("test-ws")
+	assert.Equal(t, "", ws.GetRepoPath())
+	assert.Equal(t, "", cfg.Clones["/tmp/clones/1"].InUseBy)
+
+	// Nothing left to unbind
+	_, err = cfg.UnbindWorkspaceClone("test-ws")
+	assert.Error(t, err)
+}
@@ -0,0 +1,17 @@
+package config
+
+// HandoverNone is the workspace handover that turns off the handover_note setting for it
+const HandoverNone = "none"
+
+// HandoverNote returns the message Claude is given first in a workspace's new
+// sessions: its own handover, else the handover_note setting, or "" for none
+func (c *Config) HandoverNote(ws *Workspace) string {
+	switch ws.Handover {
+	case HandoverNone:
+		return ""
+	case "":
+		return c.Settings.HandoverNote
+	default:
+		return ws.Handover
+	}
+}
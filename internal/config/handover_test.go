@@ -0,0 +1,22 @@
+package config
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestConfig_HandoverNote(t *testing.T) {
+	cfg := &Config{}
+	ws := &Workspace{Name: "feature-auth"}
+	assert.Equal(t, "", cfg.HandoverNote(ws))
+
+	cfg.Settings.HandoverNote = "Read continuation.md"
+	assert.Equal(t, "Read continuation.md", cfg.HandoverNote(ws))
+
+	ws.Handover = "Summarize the open PR review"
+	assert.Equal(t, "Summarize the open PR review", cfg.HandoverNote(ws))
+
+	ws.Handover = HandoverNone
+	assert.Equal(t, "", cfg.HandoverNote(ws))
+}
@@ -0,0 +1,114 @@
+package config
+
+import (
+	"fmt"
+	"os"
+	"strconv"
+	"time"
+)
+
+// CurrentVersion is the config schema version written by this build
+const CurrentVersion = 2
+
+// migration upgrades a config from version-1 to version
+type migration struct {
+	version     int
+	description string
+	apply       func(*Config) error
+}
+
+// migrations is the ordered registry of schema upgrades. To change the config
+// format, append a migration and bump CurrentVersion.
+var migrations = []migration{
+	{
+		version:     1,
+		description: "populate clone_path for workspaces using managed clones",
+		apply:       migrateClonePaths,
+	},
+	{
+		version:     2,
+		description: "start the free-clone clock for clones freed before it was tracked",
+		apply:       migrateCloneFreedAt,
+	},
+}
+
+// Migrate upgrades cfg in place to CurrentVersion, returning the descriptions
+// of the migrations that were applied
+func (c *Config) Migrate() ([]string, error) {
+	if c.Version > CurrentVersion {
+		return nil, fmt.Errorf("config version %d is newer than supported version %d; please upgrade claudew", c.Version, CurrentVersion)
+	}
+
+	var applied []string
+	for _, m := range migrations {
+		if m.version <= c.Version {
+			continue
+		}
+		if err := m.apply(c); err != nil {
+			return applied, fmt.Errorf("migration to version %d failed: %w", m.version, err)
+		}
+		c.Version = m.version
+		applied = append(applied, m.description)
+	}
+
+	return applied, nil
+}
+
+// NeedsMigration reports whether the config is older than CurrentVersion
+func (c *Config) NeedsMigration() bool {
+	return c.Version < CurrentVersion
+}
+
+// backupConfigFile writes config contents next to the config file as configPath+suffix.
+// An existing backup is never overwritten: if the name is taken, -2, -3 and so on is
+// appended until it is unique.
+func backupConfigFile(configPath string, data []byte, suffix string) (string, error) {
+	for n := 1; ; n++ {
+		backupPath := configPath + suffix
+		if n > 1 {
+			backupPath += "-" + strconv.Itoa(n)
+		}
+		f, err := os.OpenFile(backupPath, os.O_WRONLY|os.O_CREATE|os.O_EXCL, 0644)
+		if os.IsExist(err) {
+			continue
+		}
+		if err != nil {
+			return "", fmt.Errorf("failed to back up config: %w", err)
+		}
+		_, err = f.Write(data)
+		if closeErr := f.Close(); err == nil {
+			err = closeErr
+		}
+		if err != nil {
+			os.Remove(backupPath)
+			return "", fmt.Errorf("failed to back up config: %w", err)
+		}
+		return backupPath, nil
+	}
+}
+
+// migrateClonePaths sets ClonePath on legacy workspaces whose RepoPath points
+// at a registered clone, so clone management recognizes them
+func migrateClonePaths(c *Config) error {
+	for _, ws := range c.Workspaces {
+		if ws.ClonePath != "" || ws.RepoPath == "" {
+			continue
+		}
+		if _, exists := c.Clones[ws.RepoPath]; exists {
+			ws.ClonePath = ws.RepoPath
+		}
+	}
+	return nil
+}
+
+// migrateCloneFreedAt records free clones as freed now, since the real time
+// is unknown and treating them as long-free would make clone-gc delete them
+func migrateCloneFreedAt(c *Config) error {
+	now := time.Now()
+	for _, clone := range c.Clones {
+		if clone.InUseBy == "" && clone.FreedAt.IsZero() {
+			clone.FreedAt = now
+		}
+	}
+	return nil
+}
@@ -0,0 +1,114 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestConfig_Migrate_ClonePaths(t *testing.T) {
+	cfg := createTestConfig(t, setupTestDir(t))
+	cfg.Version = 0
+
+	cfg.AddClone("/tmp/clones/1", "origin")
+	cfg.AddWorkspace("managed", "/tmp/clones/1")
+	cfg.AddWorkspace("legacy", "/tmp/legacy-repo")
+
+	applied, err := cfg.Migrate()
+	require.NoError(t, err)
+	assert.Len(t, applied, CurrentVersion)
+	assert.Equal(t, CurrentVersion, cfg.Version)
+
+	managed, _ := cfg.GetWorkspace("managed")
+	assert.Equal(t, "/tmp/clones/1", managed.ClonePath)
+
+	legacy, _ := cfg.GetWorkspace("legacy")
+	assert.Equal(t, "", legacy.ClonePath)
+
+	// Migrating an up-to-date config is a no-op
+	applied, err = cfg.Migrate()
+	require.NoError(t, err)
+	assert.Empty(t, applied)
+}
+
+func TestConfig_Migrate_NewerVersion(t *testing.T) {
+	cfg := createTestConfig(t, setupTestDir(t))
+	cfg.Version = CurrentVersion + 1
+
+	_, err := cfg.Migrate()
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "newer than supported")
+}
+
+func TestLoad_MigratesAndBacksUp(t *testing.T) {
+	tmpDir := setupTestDir(t)
+	originalHome := os.Getenv("HOME")
+	os.Setenv("HOME", tmpDir)
+	defer os.Setenv("HOME", originalHome)
+
+	configPath, err := GetConfigPath()
+	require.NoError(t, err)
+	require.NoError(t, os.MkdirAll(filepath.Dir(configPath), 0755))
+
+	// A pre-versioning config file
+	original := []byte(`{
+  "workspaces": {
+    "ws": {"name": "ws", "repo_path": "/tmp/clones/1", "status": "idle"}
+  },
+  "clones": {
+    "/tmp/clones/1": {"path": "/tmp/clones/1", "remote_name": "origin", "in_use_by": "ws"}
+  },
+  "settings": {"workspace_dir": "/tmp/ws"}
+}`)
+	require.NoError(t, os.WriteFile(configPath, original, 0644))
+
+	cfg, err := Load()
+	require.NoError(t, err)
+	assert.Equal(t, CurrentVersion, cfg.Version)
+
+	ws, err := cfg.GetWorkspace("ws")
+	require.NoError(t, err)
+	assert.Equal(t, "/tmp/clones/1", ws.ClonePath)
+
+	// The original file is preserved as a backup
+	backup, err := os.ReadFile(configPath + ".v0.bak")
+	require.NoError(t, err)
+	assert.Equal(t, original, backup)
+
+	// The migrated config was written back
+	reloaded, err := Load()
+	require.NoError(t, err)
+	assert.Equal(t, CurrentVersion, reloaded.Version)
+}
+
+func TestLoad_NewerVersionFails(t *testing.T) {
+	tmpDir := setupTestDir(t)
+	originalHome := os.Getenv("HOME")
+	os.Setenv("HOME", tmpDir)
+	defer os.Setenv("HOME", originalHome)
+
+	configPath, err := GetConfigPath()
+	require.NoError(t, err)
+	require.NoError(t, os.MkdirAll(filepath.Dir(configPath), 0755))
+	require.NoError(t, os.WriteFile(configPath, []byte(`{"version": 999}`), 0644))
+
+	_, err = Load()
+	assert.Error(t, err)
+}
+
+func TestConfig_Migrate_CloneFreedAt(t *testing.T) {
+	cfg := createTestConfig(t, setupTestDir(t))
+	cfg.Version = 1
+
+	cfg.Clones["/tmp/clones/1"] = &Clone{Path: "/tmp/clones/1", RemoteName: "origin"}
+	cfg.Clones["/tmp/clones/2"] = &Clone{Path: "/tmp/clones/2", RemoteName: "origin", InUseBy: "ws"}
+
+	_, err := cfg.Migrate()
+	require.NoError(t, err)
+
+	assert.False(t, cfg.Clones["/tmp/clones/1"].FreedAt.IsZero())
+	assert.True(t, cfg.Clones["/tmp/clones/2"].FreedAt.IsZero())
+}
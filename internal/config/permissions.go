@@ -0,0 +1,35 @@
+package config
+
+import "fmt"
+
+// Permissions is a named set of Claude tool permission rules, e.g. "Bash(go test:*)"
+type Permissions struct {
+	Allow []string `json:"allow,omitempty"`
+	Deny  []string `json:"deny,omitempty"`
+}
+
+// GetPermissionPreset returns the permission preset with the given name
+func (c *Config) GetPermissionPreset(name string) (*Permissions, error) {
+	preset, ok := c.Settings.PermissionPresets[name]
+	if !ok {
+		return nil, fmt.Errorf("permission preset '%s' not found", name)
+	}
+	return &preset, nil
+}
+
+// ResolvePermissions returns the permission preset that applies to a workspace.
+// The workspace's own preset wins over its remote's. Returns nil if neither is set.
+func (c *Config) ResolvePermissions(ws *Workspace) (*Permissions, error) {
+	name := ws.Permissions
+	if name == "" && ws.ClonePath != "" {
+		if clone, err := c.GetClone(ws.ClonePath); err == nil {
+			if remote, err := c.GetRemote(clone.RemoteName); err == nil {
+				name = remote.Permissions
+			}
+		}
+	}
+	if name == "" {
+		return nil, nil
+	}
+	return c.GetPermissionPreset(name)
+}
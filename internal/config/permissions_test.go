@@ -0,0 +1,56 @@
+package config
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newPermissionsConfig() *Config {
+	cfg := NewDefaultConfig()
+	cfg.Settings.PermissionPresets = map[string]Permissions{
+		"go":        {Allow: []string{"Bash(go test:*)"}},
+		"sensitive": {Deny: []string{"WebFetch", "Bash"}},
+	}
+	cfg.Remotes["secure"] = &Remote{Name: "secure", Permissions: "sensitive"}
+	cfg.Clones["/clones/secure-1"] = &Clone{Path: "/clones/secure-1", RemoteName: "secure"}
+	return cfg
+}
+
+func TestResolvePermissions_FromRemote(t *testing.T) {
+	cfg := newPermissionsConfig()
+	ws := &Workspace{Name: "ws", ClonePath: "/clones/secure-1"}
+
+	perms, err := cfg.ResolvePermissions(ws)
+	require.NoError(t, err)
+	require.NotNil(t, perms)
+	assert.Equal(t, []string{"WebFetch", "Bash"}, perms.Deny)
+}
+
+func TestResolvePermissions_WorkspaceOverridesRemote(t *testing.T) {
+	cfg := newPermissionsConfig()
+	ws := &Workspace{Name: "ws", ClonePath: "/clones/secure-1", Permissions: "go"}
+
+	perms, err := cfg.ResolvePermissions(ws)
+	require.NoError(t, err)
+	require.NotNil(t, perms)
+	assert.Equal(t, []string{"Bash(go test:*)"}, perms.Allow)
+	assert.Empty(t, perms.Deny)
+}
+
+func TestResolvePermissions_None(t *testing.T) {
+	cfg := newPermissionsConfig()
+
+	perms, err := cfg.ResolvePermissions(&Workspace{Name: "ws", RepoPath: "/repo"})
+	require.NoError(t, err)
+	assert.Nil(t, perms)
+}
+
+func TestResolvePermissions_UnknownPreset(t *testing.T) {
+	cfg := newPermissionsConfig()
+
+	_, err := cfg.ResolvePermissions(&Workspace{Name: "ws", Permissions: "missing"})
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "missing")
+}
@@ -0,0 +1,104 @@
+package config
+
+import (
+	"fmt"
+	"strconv"
+)
+
+const (
+	// DefaultPortRangeStart is the first port handed out when port_range_start is unset
+	DefaultPortRangeStart = 20000
+	// DefaultPortsPerWorkspace is the port block size when ports_per_workspace is unset
+	DefaultPortsPerWorkspace = 10
+
+	maxPort = 65535
+)
+
+// AllocatePorts reserves a block of ports for a workspace that doesn't have one yet.
+// Blocks never overlap ports held by other workspaces.
+func (c *Config) AllocatePorts(name string) ([]int, error) {
+	ws, err := c.GetWorkspace(name)
+	if err != nil {
+		return nil, err
+	}
+	if len(ws.Ports) > 0 {
+		return ws.Ports, nil
+	}
+
+	start := c.Settings.PortRangeStart
+	if start <= 0 {
+		start = DefaultPortRangeStart
+	}
+	size := c.Settings.PortsPerWorkspace
+	if size <= 0 {
+		size = DefaultPortsPerWorkspace
+	}
+
+	taken := make(map[int]bool)
+	for otherName, other := range c.Workspaces {
+		if otherName == name {
+			continue
+		}
+		for _, port := range other.Ports {
+			taken[port] = true
+		}
+	}
+
+	for base := start; base+size-1 <= maxPort; base += size {
+		free := true
+		for port := base; port < base+size; port++ {
+			if taken[port] {
+				free = false
+				break
+			}
+		}
+		if !free {
+			continue
+		}
+
+		ports := make([]int, size)
+		for i := range ports {
+			ports[i] = base + i
+		}
+		ws.Ports = ports
+		return ports, nil
+	}
+
+	return nil, fmt.Errorf("no free block of %d ports at or above %d", size, start)
+}
+
+// ReleasePorts returns a workspace's ports to the pool
+func (c *Config) ReleasePorts(name string) error {
+	ws, err := c.GetWorkspace(name)
+	if err != nil {
+		return err
+	}
+	ws.Ports = nil
+	return nil
+}
+
+// PortEnv returns the environment variables describing a workspace's ports:
+// PORT and CLAUDEW_PORT are the first port, CLAUDEW_PORT_<i> each port in the block
+func (w *Workspace) PortEnv() map[string]string {
+	env := make(map[string]string)
+	if len(w.Ports) == 0 {
+		return env
+	}
+	env["PORT"] = strconv.Itoa(w.Ports[0])
+	env["CLAUDEW_PORT"] = strconv.Itoa(w.Ports[0])
+	for i, port := range w.Ports {
+		env[fmt.Sprintf("CLAUDEW_PORT_%d", i)] = strconv.Itoa(port)
+	}
+	return env
+}
+
+// FormatPorts renders a workspace's ports for display, e.g. "20000-20009"
+func (w *Workspace) FormatPorts() string {
+	switch len(w.Ports) {
+	case 0:
+		return ""
+	case 1:
+		return strconv.Itoa(w.Ports[0])
+	}
+	return fmt.Sprintf("%d-%d", w.Ports[0], w.Ports[len(w.Ports)-1])
+}
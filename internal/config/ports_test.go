@@ -0,0 +1,78 @@
+package config
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestConfig_AllocatePorts(t *testing.T) {
+	cfg := createTestConfig(t, setupTestDir(t))
+	cfg.AddWorkspace("ws-a", "/tmp/a")
+	cfg.AddWorkspace("ws-b", "/tmp/b")
+
+	portsA, err := cfg.AllocatePorts("ws-a")
+	require.NoError(t, err)
+	assert.Len(t, portsA, DefaultPortsPerWorkspace)
+	assert.Equal(t, DefaultPortRangeStart, portsA[0])
+
+	portsB, err := cfg.AllocatePorts("ws-b")
+	require.NoError(t, err)
+	assert.Equal(t, DefaultPortRangeStart+DefaultPortsPerWorkspace, portsB[0])
+
+	// Allocating again keeps the existing block
+	again, err := cfg.AllocatePorts("ws-a")
+	require.NoError(t, err)
+	assert.Equal(t, portsA, again)
+
+	// Released blocks are reused
+	require.NoError(t, cfg.ReleasePorts("ws-a"))
+	cfg.AddWorkspace("ws-c", "/tmp/c")
+	portsC, err := cfg.AllocatePorts("ws-c")
+	require.NoError(t, err)
+	assert.Equal(t, DefaultPortRangeStart, portsC[0])
+}
+
+func TestConfig_AllocatePorts_CustomRange(t *testing.T) {
+	cfg := createTestConfig(t, setupTestDir(t))
+	cfg.Settings.PortRangeStart = 3000
+	cfg.Settings.PortsPerWorkspace = 3
+	cfg.AddWorkspace("ws-a", "/tmp/a")
+	cfg.AddWorkspace("ws-b", "/tmp/b")
+
+	// A hand-edited port inside the first block pushes allocation past it
+	cfg.Workspaces["ws-b"].Ports = []int{3001}
+
+	ports, err := cfg.AllocatePorts("ws-a")
+	require.NoError(t, err)
+	assert.Equal(t, []int{3003, 3004, 3005}, ports)
+}
+
+func TestConfig_AllocatePorts_Exhausted(t *testing.T) {
+	cfg := createTestConfig(t, setupTestDir(t))
+	cfg.Settings.PortRangeStart = 65530
+	cfg.Settings.PortsPerWorkspace = 10
+	cfg.AddWorkspace("ws-a", "/tmp/a")
+
+	_, err := cfg.AllocatePorts("ws-a")
+	assert.Error(t, err)
+}
+
+func TestWorkspace_PortEnv(t *testing.T) {
+	ws := &Workspace{Ports: []int{20010, 20011}}
+
+	assert.Equal(t, map[string]string{
+		"PORT":           "20010",
+		"CLAUDEW_PORT":   "20010",
+		"CLAUDEW_PORT_0": "20010",
+		"CLAUDEW_PORT_1": "20011",
+	}, ws.PortEnv())
+	assert.Empty(t, (&Workspace{}).PortEnv())
+}
+
+func TestWorkspace_FormatPorts(t *testing.T) {
+	assert.Equal(t, "", (&Workspace{}).FormatPorts())
+	assert.Equal(t, "3000", (&Workspace{Ports: []int{3000}}).FormatPorts())
+	assert.Equal(t, "20000-20009", (&Workspace{Ports: []int{20000, 20001, 20002, 20003, 20004, 20005, 20006, 20007, 20008, 20009}}).FormatPorts())
+}
@@ -0,0 +1,33 @@
+package config
+
+import (
+	"sort"
+	"time"
+)
+
+// RecentWorkspaces returns the non-archived workspaces, most recently opened first.
+// Workspaces never opened since LastOpened was recorded fall back to LastActive.
+func (c *Config) RecentWorkspaces() []string {
+	var names []string
+	for name, ws := range c.Workspaces {
+		if ws.Status != StatusArchived {
+			names = append(names, name)
+		}
+	}
+	sort.Slice(names, func(i, j int) bool {
+		a, b := c.Workspaces[names[i]].lastUsed(), c.Workspaces[names[j]].lastUsed()
+		if !a.Equal(b) {
+			return a.After(b)
+		}
+		return names[i] < names[j]
+	})
+	return names
+}
+
+// lastUsed returns when the workspace was last opened, or last active if that isn't known
+func (w *Workspace) lastUsed() time.Time {
+	if !w.LastOpened.IsZero() {
+		return w.LastOpened
+	}
+	return w.LastActive
+}
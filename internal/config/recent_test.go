@@ -0,0 +1,22 @@
+package config
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestConfig_RecentWorkspaces(t *testing.T) {
+	now := time.Date(2026, 3, 1, 9, 0, 0, 0, time.UTC)
+	cfg := &Config{Workspaces: map[string]*Workspace{
+		"a":        {Name: "a", Status: StatusIdle, LastActive: now, LastOpened: now.Add(-2 * time.Hour)},
+		"b":        {Name: "b", Status: StatusIdle, LastActive: now.Add(-3 * time.Hour), LastOpened: now.Add(-time.Hour)},
+		"legacy":   {Name: "legacy", Status: StatusIdle, LastActive: now.Add(-90 * time.Minute)},
+		"archived": {Name: "archived", Status: StatusArchived, LastOpened: now},
+	}}
+
+	// Opening a workspace counts, not output in its session
+	assert.Equal(t, []string{"b", "legacy", "a"}, cfg.RecentWorkspaces())
+	assert.Empty(t, (&Config{}).RecentWorkspaces())
+}
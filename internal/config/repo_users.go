@@ -0,0 +1,63 @@
+package config
+
+import (
+	"fmt"
+	"path/filepath"
+	"sort"
+)
+
+// WorkspacesUsingRepo returns the non-archived workspaces other than except that hold
+// repoPath, sorted by name: for a registered clone, the workspace it is assigned to,
+// and for any other directory, workspaces created from its path. Stopped workspaces
+// still pointing at a clone they gave up don't hold it.
+func (c *Config) WorkspacesUsingRepo(repoPath, except string) []string {
+	repoPath = filepath.Clean(repoPath)
+	clone, isClone := c.Clones[repoPath]
+	var names []string
+	for name, ws := range c.Workspaces {
+		if name == except || ws.Status == StatusArchived || ws.GetRepoPath() == "" {
+			continue
+		}
+		if filepath.Clean(ws.GetRepoPath()) == repoPath && (!isClone || clone.InUseBy == name) {
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names)
+	return names
+}
+
+// CheckRepoAvailable returns an error if another workspace already uses repoPath, by
+// path or as its assigned clone: two Claude sessions in one checkout would trample
+// each other's changes
+func (c *Config) CheckRepoAvailable(repoPath, workspaceName string) error {
+	if clone, err := c.GetClone(filepath.Clean(repoPath)); err == nil && clone.InUseBy != "" && clone.InUseBy != workspaceName {
+		return fmt.Errorf("%s is already used by workspace '%s'", repoPath, clone.InUseBy)
+	}
+	if users := c.WorkspacesUsingRepo(repoPath, workspaceName); len(users) > 0 {
+		return fmt.Errorf("%s is already used by workspace '%s'", repoPath, users[0])
+	}
+	return nil
+}
+
+// SharedRepos maps each repo path that more than one non-archived workspace points
+// at to those workspaces' names. This includes stopped workspaces whose clone was
+// since given to another workspace, as starting them would run in that clone.
+func (c *Config) SharedRepos() map[string][]string {
+	users := map[string][]string{}
+	for name, ws := range c.Workspaces {
+		if ws.Status == StatusArchived || ws.GetRepoPath() == "" {
+			continue
+		}
+		path := filepath.Clean(ws.GetRepoPath())
+		users[path] = append(users[path], name)
+	}
+
+	shared := map[string][]string{}
+	for path, names := range users {
+		if len(names) > 1 {
+			sort.Strings(names)
+			shared[path] = names
+		}
+	}
+	return shared
+}
@@ -0,0 +1,55 @@
+package config
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newRepoUsersConfig(t *testing.T) *Config {
+	t.Helper()
+	cfg := &Config{Workspaces: map[string]*Workspace{}, Clones: map[string]*Clone{}}
+	require.NoError(t, cfg.AddClone("/clones/repo-1", "origin"))
+	require.NoError(t, cfg.AddClone("/clones/repo-2", "origin"))
+	return cfg
+}
+
+func TestWorkspacesUsingRepo(t *testing.T) {
+	cfg := newRepoUsersConfig(t)
+	require.NoError(t, cfg.AddWorkspace("managed", "/clones/repo-1"))
+	require.NoError(t, cfg.AssignCloneToWorkspace("/clones/repo-1", "managed"))
+	require.NoError(t, cfg.AddWorkspace("legacy", "/src/app"))
+	require.NoError(t, cfg.AddWorkspace("stopped", "/clones/repo-2")) // clone since freed
+	require.NoError(t, cfg.AddWorkspace("old", "/src/app"))
+	cfg.Workspaces["old"].Status = StatusArchived
+
+	assert.Equal(t, []string{"managed"}, cfg.WorkspacesUsingRepo("/clones/repo-1", ""))
+	assert.Empty(t, cfg.WorkspacesUsingRepo("/clones/repo-1", "managed"))
+	assert.Equal(t, []string{"legacy"}, cfg.WorkspacesUsingRepo("/src/app/", ""))
+	assert.Empty(t, cfg.WorkspacesUsingRepo("/clones/repo-2", ""))
+}
+
+func TestCheckRepoAvailable(t *testing.T) {
+	cfg := newRepoUsersConfig(t)
+	require.NoError(t, cfg.AddWorkspace("managed", "/clones/repo-1"))
+	require.NoError(t, cfg.AssignCloneToWorkspace("/clones/repo-1", "managed"))
+	require.NoError(t, cfg.AddWorkspace("legacy", "/src/app"))
+
+	assert.ErrorContains(t, cfg.CheckRepoAvailable("/clones/repo-1", "new"), "workspace 'managed'")
+	assert.ErrorContains(t, cfg.CheckRepoAvailable("/src/app", "new"), "workspace 'legacy'")
+	assert.NoError(t, cfg.CheckRepoAvailable("/clones/repo-2", "new"))
+	assert.NoError(t, cfg.CheckRepoAvailable("/clones/repo-1", "managed"))
+}
+
+func TestSharedRepos(t *testing.T) {
+	cfg := newRepoUsersConfig(t)
+	require.NoError(t, cfg.AddWorkspace("a", "/src/app"))
+	require.NoError(t, cfg.AddWorkspace("b", "/src/app"))
+	require.NoError(t, cfg.AddWorkspace("c", "/clones/repo-1"))
+	require.NoError(t, cfg.AddWorkspace("d", "/clones/repo-1"))
+	require.NoError(t, cfg.AddWorkspace("e", "/clones/repo-2"))
+	cfg.Workspaces["d"].Status = StatusArchived
+
+	assert.Equal(t, map[string][]string{"/src/app": {"a", "b"}}, cfg.SharedRepos())
+}
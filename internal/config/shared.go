@@ -0,0 +1,80 @@
+package config
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// sharedRemotesFile is the format of Settings.SharedRemotesFile, the same shape as
+// the "remotes" section of config.json so remotes can be copied between them
+type sharedRemotesFile struct {
+	Remotes map[string]*Remote `json:"remotes"`
+}
+
+// loadSharedRemotes merges the remotes in the team's shared remotes file into the
+// config. Local remotes win over shared ones with the same name. Shared remotes
+// are never written back by Save. A missing file is ignored, so the setting can be
+// synced to machines where the team repo isn't checked out yet.
+func (c *Config) loadSharedRemotes() error {
+	path := expandHome(c.Settings.SharedRemotesFile)
+	if path == "" {
+		return nil
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil
+		}
+		return fmt.Errorf("failed to read shared remotes file: %w", err)
+	}
+
+	var shared sharedRemotesFile
+	if err := json.Unmarshal(data, &shared); err != nil {
+		return fmt.Errorf("failed to parse shared remotes file %s: %w", path, err)
+	}
+
+	for name, remote := range shared.Remotes {
+		if remote == nil {
+			continue
+		}
+		if _, exists := c.Remotes[name]; exists {
+			continue
+		}
+		if remote.URL == "" || remote.CloneBaseDir == "" {
+			return fmt.Errorf("shared remote '%s' in %s needs both url and clone_base_dir", name, path)
+		}
+		remote.Name = name
+		remote.CloneBaseDir = expandHome(remote.CloneBaseDir)
+		remote.Shared = true
+		c.Remotes[name] = remote
+	}
+	return nil
+}
+
+// localRemotes returns the remotes that belong in config.json, leaving out shared ones
+func (c *Config) localRemotes() map[string]*Remote {
+	remotes := make(map[string]*Remote, len(c.Remotes))
+	for name, remote := range c.Remotes {
+		if !remote.Shared {
+			remotes[name] = remote
+		}
+	}
+	return remotes
+}
+
+// expandHome replaces a leading ~ with the home directory, since shared files can't
+// know each teammate's home
+func expandHome(path string) string {
+	if path != "~" && !strings.HasPrefix(path, "~/") {
+		return path
+	}
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return path
+	}
+	return filepath.Join(home, path[1:])
+}
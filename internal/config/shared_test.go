@@ -0,0 +1,78 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLoad_SharedRemotes(t *testing.T) {
+	tmpDir := t.TempDir()
+	t.Setenv("HOME", tmpDir)
+
+	sharedPath := filepath.Join(tmpDir, "team", "remotes.json")
+	require.NoError(t, os.MkdirAll(filepath.Dir(sharedPath), 0755))
+	require.NoError(t, os.WriteFile(sharedPath, []byte(`{
+  "remotes": {
+    "platform": {"url": "git@github.com:acme/platform.git", "clone_base_dir": "~/src/platform", "permissions": "go"},
+    "mine": {"url": "git@github.com:acme/shared-mine.git", "clone_base_dir": "~/src/shared-mine"}
+  }
+}`), 0644))
+
+	cfg := NewDefaultConfig()
+	cfg.Settings.SharedRemotesFile = "~/team/remotes.json"
+	require.NoError(t, cfg.AddRemote("mine", "git@github.com:me/mine.git", "/src/mine"))
+	require.NoError(t, cfg.Save())
+
+	loaded, err := Load()
+	require.NoError(t, err)
+
+	platform, err := loaded.GetRemote("platform")
+	require.NoError(t, err)
+	assert.Equal(t, "platform", platform.Name)
+	assert.Equal(t, filepath.Join(tmpDir, "src", "platform"), platform.CloneBaseDir)
+	assert.Equal(t, "go", platform.Permissions)
+	assert.True(t, platform.Shared)
+
+	// Local remotes win over shared ones with the same name
+	mine, err := loaded.GetRemote("mine")
+	require.NoError(t, err)
+	assert.Equal(t, "git@github.com:me/mine.git", mine.URL)
+	assert.False(t, mine.Shared)
+
+	assert.Error(t, loaded.RenameRemote("platform", "renamed"))
+
+	// Shared remotes are not written to config.json
+	require.NoError(t, loaded.Save())
+	configPath, err := GetConfigPath()
+	require.NoError(t, err)
+	data, err := os.ReadFile(configPath)
+	require.NoError(t, err)
+	assert.NotContains(t, string(data), "acme/platform")
+	assert.Contains(t, string(data), "me/mine")
+}
+
+func TestLoad_SharedRemotesMissingOrInvalid(t *testing.T) {
+	tmpDir := t.TempDir()
+	t.Setenv("HOME", tmpDir)
+
+	cfg := NewDefaultConfig()
+	cfg.Settings.SharedRemotesFile = filepath.Join(tmpDir, "missing.json")
+	require.NoError(t, cfg.Save())
+
+	// The file may not be checked out on every machine
+	loaded, err := Load()
+	require.NoError(t, err)
+	assert.Empty(t, loaded.Remotes)
+
+	require.NoError(t, os.WriteFile(cfg.Settings.SharedRemotesFile, []byte(`{"remotes": {"x": {"url": "git@example.com:x.git"}}}`), 0644))
+	_, err = Load()
+	assert.ErrorContains(t, err, "needs both url and clone_base_dir")
+
+	require.NoError(t, os.WriteFile(cfg.Settings.SharedRemotesFile, []byte(`not json`), 0644))
+	_, err = Load()
+	assert.ErrorContains(t, err, "failed to parse shared remotes file")
+}
@@ -0,0 +1,80 @@
+package config
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Workflow states track where a workspace's task is, independent of its session status
+const (
+	StateTodo       = "todo"
+	StateInProgress = "in-progress"
+	StateBlocked    = "blocked"
+	StateReview     = "review"
+	StateDone       = "done"
+)
+
+// WorkspaceStates lists the valid values of Workspace.State, in workflow order
+var WorkspaceStates = []string{StateTodo, StateInProgress, StateBlocked, StateReview, StateDone}
+
+// ValidateState checks that state is one of WorkspaceStates
+func ValidateState(state string) error {
+	for _, s := range WorkspaceStates {
+		if s == state {
+			return nil
+		}
+	}
+	return fmt.Errorf("invalid state '%s' (must be one of %s)", state, strings.Join(WorkspaceStates, ", "))
+}
+
+// SetState moves the workspace to a workflow state, or clears it when state is empty.
+// The reason is kept only with the state it was given for, and until, the time to be
+// reminded at, only for blocked workspaces; pass the zero time for no reminder.
+func (w *Workspace) SetState(state, reason string, until time.Time, now time.Time) {
+	if state != w.State {
+		w.StateChanged = now
+	}
+	w.State = state
+	w.StateReason = reason
+	w.BlockedUntil = time.Time{}
+	if state == StateBlocked {
+		w.BlockedUntil = until
+	}
+	if state == "" {
+		w.StateReason = ""
+		w.StateChanged = time.Time{}
+	}
+}
+
+// ReminderDue reports whether the workspace is blocked and its --until time has passed
+func (w *Workspace) ReminderDue(now time.Time) bool {
+	return w.State == StateBlocked && !w.BlockedUntil.IsZero() && !now.Before(w.BlockedUntil)
+}
+
+// ParseUntil parses a reminder time: a date ("2025-10-20", the start of that day),
+// a date and time ("2025-10-20 14:00"), both in local time, or a span from now
+// in days, weeks or hours ("3d", "2w", "12h")
+func ParseUntil(s string, now time.Time) (time.Time, error) {
+	s = strings.TrimSpace(s)
+	for _, layout := range []string{"2006-01-02", "2006-01-02 15:04", time.RFC3339} {
+		if t, err := time.ParseInLocation(layout, s, now.Location()); err == nil {
+			return t, nil
+		}
+	}
+
+	if len(s) > 1 {
+		if n, err := strconv.Atoi(s[:len(s)-1]); err == nil && n > 0 {
+			switch s[len(s)-1] {
+			case 'h':
+				return now.Add(time.Duration(n) * time.Hour), nil
+			case 'd':
+				return now.AddDate(0, 0, n), nil
+			case 'w':
+				return now.AddDate(0, 0, 7*n), nil
+			}
+		}
+	}
+	return time.Time{}, fmt.Errorf("invalid time '%s' (use a date like 2025-10-20, \"2025-10-20 14:00\", or a span like 3d, 2w or 12h)", s)
+}
@@ -0,0 +1,78 @@
+package config
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestValidateState(t *testing.T) {
+	for _, state := range WorkspaceStates {
+		assert.NoError(t, ValidateState(state))
+	}
+	assert.Error(t, ValidateState("waiting"))
+	assert.Error(t, ValidateState(""))
+}
+
+func TestSetState(t *testing.T) {
+	first := time.Date(2025, 10, 20, 9, 0, 0, 0, time.UTC)
+	later := first.Add(time.Hour)
+	ws := &Workspace{Name: "auth"}
+
+	ws.SetState(StateBlocked, "waiting on API review", time.Time{}, first)
+	assert.Equal(t, StateBlocked, ws.State)
+	assert.Equal(t, "waiting on API review", ws.StateReason)
+	assert.Equal(t, first, ws.StateChanged)
+
+	// Updating the reason keeps when the state was entered
+	ws.SetState(StateBlocked, "waiting on security review", time.Time{}, later)
+	assert.Equal(t, "waiting on security review", ws.StateReason)
+	assert.Equal(t, first, ws.StateChanged)
+
+	ws.SetState(StateReview, "", time.Time{}, later)
+	assert.Equal(t, "", ws.StateReason)
+	assert.Equal(t, later, ws.StateChanged)
+
+	ws.SetState("", "", time.Time{}, later)
+	assert.Equal(t, "", ws.State)
+	assert.True(t, ws.StateChanged.IsZero())
+}
+
+func TestSetState_BlockedUntil(t *testing.T) {
+	now := time.Date(2025, 10, 20, 9, 0, 0, 0, time.UTC)
+	until := now.Add(48 * time.Hour)
+	ws := &Workspace{Name: "auth"}
+
+	ws.SetState(StateBlocked, "waiting on API review", until, now)
+	assert.Equal(t, until, ws.BlockedUntil)
+	assert.False(t, ws.ReminderDue(now))
+	assert.True(t, ws.ReminderDue(until))
+	assert.True(t, ws.ReminderDue(until.Add(time.Hour)))
+
+	// Reminders only apply while blocked
+	ws.SetState(StateInProgress, "", until, now)
+	assert.True(t, ws.BlockedUntil.IsZero())
+	assert.False(t, ws.ReminderDue(until))
+}
+
+func TestParseUntil(t *testing.T) {
+	now := time.Date(2025, 10, 20, 9, 30, 0, 0, time.UTC)
+
+	for input, want := range map[string]time.Time{
+		"2025-10-24":       time.Date(2025, 10, 24, 0, 0, 0, 0, time.UTC),
+		"2025-10-24 14:00": time.Date(2025, 10, 24, 14, 0, 0, 0, time.UTC),
+		"12h":              now.Add(12 * time.Hour),
+		"3d":               time.Date(2025, 10, 23, 9, 30, 0, 0, time.UTC),
+		"2w":               time.Date(2025, 11, 3, 9, 30, 0, 0, time.UTC),
+	} {
+		got, err := ParseUntil(input, now)
+		assert.NoError(t, err, input)
+		assert.Equal(t, want, got, input)
+	}
+
+	for _, input := range []string{"", "tomorrow", "3m", "0d", "-2d", "d"} {
+		_, err := ParseUntil(input, now)
+		assert.Error(t, err, input)
+	}
+}
@@ -0,0 +1,166 @@
+package container
+
+import (
+	"errors"
+	"fmt"
+	"os/exec"
+	"sort"
+	"strings"
+
+	"github.com/pmossman/claudew/internal/execx"
+)
+
+// Spec describes how to run a workspace's container
+type Spec struct {
+	Image   string   // image with claude installed
+	Network string   // docker --network value, e.g. "none" for no network access
+	Mounts  []string // host paths mounted at the same path inside the container
+	WorkDir string   // working directory inside the container
+	Ports   []int    // published on the same host port
+	Args    []string // extra docker run arguments
+}
+
+// Manager runs workspace containers with the docker CLI
+type Manager struct{}
+
+// NewManager creates a new container manager
+func NewManager() *Manager {
+	return &Manager{}
+}
+
+// GetContainerName returns the container name for a workspace
+func (m *Manager) GetContainerName(workspaceName string) string {
+	return fmt.Sprintf("claudew-%s", workspaceName)
+}
+
+// CheckDockerInstalled verifies the docker CLI is available
+func (m *Manager) CheckDockerInstalled() error {
+	if _, err := exec.LookPath("docker"); err != nil {
+		return fmt.Errorf("docker is not installed or not in PATH")
+	}
+	return nil
+}
+
+// State returns "running", "stopped" or "none" for a container
+func (m *Manager) State(name string) (string, error) {
+	output, err := execx.Command("docker", "inspect", "-f", "{{.State.Running}}", name).Output()
+	if err != nil {
+		var exitErr *execx.ExitError
+		if errors.As(err, &exitErr) {
+			return "none", nil
+		}
+		return "", fmt.Errorf("failed to inspect container: %w", err)
+	}
+	if strings.TrimSpace(string(output)) == "true" {
+		return "running", nil
+	}
+	return "stopped", nil
+}
+
+// Ensure makes sure the container is running, creating it from spec if needed
+func (m *Manager) Ensure(name string, spec Spec) error {
+	state, err := m.State(name)
+	if err != nil {
+		return err
+	}
+
+	switch state {
+	case "running":
+		return nil
+	case "stopped":
+		if output, err := execx.Command("docker", "start", name).CombinedOutput(); err != nil {
+			return fmt.Errorf("failed to start container: %w: %s", err, strings.TrimSpace(string(output)))
+		}
+		return nil
+	}
+
+	if spec.Image == "" {
+		return fmt.Errorf("no container image configured")
+	}
+	args := RunArgs(name, spec)
+	if output, err := execx.Command("docker", args...).CombinedOutput(); err != nil {
+		return fmt.Errorf("failed to create container: %w: %s", err, strings.TrimSpace(string(output)))
+	}
+	return nil
+}
+
+// Stop stops a container if it is running
+func (m *Manager) Stop(name string) error {
+	state, err := m.State(name)
+	if err != nil || state != "running" {
+		return err
+	}
+	if output, err := execx.Command("docker", "stop", name).CombinedOutput(); err != nil {
+		return fmt.Errorf("failed to stop container: %w: %s", err, strings.TrimSpace(string(output)))
+	}
+	return nil
+}
+
+// Remove deletes a container, stopping it first if needed
+func (m *Manager) Remove(name string) error {
+	state, err := m.State(name)
+	if err != nil || state == "none" {
+		return err
+	}
+	if output, err := execx.Command("docker", "rm", "-f", name).CombinedOutput(); err != nil {
+		return fmt.Errorf("failed to remove container: %w: %s", err, strings.TrimSpace(string(output)))
+	}
+	return nil
+}
+
+// RunArgs builds the docker run arguments for a long-lived workspace container.
+// The container idles so that sessions can docker exec into it.
+func RunArgs(name string, spec Spec) []string {
+	args := []string{"run", "-d", "--name", name, "--init"}
+	for _, mount := range spec.Mounts {
+		args = append(args, "-v", mount+":"+mount)
+	}
+	if spec.WorkDir != "" {
+		args = append(args, "-w", spec.WorkDir)
+	}
+	if spec.Network != "" {
+		args = append(args, "--network", spec.Network)
+	}
+	// Containers without a network have nothing to publish
+	if spec.Network != "none" {
+		for _, port := range spec.Ports {
+			args = append(args, "-p", fmt.Sprintf("%d:%d", port, port))
+		}
+	}
+	args = append(args, spec.Args...)
+	args = append(args, spec.Image, "sleep", "infinity")
+	return args
+}
+
+// ExecArgs builds the docker exec arguments that run command interactively in the container
+func ExecArgs(name, workDir string, env map[string]string, command []string) []string {
+	return execArgs("-it", name, workDir, env, command)
+}
+
+// PipeExecArgs builds docker exec arguments like ExecArgs, but without a terminal, for
+// commands whose input and output may be pipes
+func PipeExecArgs(name, workDir string, env map[string]string, command []string) []string {
+	return execArgs("-i", name, workDir, env, command)
+}
+
+func execArgs(mode, name, workDir string, env map[string]string, command []string) []string {
+	args := []string{"exec", mode}
+	if workDir != "" {
+		args = append(args, "-w", workDir)
+	}
+	for _, key := range sortedKeys(env) {
+		args = append(args, "-e", key+"="+env[key])
+	}
+	args = append(args, name)
+	return append(args, command...)
+}
+
+// sortedKeys returns map keys in sorted order for stable argument lists
+func sortedKeys(m map[string]string) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
@@ -0,0 +1,62 @@
+package container
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGetContainerName(t *testing.T) {
+	assert.Equal(t, "claudew-feature-auth", NewManager().GetContainerName("feature-auth"))
+}
+
+func TestRunArgs(t *testing.T) {
+	args := RunArgs("claudew-ws", Spec{
+		Image:   "claude-sandbox:latest",
+		Network: "none",
+		Mounts:  []string{"/repos/app-1", "/home/me/.claude-workspaces/ws"},
+		WorkDir: "/repos/app-1",
+		Ports:   []int{20000, 20001},
+		Args:    []string{"--memory", "4g"},
+	})
+
+	assert.Equal(t, []string{
+		"run", "-d", "--name", "claudew-ws", "--init",
+		"-v", "/repos/app-1:/repos/app-1",
+		"-v", "/home/me/.claude-workspaces/ws:/home/me/.claude-workspaces/ws",
+		"-w", "/repos/app-1",
+		"--network", "none",
+		"--memory", "4g",
+		"claude-sandbox:latest", "sleep", "infinity",
+	}, args)
+}
+
+func TestRunArgs_Minimal(t *testing.T) {
+	args := RunArgs("claudew-ws", Spec{Image: "img"})
+	assert.Equal(t, []string{"run", "-d", "--name", "claudew-ws", "--init", "img", "sleep", "infinity"}, args)
+}
+
+func TestExecArgs(t *testing.T) {
+	env := map[string]string{"PORT": "3000", "API_URL": "http://localhost"}
+	assert.Equal(t,
+		[]string{"exec", "-it", "-w", "/repos/app-1", "-e", "API_URL=http://localhost", "-e", "PORT=3000", "claudew-ws", "claude", "--resume"},
+		ExecArgs("claudew-ws", "/repos/app-1", env, []string{"claude", "--resume"}),
+	)
+	assert.Equal(t, []string{"exec", "-it", "claudew-ws", "claude"}, ExecArgs("claudew-ws", "", nil, []string{"claude"}))
+}
+
+func TestPipeExecArgs(t *testing.T) {
+	assert.Equal(t,
+		[]string{"exec", "-i", "-w", "/repos/app-1", "-e", "PORT=3000", "claudew-ws", "go", "test"},
+		PipeExecArgs("claudew-ws", "/repos/app-1", map[string]string{"PORT": "3000"}, []string{"go", "test"}),
+	)
+}
+
+func TestRunArgs_PublishesPorts(t *testing.T) {
+	args := RunArgs("claudew-ws", Spec{Image: "img", Ports: []int{20000, 20001}})
+	assert.Equal(t, []string{
+		"run", "-d", "--name", "claudew-ws", "--init",
+		"-p", "20000:20000", "-p", "20001:20001",
+		"img", "sleep", "infinity",
+	}, args)
+}
@@ -0,0 +1,150 @@
+package digest
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/pmossman/claudew/internal/execx"
+)
+
+// SnippetLength caps the continuation excerpt shown for each workspace
+const SnippetLength = 200
+
+// Entry is one workspace's line in the digest
+type Entry struct {
+	Name         string
+	Summary      string
+	Branch       string
+	LastActive   string // already formatted, e.g. "3h ago"
+	Running      bool
+	State        string // workflow state, e.g. "blocked"; empty when untracked
+	StateReason  string
+	BlockedUntil time.Time // when to check back on a blocked workspace; zero for no reminder
+	ReminderDue  bool      // the blocked workspace's BlockedUntil has passed
+	Continuation string
+}
+
+// Render formats the digest as plain text that reads well in a terminal, an email or Slack
+func Render(entries []Entry, now time.Time) string {
+	var out strings.Builder
+	running := 0
+	for _, e := range entries {
+		if e.Running {
+			running++
+		}
+	}
+	fmt.Fprintf(&out, "claudew digest — %s\n", now.Format("Mon Jan 2"))
+	fmt.Fprintf(&out, "%d workspace(s), %d running\n", len(entries), running)
+
+	// Blocked workspaces whose reminder time has passed come first, so they get looked at
+	var due []Entry
+	for _, e := range entries {
+		if e.ReminderDue {
+			due = append(due, e)
+		}
+	}
+	if len(due) > 0 {
+		fmt.Fprintf(&out, "\n⏰ %d reminder(s) due\n", len(due))
+		for _, e := range due {
+			fmt.Fprintf(&out, "  %s — blocked", e.Name)
+			if e.StateReason != "" {
+				fmt.Fprintf(&out, " on %s", e.StateReason)
+			}
+			fmt.Fprintf(&out, ", due since %s\n", e.BlockedUntil.Format("Mon Jan 2"))
+		}
+	}
+
+	for _, e := range entries {
+		marker := "○"
+		if e.Running {
+			marker = "●"
+		}
+		out.WriteString("\n")
+		fmt.Fprintf(&out, "%s %s", marker, e.Name)
+		if e.Branch != "" {
+			fmt.Fprintf(&out, " (%s)", e.Branch)
+		}
+		fmt.Fprintf(&out, " — last active %s\n", e.LastActive)
+		if e.State != "" {
+			fmt.Fprintf(&out, "  [%s]", e.State)
+			if e.StateReason != "" {
+				fmt.Fprintf(&out, " %s", e.StateReason)
+			}
+			out.WriteString("\n")
+		}
+		if e.Summary != "" {
+			fmt.Fprintf(&out, "  %s\n", e.Summary)
+		}
+		if snippet := Snippet(e.Continuation, SnippetLength); snippet != "" {
+			fmt.Fprintf(&out, "  ↪ %s\n", snippet)
+		}
+	}
+	return out.String()
+}
+
+// Snippet flattens continuation text to one line of at most maxLen characters, skipping headings
+func Snippet(continuation string, maxLen int) string {
+	var parts []string
+	for _, line := range strings.Split(continuation, "\n") {
+		line = strings.TrimSpace(line)
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		parts = append(parts, line)
+	}
+	snippet := strings.Join(parts, " ")
+	if runes := []rune(snippet); len(runes) > maxLen {
+		snippet = string(runes[:maxLen-3]) + "..."
+	}
+	return snippet
+}
+
+// Sender delivers a rendered digest to a webhook and/or a local command
+type Sender struct {
+	WebhookURL string
+	Command    string
+	Timeout    time.Duration
+}
+
+// NewSender creates a sender for the given destinations
+func NewSender(webhookURL, command string) *Sender {
+	return &Sender{WebhookURL: webhookURL, Command: command, Timeout: 10 * time.Second}
+}
+
+// Enabled reports whether any destination is configured
+func (s *Sender) Enabled() bool {
+	return s.WebhookURL != "" || s.Command != ""
+}
+
+// Send POSTs {"text": text} to the webhook (the Slack incoming-webhook format)
+// and runs the command via sh -c with text on stdin (e.g. a mail command)
+func (s *Sender) Send(text string) error {
+	if s.WebhookURL != "" {
+		payload, err := json.Marshal(map[string]string{"text": text})
+		if err != nil {
+			return fmt.Errorf("failed to marshal digest: %w", err)
+		}
+		client := &http.Client{Timeout: s.Timeout}
+		resp, err := client.Post(s.WebhookURL, "application/json", bytes.NewReader(payload))
+		if err != nil {
+			return fmt.Errorf("failed to post digest to webhook: %w", err)
+		}
+		resp.Body.Close()
+		if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+			return fmt.Errorf("digest webhook returned status %d", resp.StatusCode)
+		}
+	}
+
+	if s.Command != "" {
+		cmd := execx.Command("sh", "-c", s.Command)
+		cmd.Stdin = strings.NewReader(text)
+		if output, err := cmd.CombinedOutput(); err != nil {
+			return fmt.Errorf("digest command failed: %w (%s)", err, strings.TrimSpace(string(output)))
+		}
+	}
+	return nil
+}
@@ -0,0 +1,80 @@
+package digest
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRender(t *testing.T) {
+	now := time.Date(2025, 10, 20, 9, 0, 0, 0, time.UTC)
+	out := Render([]Entry{
+		{Name: "auth", Summary: "Rewrite login flow", Branch: "feat/login", LastActive: "2h ago", Running: true, Continuation: "# Next\n\nFinish the token refresh tests.\n"},
+		{Name: "spike", LastActive: "3d ago"},
+		{Name: "billing", LastActive: "1d ago", State: "blocked", StateReason: "waiting on API review"},
+	}, now)
+
+	assert.True(t, strings.HasPrefix(out, "claudew digest — Mon Oct 20\n3 workspace(s), 1 running\n"))
+	assert.Contains(t, out, "● auth (feat/login) — last active 2h ago\n  Rewrite login flow\n  ↪ Finish the token refresh tests.\n")
+	assert.Contains(t, out, "○ spike — last active 3d ago\n")
+	assert.Contains(t, out, "○ billing — last active 1d ago\n  [blocked] waiting on API review\n")
+	assert.NotContains(t, out, "reminder")
+}
+
+func TestRender_Reminders(t *testing.T) {
+	now := time.Date(2025, 10, 20, 9, 0, 0, 0, time.UTC)
+	out := Render([]Entry{
+		{Name: "auth", LastActive: "2h ago"},
+		{Name: "billing", LastActive: "3d ago", State: "blocked", StateReason: "API review", BlockedUntil: now.Add(-24 * time.Hour), ReminderDue: true},
+		{Name: "search", LastActive: "1d ago", State: "blocked", BlockedUntil: now.Add(24 * time.Hour)},
+	}, now)
+
+	assert.Contains(t, out, "3 workspace(s), 0 running\n\n⏰ 1 reminder(s) due\n  billing — blocked on API review, due since Sun Oct 19\n\n○ auth")
+	assert.NotContains(t, out, "search — blocked")
+}
+
+func TestSnippet(t *testing.T) {
+	assert.Equal(t, "one two", Snippet("## Heading\none\n\n  two  \n", 50))
+	assert.Equal(t, "", Snippet("# Only a heading\n", 50))
+	assert.Equal(t, "abcdefg...", Snippet(strings.Repeat("abcdefghij", 3), 10))
+}
+
+func TestSender_Webhook(t *testing.T) {
+	var got map[string]string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "application/json", r.Header.Get("Content-Type"))
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&got))
+	}))
+	defer server.Close()
+
+	require.NoError(t, NewSender(server.URL, "").Send("hello"))
+	assert.Equal(t, map[string]string{"text": "hello"}, got)
+}
+
+func TestSender_WebhookError(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusForbidden)
+	}))
+	defer server.Close()
+
+	assert.Error(t, NewSender(server.URL, "").Send("hello"))
+}
+
+func TestSender_Command(t *testing.T) {
+	outPath := filepath.Join(t.TempDir(), "digest.txt")
+	require.NoError(t, NewSender("", "cat > "+outPath).Send("hello"))
+	data, err := os.ReadFile(outPath)
+	require.NoError(t, err)
+	assert.Equal(t, "hello", string(data))
+
+	assert.Error(t, NewSender("", "exit 2").Send("hello"))
+	assert.False(t, NewSender("", "").Enabled())
+}
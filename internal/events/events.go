@@ -0,0 +1,136 @@
+package events
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"os"
+	"os/exec"
+	"time"
+
+	"github.com/pmossman/claudew/internal/execx"
+)
+
+// Workspace lifecycle event types
+const (
+	WorkspaceCreated  = "workspace.created"
+	WorkspaceArchived = "workspace.archived"
+	WorkspaceRenamed  = "workspace.renamed"
+	SessionStarted    = "session.started"
+	SessionDetached   = "session.detached"
+	SessionStopped    = "session.stopped"
+	SessionRestarted  = "session.restarted"
+	ClaudeExited      = "claude.exited"
+	CloneCreated      = "clone.created"
+	CloneImported     = "clone.imported"
+	CloneAssigned     = "clone.assigned"
+	CloneFreed        = "clone.freed"
+	RemoteAdded       = "remote.added"
+)
+
+// Event is a structured workspace lifecycle event
+type Event struct {
+	Type      string            `json:"type"`
+	Time      time.Time         `json:"time"`
+	Workspace string            `json:"workspace,omitempty"`
+	Data      map[string]string `json:"data,omitempty"`
+}
+
+// Emitter delivers events to a webhook URL and/or a local command
+type Emitter struct {
+	WebhookURL string
+	Command    string
+	Timeout    time.Duration
+}
+
+// NewEmitter creates a new event emitter
+func NewEmitter(webhookURL, command string) *Emitter {
+	return &Emitter{
+		WebhookURL: webhookURL,
+		Command:    command,
+		Timeout:    5 * time.Second,
+	}
+}
+
+// Enabled reports whether any event destination is configured
+func (e *Emitter) Enabled() bool {
+	return e.WebhookURL != "" || e.Command != ""
+}
+
+// Emit sends an event to all configured destinations. A failing destination doesn't
+// keep the event from the others; all failures are returned together.
+func (e *Emitter) Emit(eventType, workspaceName string, data map[string]string) error {
+	if !e.Enabled() {
+		return nil
+	}
+
+	event := Event{
+		Type:      eventType,
+		Time:      time.Now(),
+		Workspace: workspaceName,
+		Data:      data,
+	}
+
+	payload, err := json.Marshal(event)
+	if err != nil {
+		return fmt.Errorf("failed to marshal event: %w", err)
+	}
+
+	var errs []error
+	if e.WebhookURL != "" {
+		errs = append(errs, e.postWebhook(payload))
+	}
+	if e.Command != "" {
+		errs = append(errs, e.runCommand(eventType, payload))
+	}
+	return errors.Join(errs...)
+}
+
+// postWebhook POSTs the event payload as JSON to the webhook URL
+func (e *Emitter) postWebhook(payload []byte) error {
+	client := &http.Client{Timeout: e.Timeout}
+	resp, err := client.Post(e.WebhookURL, "application/json", bytes.NewReader(payload))
+	if err != nil {
+		return fmt.Errorf("failed to post event to webhook: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return fmt.Errorf("webhook returned status %d", resp.StatusCode)
+	}
+	return nil
+}
+
+// runCommand runs the event command with the payload on stdin. It is started with
+// os/exec rather than execx so it can be killed on timeout, and logged with
+// execx.LogProcess.
+func (e *Emitter) runCommand(eventType string, payload []byte) error {
+	cmd := exec.Command("sh", "-c", e.Command)
+	cmd.Stdin = bytes.NewReader(payload)
+	cmd.Env = append(os.Environ(), "CLAUDEW_EVENT="+eventType)
+
+	start := time.Now()
+	if err := cmd.Start(); err != nil {
+		execx.LogProcess(cmd, err, time.Since(start))
+		return fmt.Errorf("failed to run event command: %w", err)
+	}
+
+	done := make(chan error, 1)
+	go func() { done <- cmd.Wait() }()
+
+	select {
+	case err := <-done:
+		execx.LogProcess(cmd, err, time.Since(start))
+		if err != nil {
+			return fmt.Errorf("event command failed: %w", err)
+		}
+		return nil
+	case <-time.After(e.Timeout):
+		_ = cmd.Process.Kill()
+		err := fmt.Errorf("event command timed out after %s", e.Timeout)
+		execx.LogProcess(cmd, err, time.Since(start))
+		return err
+	}
+}
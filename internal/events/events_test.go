@@ -0,0 +1,104 @@
+package events
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestEmitter_Disabled(t *testing.T) {
+	e := NewEmitter("", "")
+	assert.False(t, e.Enabled())
+
+	// Emitting with no destinations is a no-op
+	err := e.Emit(WorkspaceCreated, "test-ws", nil)
+	assert.NoError(t, err)
+}
+
+func TestEmitter_Webhook(t *testing.T) {
+	var received Event
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "application/json", r.Header.Get("Content-Type"))
+		body, err := io.ReadAll(r.Body)
+		require.NoError(t, err)
+		require.NoError(t, json.Unmarshal(body, &received))
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer server.Close()
+
+	e := NewEmitter(server.URL, "")
+	err := e.Emit(CloneAssigned, "test-ws", map[string]string{"clone": "/tmp/clone"})
+	require.NoError(t, err)
+
+	assert.Equal(t, CloneAssigned, received.Type)
+	assert.Equal(t, "test-ws", received.Workspace)
+	assert.Equal(t, "/tmp/clone", received.Data["clone"])
+	assert.False(t, received.Time.IsZero())
+}
+
+func TestEmitter_WebhookErrorStatus(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusInternalServerError)
+	}))
+	defer server.Close()
+
+	e := NewEmitter(server.URL, "")
+	err := e.Emit(SessionStarted, "test-ws", nil)
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "500")
+}
+
+func TestEmitter_WebhookFailureStillRunsCommand(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusInternalServerError)
+	}))
+	defer server.Close()
+	outFile := filepath.Join(t.TempDir(), "event.json")
+
+	e := NewEmitter(server.URL, "cat > "+outFile+" && exit 3")
+	err := e.Emit(SessionStarted, "test-ws", nil)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "500")
+	assert.Contains(t, err.Error(), "event command failed")
+
+	data, err := os.ReadFile(outFile)
+	require.NoError(t, err)
+	assert.Contains(t, string(data), `"type":"session.started"`)
+}
+
+func TestEmitter_Command(t *testing.T) {
+	outFile := filepath.Join(t.TempDir(), "event.json")
+
+	e := NewEmitter("", "cat > "+outFile+" && echo $CLAUDEW_EVENT >> "+outFile)
+	err := e.Emit(SessionStopped, "test-ws", nil)
+	require.NoError(t, err)
+
+	data, err := os.ReadFile(outFile)
+	require.NoError(t, err)
+	assert.Contains(t, string(data), `"type":"session.stopped"`)
+	assert.Contains(t, string(data), `"workspace":"test-ws"`)
+	assert.Contains(t, string(data), "session.stopped\n")
+}
+
+func TestEmitter_CommandFailure(t *testing.T) {
+	e := NewEmitter("", "exit 3")
+	err := e.Emit(SessionStopped, "test-ws", nil)
+	assert.Error(t, err)
+}
+
+func TestEmitter_CommandTimeout(t *testing.T) {
+	e := NewEmitter("", "sleep 5")
+	e.Timeout = 100 * time.Millisecond
+
+	err := e.Emit(SessionStopped, "test-ws", nil)
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "timed out")
+}
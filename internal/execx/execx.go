@@ -0,0 +1,192 @@
+package execx
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"os/exec"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Cmd is an external command, shaped like exec.Cmd so call sites read the same.
+// It is executed by the current Runner, which is the real OS unless replaced with Use.
+type Cmd struct {
+	Name   string
+	Args   []string
+	Dir    string
+	Env    []string
+	Stdin  io.Reader
+	Stdout io.Writer
+	Stderr io.Writer
+}
+
+// Runner executes commands
+type Runner interface {
+	Run(cmd *Cmd) error
+}
+
+// ExitError reports a command that ran but exited with a non-zero status
+type ExitError struct {
+	Code int
+	// Stderr holds the command's error output when it was collected by Output
+	Stderr []byte
+}
+
+func (e *ExitError) Error() string {
+	return fmt.Sprintf("exit status %d", e.Code)
+}
+
+// ExitCode returns the command's exit status
+func (e *ExitError) ExitCode() int {
+	return e.Code
+}
+
+var (
+	mu      sync.RWMutex
+	current Runner = OS{}
+)
+
+// Use makes r execute every command until the returned function restores the previous runner
+func Use(r Runner) (restore func()) {
+	mu.Lock()
+	prev := current
+	current = r
+	mu.Unlock()
+	return func() {
+		mu.Lock()
+		current = prev
+		mu.Unlock()
+	}
+}
+
+// Command returns a command that runs the named program with args
+func Command(name string, args ...string) *Cmd {
+	return &Cmd{Name: name, Args: args}
+}
+
+// Run executes the command and waits for it to finish. Each command is logged at
+// debug level with its exit status and duration.
+func (c *Cmd) Run() error {
+	mu.RLock()
+	r := current
+	mu.RUnlock()
+
+	start := time.Now()
+	err := r.Run(c)
+	logRun(c, err, time.Since(start))
+	return err
+}
+
+// LogProcess writes the debug record Run writes, for a command run directly with
+// os/exec by a caller that needs more than Run offers, such as the process ID or a
+// timeout. err is what Wait (or Start) returned.
+func LogProcess(cmd *exec.Cmd, err error, elapsed time.Duration) {
+	var exitErr *exec.ExitError
+	if errors.As(err, &exitErr) {
+		err = &ExitError{Code: exitErr.ExitCode()}
+	}
+	logRun(&Cmd{Name: cmd.Args[0], Args: cmd.Args[1:], Dir: cmd.Dir}, err, elapsed)
+}
+
+// logRun records a finished command in the debug log
+func logRun(c *Cmd, err error, elapsed time.Duration) {
+	attrs := []any{"name", c.Name, "args", redactArgs(c.Args), "duration", elapsed.Round(time.Microsecond)}
+	if c.Dir != "" {
+		attrs = append(attrs, "dir", c.Dir)
+	}
+
+	var exitErr *ExitError
+	switch {
+	case err == nil:
+		attrs = append(attrs, "exit", 0)
+	case errors.As(err, &exitErr):
+		attrs = append(attrs, "exit", exitErr.Code)
+	default:
+		attrs = append(attrs, "error", err)
+	}
+	slog.Debug("exec", attrs...)
+}
+
+// redactArgs returns args with the values of environment flags (-e KEY=VALUE,
+// --env KEY=VALUE, --env=KEY=VALUE, as docker takes them) replaced, so secrets
+// passed to containers don't end up in the debug log
+func redactArgs(args []string) []string {
+	redacted := make([]string, len(args))
+	copy(redacted, args)
+	for i, arg := range redacted {
+		switch {
+		case (arg == "-e" || arg == "--env") && i+1 < len(redacted):
+			redacted[i+1] = redactAssignment(redacted[i+1])
+		case strings.HasPrefix(arg, "--env="):
+			redacted[i] = "--env=" + redactAssignment(strings.TrimPrefix(arg, "--env="))
+		}
+	}
+	return redacted
+}
+
+// redactAssignment hides the value of KEY=VALUE, keeping the key
+func redactAssignment(assignment string) string {
+	key, _, found := strings.Cut(assignment, "=")
+	if !found {
+		return assignment // -e KEY passes the variable from the environment
+	}
+	return key + "=REDACTED"
+}
+
+// Output runs the command and returns its standard output. Error output is
+// attached to the returned *ExitError unless Stderr was set by the caller.
+func (c *Cmd) Output() ([]byte, error) {
+	if c.Stdout != nil {
+		return nil, errors.New("execx: Stdout already set")
+	}
+	var stdout, stderr bytes.Buffer
+	c.Stdout = &stdout
+	captureStderr := c.Stderr == nil
+	if captureStderr {
+		c.Stderr = &stderr
+	}
+
+	err := c.Run()
+	var exitErr *ExitError
+	if captureStderr && errors.As(err, &exitErr) {
+		exitErr.Stderr = stderr.Bytes()
+		slog.Debug("exec stderr", "name", c.Name, "stderr", string(exitErr.Stderr))
+	}
+	return stdout.Bytes(), err
+}
+
+// CombinedOutput runs the command and returns its standard output and error output together
+func (c *Cmd) CombinedOutput() ([]byte, error) {
+	if c.Stdout != nil || c.Stderr != nil {
+		return nil, errors.New("execx: Stdout or Stderr already set")
+	}
+	var b bytes.Buffer
+	c.Stdout = &b
+	c.Stderr = &b
+	err := c.Run()
+	return b.Bytes(), err
+}
+
+// OS runs commands as real processes
+type OS struct{}
+
+// Run starts the program with os/exec and converts its exit status to *ExitError
+func (OS) Run(c *Cmd) error {
+	cmd := exec.Command(c.Name, c.Args...)
+	cmd.Dir = c.Dir
+	cmd.Env = c.Env
+	cmd.Stdin = c.Stdin
+	cmd.Stdout = c.Stdout
+	cmd.Stderr = c.Stderr
+
+	err := cmd.Run()
+	var exitErr *exec.ExitError
+	if errors.As(err, &exitErr) {
+		return &ExitError{Code: exitErr.ExitCode()}
+	}
+	return err
+}
@@ -0,0 +1,125 @@
+package execx
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"log/slog"
+	"os/exec"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestOSRunExitError(t *testing.T) {
+	output, err := Command("sh", "-c", "echo out; echo oops >&2; exit 3").Output()
+	assert.Equal(t, "out\n", string(output))
+
+	var exitErr *ExitError
+	require.True(t, errors.As(err, &exitErr))
+	assert.Equal(t, 3, exitErr.ExitCode())
+	assert.Equal(t, "oops\n", string(exitErr.Stderr))
+}
+
+func TestCombinedOutput(t *testing.T) {
+	output, err := Command("sh", "-c", "echo out; echo err >&2").CombinedOutput()
+	require.NoError(t, err)
+	assert.Equal(t, "out\nerr\n", string(output))
+}
+
+func TestFakeHandlesRegisteredPrograms(t *testing.T) {
+	fake := NewFake(nil)
+	fake.Handle("tmux", func(c *Cmd) error {
+		if c.Args[0] == "has-session" {
+			_, _ = io.WriteString(c.Stderr, "can't find session\n")
+			return &ExitError{Code: 1}
+		}
+		_, _ = io.WriteString(c.Stdout, "fake\n")
+		return nil
+	})
+	defer Use(fake)()
+
+	output, err := Command("tmux", "-V").Output()
+	require.NoError(t, err)
+	assert.Equal(t, "fake\n", string(output))
+
+	_, err = Command("tmux", "has-session", "-t", "x").Output()
+	var exitErr *ExitError
+	require.True(t, errors.As(err, &exitErr))
+	assert.Equal(t, "can't find session\n", string(exitErr.Stderr))
+
+	// Without a fallback, unhandled programs fail instead of running
+	err = Command("git", "status").Run()
+	assert.Error(t, err)
+
+	assert.Equal(t, []string{"tmux -V", "tmux has-session -t x", "git status"}, fake.Calls())
+}
+
+func TestFakeFallback(t *testing.T) {
+	fake := NewFake(OS{})
+	defer Use(fake)()
+
+	output, err := Command("echo", "real").Output()
+	require.NoError(t, err)
+	assert.Equal(t, "real\n", string(output))
+}
+
+func TestUseRestores(t *testing.T) {
+	restore := Use(NewFake(nil))
+	assert.Error(t, Command("true").Run())
+	restore()
+	assert.NoError(t, Command("true").Run())
+}
+
+func TestRunLogsCommands(t *testing.T) {
+	var buf bytes.Buffer
+	prev := slog.Default()
+	slog.SetDefault(slog.New(slog.NewTextHandler(&buf, &slog.HandlerOptions{Level: slog.LevelDebug})))
+	defer slog.SetDefault(prev)
+
+	_, err := Command("sh", "-c", "echo oops >&2; exit 2").Output()
+	require.Error(t, err)
+
+	log := buf.String()
+	assert.Contains(t, log, "msg=exec name=sh")
+	assert.Contains(t, log, "exit=2")
+	assert.Contains(t, log, "duration=")
+	assert.Contains(t, log, `stderr="oops\n"`)
+}
+
+func TestRunLogRedactsEnv(t *testing.T) {
+	var buf bytes.Buffer
+	prev := slog.Default()
+	slog.SetDefault(slog.New(slog.NewTextHandler(&buf, &slog.HandlerOptions{Level: slog.LevelDebug})))
+	defer slog.SetDefault(prev)
+
+	args := []string{"run", "-e", "TOKEN=secret", "--env", "KEY=hunter2", "--env=PASS=x", "-e", "HOME", "image"}
+	_ = Command("true", args...).Run()
+
+	log := buf.String()
+	assert.NotContains(t, log, "secret")
+	assert.NotContains(t, log, "hunter2")
+	assert.Contains(t, log, "TOKEN=REDACTED")
+	assert.Contains(t, log, "--env=PASS=REDACTED")
+	assert.Contains(t, log, "HOME")
+	assert.Equal(t, "TOKEN=secret", args[2], "the command's own args are left alone")
+}
+
+func TestLogProcess(t *testing.T) {
+	var buf bytes.Buffer
+	prev := slog.Default()
+	slog.SetDefault(slog.New(slog.NewTextHandler(&buf, &slog.HandlerOptions{Level: slog.LevelDebug})))
+	defer slog.SetDefault(prev)
+
+	cmd := exec.Command("sh", "-c", "exit 3")
+	start := time.Now()
+	err := cmd.Run()
+	LogProcess(cmd, err, time.Since(start))
+
+	log := buf.String()
+	assert.Contains(t, log, "msg=exec name=sh")
+	assert.Contains(t, log, "exit=3")
+	assert.Contains(t, log, "duration=")
+}
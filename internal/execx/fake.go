@@ -0,0 +1,56 @@
+package execx
+
+import (
+	"fmt"
+	"strings"
+	"sync"
+)
+
+// HandlerFunc stands in for one program. It reads c.Stdin and writes c.Stdout and
+// c.Stderr (either may be nil), returning *ExitError for a non-zero exit.
+type HandlerFunc func(c *Cmd) error
+
+// Fake is a Runner that answers commands from registered handlers and records every call.
+// Programs without a handler are passed to Fallback, or fail if it is nil.
+type Fake struct {
+	Fallback Runner
+
+	mu       sync.Mutex
+	handlers map[string]HandlerFunc
+	calls    []string
+}
+
+// NewFake creates a Fake that runs unhandled programs with fallback (nil to fail them)
+func NewFake(fallback Runner) *Fake {
+	return &Fake{Fallback: fallback, handlers: make(map[string]HandlerFunc)}
+}
+
+// Handle registers h to run in place of the named program
+func (f *Fake) Handle(name string, h HandlerFunc) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.handlers[name] = h
+}
+
+// Run dispatches the command to its handler or the fallback
+func (f *Fake) Run(c *Cmd) error {
+	f.mu.Lock()
+	f.calls = append(f.calls, strings.TrimSpace(c.Name+" "+strings.Join(c.Args, " ")))
+	h, ok := f.handlers[c.Name]
+	f.mu.Unlock()
+
+	if ok {
+		return h(c)
+	}
+	if f.Fallback != nil {
+		return f.Fallback.Run(c)
+	}
+	return fmt.Errorf("exec: %q: not available in fake", c.Name)
+}
+
+// Calls returns every command run so far, one "name arg..." line per call
+func (f *Fake) Calls() []string {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return append([]string(nil), f.calls...)
+}
@@ -3,13 +3,17 @@ package git
 import (
 	"fmt"
 	"os"
-	"os/exec"
+	"sort"
+	"strconv"
 	"strings"
+	"time"
+
+	"github.com/pmossman/claudew/internal/execx"
 )
 
 // GetCurrentBranch returns the current branch name for a repository
 func GetCurrentBranch(repoPath string) (string, error) {
-	cmd := exec.Command("git", "-C", repoPath, "rev-parse", "--abbrev-ref", "HEAD")
+	cmd := execx.Command("git", "-C", repoPath, "rev-parse", "--abbrev-ref", "HEAD")
 	output, err := cmd.Output()
 	if err != nil {
 		return "", fmt.Errorf("failed to get current branch: %w", err)
@@ -19,7 +23,7 @@ func GetCurrentBranch(repoPath string) (string, error) {
 
 // Clone clones a repository to the specified path with progress output
 func Clone(url, destPath string) error {
-	cmd := exec.Command("git", "clone", "--progress", url, destPath)
+	cmd := execx.Command("git", "clone", "--progress", url, destPath)
 
 	// Stream output to user in real-time
 	cmd.Stdout = os.Stdout
@@ -33,16 +37,236 @@ func Clone(url, destPath string) error {
 
 // IsGitRepo checks if a directory is a git repository
 func IsGitRepo(path string) bool {
-	cmd := exec.Command("git", "-C", path, "rev-parse", "--git-dir")
+	cmd := execx.Command("git", "-C", path, "rev-parse", "--git-dir")
 	return cmd.Run() == nil
 }
 
 // GetRemoteURL returns the remote URL for a repository
 func GetRemoteURL(repoPath string) (string, error) {
-	cmd := exec.Command("git", "-C", repoPath, "remote", "get-url", "origin")
+	cmd := execx.Command("git", "-C", repoPath, "remote", "get-url", "origin")
 	output, err := cmd.Output()
 	if err != nil {
 		return "", fmt.Errorf("failed to get remote URL: %w", err)
 	}
 	return strings.TrimSpace(string(output)), nil
 }
+
+// HasUncommittedChanges reports whether the working tree has modified, staged, or untracked files
+func HasUncommittedChanges(repoPath string) (bool, error) {
+	cmd := execx.Command("git", "-C", repoPath, "status", "--porcelain")
+	output, err := cmd.Output()
+	if err != nil {
+		return false, fmt.Errorf("failed to get status: %w", err)
+	}
+	return strings.TrimSpace(string(output)) != "", nil
+}
+
+// UncommittedFiles returns the working tree's changes as "git status --porcelain" lines,
+// e.g. " M main.go" or "?? notes.txt"
+func UncommittedFiles(repoPath string) ([]string, error) {
+	cmd := execx.Command("git", "-C", repoPath, "status", "--porcelain")
+	output, err := cmd.Output()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get status: %w", err)
+	}
+
+	var files []string
+	for _, line := range strings.Split(string(output), "\n") {
+		if line = strings.TrimRight(line, " \r"); line != "" {
+			files = append(files, line)
+		}
+	}
+	return files, nil
+}
+
+// HasUnpushedCommits reports whether any local branch has commits not present on a remote
+func HasUnpushedCommits(repoPath string) (bool, error) {
+	cmd := execx.Command("git", "-C", repoPath, "log", "--branches", "--not", "--remotes", "--oneline", "-n", "1")
+	output, err := cmd.Output()
+	if err != nil {
+		return false, fmt.Errorf("failed to check for unpushed commits: %w", err)
+	}
+	return strings.TrimSpace(string(output)) != "", nil
+}
+
+// RecentLog returns the last n commits of the current branch in one-line format
+func RecentLog(repoPath string, n int) (string, error) {
+	cmd := execx.Command("git", "-C", repoPath, "log", "--oneline", "--no-decorate", "-n", fmt.Sprintf("%d", n))
+	output, err := cmd.Output()
+	if err != nil {
+		return "", fmt.Errorf("failed to get git log: %w", err)
+	}
+	return strings.TrimSpace(string(output)), nil
+}
+
+// LogSince returns the one-line log of commits on HEAD committed after since, newest first
+func LogSince(repoPath string, since time.Time) ([]string, error) {
+	cmd := execx.Command("git", "-C", repoPath, "log", "--oneline", "--no-decorate", "--since="+since.Format(time.RFC3339))
+	output, err := cmd.Output()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get git log: %w", err)
+	}
+	return nonEmptyLines(string(output)), nil
+}
+
+// FilesChangedSince returns the files touched by commits on HEAD committed after since, sorted
+func FilesChangedSince(repoPath string, since time.Time) ([]string, error) {
+	cmd := execx.Command("git", "-C", repoPath, "log", "--name-only", "--format=", "--since="+since.Format(time.RFC3339))
+	output, err := cmd.Output()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get changed files: %w", err)
+	}
+
+	seen := map[string]bool{}
+	var files []string
+	for _, file := range nonEmptyLines(string(output)) {
+		if !seen[file] {
+			seen[file] = true
+			files = append(files, file)
+		}
+	}
+	sort.Strings(files)
+	return files, nil
+}
+
+// nonEmptyLines splits output into lines, dropping blank ones
+func nonEmptyLines(output string) []string {
+	var lines []string
+	for _, line := range strings.Split(output, "\n") {
+		if line = strings.TrimSpace(line); line != "" {
+			lines = append(lines, line)
+		}
+	}
+	return lines
+}
+
+// Fsck checks the object database for corruption without verifying packed objects in full
+func Fsck(repoPath string) error {
+	cmd := execx.Command("git", "-C", repoPath, "fsck", "--no-full", "--no-progress")
+	if output, err := cmd.CombinedOutput(); err != nil {
+		return fmt.Errorf("git fsck failed: %s", strings.TrimSpace(string(output)))
+	}
+	return nil
+}
+
+// HasValidHead reports whether HEAD resolves to a commit (false for empty or partially-failed clones)
+func HasValidHead(repoPath string) bool {
+	cmd := execx.Command("git", "-C", repoPath, "rev-parse", "--verify", "--quiet", "HEAD^{commit}")
+	return cmd.Run() == nil
+}
+
+// IsDetachedHead reports whether HEAD is not on a branch
+func IsDetachedHead(repoPath string) (bool, error) {
+	cmd := execx.Command("git", "-C", repoPath, "symbolic-ref", "--quiet", "HEAD")
+	if err := cmd.Run(); err != nil {
+		if exitErr, ok := err.(*execx.ExitError); ok && exitErr.ExitCode() == 1 {
+			return true, nil
+		}
+		return false, fmt.Errorf("failed to check HEAD: %w", err)
+	}
+	return false, nil
+}
+
+// Checkout switches the repository to branch, creating it from a same-named
+// remote branch if it only exists there
+func Checkout(repoPath, branch string) error {
+	cmd := execx.Command("git", "-C", repoPath, "checkout", branch)
+	if output, err := cmd.CombinedOutput(); err != nil {
+		return fmt.Errorf("failed to check out %s: %w (%s)", branch, err, strings.TrimSpace(string(output)))
+	}
+	return nil
+}
+
+// GetDefaultBranch returns origin's default branch (e.g. "main") from refs/remotes/origin/HEAD
+func GetDefaultBranch(repoPath string) (string, error) {
+	cmd := execx.Command("git", "-C", repoPath, "symbolic-ref", "--short", "refs/remotes/origin/HEAD")
+	output, err := cmd.Output()
+	if err != nil {
+		return "", fmt.Errorf("failed to get default branch: %w", err)
+	}
+	return strings.TrimPrefix(strings.TrimSpace(string(output)), "origin/"), nil
+}
+
+// CommitsBehind returns how many commits on ref are not reachable from HEAD
+func CommitsBehind(repoPath, ref string) (int, error) {
+	cmd := execx.Command("git", "-C", repoPath, "rev-list", "--count", "HEAD.."+ref)
+	output, err := cmd.Output()
+	if err != nil {
+		return 0, fmt.Errorf("failed to count commits behind %s: %w", ref, err)
+	}
+	count, err := strconv.Atoi(strings.TrimSpace(string(output)))
+	if err != nil {
+		return 0, fmt.Errorf("failed to parse commit count: %w", err)
+	}
+	return count, nil
+}
+
+// NormalizeRemoteURL strips differences that don't change which repository a URL points at
+func NormalizeRemoteURL(url string) string {
+	url = strings.TrimSpace(url)
+	url = strings.TrimSuffix(url, "/")
+	url = strings.TrimSuffix(url, ".git")
+	return strings.ToLower(url)
+}
+
+// SetRemoteURL changes the URL of the origin remote
+func SetRemoteURL(repoPath, url string) error {
+	cmd := execx.Command("git", "-C", repoPath, "remote", "set-url", "origin", url)
+	if output, err := cmd.CombinedOutput(); err != nil {
+		return fmt.Errorf("failed to set remote URL: %s", strings.TrimSpace(string(output)))
+	}
+	return nil
+}
+
+// SnapshotToBranch commits the working tree's uncommitted changes, including untracked
+// files, onto HEAD and points branch at the result. The checkout, index and current branch
+// are left untouched. Returns the new commit, or "" if there was nothing to commit.
+func SnapshotToBranch(repoPath, branch, message string) (string, error) {
+	dirty, err := HasUncommittedChanges(repoPath)
+	if err != nil || !dirty {
+		return "", err
+	}
+	if !HasValidHead(repoPath) {
+		return "", fmt.Errorf("repository has no commits")
+	}
+
+	// Stage into a throwaway index so the real one keeps the user's staging
+	indexFile, err := os.CreateTemp("", "claudew-index-*")
+	if err != nil {
+		return "", fmt.Errorf("failed to create temporary index: %w", err)
+	}
+	indexFile.Close()
+	defer os.Remove(indexFile.Name())
+
+	run := func(args ...string) (string, error) {
+		cmd := execx.Command("git", append([]string{"-C", repoPath}, args...)...)
+		cmd.Env = append(os.Environ(), "GIT_INDEX_FILE="+indexFile.Name())
+		output, err := cmd.Output()
+		if err != nil {
+			if exitErr, ok := err.(*execx.ExitError); ok && len(exitErr.Stderr) > 0 {
+				return "", fmt.Errorf("git %s: %s", args[0], strings.TrimSpace(string(exitErr.Stderr)))
+			}
+			return "", fmt.Errorf("git %s: %w", args[0], err)
+		}
+		return strings.TrimSpace(string(output)), nil
+	}
+
+	if _, err := run("read-tree", "HEAD"); err != nil {
+		return "", fmt.Errorf("failed to snapshot changes: %w", err)
+	}
+	if _, err := run("add", "-A"); err != nil {
+		return "", fmt.Errorf("failed to snapshot changes: %w", err)
+	}
+	tree, err := run("write-tree")
+	if err != nil {
+		return "", fmt.Errorf("failed to snapshot changes: %w", err)
+	}
+	commit, err := run("commit-tree", tree, "-p", "HEAD", "-m", message)
+	if err != nil {
+		return "", fmt.Errorf("failed to commit snapshot: %w", err)
+	}
+	if _, err := run("update-ref", "-m", message, "refs/heads/"+branch, commit); err != nil {
+		return "", fmt.Errorf("failed to update %s: %w", branch, err)
+	}
+	return commit, nil
+}
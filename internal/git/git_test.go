@@ -4,7 +4,9 @@ import (
 	"os"
 	"os/exec"
 	"path/filepath"
+	"strings"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -189,6 +191,50 @@ func TestClone_InvalidURL(t *testing.T) {
 	assert.Contains(t, err.Error(), "failed to clone repository")
 }
 
+func TestCloneWithProgress(t *testing.T) {
+	sourceRepo := setupGitRepo(t)
+	destPath := filepath.Join(t.TempDir(), "cloned-repo")
+
+	// file:// makes git use its transport, which reports progress even for a local repo
+	var updates []Progress
+	require.NoError(t, CloneWithProgress("file://"+sourceRepo, destPath, func(p Progress) {
+		updates = append(updates, p)
+	}))
+	assert.True(t, IsGitRepo(destPath))
+	assert.FileExists(t, filepath.Join(destPath, "README.md"))
+	require.NotEmpty(t, updates)
+	assert.Equal(t, 100, updates[len(updates)-1].Percent)
+
+	// Failures carry git's message
+	err := CloneWithProgress(filepath.Join(t.TempDir(), "missing"), filepath.Join(t.TempDir(), "dest"), nil)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "failed to clone repository")
+	assert.Contains(t, err.Error(), "does not exist")
+}
+
+func TestParseProgress(t *testing.T) {
+	p, ok := ParseProgress("Receiving objects:  45% (450/1000), 1.20 MiB | 2.40 MiB/s")
+	require.True(t, ok)
+	assert.Equal(t, Progress{Phase: "Receiving objects", Percent: 45, Speed: "2.40 MiB/s"}, p)
+
+	p, ok = ParseProgress("Receiving objects: 100% (1000/1000), 3.10 MiB | 2.50 MiB/s, done.")
+	require.True(t, ok)
+	assert.Equal(t, Progress{Phase: "Receiving objects", Percent: 100, Speed: "2.50 MiB/s"}, p)
+
+	p, ok = ParseProgress("remote: Compressing objects:  12% (3/25)")
+	require.True(t, ok)
+	assert.Equal(t, Progress{Phase: "Compressing objects", Percent: 12}, p)
+
+	p, ok = ParseProgress("Resolving deltas: 100% (5/5), done.")
+	require.True(t, ok)
+	assert.Equal(t, Progress{Phase: "Resolving deltas", Percent: 100}, p)
+
+	_, ok = ParseProgress("Cloning into 'repo'...")
+	assert.False(t, ok)
+	_, ok = ParseProgress("fatal: repository 'x' does not exist")
+	assert.False(t, ok)
+}
+
 func TestClone_ExistingDestination(t *testing.T) {
 	sourceRepo := setupGitRepo(t)
 
@@ -301,3 +347,279 @@ func TestGetRemoteURL_MultipleRemotes(t *testing.T) {
 	require.NoError(t, err)
 	assert.Equal(t, originURL, url)
 }
+
+func TestHasUncommittedChanges(t *testing.T) {
+	repoPath := setupGitRepo(t)
+
+	// Clean repo
+	dirty, err := HasUncommittedChanges(repoPath)
+	require.NoError(t, err)
+	assert.False(t, dirty)
+
+	// Untracked file makes it dirty
+	err = os.WriteFile(filepath.Join(repoPath, "new.txt"), []byte("new"), 0644)
+	require.NoError(t, err)
+
+	dirty, err = HasUncommittedChanges(repoPath)
+	require.NoError(t, err)
+	assert.True(t, dirty)
+}
+
+func TestHasUncommittedChanges_NonGitRepo(t *testing.T) {
+	_, err := HasUncommittedChanges(t.TempDir())
+	assert.Error(t, err)
+}
+
+func TestUncommittedFiles(t *testing.T) {
+	repoPath := setupGitRepo(t)
+
+	files, err := UncommittedFiles(repoPath)
+	require.NoError(t, err)
+	assert.Empty(t, files)
+
+	err = os.WriteFile(filepath.Join(repoPath, "new.txt"), []byte("new"), 0644)
+	require.NoError(t, err)
+	err = os.WriteFile(filepath.Join(repoPath, "README.md"), []byte("changed"), 0644)
+	require.NoError(t, err)
+
+	files, err = UncommittedFiles(repoPath)
+	require.NoError(t, err)
+	assert.Equal(t, []string{" M README.md", "?? new.txt"}, files)
+}
+
+func TestHasUnpushedCommits(t *testing.T) {
+	repoPath := setupGitRepo(t)
+
+	// Repo without a remote: the initial commit is unpushed
+	unpushed, err := HasUnpushedCommits(repoPath)
+	require.NoError(t, err)
+	assert.True(t, unpushed)
+
+	// Clone it: the clone's commits all exist on origin
+	clonePath := filepath.Join(t.TempDir(), "clone")
+	err = exec.Command("git", "clone", "-q", repoPath, clonePath).Run()
+	require.NoError(t, err)
+
+	unpushed, err = HasUnpushedCommits(clonePath)
+	require.NoError(t, err)
+	assert.False(t, unpushed)
+}
+
+func TestRecentLog(t *testing.T) {
+	repoPath := setupGitRepo(t)
+
+	for _, msg := range []string{"Second commit", "Third commit"} {
+		cmd := exec.Command("git", "commit", "--allow-empty", "-m", msg)
+		cmd.Dir = repoPath
+		require.NoError(t, cmd.Run())
+	}
+
+	log, err := RecentLog(repoPath, 2)
+	require.NoError(t, err)
+
+	lines := strings.Split(log, "\n")
+	require.Len(t, lines, 2)
+	assert.Contains(t, lines[0], "Third commit")
+	assert.Contains(t, lines[1], "Second commit")
+}
+
+func TestRecentLog_NonGitRepo(t *testing.T) {
+	_, err := RecentLog(t.TempDir(), 5)
+	assert.Error(t, err)
+}
+
+func TestFsck(t *testing.T) {
+	repoPath := setupGitRepo(t)
+	assert.NoError(t, Fsck(repoPath))
+}
+
+func TestFsck_NonGitRepo(t *testing.T) {
+	assert.Error(t, Fsck(t.TempDir()))
+}
+
+func TestHasValidHead(t *testing.T) {
+	repoPath := setupGitRepo(t)
+	assert.True(t, HasValidHead(repoPath))
+
+	// Freshly initialized repo has no commits
+	emptyRepo := t.TempDir()
+	require.NoError(t, exec.Command("git", "init", "-q", emptyRepo).Run())
+	assert.False(t, HasValidHead(emptyRepo))
+}
+
+func TestIsDetachedHead(t *testing.T) {
+	repoPath := setupGitRepo(t)
+
+	detached, err := IsDetachedHead(repoPath)
+	require.NoError(t, err)
+	assert.False(t, detached)
+
+	require.NoError(t, exec.Command("git", "-C", repoPath, "checkout", "-q", "--detach").Run())
+
+	detached, err = IsDetachedHead(repoPath)
+	require.NoError(t, err)
+	assert.True(t, detached)
+}
+
+func TestGetDefaultBranchAndCommitsBehind(t *testing.T) {
+	originPath := setupGitRepo(t)
+	branch, err := GetCurrentBranch(originPath)
+	require.NoError(t, err)
+
+	clonePath := filepath.Join(t.TempDir(), "clone")
+	require.NoError(t, exec.Command("git", "clone", "-q", originPath, clonePath).Run())
+
+	defaultBranch, err := GetDefaultBranch(clonePath)
+	require.NoError(t, err)
+	assert.Equal(t, branch, defaultBranch)
+
+	behind, err := CommitsBehind(clonePath, "origin/"+defaultBranch)
+	require.NoError(t, err)
+	assert.Equal(t, 0, behind)
+
+	// Add commits upstream and fetch them
+	for _, msg := range []string{"Upstream 1", "Upstream 2"} {
+		require.NoError(t, exec.Command("git", "-C", originPath, "commit", "-q", "--allow-empty", "-m", msg).Run())
+	}
+	require.NoError(t, exec.Command("git", "-C", clonePath, "fetch", "-q").Run())
+
+	behind, err = CommitsBehind(clonePath, "origin/"+defaultBranch)
+	require.NoError(t, err)
+	assert.Equal(t, 2, behind)
+}
+
+func TestNormalizeRemoteURL(t *testing.T) {
+	assert.Equal(t, NormalizeRemoteURL("git@github.com:org/repo.git"), NormalizeRemoteURL("git@github.com:org/repo"))
+	assert.Equal(t, NormalizeRemoteURL("https://github.com/Org/Repo/"), NormalizeRemoteURL("https://github.com/org/repo.git"))
+	assert.NotEqual(t, NormalizeRemoteURL("git@github.com:org/repo.git"), NormalizeRemoteURL("git@github.com:org/other.git"))
+}
+
+func TestSetRemoteURL(t *testing.T) {
+	repoPath := setupGitRepo(t)
+	require.NoError(t, exec.Command("git", "-C", repoPath, "remote", "add", "origin", "git@github.com:old/repo.git").Run())
+
+	err := SetRemoteURL(repoPath, "git@github.com:new/repo.git")
+	require.NoError(t, err)
+
+	url, err := GetRemoteURL(repoPath)
+	require.NoError(t, err)
+	assert.Equal(t, "git@github.com:new/repo.git", url)
+}
+
+func TestSetRemoteURL_NoOrigin(t *testing.T) {
+	repoPath := setupGitRepo(t)
+	assert.Error(t, SetRemoteURL(repoPath, "git@github.com:new/repo.git"))
+}
+
+func TestSnapshotToBranch(t *testing.T) {
+	repoPath := setupGitRepo(t)
+	branch, err := GetCurrentBranch(repoPath)
+	require.NoError(t, err)
+
+	// Clean repo: nothing to commit
+	commit, err := SnapshotToBranch(repoPath, "wip/test", "WIP")
+	require.NoError(t, err)
+	assert.Empty(t, commit)
+
+	// Staged modification plus an untracked file
+	require.NoError(t, os.WriteFile(filepath.Join(repoPath, "README.md"), []byte("changed"), 0644))
+	stage := exec.Command("git", "add", "README.md")
+	stage.Dir = repoPath
+	require.NoError(t, stage.Run())
+	require.NoError(t, os.WriteFile(filepath.Join(repoPath, "new.txt"), []byte("new"), 0644))
+
+	commit, err = SnapshotToBranch(repoPath, "wip/test", "WIP on test")
+	require.NoError(t, err)
+	assert.NotEmpty(t, commit)
+
+	// The branch has both changes
+	show := exec.Command("git", "show", "--name-only", "--format=%s", "wip/test")
+	show.Dir = repoPath
+	output, err := show.Output()
+	require.NoError(t, err)
+	assert.Contains(t, string(output), "WIP on test")
+	assert.Contains(t, string(output), "README.md")
+	assert.Contains(t, string(output), "new.txt")
+
+	// The checkout is untouched: same branch, README still staged, new.txt still untracked
+	current, err := GetCurrentBranch(repoPath)
+	require.NoError(t, err)
+	assert.Equal(t, branch, current)
+
+	status := exec.Command("git", "status", "--porcelain")
+	status.Dir = repoPath
+	output, err = status.Output()
+	require.NoError(t, err)
+	assert.Contains(t, string(output), "M  README.md")
+	assert.Contains(t, string(output), "?? new.txt")
+}
+
+func TestSnapshotToBranch_NonGitRepo(t *testing.T) {
+	_, err := SnapshotToBranch(t.TempDir(), "wip/test", "WIP")
+	assert.Error(t, err)
+}
+
+func TestLogSinceAndFilesChangedSince(t *testing.T) {
+	repoPath := setupGitRepo(t)
+	commitAt := func(file, message string, when time.Time) {
+		require.NoError(t, os.WriteFile(filepath.Join(repoPath, file), []byte(message), 0644))
+		add := exec.Command("git", "add", file)
+		add.Dir = repoPath
+		require.NoError(t, add.Run())
+		commit := exec.Command("git", "commit", "-m", message)
+		commit.Dir = repoPath
+		date := when.Format(time.RFC3339)
+		commit.Env = append(os.Environ(), "GIT_AUTHOR_DATE="+date, "GIT_COMMITTER_DATE="+date)
+		require.NoError(t, commit.Run())
+	}
+
+	base := time.Now().Add(-48 * time.Hour)
+	commitAt("old.txt", "Old work", base)
+	commitAt("a.txt", "Add a", base.Add(24*time.Hour))
+	commitAt("b.txt", "Add b", base.Add(25*time.Hour))
+	commitAt("a.txt", "Update a", base.Add(26*time.Hour))
+
+	since := base.Add(time.Hour)
+	log, err := LogSince(repoPath, since)
+	require.NoError(t, err)
+	require.Len(t, log, 3)
+	assert.Contains(t, log[0], "Update a")
+	assert.Contains(t, log[2], "Add a")
+
+	files, err := FilesChangedSince(repoPath, since)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"a.txt", "b.txt"}, files)
+
+	// Nothing since now
+	log, err = LogSince(repoPath, time.Now().Add(time.Hour))
+	require.NoError(t, err)
+	assert.Empty(t, log)
+}
+
+func TestLogSince_NonGitRepo(t *testing.T) {
+	_, err := LogSince(t.TempDir(), time.Now())
+	assert.Error(t, err)
+	_, err = FilesChangedSince(t.TempDir(), time.Now())
+	assert.Error(t, err)
+}
+
+func TestCheckout(t *testing.T) {
+	repoPath := setupGitRepo(t)
+	original, err := GetCurrentBranch(repoPath)
+	require.NoError(t, err)
+
+	branchCmd := exec.Command("git", "branch", "feature-branch")
+	branchCmd.Dir = repoPath
+	require.NoError(t, branchCmd.Run())
+
+	require.NoError(t, Checkout(repoPath, "feature-branch"))
+	branch, err := GetCurrentBranch(repoPath)
+	require.NoError(t, err)
+	assert.Equal(t, "feature-branch", branch)
+
+	require.NoError(t, Checkout(repoPath, original))
+
+	err = Checkout(repoPath, "no-such-branch")
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "no-such-branch")
+}
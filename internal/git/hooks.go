@@ -0,0 +1,180 @@
+package git
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/pmossman/claudew/internal/execx"
+)
+
+// Lines that delimit the post-commit hook block claudew manages, so it can live
+// alongside a hook the user wrote and be updated or removed without touching it
+const (
+	hookBlockStart = "# >>> claudew post-commit >>>"
+	hookBlockEnd   = "# <<< claudew post-commit <<<"
+)
+
+// PostCommitHookPath returns the path of a repository's post-commit hook, honoring
+// core.hooksPath
+func PostCommitHookPath(repoPath string) (string, error) {
+	cmd := execx.Command("git", "-C", repoPath, "rev-parse", "--git-path", "hooks/post-commit")
+	output, err := cmd.Output()
+	if err != nil {
+		return "", fmt.Errorf("failed to find hooks directory: %w", err)
+	}
+	path := strings.TrimSpace(string(output))
+	if !filepath.IsAbs(path) {
+		path = filepath.Join(repoPath, path)
+	}
+	return path, nil
+}
+
+// HasPostCommitHook reports whether claudew's post-commit hook is installed in a repository
+func HasPostCommitHook(repoPath string) bool {
+	path, err := PostCommitHookPath(repoPath)
+	if err != nil {
+		return false
+	}
+	data, err := os.ReadFile(path)
+	return err == nil && strings.Contains(string(data), hookBlockStart)
+}
+
+// InstallPostCommitHook makes a repository's post-commit hook run command after
+// every commit, ignoring its output and failures so commits never break. An
+// existing shell hook is kept: the command goes right after its shebang, so it
+// runs even if the rest of the hook execs or exits, or replaces the one installed
+// before. Hooks in other languages are left alone and reported, as are hooks
+// shared through a core.hooksPath outside the repository, which other
+// repositories would run too.
+func InstallPostCommitHook(repoPath, command string) error {
+	path, err := PostCommitHookPath(repoPath)
+	if err != nil {
+		return err
+	}
+	if err := checkHooksPath(repoPath, filepath.Dir(path)); err != nil {
+		return err
+	}
+	existing, err := os.ReadFile(path)
+	if err != nil && !os.IsNotExist(err) {
+		return fmt.Errorf("failed to read post-commit hook: %w", err)
+	}
+
+	block := fmt.Sprintf("%s\n%s >/dev/null 2>&1 || true\n%s\n", hookBlockStart, command, hookBlockEnd)
+	content := removeHookBlock(string(existing))
+	if emptyHook(content) {
+		content = "#!/bin/sh\n" + block
+	} else {
+		shebang, rest, _ := strings.Cut(content, "\n")
+		if !shellShebang(shebang) {
+			return fmt.Errorf("post-commit hook %s is not a shell script; add this line to it to log commits: %s", path, command)
+		}
+		content = shebang + "\n" + block + "\n" + strings.TrimLeft(rest, "\n")
+	}
+
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return fmt.Errorf("failed to create hooks directory: %w", err)
+	}
+	if err := os.WriteFile(path, []byte(content), 0755); err != nil {
+		return fmt.Errorf("failed to write post-commit hook: %w", err)
+	}
+	// WriteFile keeps the mode of an existing file, which may not be executable
+	return os.Chmod(path, 0755)
+}
+
+// checkHooksPath refuses a hooks directory that core.hooksPath moved outside the
+// repository, since a hook installed there runs for every repository sharing it
+func checkHooksPath(repoPath, hooksDir string) error {
+	cmd := execx.Command("git", "-C", repoPath, "config", "--get", "core.hooksPath")
+	output, err := cmd.Output()
+	if err != nil || strings.TrimSpace(string(output)) == "" {
+		return nil
+	}
+	rel, err := filepath.Rel(repoPath, hooksDir)
+	if err != nil || rel == ".." || strings.HasPrefix(rel, ".."+string(filepath.Separator)) {
+		return fmt.Errorf("core.hooksPath points outside the repository (%s); not installing a hook other repositories would share", hooksDir)
+	}
+	return nil
+}
+
+// shellShebang reports whether a hook's first line runs it with sh or bash
+func shellShebang(line string) bool {
+	interpreter, ok := strings.CutPrefix(strings.TrimSpace(line), "#!")
+	if !ok {
+		return false
+	}
+	fields := strings.Fields(interpreter)
+	if len(fields) > 1 && filepath.Base(fields[0]) == "env" {
+		fields = fields[1:]
+	}
+	if len(fields) == 0 {
+		return false
+	}
+	switch filepath.Base(fields[0]) {
+	case "sh", "bash", "dash":
+		return true
+	}
+	return false
+}
+
+// RemovePostCommitHook takes claudew's command out of a repository's post-commit
+// hook, deleting the hook if nothing else is left in it
+func RemovePostCommitHook(repoPath string) error {
+	path, err := PostCommitHookPath(repoPath)
+	if err != nil {
+		return err
+	}
+	existing, err := os.ReadFile(path)
+	if os.IsNotExist(err) {
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("failed to read post-commit hook: %w", err)
+	}
+	if !strings.Contains(string(existing), hookBlockStart) {
+		return nil
+	}
+
+	content := removeHookBlock(string(existing))
+	if emptyHook(content) {
+		return os.Remove(path)
+	}
+	return os.WriteFile(path, []byte(content), 0755)
+}
+
+// emptyHook reports whether a hook does nothing, having at most a shebang line
+func emptyHook(hook string) bool {
+	rest := strings.TrimSpace(hook)
+	return rest == "" || rest == "#!/bin/sh"
+}
+
+// removeHookBlock returns hook without claudew's block and the blank lines around it
+func removeHookBlock(hook string) string {
+	start := strings.Index(hook, hookBlockStart)
+	if start < 0 {
+		return hook
+	}
+	end := strings.Index(hook[start:], hookBlockEnd)
+	if end < 0 {
+		return hook
+	}
+	end += start + len(hookBlockEnd)
+	before := strings.TrimRight(hook[:start], "\n")
+	after := strings.TrimLeft(hook[end:], "\n")
+	if before == "" {
+		return after
+	}
+	return before + "\n" + after
+}
+
+// LastCommit returns the abbreviated hash and subject of a repository's HEAD commit
+func LastCommit(repoPath string) (hash, subject string, err error) {
+	cmd := execx.Command("git", "-C", repoPath, "log", "-1", "--format=%h %s")
+	output, err := cmd.Output()
+	if err != nil {
+		return "", "", fmt.Errorf("failed to read last commit: %w", err)
+	}
+	hash, subject, _ = strings.Cut(strings.TrimSpace(string(output)), " ")
+	return hash, subject, nil
+}
@@ -0,0 +1,131 @@
+package git
+
+import (
+	"os"
+	"os/exec"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPostCommitHook_InstallAndRemove(t *testing.T) {
+	repoPath := setupGitRepo(t)
+	hookPath := filepath.Join(repoPath, ".git", "hooks", "post-commit")
+
+	path, err := PostCommitHookPath(repoPath)
+	require.NoError(t, err)
+	assert.Equal(t, hookPath, path)
+	assert.False(t, HasPostCommitHook(repoPath))
+
+	require.NoError(t, InstallPostCommitHook(repoPath, "'/usr/bin/claudew' _hook commit"))
+	assert.True(t, HasPostCommitHook(repoPath))
+	data, err := os.ReadFile(hookPath)
+	require.NoError(t, err)
+	assert.Equal(t, "#!/bin/sh\n"+hookBlockStart+"\n'/usr/bin/claudew' _hook commit >/dev/null 2>&1 || true\n"+hookBlockEnd+"\n", string(data))
+	info, err := os.Stat(hookPath)
+	require.NoError(t, err)
+	assert.NotZero(t, info.Mode()&0100, "hook should be executable")
+
+	// Reinstalling replaces the command instead of adding a second one
+	require.NoError(t, InstallPostCommitHook(repoPath, "/opt/claudew _hook commit"))
+	data, err = os.ReadFile(hookPath)
+	require.NoError(t, err)
+	assert.Equal(t, "#!/bin/sh\n"+hookBlockStart+"\n/opt/claudew _hook commit >/dev/null 2>&1 || true\n"+hookBlockEnd+"\n", string(data))
+
+	require.NoError(t, RemovePostCommitHook(repoPath))
+	assert.NoFileExists(t, hookPath)
+	require.NoError(t, RemovePostCommitHook(repoPath))
+}
+
+func TestPostCommitHook_KeepsUserHook(t *testing.T) {
+	repoPath := setupGitRepo(t)
+	hookPath := filepath.Join(repoPath, ".git", "hooks", "post-commit")
+	userHook := "#!/bin/sh\necho committed\n"
+	require.NoError(t, os.WriteFile(hookPath, []byte(userHook), 0644))
+
+	require.NoError(t, InstallPostCommitHook(repoPath, "claudew _hook commit"))
+	data, err := os.ReadFile(hookPath)
+	require.NoError(t, err)
+	assert.Contains(t, string(data), "#!/bin/sh\n"+hookBlockStart)
+	assert.Contains(t, string(data), hookBlockEnd+"\n\necho committed\n")
+	info, err := os.Stat(hookPath)
+	require.NoError(t, err)
+	assert.NotZero(t, info.Mode()&0100, "hook should be made executable")
+
+	require.NoError(t, RemovePostCommitHook(repoPath))
+	data, err = os.ReadFile(hookPath)
+	require.NoError(t, err)
+	assert.Equal(t, userHook, string(data))
+}
+
+func TestPostCommitHook_RunsBeforeUserHookExits(t *testing.T) {
+	repoPath := setupGitRepo(t)
+	hookPath := filepath.Join(repoPath, ".git", "hooks", "post-commit")
+	require.NoError(t, os.WriteFile(hookPath, []byte("#!/usr/bin/env bash\nexec true\n"), 0755))
+	marker := filepath.Join(t.TempDir(), "ran")
+	require.NoError(t, InstallPostCommitHook(repoPath, "touch "+marker))
+
+	commit := exec.Command("git", "commit", "--allow-empty", "-m", "Second commit")
+	commit.Dir = repoPath
+	require.NoError(t, commit.Run())
+	assert.FileExists(t, marker)
+}
+
+func TestPostCommitHook_RefusesOtherLanguages(t *testing.T) {
+	repoPath := setupGitRepo(t)
+	hookPath := filepath.Join(repoPath, ".git", "hooks", "post-commit")
+	userHook := "#!/usr/bin/env python3\nprint('committed')\n"
+	require.NoError(t, os.WriteFile(hookPath, []byte(userHook), 0755))
+
+	err := InstallPostCommitHook(repoPath, "claudew _hook commit")
+	assert.ErrorContains(t, err, "is not a shell script")
+	data, err := os.ReadFile(hookPath)
+	require.NoError(t, err)
+	assert.Equal(t, userHook, string(data))
+}
+
+func TestPostCommitHook_RefusesSharedHooksPath(t *testing.T) {
+	repoPath := setupGitRepo(t)
+	shared := t.TempDir()
+	config := exec.Command("git", "config", "core.hooksPath", shared)
+	config.Dir = repoPath
+	require.NoError(t, config.Run())
+
+	err := InstallPostCommitHook(repoPath, "claudew _hook commit")
+	assert.ErrorContains(t, err, "core.hooksPath points outside the repository")
+	assert.NoFileExists(t, filepath.Join(shared, "post-commit"))
+
+	// A hooks directory kept in the repository is fine
+	config = exec.Command("git", "config", "core.hooksPath", ".githooks")
+	config.Dir = repoPath
+	require.NoError(t, config.Run())
+	require.NoError(t, InstallPostCommitHook(repoPath, "claudew _hook commit"))
+	assert.FileExists(t, filepath.Join(repoPath, ".githooks", "post-commit"))
+}
+
+func TestShellShebang(t *testing.T) {
+	assert.True(t, shellShebang("#!/bin/sh"))
+	assert.True(t, shellShebang("#!/bin/bash -e"))
+	assert.True(t, shellShebang("#!/usr/bin/env bash"))
+	assert.False(t, shellShebang("#!/usr/bin/env python3"))
+	assert.False(t, shellShebang("#!/usr/bin/env"))
+	assert.False(t, shellShebang("echo no shebang"))
+}
+
+func TestPostCommitHook_Runs(t *testing.T) {
+	repoPath := setupGitRepo(t)
+	marker := filepath.Join(t.TempDir(), "ran")
+	require.NoError(t, InstallPostCommitHook(repoPath, "touch "+marker))
+
+	commit := exec.Command("git", "commit", "--allow-empty", "-m", "Second commit")
+	commit.Dir = repoPath
+	require.NoError(t, commit.Run())
+	assert.FileExists(t, marker)
+
+	hash, subject, err := LastCommit(repoPath)
+	require.NoError(t, err)
+	assert.NotEmpty(t, hash)
+	assert.Equal(t, "Second commit", subject)
+}
@@ -0,0 +1,82 @@
+package git
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/pmossman/claudew/internal/execx"
+)
+
+// Progress is one update from git's --progress output
+type Progress struct {
+	Phase   string // e.g. "Receiving objects"
+	Percent int
+	Speed   string // transfer rate, e.g. "2.40 MiB/s"; only reported while receiving
+}
+
+// progressPattern matches lines like
+// "Receiving objects:  45% (450/1000), 1.20 MiB | 2.40 MiB/s"
+var progressPattern = regexp.MustCompile(`^(?:remote: )?([A-Za-z ]+):\s+(\d+)% \(\d+/\d+\)(?:, [^|]*?)?(?:\s*\|\s*([^,]+?))?(?:, done\.)?\s*$`)
+
+// ParseProgress parses a line of git's progress output
+func ParseProgress(line string) (Progress, bool) {
+	m := progressPattern.FindStringSubmatch(strings.TrimSpace(line))
+	if m == nil {
+		return Progress{}, false
+	}
+	percent, _ := strconv.Atoi(m[2])
+	return Progress{Phase: m[1], Percent: percent, Speed: strings.TrimSpace(m[3])}, true
+}
+
+// CloneWithProgress clones a repository, reporting git's progress to onProgress
+// instead of the terminal, so several clones can run at once. git's last output
+// lines are included in the error if the clone fails.
+func CloneWithProgress(url, destPath string, onProgress func(Progress)) error {
+	w := &progressWriter{onProgress: onProgress}
+	cmd := execx.Command("git", "clone", "--progress", url, destPath)
+	cmd.Stdout = w
+	cmd.Stderr = w
+	if err := cmd.Run(); err != nil {
+		return fmt.Errorf("failed to clone repository: %s", strings.Join(w.messages, "; "))
+	}
+	return nil
+}
+
+// progressWriter splits git's output into lines, which git ends with \r while a
+// phase is in progress, passing progress to onProgress and keeping other messages
+type progressWriter struct {
+	onProgress func(Progress)
+	partial    []byte
+	messages   []string // the last few non-progress lines, for errors
+}
+
+func (w *progressWriter) Write(p []byte) (int, error) {
+	for _, b := range p {
+		if b != '\r' && b != '\n' {
+			w.partial = append(w.partial, b)
+			continue
+		}
+		w.line(string(w.partial))
+		w.partial = w.partial[:0]
+	}
+	return len(p), nil
+}
+
+func (w *progressWriter) line(line string) {
+	line = strings.TrimSpace(line)
+	if line == "" {
+		return
+	}
+	if progress, ok := ParseProgress(line); ok {
+		if w.onProgress != nil {
+			w.onProgress(progress)
+		}
+		return
+	}
+	w.messages = append(w.messages, line)
+	if len(w.messages) > 3 {
+		w.messages = w.messages[1:]
+	}
+}
@@ -0,0 +1,117 @@
+package handoff
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+// Placeholder replaces every redacted match
+const Placeholder = "[REDACTED]"
+
+// DefaultPatterns match common tokens and keys that shouldn't leave the machine
+var DefaultPatterns = []string{
+	`gh[pousr]_[A-Za-z0-9]{36,}`,                                    // GitHub tokens
+	`github_pat_[A-Za-z0-9_]{22,}`,                                  // GitHub fine-grained tokens
+	`sk-[A-Za-z0-9_-]{20,}`,                                         // OpenAI / Anthropic style API keys
+	`xox[abprs]-[A-Za-z0-9-]{10,}`,                                  // Slack tokens
+	`AKIA[0-9A-Z]{16}`,                                              // AWS access key IDs
+	`AIza[0-9A-Za-z_-]{35}`,                                         // Google API keys
+	`eyJ[A-Za-z0-9_-]{10,}\.[A-Za-z0-9_-]{10,}\.[A-Za-z0-9_-]{10,}`, // JWTs
+	`-----BEGIN [A-Z ]*PRIVATE KEY-----[\s\S]*?-----END [A-Z ]*PRIVATE KEY-----`,     // PEM private keys
+	`(?i)((?:password|passwd|secret|token|api[_-]?key)\s*[:=]\s*)["']?[^\s"']+["']?`, // password = ... assignments
+}
+
+// Redactor replaces secrets in text
+type Redactor struct {
+	patterns []*regexp.Regexp
+}
+
+// NewRedactor compiles the default patterns plus any extra ones
+func NewRedactor(extra []string) (*Redactor, error) {
+	r := &Redactor{}
+	for _, pattern := range append(append([]string{}, DefaultPatterns...), extra...) {
+		re, err := regexp.Compile(pattern)
+		if err != nil {
+			return nil, fmt.Errorf("invalid redaction pattern %q: %w", pattern, err)
+		}
+		r.patterns = append(r.patterns, re)
+	}
+	return r, nil
+}
+
+// Redact returns text with every match replaced by Placeholder.
+// If a pattern has a capture group, the first group is kept so "token: abc" becomes "token: [REDACTED]".
+func (r *Redactor) Redact(text string) string {
+	for _, re := range r.patterns {
+		if re.NumSubexp() > 0 {
+			text = re.ReplaceAllString(text, "${1}"+Placeholder)
+		} else {
+			text = re.ReplaceAllString(text, Placeholder)
+		}
+	}
+	return text
+}
+
+// File is a named document included in a bundle
+type File struct {
+	Name    string
+	Content string
+}
+
+// Bundle is everything a teammate needs to pick up a workstream
+type Bundle struct {
+	Workspace    string
+	Summary      string
+	Branch       string
+	PRURL        string
+	IssueURL     string
+	Context      string
+	Decisions    string
+	Continuation string
+	Research     []File
+}
+
+// Render formats a bundle as a single markdown document, redacting every field
+func Render(b Bundle, r *Redactor) string {
+	var out strings.Builder
+	fmt.Fprintf(&out, "# Handoff: %s\n", b.Workspace)
+	if b.Summary != "" {
+		fmt.Fprintf(&out, "\n%s\n", r.Redact(b.Summary))
+	}
+	if b.Branch != "" || b.PRURL != "" || b.IssueURL != "" {
+		out.WriteString("\n")
+	}
+	if b.Branch != "" {
+		fmt.Fprintf(&out, "- Branch: `%s`\n", b.Branch)
+	}
+	if b.PRURL != "" {
+		fmt.Fprintf(&out, "- Pull request: %s\n", b.PRURL)
+	}
+	if b.IssueURL != "" {
+		fmt.Fprintf(&out, "- Issue: %s\n", b.IssueURL)
+	}
+
+	sections := []struct {
+		title   string
+		content string
+	}{
+		{"Continuation", b.Continuation},
+		{"Context", b.Context},
+		{"Decisions", b.Decisions},
+	}
+	for _, s := range sections {
+		if strings.TrimSpace(s.content) == "" {
+			continue
+		}
+		fmt.Fprintf(&out, "\n## %s\n\n%s\n", s.title, strings.TrimSpace(r.Redact(s.content)))
+	}
+
+	if len(b.Research) > 0 {
+		out.WriteString("\n## Research\n")
+		for _, f := range b.Research {
+			fmt.Fprintf(&out, "\n### %s\n\n%s\n", f.Name, strings.TrimSpace(r.Redact(f.Content)))
+		}
+	}
+	return out.String()
+}
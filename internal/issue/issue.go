@@ -0,0 +1,92 @@
+package issue
+
+import (
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/pmossman/claudew/internal/execx"
+	"github.com/pmossman/claudew/internal/slug"
+)
+
+// maxSlugLength caps workspace names derived from issue titles
+const maxSlugLength = 40
+
+// Ref identifies a GitHub issue
+type Ref struct {
+	Repo   string // owner/name
+	Number int
+}
+
+func (r Ref) String() string {
+	return fmt.Sprintf("%s#%d", r.Repo, r.Number)
+}
+
+var (
+	shortRefPattern = regexp.MustCompile(`^([\w.-]+/[\w.-]+)#(\d+)$`)
+	urlRefPattern   = regexp.MustCompile(`^https?://github\.com/([\w.-]+/[\w.-]+)/issues/(\d+)/?$`)
+)
+
+// ParseRef parses "owner/repo#123" or an issue URL such as https://github.com/owner/repo/issues/123
+func ParseRef(s string) (Ref, error) {
+	s = strings.TrimSpace(s)
+	m := shortRefPattern.FindStringSubmatch(s)
+	if m == nil {
+		m = urlRefPattern.FindStringSubmatch(s)
+	}
+	if m == nil {
+		return Ref{}, fmt.Errorf("invalid issue reference %q (expected owner/repo#123 or an issue URL)", s)
+	}
+	number, err := strconv.Atoi(m[2])
+	if err != nil || number <= 0 {
+		return Ref{}, fmt.Errorf("invalid issue number in %q", s)
+	}
+	return Ref{Repo: m[1], Number: number}, nil
+}
+
+// Issue is the part of a GitHub issue used to seed a workspace
+type Issue struct {
+	Ref   Ref    `json:"-"`
+	Title string `json:"title"`
+	Body  string `json:"body"`
+	URL   string `json:"url"`
+}
+
+// Fetch reads an issue with the gh CLI
+func Fetch(ref Ref) (*Issue, error) {
+	cmd := execx.Command("gh", "issue", "view", strconv.Itoa(ref.Number), "--repo", ref.Repo, "--json", "title,body,url")
+	output, err := cmd.Output()
+	if err != nil {
+		if exitErr, ok := err.(*execx.ExitError); ok && len(exitErr.Stderr) > 0 {
+			return nil, fmt.Errorf("failed to fetch %s: %s", ref, strings.TrimSpace(string(exitErr.Stderr)))
+		}
+		return nil, fmt.Errorf("failed to fetch %s: %w", ref, err)
+	}
+
+	var iss Issue
+	if err := json.Unmarshal(output, &iss); err != nil {
+		return nil, fmt.Errorf("failed to parse gh output for %s: %w", ref, err)
+	}
+	iss.Ref = ref
+	return &iss, nil
+}
+
+// Slug returns a workspace name for the issue: its number followed by its title in kebab case,
+// e.g. "123-fix-login-redirect"
+func (i *Issue) Slug() string {
+	return slug.Make(strconv.Itoa(i.Ref.Number), i.Title, maxSlugLength)
+}
+
+// Context renders the issue as the initial context.md, ending with how to link the eventual pull request
+func (i *Issue) Context() string {
+	var out strings.Builder
+	fmt.Fprintf(&out, "# %s\n\n", i.Title)
+	fmt.Fprintf(&out, "Issue: %s\n", i.URL)
+	if body := strings.TrimSpace(i.Body); body != "" {
+		fmt.Fprintf(&out, "\n## Issue description\n\n%s\n", body)
+	}
+	fmt.Fprintf(&out, "\n## Pull request\n\nReference the issue in the pull request description with `Closes %s`.\n", i.Ref)
+	return out.String()
+}
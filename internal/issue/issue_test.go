@@ -0,0 +1,96 @@
+package issue
+
+import (
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/pmossman/claudew/internal/execx"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseRef(t *testing.T) {
+	tests := []struct {
+		in      string
+		want    Ref
+		wantErr bool
+	}{
+		{in: "airbytehq/airbyte#123", want: Ref{Repo: "airbytehq/airbyte", Number: 123}},
+		{in: "https://github.com/org/my.repo/issues/7", want: Ref{Repo: "org/my.repo", Number: 7}},
+		{in: "https://github.com/org/repo/issues/7/", want: Ref{Repo: "org/repo", Number: 7}},
+		{in: "org/repo", wantErr: true},
+		{in: "#12", wantErr: true},
+		{in: "org/repo#0", wantErr: true},
+		{in: "https://github.com/org/repo/pull/7", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.in, func(t *testing.T) {
+			got, err := ParseRef(tt.in)
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestSlug(t *testing.T) {
+	tests := []struct {
+		title string
+		want  string
+	}{
+		{"Fix login redirect", "123-fix-login-redirect"},
+		{"[UI] Crash when: saving (v2.1)!", "123-ui-crash-when-saving-v2-1"},
+		{"Support really long issue titles without producing unwieldy names", "123-support-really-long-issue-titles"},
+		{"!!!", "123"},
+	}
+	for _, tt := range tests {
+		iss := &Issue{Ref: Ref{Repo: "org/repo", Number: 123}, Title: tt.title}
+		assert.Equal(t, tt.want, iss.Slug(), tt.title)
+		assert.LessOrEqual(t, len(iss.Slug()), maxSlugLength)
+	}
+}
+
+func TestContext(t *testing.T) {
+	iss := &Issue{
+		Ref:   Ref{Repo: "org/repo", Number: 5},
+		Title: "Fix login redirect",
+		Body:  "Users land on /404 after login.\n",
+		URL:   "https://github.com/org/repo/issues/5",
+	}
+	out := iss.Context()
+	assert.True(t, strings.HasPrefix(out, "# Fix login redirect\n"))
+	assert.Contains(t, out, "Issue: https://github.com/org/repo/issues/5")
+	assert.Contains(t, out, "## Issue description\n\nUsers land on /404 after login.\n")
+	assert.Contains(t, out, "`Closes org/repo#5`")
+
+	iss.Body = ""
+	assert.NotContains(t, iss.Context(), "## Issue description")
+}
+
+func TestFetch(t *testing.T) {
+	fake := execx.NewFake(nil)
+	fake.Handle("gh", func(c *execx.Cmd) error {
+		if c.Args[2] != "5" {
+			_, _ = io.WriteString(c.Stderr, "GraphQL: Could not resolve to an issue\n")
+			return &execx.ExitError{Code: 1}
+		}
+		_, _ = io.WriteString(c.Stdout, `{"title":"Fix login redirect","body":"Details","url":"https://github.com/org/repo/issues/5"}`)
+		return nil
+	})
+	defer execx.Use(fake)()
+
+	iss, err := Fetch(Ref{Repo: "org/repo", Number: 5})
+	require.NoError(t, err)
+	assert.Equal(t, "Fix login redirect", iss.Title)
+	assert.Equal(t, "Details", iss.Body)
+	assert.Equal(t, "https://github.com/org/repo/issues/5", iss.URL)
+	assert.Equal(t, 5, iss.Ref.Number)
+	assert.Equal(t, []string{"gh issue view 5 --repo org/repo --json title,body,url"}, fake.Calls())
+
+	_, err = Fetch(Ref{Repo: "org/repo", Number: 6})
+	assert.ErrorContains(t, err, "Could not resolve to an issue")
+}
@@ -0,0 +1,172 @@
+package jira
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/pmossman/claudew/internal/slug"
+)
+
+// maxSlugLength caps workspace names derived from ticket summaries
+const maxSlugLength = 40
+
+var keyPattern = regexp.MustCompile(`^[A-Z][A-Z0-9_]+-[0-9]+$`)
+
+// ValidateKey checks that key looks like a Jira issue key such as PROJ-123
+func ValidateKey(key string) error {
+	if !keyPattern.MatchString(key) {
+		return fmt.Errorf("invalid Jira key %q (expected e.g. PROJ-123)", key)
+	}
+	return nil
+}
+
+// Ticket is the part of a Jira issue used to seed a workspace
+type Ticket struct {
+	Key                string
+	Summary            string
+	Description        string
+	AcceptanceCriteria string
+	URL                string
+}
+
+// Client reads issues from the Jira REST API.
+// With Email set it authenticates like Jira Cloud (email + API token),
+// otherwise Token is sent as a bearer personal access token (Jira Server/Data Center).
+type Client struct {
+	BaseURL string
+	Email   string
+	Token   string
+	// AcceptanceField is the custom field holding acceptance criteria, e.g. customfield_10035.
+	// When empty they are taken from an "Acceptance Criteria" heading in the description.
+	AcceptanceField string
+	HTTP            *http.Client
+}
+
+// NewClient creates a client for the Jira site at baseURL
+func NewClient(baseURL, email, token, acceptanceField string) *Client {
+	return &Client{
+		BaseURL:         strings.TrimSuffix(baseURL, "/"),
+		Email:           email,
+		Token:           token,
+		AcceptanceField: acceptanceField,
+		HTTP:            &http.Client{Timeout: 30 * time.Second},
+	}
+}
+
+// Fetch reads one ticket
+func (c *Client) Fetch(key string) (*Ticket, error) {
+	if err := ValidateKey(key); err != nil {
+		return nil, err
+	}
+
+	fields := []string{"summary", "description"}
+	if c.AcceptanceField != "" {
+		fields = append(fields, c.AcceptanceField)
+	}
+	endpoint := fmt.Sprintf("%s/rest/api/2/issue/%s?fields=%s", c.BaseURL, url.PathEscape(key), url.QueryEscape(strings.Join(fields, ",")))
+
+	req, err := http.NewRequest(http.MethodGet, endpoint, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch %s: %w", key, err)
+	}
+	req.Header.Set("Accept", "application/json")
+	if c.Email != "" {
+		req.SetBasicAuth(c.Email, c.Token)
+	} else if c.Token != "" {
+		req.Header.Set("Authorization", "Bearer "+c.Token)
+	}
+
+	resp, err := c.HTTP.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch %s: %w", key, err)
+	}
+	defer resp.Body.Close()
+
+	switch resp.StatusCode {
+	case http.StatusOK:
+	case http.StatusUnauthorized, http.StatusForbidden:
+		return nil, fmt.Errorf("failed to fetch %s: Jira rejected the credentials (status %d); check jira_email and jira_token", key, resp.StatusCode)
+	case http.StatusNotFound:
+		return nil, fmt.Errorf("failed to fetch %s: ticket not found", key)
+	default:
+		return nil, fmt.Errorf("failed to fetch %s: Jira returned status %d", key, resp.StatusCode)
+	}
+
+	var body struct {
+		Fields map[string]json.RawMessage `json:"fields"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&body); err != nil {
+		return nil, fmt.Errorf("failed to parse %s: %w", key, err)
+	}
+
+	ticket := &Ticket{
+		Key:         key,
+		Summary:     fieldText(body.Fields["summary"]),
+		Description: fieldText(body.Fields["description"]),
+		URL:         c.BaseURL + "/browse/" + key,
+	}
+	if c.AcceptanceField != "" {
+		ticket.AcceptanceCriteria = fieldText(body.Fields[c.AcceptanceField])
+	}
+	if ticket.AcceptanceCriteria == "" {
+		ticket.Description, ticket.AcceptanceCriteria = splitAcceptanceCriteria(ticket.Description)
+	}
+	return ticket, nil
+}
+
+// fieldText returns a string field's value, or "" for null and non-string (e.g. rich text) fields
+func fieldText(raw json.RawMessage) string {
+	var s string
+	if err := json.Unmarshal(raw, &s); err != nil {
+		return ""
+	}
+	return strings.TrimSpace(s)
+}
+
+// acceptanceHeading matches an "Acceptance Criteria" heading in Jira wiki markup or markdown
+var acceptanceHeading = regexp.MustCompile(`(?im)^[ \t]*(?:h[1-6]\.[ \t]*|#{1,6}[ \t]*|\*)?acceptance criteria:?\*?:?[ \t]*$`)
+
+// nextHeading matches the heading that ends the acceptance criteria. A single "#" is a
+// numbered list item in Jira wiki markup, so only "##" and deeper count in markdown.
+var nextHeading = regexp.MustCompile(`(?m)^[ \t]*(?:h[1-6]\.|#{2,6}[ \t])`)
+
+// splitAcceptanceCriteria moves an "Acceptance Criteria" section out of a description
+func splitAcceptanceCriteria(description string) (rest, criteria string) {
+	loc := acceptanceHeading.FindStringIndex(description)
+	if loc == nil {
+		return description, ""
+	}
+	section := description[loc[1]:]
+	after := ""
+	if next := nextHeading.FindStringIndex(section); next != nil {
+		section, after = section[:next[0]], section[next[0]:]
+	}
+	rest = strings.TrimSpace(strings.TrimSpace(description[:loc[0]]) + "\n\n" + strings.TrimSpace(after))
+	return rest, strings.TrimSpace(section)
+}
+
+// Slug returns a workspace name for the ticket: its key followed by its summary in kebab case,
+// e.g. "proj-123-fix-login-redirect"
+func (t *Ticket) Slug() string {
+	return slug.Make(t.Key, t.Summary, maxSlugLength)
+}
+
+// Context renders the ticket as the initial context.md, ending with how to link the eventual pull request
+func (t *Ticket) Context() string {
+	var out strings.Builder
+	fmt.Fprintf(&out, "# %s: %s\n\n", t.Key, t.Summary)
+	fmt.Fprintf(&out, "Ticket: %s\n", t.URL)
+	if t.Description != "" {
+		fmt.Fprintf(&out, "\n## Ticket description\n\n%s\n", t.Description)
+	}
+	if t.AcceptanceCriteria != "" {
+		fmt.Fprintf(&out, "\n## Acceptance criteria\n\n%s\n", t.AcceptanceCriteria)
+	}
+	fmt.Fprintf(&out, "\n## Pull request\n\nInclude %s in the branch name and pull request title so Jira links them.\n", t.Key)
+	return out.String()
+}
@@ -0,0 +1,111 @@
+package jira
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestValidateKey(t *testing.T) {
+	assert.NoError(t, ValidateKey("PROJ-123"))
+	assert.NoError(t, ValidateKey("AB2_X-1"))
+	assert.Error(t, ValidateKey("proj-123"))
+	assert.Error(t, ValidateKey("PROJ"))
+	assert.Error(t, ValidateKey("PROJ-12a"))
+	assert.Error(t, ValidateKey("../PROJ-1"))
+}
+
+func TestClient_FetchCloud(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/rest/api/2/issue/PROJ-7", r.URL.Path)
+		assert.Equal(t, "summary,description,customfield_10035", r.URL.Query().Get("fields"))
+		user, pass, ok := r.BasicAuth()
+		assert.True(t, ok)
+		assert.Equal(t, "me@example.com", user)
+		assert.Equal(t, "tok", pass)
+		fmt.Fprint(w, `{"key":"PROJ-7","fields":{"summary":"Fix login redirect","description":"Users land on /404.","customfield_10035":"* Redirects to /home"}}`)
+	}))
+	defer server.Close()
+
+	client := NewClient(server.URL+"/", "me@example.com", "tok", "customfield_10035")
+	client.HTTP = server.Client()
+	ticket, err := client.Fetch("PROJ-7")
+	require.NoError(t, err)
+	assert.Equal(t, "Fix login redirect", ticket.Summary)
+	assert.Equal(t, "Users land on /404.", ticket.Description)
+	assert.Equal(t, "* Redirects to /home", ticket.AcceptanceCriteria)
+	assert.Equal(t, server.URL+"/browse/PROJ-7", ticket.URL)
+}
+
+func TestClient_FetchBearerAndDescriptionCriteria(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "Bearer pat", r.Header.Get("Authorization"))
+		fmt.Fprint(w, `{"key":"PROJ-7","fields":{"summary":"Fix login","description":"Background.\n\nh3. Acceptance Criteria\n* Redirects home\n* Keeps the session\n\nh3. Notes\nSee wiki."}}`)
+	}))
+	defer server.Close()
+
+	client := NewClient(server.URL, "", "pat", "")
+	client.HTTP = server.Client()
+	ticket, err := client.Fetch("PROJ-7")
+	require.NoError(t, err)
+	assert.Equal(t, "* Redirects home\n* Keeps the session", ticket.AcceptanceCriteria)
+	assert.Equal(t, "Background.\n\nh3. Notes\nSee wiki.", ticket.Description)
+}
+
+func TestClient_FetchErrors(t *testing.T) {
+	status := http.StatusUnauthorized
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(status)
+	}))
+	defer server.Close()
+
+	client := NewClient(server.URL, "", "bad", "")
+	client.HTTP = server.Client()
+	_, err := client.Fetch("PROJ-7")
+	assert.ErrorContains(t, err, "credentials")
+
+	status = http.StatusNotFound
+	_, err = client.Fetch("PROJ-7")
+	assert.ErrorContains(t, err, "not found")
+
+	_, err = client.Fetch("not a key")
+	assert.Error(t, err)
+}
+
+func TestSplitAcceptanceCriteria(t *testing.T) {
+	rest, criteria := splitAcceptanceCriteria("Intro\n\n## Acceptance criteria\n- works\n")
+	assert.Equal(t, "Intro", rest)
+	assert.Equal(t, "- works", criteria)
+
+	// Jira wiki bold heading followed by a numbered list
+	rest, criteria = splitAcceptanceCriteria("*Acceptance Criteria:*\n# first\n# second")
+	assert.Empty(t, rest)
+	assert.Equal(t, "# first\n# second", criteria)
+
+	rest, criteria = splitAcceptanceCriteria("No criteria here")
+	assert.Equal(t, "No criteria here", rest)
+	assert.Empty(t, criteria)
+}
+
+func TestTicketSlugAndContext(t *testing.T) {
+	ticket := &Ticket{
+		Key:                "PROJ-7",
+		Summary:            "Fix the login redirect after SSO sign-in",
+		Description:        "Users land on /404.",
+		AcceptanceCriteria: "* Redirects home",
+		URL:                "https://acme.atlassian.net/browse/PROJ-7",
+	}
+	assert.Equal(t, "proj-7-fix-the-login-redirect-after-sso", ticket.Slug())
+
+	out := ticket.Context()
+	assert.True(t, strings.HasPrefix(out, "# PROJ-7: Fix the login redirect after SSO sign-in\n"))
+	assert.Contains(t, out, "Ticket: https://acme.atlassian.net/browse/PROJ-7")
+	assert.Contains(t, out, "## Ticket description\n\nUsers land on /404.\n")
+	assert.Contains(t, out, "## Acceptance criteria\n\n* Redirects home\n")
+	assert.Contains(t, out, "Include PROJ-7 in the branch name")
+}
@@ -0,0 +1,232 @@
+package markdown
+
+import (
+	"regexp"
+	"strings"
+	"unicode/utf8"
+)
+
+// DefaultWidth is used when the terminal width is unknown
+const DefaultWidth = 80
+
+// ANSI styles used when rendering with color
+const (
+	styleReset   = "\033[0m"
+	styleBold    = "\033[1m"
+	styleHeading = "\033[1;36m"
+	styleCode    = "\033[33m"
+	styleMuted   = "\033[90m"
+)
+
+var (
+	headingRe  = regexp.MustCompile(`^(#{1,6})\s+(.*)$`)
+	bulletRe   = regexp.MustCompile(`^(\s*)[-*+]\s+(.*)$`)
+	numberedRe = regexp.MustCompile(`^(\s*)(\d+[.)])\s+(.*)$`)
+	ruleRe     = regexp.MustCompile(`^\s*([-*_])(\s*[-*_]){2,}\s*$`)
+)
+
+// Render formats markdown for a terminal: headings are emphasized, list markers become
+// bullets, inline code and bold are styled, and paragraphs are wrapped to width.
+// With color false the markup is removed but no escape codes are written.
+func Render(text string, width int, color bool) string {
+	if width <= 0 {
+		width = DefaultWidth
+	}
+	r := renderer{width: width, color: color}
+
+	inFence := false
+	for _, line := range strings.Split(strings.TrimRight(text, "\n"), "\n") {
+		trimmed := strings.TrimSpace(line)
+
+		if strings.HasPrefix(trimmed, "```") {
+			inFence = !inFence
+			continue
+		}
+		if inFence {
+			r.out = append(r.out, r.style(styleMuted, "  "+line))
+			continue
+		}
+
+		switch {
+		case trimmed == "":
+			r.out = append(r.out, "")
+		case ruleRe.MatchString(line):
+			r.out = append(r.out, r.style(styleMuted, strings.Repeat("─", min(width, 40))))
+		case headingRe.MatchString(trimmed):
+			m := headingRe.FindStringSubmatch(trimmed)
+			heading := plain(m[2])
+			if len(m[1]) == 1 {
+				heading = strings.ToUpper(heading)
+			}
+			r.out = append(r.out, r.style(styleHeading, heading))
+		case bulletRe.MatchString(line):
+			m := bulletRe.FindStringSubmatch(line)
+			r.wrap(m[2], m[1]+"• ", m[1]+"  ")
+		case numberedRe.MatchString(line):
+			m := numberedRe.FindStringSubmatch(line)
+			marker := m[1] + m[2] + " "
+			r.wrap(m[3], marker, strings.Repeat(" ", utf8.RuneCountInString(marker)))
+		case strings.HasPrefix(trimmed, ">"):
+			quote := strings.TrimSpace(strings.TrimPrefix(trimmed, ">"))
+			r.wrap(quote, r.style(styleMuted, "│ "), r.style(styleMuted, "│ "))
+		default:
+			r.wrap(trimmed, "", "")
+		}
+	}
+
+	return strings.Join(r.out, "\n") + "\n"
+}
+
+type renderer struct {
+	width int
+	color bool
+	out   []string
+}
+
+// style wraps s in an ANSI style when rendering with color
+func (r *renderer) style(code, s string) string {
+	if !r.color {
+		return s
+	}
+	return code + s + styleReset
+}
+
+// word is a run of non-space text with its rendered form and visible width
+type word struct {
+	text  string
+	width int
+}
+
+// wrap renders inline markup in text and appends it to the output, wrapped to the
+// renderer's width, with first before the first line and indent before the rest
+func (r *renderer) wrap(text, first, indent string) {
+	words := r.words(text)
+	prefix := first
+	prefixWidth := visibleWidth(first)
+
+	var line strings.Builder
+	lineWidth := 0
+	for _, w := range words {
+		if lineWidth > 0 && prefixWidth+lineWidth+1+w.width > r.width {
+			r.out = append(r.out, prefix+line.String())
+			line.Reset()
+			lineWidth = 0
+			prefix = indent
+			prefixWidth = visibleWidth(indent)
+		}
+		if lineWidth > 0 {
+			line.WriteString(" ")
+			lineWidth++
+		}
+		line.WriteString(w.text)
+		lineWidth += w.width
+	}
+	r.out = append(r.out, prefix+line.String())
+}
+
+// words splits text on spaces and styles **bold**, __bold__ and `code` spans, which may
+// cover several words
+func (r *renderer) words(text string) []word {
+	var words []word
+	var current strings.Builder
+	currentWidth := 0
+	bold, code := false, false
+
+	flush := func() {
+		if currentWidth > 0 {
+			s := current.String()
+			if bold || code {
+				// Close the style at the word boundary so wrapped lines don't bleed color
+				s += r.styleEnd()
+			}
+			words = append(words, word{text: s, width: currentWidth})
+		}
+		current.Reset()
+		currentWidth = 0
+		if bold {
+			current.WriteString(r.styleStart(styleBold))
+		}
+		if code {
+			current.WriteString(r.styleStart(styleCode))
+		}
+	}
+
+	for i := 0; i < len(text); {
+		switch {
+		case text[i] == '`':
+			code = !code
+			if code {
+				current.WriteString(r.styleStart(styleCode))
+			} else {
+				current.WriteString(r.styleEnd())
+				if bold {
+					current.WriteString(r.styleStart(styleBold))
+				}
+			}
+			i++
+		case !code && (strings.HasPrefix(text[i:], "**") || strings.HasPrefix(text[i:], "__")):
+			bold = !bold
+			if bold {
+				current.WriteString(r.styleStart(styleBold))
+			} else {
+				current.WriteString(r.styleEnd())
+			}
+			i += 2
+		case text[i] == ' ':
+			flush()
+			i++
+		default:
+			_, size := utf8.DecodeRuneInString(text[i:])
+			current.WriteString(text[i : i+size])
+			currentWidth++
+			i += size
+		}
+	}
+	if bold || code {
+		current.WriteString(r.styleEnd())
+	}
+	if currentWidth > 0 {
+		words = append(words, word{text: current.String(), width: currentWidth})
+	}
+	return words
+}
+
+func (r *renderer) styleStart(code string) string {
+	if !r.color {
+		return ""
+	}
+	return code
+}
+
+func (r *renderer) styleEnd() string {
+	if !r.color {
+		return ""
+	}
+	return styleReset
+}
+
+// plain removes inline markup from text
+func plain(text string) string {
+	text = strings.ReplaceAll(text, "**", "")
+	text = strings.ReplaceAll(text, "__", "")
+	return strings.ReplaceAll(text, "`", "")
+}
+
+// visibleWidth returns the number of runes in s, ignoring ANSI escape sequences
+func visibleWidth(s string) int {
+	width := 0
+	inEscape := false
+	for _, c := range s {
+		switch {
+		case c == '\033':
+			inEscape = true
+		case inEscape:
+			if c == 'm' {
+				inEscape = false
+			}
+		default:
+			width++
+		}
+	}
+	return width
+}
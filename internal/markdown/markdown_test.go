@@ -0,0 +1,73 @@
+package markdown
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRender_Plain(t *testing.T) {
+	in := `# Context
+
+## Done
+- Added **login** form
+- Wired ` + "`auth.Check`" + `
+  - nested item
+
+1. First step
+> quoted note
+
+---
+Plain paragraph.`
+
+	want := `CONTEXT
+
+Done
+• Added login form
+• Wired auth.Check
+  • nested item
+
+1. First step
+│ quoted note
+
+────────────────────────────────────────
+Plain paragraph.
+`
+	assert.Equal(t, want, Render(in, 80, false))
+}
+
+func TestRender_Wraps(t *testing.T) {
+	out := Render("- one two three four five six", 12, false)
+	assert.Equal(t, "• one two\n  three four\n  five six\n", out)
+
+	out = Render("alpha beta gamma", 10, false)
+	assert.Equal(t, "alpha beta\ngamma\n", out)
+}
+
+func TestRender_CodeFence(t *testing.T) {
+	out := Render("Run:\n```\ngo test ./...\n```", 80, false)
+	assert.Equal(t, "Run:\n  go test ./...\n", out)
+}
+
+func TestRender_Color(t *testing.T) {
+	out := Render("# Title\nsome **bold text** here", 80, true)
+	assert.Contains(t, out, styleHeading+"TITLE"+styleReset)
+	assert.Contains(t, out, styleBold+"bold"+styleReset)
+	// Bold spans are closed and reopened at word boundaries
+	assert.Contains(t, out, styleBold+"text"+styleReset)
+	assert.NotContains(t, Render("plain", 80, true), "\033")
+}
+
+func TestRender_ColorWrapWidthIgnoresEscapes(t *testing.T) {
+	out := Render("**aaaa** **bbbb**", 9, true)
+	lines := strings.Split(strings.TrimSuffix(out, "\n"), "\n")
+	assert.Len(t, lines, 1)
+}
+
+func TestRender_DefaultWidth(t *testing.T) {
+	long := strings.Repeat("word ", 30)
+	for _, line := range strings.Split(Render(long, 0, false), "\n") {
+		assert.LessOrEqual(t, len(line), DefaultWidth)
+	}
+}
@@ -0,0 +1,30 @@
+package menu
+
+import "strings"
+
+// FuzzyMatch reports whether text matches query the way fzf's default matching
+// does: every space-separated term of query must appear in text as a subsequence
+// of its characters, ignoring case. An empty query matches everything.
+func FuzzyMatch(query, text string) bool {
+	text = strings.ToLower(text)
+	for _, term := range strings.Fields(strings.ToLower(query)) {
+		if !subsequence(term, text) {
+			return false
+		}
+	}
+	return true
+}
+
+// subsequence reports whether the characters of term appear in text in order
+func subsequence(term, text string) bool {
+	rest := []rune(term)
+	for _, r := range text {
+		if len(rest) == 0 {
+			break
+		}
+		if r == rest[0] {
+			rest = rest[1:]
+		}
+	}
+	return len(rest) == 0
+}
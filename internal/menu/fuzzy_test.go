@@ -0,0 +1,19 @@
+package menu
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFuzzyMatch(t *testing.T) {
+	assert.True(t, FuzzyMatch("auth", "feature-auth"))
+	assert.True(t, FuzzyMatch("fa", "feature-auth"))
+	assert.True(t, FuzzyMatch("FeAu", "feature-auth"))
+	assert.True(t, FuzzyMatch("feat auth", "feature-auth"))
+	assert.True(t, FuzzyMatch("", "anything"))
+
+	assert.False(t, FuzzyMatch("htua", "feature-auth"))
+	assert.False(t, FuzzyMatch("feat billing", "feature-auth"))
+	assert.False(t, FuzzyMatch("authx", "feature-auth"))
+}
@@ -0,0 +1,86 @@
+package menu
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+)
+
+// IDSeparator separates a menu line's hidden ID from the text shown for it. fzf is
+// told to show only the text (--delimiter and --with-nth) and prints the whole line
+// when it's chosen, so the selection can be identified without parsing the text.
+const IDSeparator = "\t"
+
+// Line returns a menu line that shows display and is identified by id. Headers and
+// separators in menus of such lines use an empty id.
+func Line(id, display string) string {
+	return id + IDSeparator + display
+}
+
+// ID returns the hidden ID of a menu line, or "" for lines without one
+func ID(line string) string {
+	id, _, found := strings.Cut(line, IDSeparator)
+	if !found {
+		return ""
+	}
+	return id
+}
+
+// Display returns the text a menu line shows, without its hidden ID
+func Display(line string) string {
+	if _, display, found := strings.Cut(line, IDSeparator); found {
+		return display
+	}
+	return line
+}
+
+// Numbered shows lines as a numbered list on out and reads the user's choice from in.
+// Lines for which selectable returns false (headers, separators) are shown without a number.
+// Lines made with Line show only their display text, which is what selectable is given.
+// Returns the chosen line, or "" if the user cancelled with an empty answer, "q" or EOF.
+func Numbered(in io.Reader, out io.Writer, header string, lines []string, selectable func(string) bool) (string, error) {
+	var choices []string
+	if header != "" {
+		fmt.Fprintln(out, header)
+		fmt.Fprintln(out)
+	}
+	for _, line := range lines {
+		display := Display(line)
+		if selectable != nil && !selectable(display) {
+			if strings.TrimSpace(display) == "" {
+				fmt.Fprintln(out)
+			} else {
+				fmt.Fprintf(out, "     %s\n", display)
+			}
+			continue
+		}
+		choices = append(choices, line)
+		fmt.Fprintf(out, "%3d) %s\n", len(choices), display)
+	}
+
+	if len(choices) == 0 {
+		return "", nil
+	}
+
+	reader := bufio.NewReader(in)
+	for {
+		fmt.Fprintf(out, "\nSelect [1-%d] (Enter to cancel): ", len(choices))
+		answer, err := reader.ReadString('\n')
+		answer = strings.TrimSpace(answer)
+		if answer == "" || answer == "q" {
+			return "", nil
+		}
+
+		n, convErr := strconv.Atoi(answer)
+		if convErr == nil && n >= 1 && n <= len(choices) {
+			return choices[n-1], nil
+		}
+		if err != nil {
+			// EOF after an invalid answer: nothing more to read
+			return "", nil
+		}
+		fmt.Fprintf(out, "Invalid choice: %s\n", answer)
+	}
+}
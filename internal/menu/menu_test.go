@@ -0,0 +1,83 @@
+package menu
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func notHeader(line string) bool {
+	return line != "" && !strings.HasPrefix(line, "──")
+}
+
+func TestNumbered(t *testing.T) {
+	var out bytes.Buffer
+	lines := []string{"alpha", "", "── ACTIONS ──", "beta"}
+
+	selected, err := Numbered(strings.NewReader("2\n"), &out, "Pick one", lines, notHeader)
+	require.NoError(t, err)
+	assert.Equal(t, "beta", selected)
+	assert.Contains(t, out.String(), "Pick one")
+	assert.Contains(t, out.String(), "  1) alpha")
+	assert.Contains(t, out.String(), "  2) beta")
+	assert.NotContains(t, out.String(), ") ── ACTIONS")
+}
+
+func TestNumbered_RetriesInvalidChoice(t *testing.T) {
+	var out bytes.Buffer
+
+	selected, err := Numbered(strings.NewReader("7\nabc\n1\n"), &out, "", []string{"only"}, nil)
+	require.NoError(t, err)
+	assert.Equal(t, "only", selected)
+	assert.Equal(t, 2, strings.Count(out.String(), "Invalid choice"))
+}
+
+func TestNumbered_Cancel(t *testing.T) {
+	for _, input := range []string{"\n", "q\n", ""} {
+		selected, err := Numbered(strings.NewReader(input), &bytes.Buffer{}, "", []string{"a", "b"}, nil)
+		require.NoError(t, err)
+		assert.Equal(t, "", selected, "input %q", input)
+	}
+}
+
+func TestNumbered_NoInputAfterInvalid(t *testing.T) {
+	selected, err := Numbered(strings.NewReader("9"), &bytes.Buffer{}, "", []string{"a"}, nil)
+	require.NoError(t, err)
+	assert.Equal(t, "", selected)
+}
+
+func TestNumbered_LastLineWithoutNewline(t *testing.T) {
+	selected, err := Numbered(strings.NewReader("1"), &bytes.Buffer{}, "", []string{"a"}, nil)
+	require.NoError(t, err)
+	assert.Equal(t, "a", selected)
+}
+
+func TestNumbered_NothingSelectable(t *testing.T) {
+	selected, err := Numbered(strings.NewReader("1\n"), &bytes.Buffer{}, "", []string{"── HEADER ──"}, notHeader)
+	require.NoError(t, err)
+	assert.Equal(t, "", selected)
+}
+
+func TestLine(t *testing.T) {
+	line := Line("feat[1]", "feat[1]  [detached] 日本語 summary")
+	assert.Equal(t, "feat[1]", ID(line))
+	assert.Equal(t, "feat[1]  [detached] 日本語 summary", Display(line))
+
+	assert.Equal(t, "", ID("→ Add remote"))
+	assert.Equal(t, "→ Add remote", Display("→ Add remote"))
+	assert.Equal(t, "", ID(Line("", "── HEADER ──")))
+}
+
+func TestNumbered_HidesIDs(t *testing.T) {
+	var out bytes.Buffer
+	lines := []string{Line("", "── WORKSPACES ──"), Line("a[1]", "a[1] first"), Line("b", "b second")}
+
+	selected, err := Numbered(strings.NewReader("2\n"), &out, "", lines, notHeader)
+	require.NoError(t, err)
+	assert.Equal(t, "b", ID(selected))
+	assert.Contains(t, out.String(), "     ── WORKSPACES ──\n  1) a[1] first\n  2) b second\n")
+	assert.NotContains(t, out.String(), "\t")
+}
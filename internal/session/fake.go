@@ -0,0 +1,420 @@
+package session
+
+import (
+	"fmt"
+	"io"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/pmossman/claudew/internal/execx"
+)
+
+// FakeTmux is an in-memory tmux server that understands the commands Manager runs.
+// Register it with an execx.Fake to exercise session flows without a real tmux:
+//
+//	fake := execx.NewFake(execx.OS{})
+//	fake.Handle("tmux", session.NewFakeTmux().Handle)
+//	defer execx.Use(fake)()
+//
+// Attaching returns at once, as if the user detached straight away.
+type FakeTmux struct {
+	mu       sync.Mutex
+	sessions map[string]*fakeSession
+	clients  []Client
+	buffers  map[string]string
+	bindings map[string][]string
+	startup  int
+}
+
+type fakeSession struct {
+	windows  []string
+	env      map[string]string
+	options  map[string]string
+	input    []string
+	attaches int
+	activity time.Time
+	// startup counts the pane captures left before the shell shows its prompt
+	startup int
+}
+
+// NewFakeTmux creates a fake tmux server with no sessions
+func NewFakeTmux() *FakeTmux {
+	return &FakeTmux{
+		sessions: make(map[string]*fakeSession),
+		buffers:  make(map[string]string),
+		bindings: make(map[string][]string),
+	}
+}
+
+// fakeValueFlags are the tmux flags that take a value, for every command Manager runs
+var fakeValueFlags = map[string]bool{"-t": true, "-s": true, "-c": true, "-n": true, "-F": true, "-f": true, "-b": true}
+
+// Handle runs one tmux command against the fake server
+func (f *FakeTmux) Handle(c *execx.Cmd) error {
+	if len(c.Args) == 0 {
+		return fakeFail(c, "usage: tmux command [flags]")
+	}
+	if c.Args[0] == "-V" {
+		fakeWrite(c.Stdout, "tmux fake\n")
+		return nil
+	}
+
+	flags := make(map[string]string)
+	var args []string
+	rest := c.Args[1:]
+	for i := 0; i < len(rest); i++ {
+		arg := rest[i]
+		if len(arg) == 2 && arg[0] == '-' {
+			if fakeValueFlags[arg] && i+1 < len(rest) {
+				flags[arg] = rest[i+1]
+				i++
+			} else {
+				flags[arg] = ""
+			}
+			continue
+		}
+		args = append(args, arg)
+	}
+
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	target, window, _ := strings.Cut(flags["-t"], ":")
+
+	switch c.Args[0] {
+	case "new-session":
+		name := flags["-s"]
+		if _, ok := f.sessions[name]; ok {
+			return fakeFail(c, "duplicate session: "+name)
+		}
+		f.sessions[name] = &fakeSession{
+			windows:  []string{"shell"},
+			env:      make(map[string]string),
+			options:  make(map[string]string),
+			activity: time.Now(),
+			startup:  f.startup,
+		}
+		return nil
+
+	case "list-sessions":
+		if len(f.sessions) == 0 {
+			return fakeFail(c, "no server running on /tmp/tmux-fake/default")
+		}
+		only, filtered := strings.CutPrefix(flags["-f"], "#{==:#{session_name},")
+		only = strings.TrimSuffix(only, "}")
+		for _, name := range f.sessionNames() {
+			if filtered && name != only {
+				continue
+			}
+			s := f.sessions[name]
+			attached := 0
+			for _, client := range f.clients {
+				if client.Session == name {
+					attached++
+				}
+			}
+			fakeWrite(c.Stdout, fakeFormat(flags["-F"], map[string]string{
+				"session_name":     name,
+				"session_attached": strconv.Itoa(attached),
+				"session_activity": strconv.FormatInt(s.activity.Unix(), 10),
+			}))
+		}
+		return nil
+
+	case "list-clients":
+		if len(f.sessions) == 0 {
+			return fakeFail(c, "no server running on /tmp/tmux-fake/default")
+		}
+		for _, client := range f.clients {
+			fakeWrite(c.Stdout, fakeFormat(flags["-F"], map[string]string{
+				"client_tty":     client.TTY,
+				"client_session": client.Session,
+			}))
+		}
+		return nil
+
+	case "detach-client":
+		var kept []Client
+		for _, client := range f.clients {
+			if _, bySession := flags["-s"]; bySession && client.Session == flags["-s"] {
+				continue
+			}
+			if _, byTTY := flags["-t"]; byTTY && client.TTY == flags["-t"] {
+				continue
+			}
+			kept = append(kept, client)
+		}
+		f.clients = kept
+		return nil
+
+	case "load-buffer":
+		if c.Stdin == nil {
+			return fakeFail(c, "no data for buffer")
+		}
+		data, err := io.ReadAll(c.Stdin)
+		if err != nil {
+			return err
+		}
+		f.buffers[flags["-b"]] = string(data)
+		return nil
+
+	case "show-buffer":
+		text, ok := f.buffers[flags["-b"]]
+		if !ok {
+			return fakeFail(c, "no buffer "+flags["-b"])
+		}
+		fakeWrite(c.Stdout, text)
+		return nil
+
+	case "delete-buffer":
+		delete(f.buffers, flags["-b"])
+		return nil
+
+	case "bind-key":
+		if len(args) < 2 {
+			return fakeFail(c, "usage: bind-key key command [arguments]")
+		}
+		f.bindings[args[0]] = c.Args[2:]
+		return nil
+
+	case "display-message":
+		// This process is never inside the fake server
+		return fakeFail(c, "no current client")
+	}
+
+	// Every other command targets an existing session
+	name := target
+	if name == "" {
+		name = flags["-s"]
+	}
+	s, ok := f.sessions[name]
+	if !ok {
+		return fakeFail(c, "can't find session: "+name)
+	}
+
+	switch c.Args[0] {
+	case "has-session":
+		return nil
+
+	case "kill-session":
+		delete(f.sessions, name)
+		f.dropClients(name)
+		return nil
+
+	case "rename-session":
+		if len(args) != 1 {
+			return fakeFail(c, "usage: rename-session [-t target-session] new-name")
+		}
+		if _, ok := f.sessions[args[0]]; ok {
+			return fakeFail(c, "duplicate session: "+args[0])
+		}
+		delete(f.sessions, name)
+		f.sessions[args[0]] = s
+		for i := range f.clients {
+			if f.clients[i].Session == name {
+				f.clients[i].Session = args[0]
+			}
+		}
+		return nil
+
+	case "attach-session", "switch-client":
+		s.attaches++
+		return nil
+
+	case "new-window":
+		s.windows = append(s.windows, flags["-n"])
+		return nil
+
+	case "kill-window":
+		for i, w := range s.windows {
+			if w == window {
+				s.windows = append(s.windows[:i], s.windows[i+1:]...)
+				return nil
+			}
+		}
+		return fakeFail(c, "can't find window: "+window)
+
+	case "select-window":
+		for _, w := range s.windows {
+			if w == window {
+				return nil
+			}
+		}
+		return fakeFail(c, "can't find window: "+window)
+
+	case "list-windows":
+		for _, w := range s.windows {
+			fakeWrite(c.Stdout, fakeFormat(flags["-F"], map[string]string{"window_name": w}))
+		}
+		return nil
+
+	case "list-panes":
+		// No process runs in a fake pane
+		fakeWrite(c.Stdout, fakeFormat(flags["-F"], map[string]string{"pane_pid": "", "pane_tty": "/dev/fake/" + name}))
+		return nil
+
+	case "capture-pane":
+		if s.startup > 0 {
+			s.startup--
+			return nil
+		}
+		for _, input := range s.input {
+			fakeWrite(c.Stdout, "$ "+strings.TrimSuffix(input, " C-m")+"\n")
+		}
+		fakeWrite(c.Stdout, "$ \n")
+		return nil
+
+	case "send-keys":
+		s.input = append(s.input, strings.Join(args, " "))
+		s.activity = time.Now()
+		return nil
+
+	case "paste-buffer":
+		text, ok := f.buffers[flags["-b"]]
+		if !ok {
+			return fakeFail(c, "no buffer "+flags["-b"])
+		}
+		s.input = append(s.input, text)
+		s.activity = time.Now()
+		if _, del := flags["-d"]; del {
+			delete(f.buffers, flags["-b"])
+		}
+		return nil
+
+	case "set-option":
+		if len(args) != 2 {
+			return fakeFail(c, "usage: set-option [-t target-session] option value")
+		}
+		s.options[args[0]] = args[1]
+		return nil
+
+	case "set-environment":
+		if len(args) != 2 {
+			return fakeFail(c, "usage: set-environment [-t target-session] name value")
+		}
+		s.env[args[0]] = args[1]
+		return nil
+
+	case "show-environment":
+		if len(args) != 1 {
+			return fakeFail(c, "usage: show-environment [-t target-session] name")
+		}
+		value, ok := s.env[args[0]]
+		if !ok {
+			return fakeFail(c, "unknown variable: "+args[0])
+		}
+		fakeWrite(c.Stdout, args[0]+"="+value+"\n")
+		return nil
+	}
+
+	return fakeFail(c, "unknown command: "+c.Args[0])
+}
+
+// Binding returns the command and arguments bound to a key, or nil
+func (f *FakeTmux) Binding(key string) []string {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return append([]string(nil), f.bindings[key]...)
+}
+
+// SetBuffer stores text in a paste buffer, as a key binding's prompt would
+func (f *FakeTmux) SetBuffer(name, text string) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.buffers[name] = text
+}
+
+// Option returns the value of a session option, or "" if it is unset
+func (f *FakeTmux) Option(sessionName, name string) string {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if s, ok := f.sessions[sessionName]; ok {
+		return s.options[name]
+	}
+	return ""
+}
+
+// AddClient attaches a client with the given tty to a session
+func (f *FakeTmux) AddClient(tty, sessionName string) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.clients = append(f.clients, Client{TTY: tty, Session: sessionName})
+}
+
+// Sessions returns the names of the running sessions, sorted
+func (f *FakeTmux) Sessions() []string {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return f.sessionNames()
+}
+
+// Input returns everything typed or pasted into a session, one entry per command
+func (f *FakeTmux) Input(sessionName string) []string {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if s, ok := f.sessions[sessionName]; ok {
+		return append([]string(nil), s.input...)
+	}
+	return nil
+}
+
+// SlowShell makes the shells of sessions created afterwards take captures pane
+// captures to show their prompt, like a shell busy running a heavy startup file.
+// Keys sent before then are queued, as a terminal does, and show up with the prompt.
+func (f *FakeTmux) SlowShell(captures int) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.startup = captures
+}
+
+// Attaches returns how many times a session has been attached to or switched to
+func (f *FakeTmux) Attaches(sessionName string) int {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if s, ok := f.sessions[sessionName]; ok {
+		return s.attaches
+	}
+	return 0
+}
+
+func (f *FakeTmux) sessionNames() []string {
+	names := make([]string, 0, len(f.sessions))
+	for name := range f.sessions {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+func (f *FakeTmux) dropClients(sessionName string) {
+	var kept []Client
+	for _, client := range f.clients {
+		if client.Session != sessionName {
+			kept = append(kept, client)
+		}
+	}
+	f.clients = kept
+}
+
+// fakeFormat expands #{name} references in a tmux format string and ends the line
+func fakeFormat(format string, vars map[string]string) string {
+	for key, value := range vars {
+		format = strings.ReplaceAll(format, "#{"+key+"}", value)
+	}
+	return format + "\n"
+}
+
+func fakeWrite(w io.Writer, s string) {
+	if w != nil {
+		_, _ = io.WriteString(w, s)
+	}
+}
+
+// fakeFail reports an error the way tmux does: a message on stderr and exit status 1
+func fakeFail(c *execx.Cmd, msg string) error {
+	fakeWrite(c.Stderr, fmt.Sprintln(msg))
+	return &execx.ExitError{Code: 1}
+}
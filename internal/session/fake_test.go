@@ -0,0 +1,140 @@
+package session
+
+import (
+	"testing"
+
+	"github.com/pmossman/claudew/internal/execx"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// useFakeTmux routes the Manager's tmux commands to a fresh FakeTmux for the rest of the test
+func useFakeTmux(t *testing.T) *FakeTmux {
+	tmux := NewFakeTmux()
+	fake := execx.NewFake(nil)
+	fake.Handle("tmux", tmux.Handle)
+	t.Cleanup(execx.Use(fake))
+	return tmux
+}
+
+func TestFakeTmuxSessionLifecycle(t *testing.T) {
+	tmux := useFakeTmux(t)
+	t.Setenv("TMUX", "")
+	mgr := NewManager()
+
+	sessions, err := mgr.List()
+	require.NoError(t, err)
+	assert.Empty(t, sessions)
+
+	exists, err := mgr.Exists("claude-ws-a")
+	require.NoError(t, err)
+	assert.False(t, exists)
+
+	require.NoError(t, mgr.Create("claude-ws-a", "/tmp"))
+	require.NoError(t, mgr.Create("other", "/tmp"))
+	assert.Error(t, mgr.Create("claude-ws-a", "/tmp"))
+
+	exists, err = mgr.Exists("claude-ws-a")
+	require.NoError(t, err)
+	assert.True(t, exists)
+
+	workspaceSessions, err := mgr.ListWorkspaceSessions()
+	require.NoError(t, err)
+	assert.Equal(t, []string{"claude-ws-a"}, workspaceSessions)
+
+	require.NoError(t, mgr.SendKeys("claude-ws-a", "claude"))
+	require.NoError(t, mgr.Paste("claude-ws-a", "line one\nline two"))
+	assert.Equal(t, []string{"claude C-m", "line one\nline two"}, tmux.Input("claude-ws-a"))
+
+	require.NoError(t, mgr.Attach("claude-ws-a"))
+	assert.Equal(t, 1, tmux.Attaches("claude-ws-a"))
+
+	require.NoError(t, mgr.Rename("claude-ws-a", "claude-ws-b"))
+	require.NoError(t, mgr.Kill("claude-ws-b"))
+	assert.Equal(t, []string{"other"}, tmux.Sessions())
+}
+
+func TestFakeTmuxClients(t *testing.T) {
+	tmux := useFakeTmux(t)
+	mgr := NewManager()
+	require.NoError(t, mgr.Create("claude-ws-a", "/tmp"))
+
+	state, err := mgr.GetSessionState("claude-ws-a")
+	require.NoError(t, err)
+	assert.Equal(t, "detached", state)
+
+	tmux.AddClient("/dev/ttys001", "claude-ws-a")
+	tmux.AddClient("/dev/ttys002", "claude-ws-a")
+	state, err = mgr.GetSessionState("claude-ws-a")
+	require.NoError(t, err)
+	assert.Equal(t, "attached", state)
+
+	require.NoError(t, mgr.Create("claude-ws-b", "/tmp"))
+	attached, err := mgr.ListAttached()
+	require.NoError(t, err)
+	assert.Equal(t, map[string]int{"claude-ws-a": 2, "claude-ws-b": 0}, attached)
+
+	require.NoError(t, mgr.DetachClient("/dev/ttys001"))
+	clients, err := mgr.ListClients()
+	require.NoError(t, err)
+	assert.Equal(t, []Client{{TTY: "/dev/ttys002", Session: "claude-ws-a"}}, clients)
+
+	require.NoError(t, mgr.Detach("claude-ws-a"))
+	state, err = mgr.GetSessionState("claude-ws-a")
+	require.NoError(t, err)
+	assert.Equal(t, "detached", state)
+}
+
+func TestFakeTmuxWindowsAndEnvironment(t *testing.T) {
+	tmux := useFakeTmux(t)
+	mgr := NewManager()
+	require.NoError(t, mgr.Create("claude-ws-a", "/tmp"))
+
+	require.NoError(t, mgr.NewWindow("claude-ws-a", "notes", "/tmp"))
+	windows, err := mgr.ListWindows("claude-ws-a")
+	require.NoError(t, err)
+	assert.Equal(t, []string{"shell", "notes"}, windows)
+	require.NoError(t, mgr.SelectWindow("claude-ws-a", "notes"))
+	assert.Error(t, mgr.SelectWindow("claude-ws-a", "missing"))
+	require.NoError(t, mgr.KillWindow("claude-ws-a", "notes"))
+	assert.Error(t, mgr.KillWindow("claude-ws-a", "notes"))
+
+	require.NoError(t, mgr.SetEnvironment("claude-ws-a", map[string]string{"PORT": "3000"}))
+	value, err := mgr.GetEnvironment("claude-ws-a", "PORT")
+	require.NoError(t, err)
+	assert.Equal(t, "3000", value)
+	_, err = mgr.GetEnvironment("claude-ws-a", "MISSING")
+	assert.Error(t, err)
+
+	require.NoError(t, mgr.SetStatusLine("claude-ws-a", "left", "right"))
+	require.NoError(t, mgr.SetStatusColor("claude-ws-a", "blue"))
+	assert.Equal(t, "bg=blue,fg=black", tmux.Option("claude-ws-a", "status-style"))
+	assert.Equal(t, "left", tmux.Option("claude-ws-a", "status-left"))
+
+	activity, err := mgr.ListActivity()
+	require.NoError(t, err)
+	assert.Contains(t, activity, "claude-ws-a")
+
+	pid, err := mgr.PanePID("claude-ws-a")
+	require.NoError(t, err)
+	assert.Empty(t, pid)
+}
+
+func TestFakeTmuxDecisionKey(t *testing.T) {
+	tmux := useFakeTmux(t)
+	mgr := NewManager()
+
+	require.NoError(t, mgr.BindDecisionKey("D", `/bin/claudew decision --session "#{session_name}"`))
+	assert.Equal(t, []string{"command-prompt", "-p", "Decision:",
+		`set-buffer -b claudew-decision "%%%" ; run-shell -b "/bin/claudew decision --session \"#{session_name}\""`}, tmux.Binding("D"))
+
+	tmux.SetBuffer(DecisionBuffer, "Use Postgres, not SQLite")
+	text, err := mgr.TakeBuffer(DecisionBuffer)
+	require.NoError(t, err)
+	assert.Equal(t, "Use Postgres, not SQLite", text)
+	_, err = mgr.TakeBuffer(DecisionBuffer)
+	assert.Error(t, err)
+
+	assert.Equal(t, "feature-auth", mgr.WorkspaceName("claude-ws-feature-auth"))
+	assert.Empty(t, mgr.WorkspaceName("scratch"))
+}
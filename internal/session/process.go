@@ -0,0 +1,84 @@
+package session
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/pmossman/claudew/internal/execx"
+)
+
+// PaneTTY returns the terminal device of a session's active pane, e.g. /dev/pts/3,
+// or "" if tmux doesn't report one
+func (m *Manager) PaneTTY(sessionName string) (string, error) {
+	cmd := execx.Command("tmux", "list-panes", "-t", sessionName, "-F", "#{pane_tty}")
+	output, err := cmd.Output()
+	if err != nil {
+		return "", fmt.Errorf("failed to get pane terminal: %w", err)
+	}
+	tty, _, _ := strings.Cut(strings.TrimSpace(string(output)), "\n")
+	return tty, nil
+}
+
+// PaneProcesses returns the PIDs of the processes on the terminals of a session's
+// panes whose name matches pattern exactly (an extended regular expression over the
+// process name, not its command line, so editors and viewers with "claude" in their
+// arguments aren't counted), in ascending order. Matching on the terminals rather
+// than the panes' child processes also finds programs started through wrappers and
+// scripts. The panes' shells and this process are never included.
+func (m *Manager) PaneProcesses(sessionName, pattern string) ([]int, error) {
+	cmd := execx.Command("tmux", "list-panes", "-t", sessionName, "-F", "#{pane_tty}\t#{pane_pid}")
+	output, err := cmd.Output()
+	if err != nil {
+		return nil, fmt.Errorf("failed to list panes: %w", err)
+	}
+	var ttys []string
+	shells := map[string]bool{}
+	for _, line := range strings.Split(strings.TrimSpace(string(output)), "\n") {
+		tty, shell, _ := strings.Cut(line, "\t")
+		if tty != "" {
+			ttys = append(ttys, strings.TrimPrefix(tty, "/dev/"))
+		}
+		shells[shell] = true
+	}
+	if len(ttys) == 0 {
+		return nil, nil
+	}
+
+	cmd = execx.Command("pgrep", "-x", "-t", strings.Join(ttys, ","), pattern)
+	output, err = cmd.Output()
+	if err != nil {
+		var exitErr *execx.ExitError
+		if errors.As(err, &exitErr) && exitErr.ExitCode() == 1 {
+			return nil, nil // Nothing matched
+		}
+		return nil, fmt.Errorf("failed to list pane processes: %w", err)
+	}
+
+	var pids []int
+	for _, field := range strings.Fields(string(output)) {
+		pid, err := strconv.Atoi(field)
+		if err != nil || shells[field] || pid == os.Getpid() {
+			continue
+		}
+		pids = append(pids, pid)
+	}
+	sort.Ints(pids)
+	return pids, nil
+}
+
+// SignalProcesses sends signal (e.g. "TERM" or "KILL") to each of pids
+func (m *Manager) SignalProcesses(pids []int, signal string) {
+	if len(pids) == 0 {
+		return
+	}
+	args := []string{"-" + signal}
+	for _, pid := range pids {
+		args = append(args, strconv.Itoa(pid))
+	}
+	// kill fails if any process already exited, which is what was wanted
+	_ = execx.Command("kill", args...).Run()
+}
@@ -0,0 +1,57 @@
+package session
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/pmossman/claudew/internal/execx"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPaneProcesses(t *testing.T) {
+	tmux := NewFakeTmux()
+	fake := execx.NewFake(nil)
+	fake.Handle("tmux", tmux.Handle)
+	var pgrepArgs []string
+	fake.Handle("pgrep", func(c *execx.Cmd) error {
+		pgrepArgs = c.Args
+		if strings.HasSuffix(c.Args[2], "claude-ws-idle") {
+			return &execx.ExitError{Code: 1}
+		}
+		_, _ = c.Stdout.Write([]byte("4321\n1234\n"))
+		return nil
+	})
+	t.Cleanup(execx.Use(fake))
+
+	mgr := NewManager()
+	require.NoError(t, mgr.Create("claude-ws-a", "/tmp"))
+	require.NoError(t, mgr.Create("claude-ws-idle", "/tmp"))
+
+	tty, err := mgr.PaneTTY("claude-ws-a")
+	require.NoError(t, err)
+	assert.Equal(t, "/dev/fake/claude-ws-a", tty)
+
+	pids, err := mgr.PaneProcesses("claude-ws-a", "claude")
+	require.NoError(t, err)
+	assert.Equal(t, []int{1234, 4321}, pids)
+	assert.Equal(t, []string{"-x", "-t", "fake/claude-ws-a", "claude"}, pgrepArgs)
+
+	pids, err = mgr.PaneProcesses("claude-ws-idle", "claude")
+	require.NoError(t, err)
+	assert.Empty(t, pids)
+
+	_, err = mgr.PaneProcesses("claude-ws-missing", "claude")
+	assert.Error(t, err)
+}
+
+func TestSignalProcesses(t *testing.T) {
+	fake := execx.NewFake(nil)
+	fake.Handle("kill", func(c *execx.Cmd) error { return nil })
+	t.Cleanup(execx.Use(fake))
+
+	mgr := NewManager()
+	mgr.SignalProcesses(nil, "TERM")
+	mgr.SignalProcesses([]int{12, 34}, "TERM")
+	assert.Equal(t, []string{"kill -TERM 12 34"}, fake.Calls())
+}
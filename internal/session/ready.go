@@ -0,0 +1,83 @@
+package session
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/pmossman/claudew/internal/execx"
+)
+
+var (
+	// readyPollInterval is how often a pane is captured while waiting on its shell
+	readyPollInterval = 100 * time.Millisecond
+	// readySettle is how long a pane must stay unchanged to count as ready when its
+	// last line doesn't look like a prompt
+	readySettle = 500 * time.Millisecond
+)
+
+// promptSuffixes end the prompts of common shells and themes
+var promptSuffixes = []string{"$", "%", "#", ">", "❯", "➜", "λ", "»"}
+
+// CapturePane returns the visible text of a session's pane, with wrapped lines joined
+func (m *Manager) CapturePane(sessionName string) (string, error) {
+	cmd := execx.Command("tmux", "capture-pane", "-p", "-J", "-t", sessionName)
+	output, err := cmd.Output()
+	if err != nil {
+		return "", fmt.Errorf("failed to capture pane: %w", err)
+	}
+	return string(output), nil
+}
+
+// WaitForPrompt waits up to timeout for the shell in a session's pane to be ready
+// for input: its last line looks like a prompt, or the pane has printed something
+// and then stayed unchanged for a moment. It reports whether the shell became ready.
+func (m *Manager) WaitForPrompt(sessionName string, timeout time.Duration) bool {
+	deadline := time.Now().Add(timeout)
+	var last string
+	var since time.Time
+	for {
+		content, err := m.CapturePane(sessionName)
+		if err != nil {
+			return false
+		}
+		if looksLikePrompt(content) {
+			return true
+		}
+		now := time.Now()
+		if content != last {
+			last, since = content, now
+		} else if strings.TrimSpace(content) != "" && now.Sub(since) >= readySettle {
+			return true
+		}
+		if now.After(deadline) {
+			return false
+		}
+		time.Sleep(readyPollInterval)
+	}
+}
+
+// looksLikePrompt reports whether the last non-blank line of pane content ends the
+// way a shell prompt does
+func looksLikePrompt(content string) bool {
+	lines := strings.Split(strings.TrimRight(content, " \n"), "\n")
+	line := strings.TrimSpace(lines[len(lines)-1])
+	for _, suffix := range promptSuffixes {
+		if strings.HasSuffix(line, suffix) {
+			return true
+		}
+	}
+	return false
+}
+
+// SendKeysWhenReady types keys into a session, then presses Enter, once its shell is
+// ready for input. A shell that is still starting up (a slow .zshrc, say) may not be
+// reading its input yet, so this waits up to timeout for the prompt first. The keys
+// are sent exactly once; if the shell never looks ready they are not sent at all,
+// since a command queued behind a stuck startup could run at any later moment.
+func (m *Manager) SendKeysWhenReady(sessionName, keys string, timeout time.Duration) error {
+	if !m.WaitForPrompt(sessionName, timeout) {
+		return fmt.Errorf("shell in session %s was not ready for input after %s", sessionName, timeout)
+	}
+	return m.SendKeys(sessionName, keys)
+}
@@ -0,0 +1,70 @@
+package session
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fastReadyPolling shrinks the readiness waits for the rest of the test
+func fastReadyPolling(t *testing.T) {
+	interval, settle := readyPollInterval, readySettle
+	readyPollInterval, readySettle = time.Millisecond, 5*time.Millisecond
+	t.Cleanup(func() {
+		readyPollInterval, readySettle = interval, settle
+	})
+}
+
+func TestLooksLikePrompt(t *testing.T) {
+	assert.True(t, looksLikePrompt("user@host:~/src$ \n\n\n"))
+	assert.True(t, looksLikePrompt("Last login: today\n~/src ❯ "))
+	assert.True(t, looksLikePrompt("host% "))
+	assert.False(t, looksLikePrompt(""))
+	assert.False(t, looksLikePrompt("Loading plugins...\n"))
+}
+
+func TestWaitForPrompt(t *testing.T) {
+	fastReadyPolling(t)
+	tmux := useFakeTmux(t)
+	mgr := NewManager()
+	tmux.SlowShell(3)
+	require.NoError(t, mgr.Create("claude-ws-a", "/tmp"))
+
+	assert.True(t, mgr.WaitForPrompt("claude-ws-a", time.Second))
+	assert.False(t, mgr.WaitForPrompt("claude-ws-missing", time.Second))
+}
+
+func TestWaitForPrompt_TimesOut(t *testing.T) {
+	fastReadyPolling(t)
+	tmux := useFakeTmux(t)
+	mgr := NewManager()
+	tmux.SlowShell(1000)
+	require.NoError(t, mgr.Create("claude-ws-a", "/tmp"))
+
+	assert.False(t, mgr.WaitForPrompt("claude-ws-a", 10*time.Millisecond))
+}
+
+func TestSendKeysWhenReady(t *testing.T) {
+	fastReadyPolling(t)
+	tmux := useFakeTmux(t)
+	mgr := NewManager()
+	tmux.SlowShell(3)
+	require.NoError(t, mgr.Create("claude-ws-a", "/tmp"))
+
+	require.NoError(t, mgr.SendKeysWhenReady("claude-ws-a", "claude", time.Second))
+	assert.Equal(t, []string{"claude C-m"}, tmux.Input("claude-ws-a"))
+}
+
+func TestSendKeysWhenReady_TimesOut(t *testing.T) {
+	fastReadyPolling(t)
+	tmux := useFakeTmux(t)
+	mgr := NewManager()
+	tmux.SlowShell(1000)
+	require.NoError(t, mgr.Create("claude-ws-a", "/tmp"))
+
+	err := mgr.SendKeysWhenReady("claude-ws-a", "claude", 10*time.Millisecond)
+	assert.ErrorContains(t, err, "was not ready for input")
+	assert.Empty(t, tmux.Input("claude-ws-a"))
+}
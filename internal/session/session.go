@@ -1,32 +1,104 @@
 package session
 
 import (
+	"errors"
 	"fmt"
 	"os"
 	"os/exec"
+	"sort"
 	"strconv"
 	"strings"
+	"time"
+	"unicode"
+
+	"github.com/pmossman/claudew/internal/config"
+	"github.com/pmossman/claudew/internal/execx"
 )
 
+// SessionPrefix starts the name of every workspace's tmux session
+const SessionPrefix = "claude-ws-"
+
+// tmuxMissing reports whether err means tmux isn't installed, in which case no
+// sessions can exist. This keeps listing commands usable on machines without tmux.
+func tmuxMissing(err error) bool {
+	return errors.Is(err, exec.ErrNotFound)
+}
+
 // Manager handles tmux session operations
-type Manager struct{}
+type Manager struct {
+	cfg *config.Config // nil unless created with NewManagerForConfig
+}
 
 // NewManager creates a new session manager
 func NewManager() *Manager {
 	return &Manager{}
 }
 
+// NewManagerForConfig creates a session manager that uses the session names recorded
+// in cfg, for workspaces whose session name has a suffix
+func NewManagerForConfig(cfg *config.Config) *Manager {
+	return &Manager{cfg: cfg}
+}
+
 // GetSessionName returns the tmux session name for a workspace
 func (m *Manager) GetSessionName(workspaceName string) string {
-	return fmt.Sprintf("claude-ws-%s", workspaceName)
+	if m.cfg != nil {
+		if ws, ok := m.cfg.Workspaces[workspaceName]; ok && ws.SessionName != "" {
+			return ws.SessionName
+		}
+	}
+	return SessionPrefix + workspaceName
+}
+
+// maxSlugLength limits the suffix SessionName adds, so names stay readable in tmux's
+// session list and status line
+const maxSlugLength = 24
+
+// SessionName returns a tmux session name for a workspace with a short slug of
+// suffix appended, e.g. "claude-ws-auth-fix-login-redirect" for the summary "Fix
+// login redirect". Without anything to slug it's the plain session name.
+func SessionName(workspaceName, suffix string) string {
+	s := slug(suffix)
+	if s == "" {
+		return SessionPrefix + workspaceName
+	}
+	return SessionPrefix + workspaceName + "-" + s
+}
+
+// slug lowercases text and joins its words with hyphens, cut at a word boundary
+// to at most maxSlugLength characters. tmux doesn't allow '.' or ':' in session
+// names, so only letters and digits are kept.
+func slug(text string) string {
+	words := strings.FieldsFunc(strings.ToLower(text), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+
+	result := ""
+	for _, word := range words {
+		next := word
+		if result != "" {
+			next = result + "-" + word
+		}
+		if len([]rune(next)) > maxSlugLength {
+			if result == "" {
+				result = string([]rune(word)[:maxSlugLength])
+			}
+			break
+		}
+		result = next
+	}
+	return result
 }
 
 // Exists checks if a tmux session exists
 func (m *Manager) Exists(sessionName string) (bool, error) {
-	cmd := exec.Command("tmux", "has-session", "-t", sessionName)
+	cmd := execx.Command("tmux", "has-session", "-t", sessionName)
 	err := cmd.Run()
 	if err != nil {
-		if exitErr, ok := err.(*exec.ExitError); ok {
+		if tmuxMissing(err) {
+			return false, nil
+		}
+		if exitErr, ok := err.(*execx.ExitError); ok {
 			// Exit code 1 means session doesn't exist
 			if exitErr.ExitCode() == 1 {
 				return false, nil
@@ -40,19 +112,61 @@ func (m *Manager) Exists(sessionName string) (bool, error) {
 // Create creates a new tmux session
 func (m *Manager) Create(sessionName, repoPath string) error {
 	// Create detached session in the repo directory
-	cmd := exec.Command("tmux", "new-session", "-d", "-s", sessionName, "-c", repoPath)
+	cmd := execx.Command("tmux", "new-session", "-d", "-s", sessionName, "-c", repoPath)
 	if err := cmd.Run(); err != nil {
 		return fmt.Errorf("failed to create tmux session: %w", err)
 	}
 	return nil
 }
 
+// NewWindow adds a named window to a session, starting in dir, without switching to it
+func (m *Manager) NewWindow(sessionName, windowName, dir string) error {
+	cmd := execx.Command("tmux", "new-window", "-d", "-t", sessionName+":", "-n", windowName, "-c", dir)
+	if err := cmd.Run(); err != nil {
+		return fmt.Errorf("failed to create tmux window: %w", err)
+	}
+	return nil
+}
+
+// KillWindow closes a named window of a session
+func (m *Manager) KillWindow(sessionName, windowName string) error {
+	cmd := execx.Command("tmux", "kill-window", "-t", sessionName+":"+windowName)
+	if err := cmd.Run(); err != nil {
+		return fmt.Errorf("failed to kill tmux window: %w", err)
+	}
+	return nil
+}
+
+// SelectWindow makes a named window the current window of a session, so attaching shows it
+func (m *Manager) SelectWindow(sessionName, windowName string) error {
+	cmd := execx.Command("tmux", "select-window", "-t", sessionName+":"+windowName)
+	if err := cmd.Run(); err != nil {
+		return fmt.Errorf("failed to select tmux window: %w", err)
+	}
+	return nil
+}
+
+// ListWindows returns the names of the windows in a session
+func (m *Manager) ListWindows(sessionName string) ([]string, error) {
+	cmd := execx.Command("tmux", "list-windows", "-t", sessionName, "-F", "#{window_name}")
+	output, err := cmd.Output()
+	if err != nil {
+		return nil, fmt.Errorf("failed to list tmux windows: %w", err)
+	}
+
+	windows := strings.Split(strings.TrimSpace(string(output)), "\n")
+	if len(windows) == 1 && windows[0] == "" {
+		return []string{}, nil
+	}
+	return windows, nil
+}
+
 // Attach attaches to an existing tmux session or creates and attaches if it doesn't exist
 func (m *Manager) Attach(sessionName string) error {
 	// Check if we're already in a tmux session
 	if os.Getenv("TMUX") != "" {
 		// We're inside tmux, switch to the session
-		cmd := exec.Command("tmux", "switch-client", "-t", sessionName)
+		cmd := execx.Command("tmux", "switch-client", "-t", sessionName)
 		cmd.Stdin = os.Stdin
 		cmd.Stdout = os.Stdout
 		cmd.Stderr = os.Stderr
@@ -60,7 +174,7 @@ func (m *Manager) Attach(sessionName string) error {
 	}
 
 	// Not in tmux, attach normally
-	cmd := exec.Command("tmux", "attach-session", "-t", sessionName)
+	cmd := execx.Command("tmux", "attach-session", "-t", sessionName)
 	cmd.Stdin = os.Stdin
 	cmd.Stdout = os.Stdout
 	cmd.Stderr = os.Stderr
@@ -69,29 +183,203 @@ func (m *Manager) Attach(sessionName string) error {
 
 // SendKeys sends keys to a tmux session
 func (m *Manager) SendKeys(sessionName, keys string) error {
-	cmd := exec.Command("tmux", "send-keys", "-t", sessionName, keys, "C-m")
+	cmd := execx.Command("tmux", "send-keys", "-t", sessionName, keys, "C-m")
 	return cmd.Run()
 }
 
 // SendKeysLiteral sends keys to a tmux session without automatically pressing Enter
 func (m *Manager) SendKeysLiteral(sessionName, keys string) error {
-	cmd := exec.Command("tmux", "send-keys", "-t", sessionName, keys)
+	cmd := execx.Command("tmux", "send-keys", "-t", sessionName, keys)
 	return cmd.Run()
 }
 
+// Paste types text into a tmux session as a bracketed paste, so multi-line text
+// arrives as one input instead of being submitted line by line
+func (m *Manager) Paste(sessionName, text string) error {
+	load := execx.Command("tmux", "load-buffer", "-b", "claudew-paste", "-")
+	load.Stdin = strings.NewReader(text)
+	if output, err := load.CombinedOutput(); err != nil {
+		return fmt.Errorf("failed to load tmux buffer: %w (%s)", err, strings.TrimSpace(string(output)))
+	}
+
+	cmd := execx.Command("tmux", "paste-buffer", "-p", "-d", "-b", "claudew-paste", "-t", sessionName)
+	if output, err := cmd.CombinedOutput(); err != nil {
+		return fmt.Errorf("failed to paste into session: %w (%s)", err, strings.TrimSpace(string(output)))
+	}
+	return nil
+}
+
+// DecisionBuffer is the tmux paste buffer the decision key binding stores its input in
+const DecisionBuffer = "claudew-decision"
+
+// BindDecisionKey binds prefix+key, for every session, to a prompt whose answer is
+// stored in DecisionBuffer before command runs. Formats like #{session_name} in
+// command are expanded by tmux. Bindings are global in tmux, so this only needs to
+// run once per server, but repeating it is harmless.
+func (m *Manager) BindDecisionKey(key, command string) error {
+	// %%% is replaced by the answer with double quotes escaped
+	template := fmt.Sprintf(`set-buffer -b %s "%%%%%%" ; run-shell -b "%s"`, DecisionBuffer, strings.ReplaceAll(command, `"`, `\"`))
+	cmd := execx.Command("tmux", "bind-key", key, "command-prompt", "-p", "Decision:", template)
+	if output, err := cmd.CombinedOutput(); err != nil {
+		return fmt.Errorf("failed to bind tmux key %s: %w (%s)", key, err, strings.TrimSpace(string(output)))
+	}
+	return nil
+}
+
+// TakeBuffer returns the contents of a tmux paste buffer and deletes it
+func (m *Manager) TakeBuffer(name string) (string, error) {
+	output, err := execx.Command("tmux", "show-buffer", "-b", name).Output()
+	if err != nil {
+		return "", fmt.Errorf("failed to read tmux buffer %s: %w", name, err)
+	}
+	_ = execx.Command("tmux", "delete-buffer", "-b", name).Run()
+	return string(output), nil
+}
+
+// DisplayMessage shows a message in the status line of a session's clients
+func (m *Manager) DisplayMessage(sessionName, message string) error {
+	cmd := execx.Command("tmux", "display-message", "-t", sessionName, message)
+	return cmd.Run()
+}
+
+// CurrentSession returns the name of the tmux session this process runs in, or "" outside tmux
+func (m *Manager) CurrentSession() string {
+	if os.Getenv("TMUX") == "" {
+		return ""
+	}
+	args := []string{"display-message", "-p"}
+	if pane := os.Getenv("TMUX_PANE"); pane != "" {
+		args = append(args, "-t", pane)
+	}
+	output, err := execx.Command("tmux", append(args, "#{session_name}")...).Output()
+	if err != nil {
+		return ""
+	}
+	return strings.TrimSpace(string(output))
+}
+
+// WorkspaceName returns the workspace a tmux session belongs to, or "" for other sessions
+func (m *Manager) WorkspaceName(sessionName string) string {
+	if m.cfg != nil {
+		for name, ws := range m.cfg.Workspaces {
+			if ws.SessionName == sessionName {
+				return name
+			}
+		}
+	}
+	name, ok := strings.CutPrefix(sessionName, SessionPrefix)
+	if !ok {
+		return ""
+	}
+	return name
+}
+
 // Kill kills a tmux session
 func (m *Manager) Kill(sessionName string) error {
-	cmd := exec.Command("tmux", "kill-session", "-t", sessionName)
+	cmd := execx.Command("tmux", "kill-session", "-t", sessionName)
+	return cmd.Run()
+}
+
+// Rename renames a tmux session
+func (m *Manager) Rename(oldName, newName string) error {
+	cmd := execx.Command("tmux", "rename-session", "-t", oldName, newName)
+	if err := cmd.Run(); err != nil {
+		return fmt.Errorf("failed to rename tmux session: %w", err)
+	}
+	return nil
+}
+
+// PanePID returns the PID of the process running in a session's active pane, or "" if there is none
+func (m *Manager) PanePID(sessionName string) (string, error) {
+	cmd := execx.Command("tmux", "list-panes", "-t", sessionName, "-F", "#{pane_pid}")
+	output, err := cmd.Output()
+	if err != nil {
+		return "", fmt.Errorf("failed to get pane PID: %w", err)
+	}
+	return strings.TrimSpace(string(output)), nil
+}
+
+// Detach detaches every client attached to a tmux session, leaving it running
+func (m *Manager) Detach(sessionName string) error {
+	state, err := m.GetSessionState(sessionName)
+	if err != nil {
+		return err
+	}
+	if state != "attached" {
+		// tmux fails when there is no client to detach
+		return nil
+	}
+
+	cmd := execx.Command("tmux", "detach-client", "-s", sessionName)
+	return cmd.Run()
+}
+
+// Client is a terminal attached to a tmux session
+type Client struct {
+	TTY     string
+	Session string
+}
+
+// ListClients returns every client attached to any tmux session
+func (m *Manager) ListClients() ([]Client, error) {
+	cmd := execx.Command("tmux", "list-clients", "-F", "#{client_tty} #{client_session}")
+	output, err := cmd.Output()
+	if err != nil {
+		if tmuxMissing(err) {
+			return nil, nil
+		}
+		// If there is no server, there are no clients
+		if exitErr, ok := err.(*execx.ExitError); ok {
+			if strings.Contains(string(exitErr.Stderr), "no server running") || strings.Contains(string(exitErr.Stderr), "error connecting") {
+				return nil, nil
+			}
+		}
+		return nil, fmt.Errorf("failed to list tmux clients: %w", err)
+	}
+
+	var clients []Client
+	for _, line := range strings.Split(strings.TrimSpace(string(output)), "\n") {
+		tty, sessionName, ok := strings.Cut(line, " ")
+		if !ok {
+			continue
+		}
+		clients = append(clients, Client{TTY: tty, Session: sessionName})
+	}
+	return clients, nil
+}
+
+// CurrentClientTTY returns the tty of the tmux client this process runs in, or "" outside tmux
+func (m *Manager) CurrentClientTTY() string {
+	if os.Getenv("TMUX") == "" {
+		return ""
+	}
+	args := []string{"display-message", "-p"}
+	if pane := os.Getenv("TMUX_PANE"); pane != "" {
+		args = append(args, "-t", pane)
+	}
+	output, err := execx.Command("tmux", append(args, "#{client_tty}")...).Output()
+	if err != nil {
+		return ""
+	}
+	return strings.TrimSpace(string(output))
+}
+
+// DetachClient detaches one tmux client, leaving its session running
+func (m *Manager) DetachClient(tty string) error {
+	cmd := execx.Command("tmux", "detach-client", "-t", tty)
 	return cmd.Run()
 }
 
 // List returns all tmux sessions
 func (m *Manager) List() ([]string, error) {
-	cmd := exec.Command("tmux", "list-sessions", "-F", "#{session_name}")
+	cmd := execx.Command("tmux", "list-sessions", "-F", "#{session_name}")
 	output, err := cmd.Output()
 	if err != nil {
+		if tmuxMissing(err) {
+			return []string{}, nil
+		}
 		// If there are no sessions, tmux returns an error
-		if exitErr, ok := err.(*exec.ExitError); ok {
+		if exitErr, ok := err.(*execx.ExitError); ok {
 			if strings.Contains(string(exitErr.Stderr), "no server running") {
 				return []string{}, nil
 			}
@@ -106,9 +394,25 @@ func (m *Manager) List() ([]string, error) {
 	return sessions, nil
 }
 
+// ListWorkspaceSessions returns the tmux sessions that belong to workspaces
+func (m *Manager) ListWorkspaceSessions() ([]string, error) {
+	sessions, err := m.List()
+	if err != nil {
+		return nil, err
+	}
+
+	var workspaceSessions []string
+	for _, name := range sessions {
+		if strings.HasPrefix(name, SessionPrefix) {
+			workspaceSessions = append(workspaceSessions, name)
+		}
+	}
+	return workspaceSessions, nil
+}
+
 // CheckTmuxInstalled checks if tmux is installed
 func (m *Manager) CheckTmuxInstalled() error {
-	cmd := exec.Command("tmux", "-V")
+	cmd := execx.Command("tmux", "-V")
 	if err := cmd.Run(); err != nil {
 		return fmt.Errorf("tmux is not installed. Please install tmux to use claude-workspace")
 	}
@@ -127,7 +431,7 @@ func (m *Manager) GetSessionState(sessionName string) (string, error) {
 	}
 
 	// Check if session is attached
-	cmd := exec.Command("tmux", "list-sessions", "-F", "#{session_name}:#{session_attached}", "-f", fmt.Sprintf("#{==:#{session_name},%s}", sessionName))
+	cmd := execx.Command("tmux", "list-sessions", "-F", "#{session_name}:#{session_attached}", "-f", fmt.Sprintf("#{==:#{session_name},%s}", sessionName))
 	output, err := cmd.Output()
 	if err != nil {
 		return "", fmt.Errorf("failed to get session state: %w", err)
@@ -160,7 +464,7 @@ func (m *Manager) SetStatusLine(sessionName, statusLeft, statusRight string) err
 	}
 
 	for _, cmdArgs := range commands {
-		cmd := exec.Command(cmdArgs[0], cmdArgs[1:]...)
+		cmd := execx.Command(cmdArgs[0], cmdArgs[1:]...)
 		if err := cmd.Run(); err != nil {
 			return fmt.Errorf("failed to set tmux option: %w", err)
 		}
@@ -168,3 +472,105 @@ func (m *Manager) SetStatusLine(sessionName, statusLeft, statusRight string) err
 
 	return nil
 }
+
+// SetStatusColor colors a session's status line background, so workspaces can be
+// told apart at a glance. color is any tmux color name.
+func (m *Manager) SetStatusColor(sessionName, color string) error {
+	cmd := execx.Command("tmux", "set-option", "-t", sessionName, "status-style", fmt.Sprintf("bg=%s,fg=black", color))
+	if err := cmd.Run(); err != nil {
+		return fmt.Errorf("failed to set status color: %w", err)
+	}
+	return nil
+}
+
+// SetEnvironment sets session environment variables inherited by new windows and panes
+func (m *Manager) SetEnvironment(sessionName string, env map[string]string) error {
+	keys := make([]string, 0, len(env))
+	for key := range env {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	for _, key := range keys {
+		cmd := execx.Command("tmux", "set-environment", "-t", sessionName, key, env[key])
+		if err := cmd.Run(); err != nil {
+			return fmt.Errorf("failed to set tmux environment variable %s: %w", key, err)
+		}
+	}
+	return nil
+}
+
+// GetEnvironment returns the value of a session environment variable
+func (m *Manager) GetEnvironment(sessionName, key string) (string, error) {
+	cmd := execx.Command("tmux", "show-environment", "-t", sessionName, key)
+	output, err := cmd.Output()
+	if err != nil {
+		return "", fmt.Errorf("failed to get tmux environment variable %s: %w", key, err)
+	}
+	return strings.TrimPrefix(strings.TrimSpace(string(output)), key+"="), nil
+}
+
+// ListActivity returns the time of the last activity in each tmux session, keyed by session name
+func (m *Manager) ListActivity() (map[string]time.Time, error) {
+	cmd := execx.Command("tmux", "list-sessions", "-F", "#{session_name} #{session_activity}")
+	output, err := cmd.Output()
+	if err != nil {
+		if tmuxMissing(err) {
+			return map[string]time.Time{}, nil
+		}
+		// If there are no sessions, tmux returns an error
+		if exitErr, ok := err.(*execx.ExitError); ok {
+			if strings.Contains(string(exitErr.Stderr), "no server running") || strings.Contains(string(exitErr.Stderr), "error connecting") {
+				return map[string]time.Time{}, nil
+			}
+		}
+		return nil, fmt.Errorf("failed to list tmux session activity: %w", err)
+	}
+
+	activity := make(map[string]time.Time)
+	for _, line := range strings.Split(strings.TrimSpace(string(output)), "\n") {
+		idx := strings.LastIndex(line, " ")
+		if idx == -1 {
+			continue
+		}
+		secs, err := strconv.ParseInt(line[idx+1:], 10, 64)
+		if err != nil {
+			continue
+		}
+		activity[line[:idx]] = time.Unix(secs, 0)
+	}
+	return activity, nil
+}
+
+// ListAttached returns the number of clients attached to each tmux session, keyed by
+// session name, in a single tmux call. Sessions missing from the map don't exist.
+func (m *Manager) ListAttached() (map[string]int, error) {
+	cmd := execx.Command("tmux", "list-sessions", "-F", "#{session_name} #{session_attached}")
+	output, err := cmd.Output()
+	if err != nil {
+		if tmuxMissing(err) {
+			return map[string]int{}, nil
+		}
+		// If there are no sessions, tmux returns an error
+		if exitErr, ok := err.(*execx.ExitError); ok {
+			if strings.Contains(string(exitErr.Stderr), "no server running") || strings.Contains(string(exitErr.Stderr), "error connecting") {
+				return map[string]int{}, nil
+			}
+		}
+		return nil, fmt.Errorf("failed to list tmux sessions: %w", err)
+	}
+
+	attached := make(map[string]int)
+	for _, line := range strings.Split(strings.TrimSpace(string(output)), "\n") {
+		idx := strings.LastIndex(line, " ")
+		if idx == -1 {
+			continue
+		}
+		count, err := strconv.Atoi(line[idx+1:])
+		if err != nil {
+			continue
+		}
+		attached[line[:idx]] = count
+	}
+	return attached, nil
+}
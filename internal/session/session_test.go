@@ -5,7 +5,9 @@ import (
 	"os/exec"
 	"strings"
 	"testing"
+	"time"
 
+	"github.com/pmossman/claudew/internal/config"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
@@ -65,6 +67,38 @@ func TestGetSessionName(t *testing.T) {
 	}
 }
 
+func TestGetSessionName_FromConfig(t *testing.T) {
+	cfg := &config.Config{Workspaces: map[string]*config.Workspace{
+		"auth":  {Name: "auth", SessionName: "claude-ws-auth-fix-login"},
+		"plain": {Name: "plain"},
+	}}
+	mgr := NewManagerForConfig(cfg)
+
+	assert.Equal(t, "claude-ws-auth-fix-login", mgr.GetSessionName("auth"))
+	assert.Equal(t, "claude-ws-plain", mgr.GetSessionName("plain"))
+	assert.Equal(t, "auth", mgr.WorkspaceName("claude-ws-auth-fix-login"))
+	assert.Equal(t, "plain", mgr.WorkspaceName("claude-ws-plain"))
+	assert.Equal(t, "", mgr.WorkspaceName("other"))
+}
+
+func TestSessionName(t *testing.T) {
+	tests := []struct {
+		suffix   string
+		expected string
+	}{
+		{"Fix login redirect", "claude-ws-auth-fix-login-redirect"},
+		{"  OAuth2: token.refresh!  ", "claude-ws-auth-oauth2-token-refresh"},
+		{"Migrate the billing service to the new payments API", "claude-ws-auth-migrate-the-billing"},
+		{"Supercalifragilisticexpialidocious", "claude-ws-auth-supercalifragilisticexpi"},
+		{"ログイン修正", "claude-ws-auth-ログイン修正"},
+		{"...", "claude-ws-auth"},
+		{"", "claude-ws-auth"},
+	}
+	for _, tt := range tests {
+		assert.Equal(t, tt.expected, SessionName("auth", tt.suffix), tt.suffix)
+	}
+}
+
 func TestCheckTmuxInstalled(t *testing.T) {
 	mgr := NewManager()
 
@@ -185,6 +219,27 @@ func TestKill(t *testing.T) {
 	assert.False(t, exists)
 }
 
+func TestDetach(t *testing.T) {
+	if !isTmuxInstalled() {
+		t.Skip("tmux not installed")
+	}
+
+	mgr := NewManager()
+	testSession := "test-session-detach-" + strings.ReplaceAll(t.Name(), "/", "-")
+	defer cleanupSession(t, testSession)
+
+	err := mgr.Create(testSession, "/tmp")
+	require.NoError(t, err)
+
+	// Detaching a session without clients leaves it running
+	err = mgr.Detach(testSession)
+	require.NoError(t, err)
+
+	state, err := mgr.GetSessionState(testSession)
+	require.NoError(t, err)
+	assert.Equal(t, "detached", state)
+}
+
 func TestKill_NonExistent(t *testing.T) {
 	if !isTmuxInstalled() {
 		t.Skip("tmux not installed")
@@ -225,6 +280,26 @@ func TestList(t *testing.T) {
 	assert.Contains(t, sessions, testSession2)
 }
 
+func TestListWorkspaceSessions(t *testing.T) {
+	if !isTmuxInstalled() {
+		t.Skip("tmux not installed")
+	}
+
+	mgr := NewManager()
+	wsSession := mgr.GetSessionName("test-list-ws")
+	otherSession := "test-session-list-other"
+	defer cleanupSession(t, wsSession)
+	defer cleanupSession(t, otherSession)
+
+	require.NoError(t, mgr.Create(wsSession, "/tmp"))
+	require.NoError(t, mgr.Create(otherSession, "/tmp"))
+
+	sessions, err := mgr.ListWorkspaceSessions()
+	require.NoError(t, err)
+	assert.Contains(t, sessions, wsSession)
+	assert.NotContains(t, sessions, otherSession)
+}
+
 func TestList_NoSessions(t *testing.T) {
 	if !isTmuxInstalled() {
 		t.Skip("tmux not installed")
@@ -273,6 +348,54 @@ func TestSendKeys_NonExistent(t *testing.T) {
 	assert.Error(t, err)
 }
 
+func TestPaste(t *testing.T) {
+	if !isTmuxInstalled() {
+		t.Skip("tmux not installed")
+	}
+
+	mgr := NewManager()
+	testSession := "test-session-paste-" + strings.ReplaceAll(t.Name(), "/", "-")
+	defer cleanupSession(t, testSession)
+
+	err := mgr.Create(testSession, "/tmp")
+	require.NoError(t, err)
+
+	err = mgr.Paste(testSession, "line one\nline two")
+	assert.NoError(t, err)
+
+	err = mgr.Paste(testSession+"-missing", "text")
+	assert.Error(t, err)
+}
+
+func TestListClients_Detached(t *testing.T) {
+	if !isTmuxInstalled() {
+		t.Skip("tmux not installed")
+	}
+
+	mgr := NewManager()
+	testSession := "test-session-clients-" + strings.ReplaceAll(t.Name(), "/", "-")
+	defer cleanupSession(t, testSession)
+
+	err := mgr.Create(testSession, "/tmp")
+	require.NoError(t, err)
+
+	// A detached session has no clients
+	clients, err := mgr.ListClients()
+	require.NoError(t, err)
+	for _, client := range clients {
+		assert.NotEqual(t, testSession, client.Session)
+	}
+}
+
+func TestDetachClient_NonExistent(t *testing.T) {
+	if !isTmuxInstalled() {
+		t.Skip("tmux not installed")
+	}
+
+	err := NewManager().DetachClient("/dev/nonexistent-tty")
+	assert.Error(t, err)
+}
+
 func TestGetSessionState(t *testing.T) {
 	if !isTmuxInstalled() {
 		t.Skip("tmux not installed")
@@ -463,3 +586,138 @@ func TestAttach_TMUXEnvDetection(t *testing.T) {
 	// Note: We can't actually test Attach behavior without blocking or tmux setup
 	t.Log("TMUX environment variable detection logic verified")
 }
+
+func TestNewWindow(t *testing.T) {
+	if !isTmuxInstalled() {
+		t.Skip("tmux not installed")
+	}
+
+	mgr := NewManager()
+	testSession := "test-session-window-" + strings.ReplaceAll(t.Name(), "/", "-")
+	defer cleanupSession(t, testSession)
+
+	err := mgr.Create(testSession, "/tmp")
+	require.NoError(t, err)
+
+	err = mgr.NewWindow(testSession, "notes", os.TempDir())
+	require.NoError(t, err)
+
+	windows, err := mgr.ListWindows(testSession)
+	require.NoError(t, err)
+	assert.Len(t, windows, 2)
+	assert.Equal(t, "notes", windows[1])
+}
+
+func TestKillWindow(t *testing.T) {
+	if !isTmuxInstalled() {
+		t.Skip("tmux not installed")
+	}
+
+	mgr := NewManager()
+	testSession := "test-session-killwindow"
+	defer cleanupSession(t, testSession)
+
+	require.NoError(t, mgr.Create(testSession, "/tmp"))
+	require.NoError(t, mgr.NewWindow(testSession, "extra", "/tmp"))
+
+	require.NoError(t, mgr.KillWindow(testSession, "extra"))
+	windows, err := mgr.ListWindows(testSession)
+	require.NoError(t, err)
+	assert.NotContains(t, windows, "extra")
+
+	assert.Error(t, mgr.KillWindow(testSession, "extra"))
+}
+
+func TestNewWindow_NonExistent(t *testing.T) {
+	if !isTmuxInstalled() {
+		t.Skip("tmux not installed")
+	}
+
+	mgr := NewManager()
+	err := mgr.NewWindow("test-session-window-nonexistent", "notes", "/tmp")
+	assert.Error(t, err)
+}
+
+func TestSetEnvironment(t *testing.T) {
+	if !isTmuxInstalled() {
+		t.Skip("tmux not installed")
+	}
+
+	mgr := NewManager()
+	testSession := "test-session-env-" + strings.ReplaceAll(t.Name(), "/", "-")
+	defer cleanupSession(t, testSession)
+
+	err := mgr.Create(testSession, "/tmp")
+	require.NoError(t, err)
+
+	err = mgr.SetEnvironment(testSession, map[string]string{
+		"PORT":    "3001",
+		"API_KEY": "secret value",
+	})
+	require.NoError(t, err)
+
+	value, err := mgr.GetEnvironment(testSession, "PORT")
+	require.NoError(t, err)
+	assert.Equal(t, "3001", value)
+
+	value, err = mgr.GetEnvironment(testSession, "API_KEY")
+	require.NoError(t, err)
+	assert.Equal(t, "secret value", value)
+}
+
+func TestSetEnvironment_NonExistent(t *testing.T) {
+	if !isTmuxInstalled() {
+		t.Skip("tmux not installed")
+	}
+
+	mgr := NewManager()
+	err := mgr.SetEnvironment("test-session-env-nonexistent", map[string]string{"PORT": "3001"})
+	assert.Error(t, err)
+}
+
+func TestListActivity(t *testing.T) {
+	if !isTmuxInstalled() {
+		t.Skip("tmux not installed")
+	}
+
+	mgr := NewManager()
+	testSession := "test-session-activity-" + strings.ReplaceAll(t.Name(), "/", "-")
+	defer cleanupSession(t, testSession)
+
+	before := time.Now().Add(-time.Second)
+	err := mgr.Create(testSession, "/tmp")
+	require.NoError(t, err)
+
+	activity, err := mgr.ListActivity()
+	require.NoError(t, err)
+
+	last, ok := activity[testSession]
+	require.True(t, ok, "session should be listed")
+	assert.False(t, last.Before(before.Truncate(time.Second)))
+	assert.False(t, last.After(time.Now().Add(time.Second)))
+}
+
+func TestManager_WithoutTmux(t *testing.T) {
+	t.Setenv("PATH", t.TempDir())
+	mgr := NewManager()
+
+	exists, err := mgr.Exists("claude-ws-missing")
+	require.NoError(t, err)
+	assert.False(t, exists)
+
+	sessions, err := mgr.List()
+	require.NoError(t, err)
+	assert.Empty(t, sessions)
+
+	activity, err := mgr.ListActivity()
+	require.NoError(t, err)
+	assert.Empty(t, activity)
+
+	attached, err := mgr.ListAttached()
+	require.NoError(t, err)
+	assert.Empty(t, attached)
+
+	clients, err := mgr.ListClients()
+	require.NoError(t, err)
+	assert.Empty(t, clients)
+}
@@ -0,0 +1,26 @@
+package slug
+
+import (
+	"regexp"
+	"strings"
+)
+
+var nonSlugChars = regexp.MustCompile(`[^a-z0-9]+`)
+
+// Make returns prefix followed by the words of text in kebab case, e.g.
+// "123-fix-login-redirect" for prefix "123" and text "Fix login redirect". Only
+// ASCII letters and digits are kept, and words that would make the result longer
+// than maxLength are left off.
+func Make(prefix, text string, maxLength int) string {
+	slug := strings.ToLower(prefix)
+	for _, word := range strings.Split(nonSlugChars.ReplaceAllString(strings.ToLower(text), " "), " ") {
+		if word == "" {
+			continue
+		}
+		if len(slug)+1+len(word) > maxLength {
+			break
+		}
+		slug += "-" + word
+	}
+	return slug
+}
@@ -0,0 +1,26 @@
+package slug
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMake(t *testing.T) {
+	tests := []struct {
+		prefix string
+		text   string
+		want   string
+	}{
+		{"123", "Fix login redirect", "123-fix-login-redirect"},
+		{"PROJ-7", "Fix the login redirect", "proj-7-fix-the-login-redirect"},
+		{"123", "[UI] Crash when: saving (v2.1)!", "123-ui-crash-when-saving-v2-1"},
+		{"123", "Support really long titles without producing unwieldy names", "123-support-really-long-titles-without"},
+		{"123", "!!!", "123"},
+	}
+	for _, tt := range tests {
+		got := Make(tt.prefix, tt.text, 40)
+		assert.Equal(t, tt.want, got, tt.text)
+		assert.LessOrEqual(t, len(got), 40)
+	}
+}
@@ -0,0 +1,229 @@
+package stats
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"sort"
+	"time"
+
+	"github.com/pmossman/claudew/internal/config"
+	"github.com/pmossman/claudew/internal/transcript"
+)
+
+// Week counts the workspaces created and archived in the week starting on Start, a Monday
+type Week struct {
+	Start    time.Time
+	Created  int
+	Archived int
+}
+
+// Weekly counts workspaces created and archived in each of the last weeks weeks, oldest
+// first, the last being the week containing now. Archived workspaces are counted in
+// the week they were last active, which is when they were archived.
+func Weekly(cfg *config.Config, now time.Time, weeks int) []Week {
+	if weeks <= 0 {
+		return nil
+	}
+	first := weekStart(now).AddDate(0, 0, -7*(weeks-1))
+	result := make([]Week, weeks)
+	for i := range result {
+		result[i].Start = first.AddDate(0, 0, 7*i)
+	}
+
+	index := func(t time.Time) int {
+		if t.IsZero() || t.Before(first) {
+			return -1
+		}
+		i := int(weekStart(t).Sub(first).Hours()+12) / (7 * 24) // +12 absorbs DST shifts
+		if i >= weeks {
+			return -1
+		}
+		return i
+	}
+	for _, ws := range cfg.Workspaces {
+		if i := index(ws.CreatedAt.In(now.Location())); i >= 0 {
+			result[i].Created++
+		}
+		if ws.Status != config.StatusArchived {
+			continue
+		}
+		if i := index(ws.LastActive.In(now.Location())); i >= 0 {
+			result[i].Archived++
+		}
+	}
+	return result
+}
+
+// weekStart returns midnight on the Monday of t's week, in t's location
+func weekStart(t time.Time) time.Time {
+	days := (int(t.Weekday()) + 6) % 7 // Monday is 0
+	y, m, d := t.Date()
+	return time.Date(y, m, d-days, 0, 0, 0, 0, t.Location())
+}
+
+// Lifetime is the average age of a group of workspaces
+type Lifetime struct {
+	Count   int
+	Average time.Duration
+}
+
+// Lifetimes returns how long archived workspaces lived, from creation to archiving,
+// and how old the others are as of now. Workspaces without a creation time are skipped.
+func Lifetimes(cfg *config.Config, now time.Time) (archived, active Lifetime) {
+	var archivedTotal, activeTotal time.Duration
+	for _, ws := range cfg.Workspaces {
+		if ws.CreatedAt.IsZero() {
+			continue
+		}
+		if ws.Status == config.StatusArchived {
+			if ws.LastActive.Before(ws.CreatedAt) {
+				continue
+			}
+			archived.Count++
+			archivedTotal += ws.LastActive.Sub(ws.CreatedAt)
+			continue
+		}
+		active.Count++
+		activeTotal += now.Sub(ws.CreatedAt)
+	}
+	if archived.Count > 0 {
+		archived.Average = archivedTotal / time.Duration(archived.Count)
+	}
+	if active.Count > 0 {
+		active.Average = activeTotal / time.Duration(active.Count)
+	}
+	return archived, active
+}
+
+// CloneUsage is how many of a remote's clones are assigned to a workspace
+type CloneUsage struct {
+	Remote string
+	Clones int
+	InUse  int
+}
+
+// Percent returns the share of the remote's clones in use, 0 when it has none
+func (u CloneUsage) Percent() int {
+	if u.Clones == 0 {
+		return 0
+	}
+	return u.InUse * 100 / u.Clones
+}
+
+// CloneUtilization returns the clone usage of every remote, sorted by remote name
+func CloneUtilization(cfg *config.Config) []CloneUsage {
+	byRemote := map[string]*CloneUsage{}
+	for name := range cfg.Remotes {
+		byRemote[name] = &CloneUsage{Remote: name}
+	}
+	for _, clone := range cfg.Clones {
+		usage, ok := byRemote[clone.RemoteName]
+		if !ok {
+			usage = &CloneUsage{Remote: clone.RemoteName}
+			byRemote[clone.RemoteName] = usage
+		}
+		usage.Clones++
+		if clone.InUseBy != "" {
+			usage.InUse++
+		}
+	}
+
+	result := make([]CloneUsage, 0, len(byRemote))
+	for _, usage := range byRemote {
+		result = append(result, *usage)
+	}
+	sort.Slice(result, func(i, j int) bool { return result[i].Remote < result[j].Remote })
+	return result
+}
+
+// Continuation describes how often Claude updated a workspace's continuation.md
+type Continuation struct {
+	Sessions int           // Claude sessions in the workspace
+	Active   time.Duration // total time those sessions ran
+	Updates  int           // writes and edits of continuation.md
+	Last     time.Time     // latest update, zero if none
+}
+
+// Interval returns the average session time between updates, 0 if there were none
+func (c Continuation) Interval() time.Duration {
+	if c.Updates == 0 {
+		return 0
+	}
+	return c.Active / time.Duration(c.Updates)
+}
+
+// ContinuationUpdates reads the transcripts in projectDir for edits of continuationPath.
+// A clone's transcripts cover every workspace that used it, so only sessions that
+// started between from and until (zero for no limit) are counted.
+func ContinuationUpdates(projectDir, continuationPath string, from, until time.Time) (Continuation, error) {
+	sessions, err := transcript.List(projectDir)
+	if err != nil {
+		return Continuation{}, err
+	}
+
+	var c Continuation
+	for _, session := range sessions {
+		if session.Started.Before(from) || (!until.IsZero() && session.Started.After(until)) {
+			continue
+		}
+		c.Sessions++
+		if session.Ended.After(session.Started) {
+			c.Active += session.Ended.Sub(session.Started)
+		}
+		edits, err := transcript.FileEdits(session.Path, continuationPath)
+		if err != nil {
+			continue
+		}
+		c.Updates += len(edits)
+		for _, t := range edits {
+			if t.After(c.Last) {
+				c.Last = t
+			}
+		}
+	}
+	return c, nil
+}
+
+// Activity counts the main-thread entries of the transcripts in projectDir on each of
+// the days days up to and including now's, oldest first, in now's time zone. As with
+// ContinuationUpdates, only sessions that started between from and until (zero for
+// no limit) are counted. Transcripts last written before the first day are skipped
+// unread.
+func Activity(projectDir string, from, until, now time.Time, days int) ([]int, error) {
+	entries, err := os.ReadDir(projectDir)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read transcripts: %w", err)
+	}
+
+	y, m, d := now.Date()
+	first := time.Date(y, m, d-days+1, 0, 0, 0, 0, now.Location())
+	index := make(map[string]int, days)
+	for i := range days {
+		index[first.AddDate(0, 0, i).Format(time.DateOnly)] = i
+	}
+	counts := make([]int, days)
+	for _, e := range entries {
+		if e.IsDir() || filepath.Ext(e.Name()) != ".jsonl" {
+			continue
+		}
+		if info, err := e.Info(); err != nil || info.ModTime().Before(first) {
+			continue
+		}
+		times, err := transcript.Activity(filepath.Join(projectDir, e.Name()))
+		if err != nil || len(times) == 0 {
+			continue
+		}
+		started := slices.MinFunc(times, func(a, b time.Time) int { return a.Compare(b) })
+		if started.Before(from) || (!until.IsZero() && started.After(until)) {
+			continue
+		}
+		for _, t := range times {
+			if i, ok := index[t.In(now.Location()).Format(time.DateOnly)]; ok {
+				counts[i]++
+			}
+		}
+	}
+	return counts, nil
+}
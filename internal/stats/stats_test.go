@@ -0,0 +1,147 @@
+package stats
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/pmossman/claudew/internal/config"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// 2026-03-11 is a Wednesday
+var now = time.Date(2026, 3, 11, 15, 0, 0, 0, time.UTC)
+
+func day(d int) time.Time {
+	return time.Date(2026, 3, d, 10, 0, 0, 0, time.UTC)
+}
+
+func TestWeekly(t *testing.T) {
+	cfg := &config.Config{Workspaces: map[string]*config.Workspace{
+		"old":      {CreatedAt: day(1), LastActive: day(3), Status: config.StatusArchived}, // created on Sunday, before the range
+		"monday":   {CreatedAt: day(2), LastActive: day(10), Status: config.StatusArchived},
+		"thisweek": {CreatedAt: day(9), LastActive: day(11), Status: config.StatusActive},
+		"today":    {CreatedAt: day(11), LastActive: day(11), Status: config.StatusActive},
+	}}
+
+	weeks := Weekly(cfg, now, 2)
+	assert.Equal(t, []Week{
+		{Start: time.Date(2026, 3, 2, 0, 0, 0, 0, time.UTC), Created: 1, Archived: 1},
+		{Start: time.Date(2026, 3, 9, 0, 0, 0, 0, time.UTC), Created: 2, Archived: 1},
+	}, weeks)
+
+	assert.Nil(t, Weekly(cfg, now, 0))
+}
+
+func TestLifetimes(t *testing.T) {
+	cfg := &config.Config{Workspaces: map[string]*config.Workspace{
+		"a":       {CreatedAt: day(1), LastActive: day(3), Status: config.StatusArchived},
+		"b":       {CreatedAt: day(1), LastActive: day(5), Status: config.StatusArchived},
+		"running": {CreatedAt: day(10).Add(5 * time.Hour), Status: config.StatusActive},
+		"legacy":  {Status: config.StatusActive},
+	}}
+
+	archived, active := Lifetimes(cfg, now)
+	assert.Equal(t, Lifetime{Count: 2, Average: 3 * 24 * time.Hour}, archived)
+	assert.Equal(t, Lifetime{Count: 1, Average: 24 * time.Hour}, active)
+}
+
+func TestCloneUtilization(t *testing.T) {
+	cfg := &config.Config{
+		Remotes: map[string]*config.Remote{"api": {}, "web": {}, "docs": {}},
+		Clones: map[string]*config.Clone{
+			"/c/api-1": {RemoteName: "api", InUseBy: "auth"},
+			"/c/api-2": {RemoteName: "api"},
+			"/c/api-3": {RemoteName: "api", InUseBy: "billing"},
+			"/c/web-1": {RemoteName: "web", InUseBy: "ui"},
+		},
+	}
+
+	usage := CloneUtilization(cfg)
+	assert.Equal(t, []CloneUsage{
+		{Remote: "api", Clones: 3, InUse: 2},
+		{Remote: "docs"},
+		{Remote: "web", Clones: 1, InUse: 1},
+	}, usage)
+	assert.Equal(t, 66, usage[0].Percent())
+	assert.Equal(t, 0, usage[1].Percent())
+	assert.Equal(t, 100, usage[2].Percent())
+}
+
+func TestContinuationUpdates(t *testing.T) {
+	dir := t.TempDir()
+	write := func(name string, lines ...string) {
+		require.NoError(t, os.WriteFile(filepath.Join(dir, name), []byte(strings.Join(lines, "\n")+"\n"), 0644))
+	}
+	edit := func(ts, path string) string {
+		return `{"type":"assistant","timestamp":"` + ts + `","message":{"content":[{"type":"tool_use","name":"Edit","input":{"file_path":"` + path + `"}}]}}`
+	}
+	// Before the workspace existed, from another workspace using the clone
+	write("old.jsonl", edit("2026-03-01T09:00:00Z", "/ws/other/continuation.md"))
+	write("s1.jsonl",
+		`{"type":"user","timestamp":"2026-03-05T09:00:00Z","message":{"content":"go"}}`,
+		edit("2026-03-05T09:30:00Z", "/ws/auth/continuation.md"),
+		edit("2026-03-05T10:00:00Z", "/ws/auth/continuation.md"),
+	)
+	write("s2.jsonl",
+		`{"type":"user","timestamp":"2026-03-06T09:00:00Z","message":{"content":"go"}}`,
+		edit("2026-03-06T10:00:00Z", "/ws/auth/continuation.md"),
+	)
+
+	c, err := ContinuationUpdates(dir, "/ws/auth/continuation.md", day(4), time.Time{})
+	require.NoError(t, err)
+	assert.Equal(t, 2, c.Sessions)
+	assert.Equal(t, 2*time.Hour, c.Active)
+	assert.Equal(t, 3, c.Updates)
+	assert.Equal(t, 40*time.Minute, c.Interval())
+	assert.Equal(t, time.Date(2026, 3, 6, 10, 0, 0, 0, time.UTC), c.Last)
+
+	c, err = ContinuationUpdates(dir, "/ws/auth/continuation.md", day(4), day(5))
+	require.NoError(t, err)
+	assert.Equal(t, 1, c.Sessions)
+	assert.Equal(t, 2, c.Updates)
+
+	assert.Equal(t, time.Duration(0), Continuation{}.Interval())
+
+	_, err = ContinuationUpdates(filepath.Join(dir, "missing"), "/ws/auth/continuation.md", day(4), time.Time{})
+	assert.Error(t, err)
+}
+
+func TestActivity(t *testing.T) {
+	dir := t.TempDir()
+	write := func(name string, modTime time.Time, lines ...string) {
+		path := filepath.Join(dir, name)
+		require.NoError(t, os.WriteFile(path, []byte(strings.Join(lines, "\n")+"\n"), 0644))
+		require.NoError(t, os.Chtimes(path, modTime, modTime))
+	}
+	entry := func(typ, ts string) string {
+		return `{"type":"` + typ + `","timestamp":"` + ts + `","message":{"content":"x"}}`
+	}
+	// Last written before the window, so never read
+	write("old.jsonl", day(1), entry("user", "2026-03-10T09:00:00Z"))
+	// Started before the workspace existed
+	write("other.jsonl", day(9), entry("user", "2026-03-05T23:00:00Z"), entry("assistant", "2026-03-09T09:00:00Z"))
+	write("s1.jsonl", day(9),
+		entry("user", "2026-03-08T09:00:00Z"),
+		entry("assistant", "2026-03-08T09:01:00Z"),
+		`{"type":"assistant","isSidechain":true,"timestamp":"2026-03-08T09:02:00Z","message":{"content":"x"}}`,
+		`{"type":"summary","timestamp":"2026-03-08T09:03:00Z"}`,
+		entry("user", "2026-03-09T10:00:00Z"),
+	)
+	write("s2.jsonl", now, entry("user", "2026-03-11T08:00:00Z"), entry("assistant", "2026-03-11T08:05:00Z"))
+
+	counts, err := Activity(dir, day(6), time.Time{}, now, 7)
+	require.NoError(t, err)
+	// Mar 5 through Mar 11
+	assert.Equal(t, []int{0, 0, 0, 2, 1, 0, 2}, counts)
+
+	counts, err = Activity(dir, day(6), day(10), now, 7)
+	require.NoError(t, err)
+	assert.Equal(t, []int{0, 0, 0, 2, 1, 0, 0}, counts, "sessions started after until are left out")
+
+	_, err = Activity(filepath.Join(dir, "missing"), day(6), time.Time{}, now, 7)
+	assert.Error(t, err)
+}
@@ -0,0 +1,100 @@
+package statusline
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+)
+
+// DefaultContextWindow is the context size assumed when Claude doesn't report one
+const DefaultContextWindow = 200000
+
+// Input is the session state Claude Code passes to a statusline command on stdin
+type Input struct {
+	SessionID      string `json:"session_id"`
+	TranscriptPath string `json:"transcript_path"`
+	ContextWindow  struct {
+		Size int `json:"context_window_size"`
+	} `json:"context_window"`
+}
+
+// ParseInput decodes the statusline JSON Claude Code writes to stdin
+func ParseInput(r io.Reader) (Input, error) {
+	var in Input
+	if err := json.NewDecoder(r).Decode(&in); err != nil {
+		return Input{}, fmt.Errorf("failed to parse statusline input: %w", err)
+	}
+	return in, nil
+}
+
+// WindowSize returns the reported context window size, or DefaultContextWindow
+func (in Input) WindowSize() int {
+	if in.ContextWindow.Size > 0 {
+		return in.ContextWindow.Size
+	}
+	return DefaultContextWindow
+}
+
+// transcriptEntry is the subset of a transcript line needed to read token usage
+type transcriptEntry struct {
+	Type        string `json:"type"`
+	IsSidechain bool   `json:"isSidechain"`
+	Message     struct {
+		Usage *struct {
+			InputTokens              int `json:"input_tokens"`
+			CacheCreationInputTokens int `json:"cache_creation_input_tokens"`
+			CacheReadInputTokens     int `json:"cache_read_input_tokens"`
+		} `json:"usage"`
+	} `json:"message"`
+}
+
+// ContextTokens returns the context size of the most recent main-thread assistant turn in a transcript
+func ContextTokens(transcriptPath string) (int, error) {
+	f, err := os.Open(transcriptPath)
+	if err != nil {
+		return 0, fmt.Errorf("failed to open transcript: %w", err)
+	}
+	defer f.Close()
+
+	tokens := 0
+	reader := bufio.NewReader(f)
+	for {
+		line, err := reader.ReadBytes('\n')
+		if len(line) > 0 {
+			var entry transcriptEntry
+			if json.Unmarshal(line, &entry) == nil && entry.Type == "assistant" && !entry.IsSidechain && entry.Message.Usage != nil {
+				u := entry.Message.Usage
+				tokens = u.InputTokens + u.CacheCreationInputTokens + u.CacheReadInputTokens
+			}
+		}
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return 0, fmt.Errorf("failed to read transcript: %w", err)
+		}
+	}
+	return tokens, nil
+}
+
+// Percent returns tokens as a percentage of the window, capped at 100
+func Percent(tokens, windowSize int) int {
+	if windowSize <= 0 {
+		return 0
+	}
+	percent := tokens * 100 / windowSize
+	if percent > 100 {
+		return 100
+	}
+	return percent
+}
+
+// Format renders context usage for a status line, flagging it once it reaches warnAt
+func Format(percent, warnAt int) string {
+	if warnAt > 0 && percent >= warnAt {
+		return fmt.Sprintf("ctx %d%% ⚠", percent)
+	}
+	return fmt.Sprintf("ctx %d%%", percent)
+}
@@ -0,0 +1,64 @@
+package statusline
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseInput(t *testing.T) {
+	in, err := ParseInput(strings.NewReader(`{"session_id":"abc","transcript_path":"/tmp/t.jsonl","model":{"id":"x"}}`))
+	require.NoError(t, err)
+	assert.Equal(t, "abc", in.SessionID)
+	assert.Equal(t, "/tmp/t.jsonl", in.TranscriptPath)
+	assert.Equal(t, DefaultContextWindow, in.WindowSize())
+}
+
+func TestParseInput_ReportedWindowSize(t *testing.T) {
+	in, err := ParseInput(strings.NewReader(`{"context_window":{"context_window_size":1000000}}`))
+	require.NoError(t, err)
+	assert.Equal(t, 1000000, in.WindowSize())
+}
+
+func TestParseInput_Invalid(t *testing.T) {
+	_, err := ParseInput(strings.NewReader("not json"))
+	assert.Error(t, err)
+}
+
+func TestContextTokens(t *testing.T) {
+	transcript := strings.Join([]string{
+		`{"type":"user","message":{"content":"hi"}}`,
+		`{"type":"assistant","message":{"usage":{"input_tokens":10,"cache_creation_input_tokens":100,"cache_read_input_tokens":1000}}}`,
+		`{"type":"assistant","isSidechain":true,"message":{"usage":{"input_tokens":99999}}}`,
+		`{"type":"assistant","message":{"usage":{"input_tokens":20,"cache_creation_input_tokens":200,"cache_read_input_tokens":2000}}}`,
+		`not json`,
+		`{"type":"user","message":{"content":"more"}}`,
+	}, "\n")
+	path := filepath.Join(t.TempDir(), "transcript.jsonl")
+	require.NoError(t, os.WriteFile(path, []byte(transcript), 0644))
+
+	tokens, err := ContextTokens(path)
+	require.NoError(t, err)
+	assert.Equal(t, 2220, tokens)
+}
+
+func TestContextTokens_MissingFile(t *testing.T) {
+	_, err := ContextTokens(filepath.Join(t.TempDir(), "missing.jsonl"))
+	assert.Error(t, err)
+}
+
+func TestPercent(t *testing.T) {
+	assert.Equal(t, 78, Percent(156000, 200000))
+	assert.Equal(t, 100, Percent(250000, 200000))
+	assert.Equal(t, 0, Percent(100, 0))
+}
+
+func TestFormat(t *testing.T) {
+	assert.Equal(t, "ctx 42%", Format(42, 80))
+	assert.Equal(t, "ctx 85% ⚠", Format(85, 80))
+	assert.Equal(t, "ctx 95%", Format(95, 0))
+}
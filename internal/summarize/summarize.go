@@ -0,0 +1,113 @@
+package summarize
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+
+	"github.com/pmossman/claudew/internal/execx"
+)
+
+// MaxSummaryLength is the longest one-line summary that will be saved
+const MaxSummaryLength = 100
+
+// Input is the workspace state a summary is generated from
+type Input struct {
+	Context      string
+	Continuation string
+	GitLog       string
+	Transcript   string // recent conversation excerpt
+}
+
+// BuildSummaryPrompt creates the prompt asking Claude for a one-line summary
+func BuildSummaryPrompt(in Input) string {
+	var b strings.Builder
+	b.WriteString("You are summarizing a software development workspace.\n")
+	b.WriteString("Reply with a single line of at most 80 characters describing the current work.\n")
+	b.WriteString("Do not use quotes, markdown, or a trailing period. Reply with the summary only.\n")
+	writeSections(&b, in)
+	return b.String()
+}
+
+// BuildContextPrompt creates the prompt asking Claude to rewrite context.md
+func BuildContextPrompt(in Input) string {
+	var b strings.Builder
+	b.WriteString("You are maintaining the context.md file for a software development workspace.\n")
+	b.WriteString("Rewrite it so it accurately reflects the current state of the work, using the\n")
+	b.WriteString("continuation notes and recent commits below. Keep the existing markdown structure,\n")
+	b.WriteString("drop information that is no longer relevant, and reply with the file contents only.\n")
+	writeSections(&b, in)
+	return b.String()
+}
+
+// BuildContinuationPrompt creates the prompt asking Claude to write continuation.md
+// from the end of a session, so the work can be picked up in a fresh one
+func BuildContinuationPrompt(in Input) string {
+	var b strings.Builder
+	b.WriteString("You are writing the continuation.md file for a software development workspace\n")
+	b.WriteString("whose Claude session is being stopped. Using the recent conversation and notes below,\n")
+	b.WriteString("write a short prompt that a new session can be started with: what was being worked on,\n")
+	b.WriteString("what is done, what is in progress, and the next steps. Reply with the file contents only.\n")
+	writeSections(&b, in)
+	return b.String()
+}
+
+// writeSections appends the non-empty inputs to the prompt
+func writeSections(b *strings.Builder, in Input) {
+	sections := []struct {
+		title   string
+		content string
+	}{
+		{"context.md", in.Context},
+		{"continuation.md", in.Continuation},
+		{"Recent commits", in.GitLog},
+		{"Recent conversation", in.Transcript},
+	}
+	for _, s := range sections {
+		content := strings.TrimSpace(s.content)
+		if content == "" {
+			continue
+		}
+		fmt.Fprintf(b, "\n## %s\n\n%s\n", s.title, content)
+	}
+}
+
+// Run sends the prompt to Claude in print mode and returns its reply.
+// claudeCommand is the configured claude command, which may include extra flags.
+func Run(claudeCommand, prompt string) (string, error) {
+	cmd := execx.Command("sh", "-c", claudeCommand+" -p")
+	cmd.Stdin = strings.NewReader(prompt)
+
+	var stderr bytes.Buffer
+	cmd.Stderr = &stderr
+
+	output, err := cmd.Output()
+	if err != nil {
+		if msg := strings.TrimSpace(stderr.String()); msg != "" {
+			return "", fmt.Errorf("claude failed: %w: %s", err, msg)
+		}
+		return "", fmt.Errorf("claude failed: %w", err)
+	}
+	return strings.TrimSpace(string(output)), nil
+}
+
+// CleanSummary reduces Claude's reply to a single summary line
+func CleanSummary(output string) string {
+	var line string
+	for _, l := range strings.Split(output, "\n") {
+		if l = strings.TrimSpace(l); l != "" {
+			line = l
+			break
+		}
+	}
+
+	line = strings.TrimLeft(line, "#*- ")
+	line = strings.Trim(line, "\"'`*")
+	line = strings.TrimSuffix(line, ".")
+	line = strings.TrimSpace(line)
+
+	if runes := []rune(line); len(runes) > MaxSummaryLength {
+		line = string(runes[:MaxSummaryLength-3]) + "..."
+	}
+	return line
+}
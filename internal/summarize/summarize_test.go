@@ -0,0 +1,92 @@
+package summarize
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBuildSummaryPrompt(t *testing.T) {
+	prompt := BuildSummaryPrompt(Input{
+		Context:      "# Context\nWorking on auth",
+		Continuation: "Next: add tests",
+		GitLog:       "abc123 Add login endpoint",
+	})
+
+	assert.Contains(t, prompt, "single line")
+	assert.Contains(t, prompt, "## context.md")
+	assert.Contains(t, prompt, "Working on auth")
+	assert.Contains(t, prompt, "## continuation.md")
+	assert.Contains(t, prompt, "## Recent commits")
+	assert.Contains(t, prompt, "abc123 Add login endpoint")
+}
+
+func TestBuildSummaryPrompt_SkipsEmptySections(t *testing.T) {
+	prompt := BuildSummaryPrompt(Input{Context: "Working on auth"})
+
+	assert.Contains(t, prompt, "## context.md")
+	assert.NotContains(t, prompt, "## continuation.md")
+	assert.NotContains(t, prompt, "## Recent commits")
+}
+
+func TestBuildContextPrompt(t *testing.T) {
+	prompt := BuildContextPrompt(Input{Context: "Old context", GitLog: "abc123 Fix bug"})
+
+	assert.Contains(t, prompt, "Rewrite")
+	assert.Contains(t, prompt, "Old context")
+	assert.Contains(t, prompt, "abc123 Fix bug")
+}
+
+func TestBuildContinuationPrompt(t *testing.T) {
+	prompt := BuildContinuationPrompt(Input{
+		Context:    "Working on auth",
+		Transcript: "User: add rate limiting\n\nAssistant: Added middleware, tests pending",
+	})
+
+	assert.Contains(t, prompt, "continuation.md")
+	assert.Contains(t, prompt, "## Recent conversation")
+	assert.Contains(t, prompt, "tests pending")
+	assert.NotContains(t, prompt, "## Recent commits")
+}
+
+func TestCleanSummary(t *testing.T) {
+	tests := []struct {
+		name   string
+		input  string
+		expect string
+	}{
+		{"plain", "Adding OAuth login flow", "Adding OAuth login flow"},
+		{"quoted", `"Adding OAuth login flow."`, "Adding OAuth login flow"},
+		{"markdown", "**Adding OAuth login flow**", "Adding OAuth login flow"},
+		{"heading", "# Adding OAuth login flow", "Adding OAuth login flow"},
+		{"multiline", "\n\nAdding OAuth login flow\nMore details", "Adding OAuth login flow"},
+		{"empty", "  \n ", ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expect, CleanSummary(tt.input))
+		})
+	}
+}
+
+func TestCleanSummary_Truncates(t *testing.T) {
+	summary := CleanSummary(strings.Repeat("x", 200))
+	assert.Len(t, summary, MaxSummaryLength)
+	assert.True(t, strings.HasSuffix(summary, "..."))
+}
+
+func TestRun(t *testing.T) {
+	// Any command that accepts -p and reads stdin stands in for claude
+	output, err := Run("sh -c 'cat' _", "hello from stdin")
+	require.NoError(t, err)
+	assert.Equal(t, "hello from stdin", output)
+}
+
+func TestRun_Failure(t *testing.T) {
+	_, err := Run("sh -c 'echo boom >&2; exit 1' _", "prompt")
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "boom")
+}
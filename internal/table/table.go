@@ -0,0 +1,215 @@
+package table
+
+import (
+	"fmt"
+	"io"
+	"strings"
+	"unicode"
+)
+
+// Ellipsis marks a truncated cell
+const Ellipsis = "…"
+
+// Column describes one table column
+type Column struct {
+	Header string
+	// Max caps the column's width (0 means unlimited). Ignored in wide mode.
+	Max int
+	// KeepEnd truncates from the left, which suits paths
+	KeepEnd bool
+}
+
+// Table is a column-aligned table whose cells may contain ANSI colors
+type Table struct {
+	columns []Column
+	rows    []row
+	// Wide disables truncation so every cell is printed in full
+	Wide bool
+}
+
+// row is either a set of cells or a free-form line printed under the previous row
+type row struct {
+	cells []string
+	line  string
+}
+
+// New creates a table with the given columns
+func New(columns ...Column) *Table {
+	return &Table{columns: columns}
+}
+
+// AddRow appends a row. Missing cells are left blank and extra cells are dropped.
+func (t *Table) AddRow(cells ...string) {
+	r := make([]string, len(t.columns))
+	copy(r, cells)
+	t.rows = append(t.rows, row{cells: r})
+}
+
+// AddLine appends a line printed as-is, such as a detail line under a row
+func (t *Table) AddLine(line string) {
+	t.rows = append(t.rows, row{line: line})
+}
+
+// Render writes the header, a separator and every row to w
+func (t *Table) Render(w io.Writer) {
+	widths := t.widths()
+
+	headers := make([]string, len(t.columns))
+	total := 0
+	for i, col := range t.columns {
+		headers[i] = col.Header
+		total += widths[i]
+	}
+	total += len(t.columns) - 1
+
+	fmt.Fprintln(w, t.formatRow(headers, widths))
+	fmt.Fprintln(w, strings.Repeat("─", total))
+	for _, r := range t.rows {
+		if r.cells == nil {
+			fmt.Fprintln(w, r.line)
+			continue
+		}
+		fmt.Fprintln(w, t.formatRow(r.cells, widths))
+	}
+}
+
+// widths returns each column's display width: its widest cell, capped by Max
+func (t *Table) widths() []int {
+	widths := make([]int, len(t.columns))
+	for i, col := range t.columns {
+		widths[i] = Width(col.Header)
+	}
+	for _, r := range t.rows {
+		for i, cell := range r.cells {
+			widths[i] = max(widths[i], Width(cell))
+		}
+	}
+	if !t.Wide {
+		for i, col := range t.columns {
+			if col.Max > 0 {
+				widths[i] = min(widths[i], max(col.Max, Width(col.Header)))
+			}
+		}
+	}
+	return widths
+}
+
+// formatRow pads (and if needed truncates) each cell to its column width
+func (t *Table) formatRow(cells []string, widths []int) string {
+	var b strings.Builder
+	for i, cell := range cells {
+		if Width(cell) > widths[i] {
+			cell = Truncate(cell, widths[i], t.columns[i].KeepEnd)
+		}
+		b.WriteString(cell)
+		if i < len(cells)-1 {
+			b.WriteString(strings.Repeat(" ", widths[i]-Width(cell)+1))
+		}
+	}
+	return strings.TrimRight(b.String(), " ")
+}
+
+// Width returns the number of terminal cells s occupies, ignoring ANSI escape
+// sequences and counting wide (e.g. CJK and emoji) characters as two
+func Width(s string) int {
+	width := 0
+	inEscape := false
+	for _, c := range s {
+		switch {
+		case c == '\033':
+			inEscape = true
+		case inEscape:
+			if c == 'm' {
+				inEscape = false
+			}
+		default:
+			width += runeWidth(c)
+		}
+	}
+	return width
+}
+
+// Truncate shortens s to at most width cells, marking the cut with an ellipsis.
+// With keepEnd the start is dropped instead of the end. Colors are removed from
+// truncated cells so a cut can't leave an escape sequence unterminated.
+func Truncate(s string, width int, keepEnd bool) string {
+	s = StripANSI(s)
+	if Width(s) <= width {
+		return s
+	}
+	if width <= 0 {
+		return ""
+	}
+
+	runes := []rune(s)
+	budget := width - Width(Ellipsis)
+	kept := 0
+	used := 0
+	for kept < len(runes) {
+		idx := kept
+		if keepEnd {
+			idx = len(runes) - 1 - kept
+		}
+		w := runeWidth(runes[idx])
+		if used+w > budget {
+			break
+		}
+		used += w
+		kept++
+	}
+
+	if keepEnd {
+		return Ellipsis + string(runes[len(runes)-kept:])
+	}
+	return string(runes[:kept]) + Ellipsis
+}
+
+// StripANSI removes ANSI color escape sequences from s
+func StripANSI(s string) string {
+	var b strings.Builder
+	inEscape := false
+	for _, c := range s {
+		switch {
+		case c == '\033':
+			inEscape = true
+		case inEscape:
+			if c == 'm' {
+				inEscape = false
+			}
+		default:
+			b.WriteRune(c)
+		}
+	}
+	return b.String()
+}
+
+// runeWidth returns the number of terminal cells a rune occupies
+func runeWidth(c rune) int {
+	switch {
+	case c == 0 || unicode.Is(unicode.Mn, c) || c == 0x200d || (c >= 0xfe00 && c <= 0xfe0f):
+		// Combining marks, zero-width joiners and variation selectors
+		return 0
+	case isWide(c):
+		return 2
+	default:
+		return 1
+	}
+}
+
+// isWide reports whether c is an East Asian wide/fullwidth character or an emoji
+func isWide(c rune) bool {
+	return (c >= 0x1100 && c <= 0x115f) || // Hangul Jamo
+		(c >= 0x2e80 && c <= 0x303e) || // CJK radicals and punctuation
+		(c >= 0x3041 && c <= 0x33ff) || // Hiragana, Katakana, CJK symbols
+		(c >= 0x3400 && c <= 0x4dbf) || // CJK extension A
+		(c >= 0x4e00 && c <= 0x9fff) || // CJK unified ideographs
+		(c >= 0xa000 && c <= 0xa4cf) || // Yi
+		(c >= 0xac00 && c <= 0xd7a3) || // Hangul syllables
+		(c >= 0xf900 && c <= 0xfaff) || // CJK compatibility ideographs
+		(c >= 0xfe30 && c <= 0xfe4f) || // CJK compatibility forms
+		(c >= 0xff00 && c <= 0xff60) || // Fullwidth forms
+		(c >= 0xffe0 && c <= 0xffe6) ||
+		(c >= 0x1f300 && c <= 0x1f64f) || // Emoji and pictographs
+		(c >= 0x1f900 && c <= 0x1f9ff) ||
+		(c >= 0x20000 && c <= 0x3fffd) // CJK extensions B and beyond
+}
@@ -0,0 +1,73 @@
+package table
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func render(t *Table) string {
+	var b strings.Builder
+	t.Render(&b)
+	return b.String()
+}
+
+func TestRender_AutoWidth(t *testing.T) {
+	tbl := New(Column{Header: "NAME"}, Column{Header: "STATUS"}, Column{Header: "PATH"})
+	tbl.AddRow("alpha", "idle", "/a")
+	tbl.AddRow("b", "active", "/some/longer/path")
+
+	assert.Equal(t, ""+
+		"NAME  STATUS PATH\n"+
+		"──────────────────────────────\n"+
+		"alpha idle   /a\n"+
+		"b     active /some/longer/path\n", render(tbl))
+}
+
+func TestRender_IgnoresANSIInWidth(t *testing.T) {
+	tbl := New(Column{Header: "NAME"}, Column{Header: "X"})
+	tbl.AddRow("\033[32mab\033[0m", "1")
+	tbl.AddRow("abcdef", "2")
+
+	lines := strings.Split(render(tbl), "\n")
+	assert.Equal(t, "\033[32mab\033[0m     1", lines[2])
+	assert.Equal(t, "abcdef 2", lines[3])
+}
+
+func TestRender_TruncatesUnlessWide(t *testing.T) {
+	tbl := New(Column{Header: "NAME", Max: 6}, Column{Header: "PATH", Max: 8, KeepEnd: true})
+	tbl.AddRow("very-long-name", "/home/user/dev/repo")
+
+	lines := strings.Split(render(tbl), "\n")
+	assert.Equal(t, "very-… …ev/repo", lines[2])
+
+	tbl.Wide = true
+	lines = strings.Split(render(tbl), "\n")
+	assert.Equal(t, "very-long-name /home/user/dev/repo", lines[2])
+}
+
+func TestRender_Lines(t *testing.T) {
+	tbl := New(Column{Header: "A"}, Column{Header: "B"})
+	tbl.AddRow("1", "2")
+	tbl.AddLine("  └─ detail")
+
+	assert.Equal(t, "A B\n───\n1 2\n  └─ detail\n", render(tbl))
+}
+
+func TestWidth(t *testing.T) {
+	assert.Equal(t, 5, Width("hello"))
+	assert.Equal(t, 5, Width("\033[31mhello\033[0m"))
+	assert.Equal(t, 4, Width("日本"))
+	assert.Equal(t, 4, Width("café"))
+	assert.Equal(t, 4, Width("cafe\u0301"))
+}
+
+func TestTruncate(t *testing.T) {
+	assert.Equal(t, "hello", Truncate("hello", 5, false))
+	assert.Equal(t, "hel…", Truncate("hello", 4, false))
+	assert.Equal(t, "…llo", Truncate("hello", 4, true))
+	assert.Equal(t, "日…", Truncate("日本語", 4, false))
+	assert.Equal(t, "he…", Truncate("\033[31mhello\033[0m", 3, false))
+	assert.Equal(t, "", Truncate("hello", 0, false))
+}
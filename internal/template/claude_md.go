@@ -4,10 +4,29 @@ import (
 	"fmt"
 	"os"
 	"path/filepath"
+	"regexp"
+	"strconv"
 	"strings"
 	"text/template"
 )
 
+// ClaudeMdVersion is the version of claudeMdTemplate. Bump it whenever the template
+// changes, so "claudew template upgrade" re-renders files written by older versions.
+// Version 1 is the unversioned file written before the block markers existed.
+const ClaudeMdVersion = 2
+
+// The generated instructions sit between these markers, so anything else in the
+// file is left alone when they are re-rendered or removed
+const (
+	claudeMdBegin = "<!-- claudew:begin version=%d -->"
+	claudeMdEnd   = "<!-- claudew:end -->"
+)
+
+var claudeMdBeginPattern = regexp.MustCompile(`<!-- claudew:begin version=(\d+) -->`)
+
+// legacyClaudeMdPrefix starts every CLAUDE.md written before the block markers
+const legacyClaudeMdPrefix = "# Workspace: "
+
 type ClaudeMdData struct {
 	WorkspaceName string
 	WorkspaceDir  string
@@ -116,18 +135,82 @@ func GenerateClaudeMd(workspaceName, workspaceDir, repoPath string) error {
 	}
 
 	var buf strings.Builder
+	fmt.Fprintf(&buf, claudeMdBegin+"\n", ClaudeMdVersion)
 	if err := tmpl.Execute(&buf, data); err != nil {
 		return fmt.Errorf("failed to execute template: %w", err)
 	}
+	buf.WriteString(claudeMdEnd + "\n")
+
+	existing, err := os.ReadFile(claudeMdPath)
+	if err != nil && !os.IsNotExist(err) {
+		return fmt.Errorf("failed to read CLAUDE.md: %w", err)
+	}
 
 	// Write CLAUDE.md file
-	if err := os.WriteFile(claudeMdPath, []byte(buf.String()), 0644); err != nil {
+	if err := os.WriteFile(claudeMdPath, []byte(mergeClaudeMd(string(existing), buf.String())), 0644); err != nil {
 		return fmt.Errorf("failed to write CLAUDE.md: %w", err)
 	}
 
 	return nil
 }
 
+// ClaudeMdVersionAt returns the template version of the repo's CLAUDE.md: 0 if there
+// is none or it wasn't written by claudew, 1 if it predates versioning
+func ClaudeMdVersionAt(repoPath string) (int, error) {
+	content, err := os.ReadFile(filepath.Join(repoPath, ".claude", "CLAUDE.md"))
+	if os.IsNotExist(err) {
+		return 0, nil
+	}
+	if err != nil {
+		return 0, fmt.Errorf("failed to read CLAUDE.md: %w", err)
+	}
+
+	if match := claudeMdBeginPattern.FindStringSubmatch(string(content)); match != nil {
+		version, err := strconv.Atoi(match[1])
+		if err != nil {
+			return 0, fmt.Errorf("invalid CLAUDE.md version %q", match[1])
+		}
+		return version, nil
+	}
+	if strings.HasPrefix(string(content), legacyClaudeMdPrefix) {
+		return 1, nil
+	}
+	return 0, nil
+}
+
+// mergeClaudeMd puts block in place of the generated part of existing, keeping
+// everything else. A legacy file is generated throughout and is replaced whole;
+// a file claudew didn't write keeps its content after the block.
+func mergeClaudeMd(existing, block string) string {
+	before, after, found := splitClaudeMd(existing)
+	switch {
+	case found:
+		return before + block + after
+	case existing == "" || strings.HasPrefix(existing, legacyClaudeMdPrefix):
+		return block
+	default:
+		return block + "\n" + existing
+	}
+}
+
+// splitClaudeMd returns the content before and after the generated block, and
+// whether there is one
+func splitClaudeMd(content string) (before, after string, found bool) {
+	loc := claudeMdBeginPattern.FindStringIndex(content)
+	if loc == nil {
+		return "", "", false
+	}
+	end := strings.Index(content[loc[1]:], claudeMdEnd)
+	if end < 0 {
+		return "", "", false
+	}
+	end += loc[1] + len(claudeMdEnd)
+	if end < len(content) && content[end] == '\n' {
+		end++
+	}
+	return content[:loc[0]], content[end:], true
+}
+
 // EnsureGitignore ensures .claude/ is in the repo's .gitignore
 func EnsureGitignore(repoPath string) error {
 	gitignorePath := filepath.Join(repoPath, ".gitignore")
@@ -158,9 +241,19 @@ func EnsureGitignore(repoPath string) error {
 	return nil
 }
 
-// RemoveClaudeMd removes the CLAUDE.md file from the repo
+// RemoveClaudeMd removes the CLAUDE.md file from the repo, or just its generated
+// block if the file has other content
 func RemoveClaudeMd(repoPath string) error {
 	claudeMdPath := filepath.Join(repoPath, ".claude", "CLAUDE.md")
+	if content, err := os.ReadFile(claudeMdPath); err == nil {
+		before, after, found := splitClaudeMd(string(content))
+		if rest := strings.TrimSpace(before + after); found && rest != "" {
+			if err := os.WriteFile(claudeMdPath, []byte(rest+"\n"), 0644); err != nil {
+				return fmt.Errorf("failed to write CLAUDE.md: %w", err)
+			}
+			return nil
+		}
+	}
 	err := os.Remove(claudeMdPath)
 	if err != nil && !os.IsNotExist(err) {
 		return fmt.Errorf("failed to remove CLAUDE.md: %w", err)
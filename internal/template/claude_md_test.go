@@ -365,3 +365,67 @@ func TestEnsureGitignore_PreservesNewlines(t *testing.T) {
 	lines := strings.Split(strings.TrimSpace(contentStr), "\n")
 	assert.GreaterOrEqual(t, len(lines), 2)
 }
+
+func TestGenerateClaudeMd_PreservesContentOutsideMarkers(t *testing.T) {
+	tmpDir := t.TempDir()
+	repoPath := filepath.Join(tmpDir, "repo")
+	workspaceDir := filepath.Join(tmpDir, "workspace")
+	require.NoError(t, os.MkdirAll(filepath.Join(repoPath, ".claude"), 0755))
+	claudeMd := filepath.Join(repoPath, ".claude", "CLAUDE.md")
+
+	// A CLAUDE.md claudew didn't write keeps its content after the block
+	require.NoError(t, os.WriteFile(claudeMd, []byte("# Team notes\nUse tabs.\n"), 0644))
+	require.NoError(t, GenerateClaudeMd("first-workspace", workspaceDir, repoPath))
+
+	// Re-rendering replaces only the block, keeping edits around it
+	content, err := os.ReadFile(claudeMd)
+	require.NoError(t, err)
+	require.NoError(t, os.WriteFile(claudeMd, []byte("Read me first.\n"+string(content)), 0644))
+	require.NoError(t, GenerateClaudeMd("second-workspace", workspaceDir, repoPath))
+
+	content, err = os.ReadFile(claudeMd)
+	require.NoError(t, err)
+	contentStr := string(content)
+	assert.True(t, strings.HasPrefix(contentStr, "Read me first.\n<!-- claudew:begin version="))
+	assert.True(t, strings.HasSuffix(contentStr, "<!-- claudew:end -->\n\n# Team notes\nUse tabs.\n"))
+	assert.Contains(t, contentStr, "second-workspace")
+	assert.NotContains(t, contentStr, "first-workspace")
+	assert.Equal(t, 1, strings.Count(contentStr, "<!-- claudew:end -->"))
+
+	// Removing leaves only the other content
+	require.NoError(t, RemoveClaudeMd(repoPath))
+	content, err = os.ReadFile(claudeMd)
+	require.NoError(t, err)
+	assert.Equal(t, "Read me first.\n\n# Team notes\nUse tabs.\n", string(content))
+}
+
+func TestClaudeMdVersionAt(t *testing.T) {
+	tmpDir := t.TempDir()
+	repoPath := filepath.Join(tmpDir, "repo")
+	require.NoError(t, os.MkdirAll(filepath.Join(repoPath, ".claude"), 0755))
+	claudeMd := filepath.Join(repoPath, ".claude", "CLAUDE.md")
+
+	version, err := ClaudeMdVersionAt(repoPath)
+	require.NoError(t, err)
+	assert.Equal(t, 0, version)
+
+	// Files from before versioning
+	require.NoError(t, os.WriteFile(claudeMd, []byte("# Workspace: old\n# Repository: /repo\n"), 0644))
+	version, err = ClaudeMdVersionAt(repoPath)
+	require.NoError(t, err)
+	assert.Equal(t, 1, version)
+
+	// Upgrading replaces the legacy file whole
+	require.NoError(t, GenerateClaudeMd("old", tmpDir, repoPath))
+	version, err = ClaudeMdVersionAt(repoPath)
+	require.NoError(t, err)
+	assert.Equal(t, ClaudeMdVersion, version)
+	content, err := os.ReadFile(claudeMd)
+	require.NoError(t, err)
+	assert.NotContains(t, string(content), "# Repository: /repo")
+
+	require.NoError(t, os.WriteFile(claudeMd, []byte("hand-written\n"), 0644))
+	version, err = ClaudeMdVersionAt(repoPath)
+	require.NoError(t, err)
+	assert.Equal(t, 0, version)
+}
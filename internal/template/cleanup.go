@@ -0,0 +1,180 @@
+package template
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+)
+
+// gitignoreBlock is the entry EnsureGitignore appends
+const gitignoreBlock = "# Claude workspace files\n.claude/\n"
+
+// CleanupReport lists what CleanRepo removed, and what it left in .claude because
+// claudew did not create it
+type CleanupReport struct {
+	Removed []string // paths relative to the repo, e.g. ".claude/CLAUDE.md"
+	Kept    []string
+}
+
+// CleanRepo undoes what claudew writes into a repo: the generated CLAUDE.md block
+// and the settings.local.json keys in workDir's .claude directory (removing the files
+// and directory once nothing else is left in them), and the .gitignore entry at the
+// repo root. workDir is the repo itself, or the sub-path a monorepo workspace uses.
+func CleanRepo(repoPath, workDir string) (CleanupReport, error) {
+	var report CleanupReport
+	rel := func(path string) string {
+		if r, err := filepath.Rel(repoPath, path); err == nil {
+			return r
+		}
+		return path
+	}
+
+	claudeDir := filepath.Join(workDir, ".claude")
+	claudeMdPath := filepath.Join(claudeDir, "CLAUDE.md")
+	if _, err := os.Stat(claudeMdPath); err == nil {
+		if version, _ := ClaudeMdVersionAt(workDir); version > 0 {
+			if err := RemoveClaudeMd(workDir); err != nil {
+				return report, err
+			}
+			report.Removed = append(report.Removed, rel(claudeMdPath))
+		}
+	}
+
+	settingsPath := filepath.Join(claudeDir, "settings.local.json")
+	removed, err := cleanSettingsLocal(settingsPath)
+	if err != nil {
+		return report, err
+	}
+	if removed {
+		report.Removed = append(report.Removed, rel(settingsPath))
+	}
+
+	if entries, err := os.ReadDir(claudeDir); err == nil {
+		if len(entries) == 0 {
+			if err := os.Remove(claudeDir); err != nil {
+				return report, fmt.Errorf("failed to remove .claude directory: %w", err)
+			}
+			report.Removed = append(report.Removed, rel(claudeDir)+"/")
+		}
+		for _, entry := range entries {
+			report.Kept = append(report.Kept, rel(filepath.Join(claudeDir, entry.Name())))
+		}
+	}
+
+	removed, err = removeGitignoreEntry(repoPath)
+	if err != nil {
+		return report, err
+	}
+	if removed {
+		report.Removed = append(report.Removed, ".gitignore entry for .claude/")
+	}
+
+	sort.Strings(report.Kept)
+	return report, nil
+}
+
+// cleanSettingsLocal removes the permission entries claudew added and a statusLine
+// pointing at "claudew statusline" from a settings.local.json, deleting the file if
+// nothing else is left in it. Permission entries the user had before are kept. It
+// reports whether anything was removed.
+func cleanSettingsLocal(settingsPath string) (bool, error) {
+	claudeDir := filepath.Dir(settingsPath)
+	added, err := readAddedPermissions(claudeDir)
+	if err != nil {
+		return false, err
+	}
+	if err := writeAddedPermissions(claudeDir, SettingsPermissions{}); err != nil {
+		return false, err
+	}
+
+	if _, err := os.Stat(settingsPath); os.IsNotExist(err) {
+		return false, nil
+	}
+	settings, err := readSettingsLocal(settingsPath)
+	if err != nil {
+		return false, err
+	}
+
+	changed := false
+	if len(added.Allow) > 0 || len(added.Deny) > 0 {
+		block, err := permissionsBlock(settings)
+		if err != nil {
+			return false, err
+		}
+		for key, remove := range map[string][]string{"allow": added.Allow, "deny": added.Deny} {
+			entries, err := permissionList(block, key)
+			if err != nil {
+				return false, err
+			}
+			kept := removeEntries(entries, remove)
+			if len(kept) != len(entries) {
+				changed = true
+			}
+			if err := setPermissionList(block, key, kept); err != nil {
+				return false, err
+			}
+		}
+		if err := setPermissionsBlock(settings, block); err != nil {
+			return false, err
+		}
+	}
+	var statusLine SettingsStatusLine
+	if raw, ok := settings["statusLine"]; ok && json.Unmarshal(raw, &statusLine) == nil && strings.Contains(statusLine.Command, " statusline ") {
+		delete(settings, "statusLine")
+		changed = true
+	}
+
+	if len(settings) == 0 {
+		if err := os.Remove(settingsPath); err != nil {
+			return false, fmt.Errorf("failed to remove settings.local.json: %w", err)
+		}
+		return true, nil
+	}
+	if !changed {
+		return false, nil
+	}
+
+	if err := writeSettingsLocal(settingsPath, settings); err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
+// removeGitignoreEntry takes out the entry EnsureGitignore added, deleting .gitignore
+// if nothing else is left in it. It reports whether the entry was found.
+func removeGitignoreEntry(repoPath string) (bool, error) {
+	gitignorePath := filepath.Join(repoPath, ".gitignore")
+	content, err := os.ReadFile(gitignorePath)
+	if os.IsNotExist(err) {
+		return false, nil
+	}
+	if err != nil {
+		return false, fmt.Errorf("failed to read .gitignore: %w", err)
+	}
+
+	gitignoreStr := string(content)
+	idx := strings.Index(gitignoreStr, gitignoreBlock)
+	if idx < 0 {
+		return false, nil
+	}
+	// EnsureGitignore separates the entry from earlier lines with a blank line
+	start := idx
+	if start > 0 && gitignoreStr[start-1] == '\n' {
+		start--
+	}
+	gitignoreStr = gitignoreStr[:start] + gitignoreStr[idx+len(gitignoreBlock):]
+
+	if strings.TrimSpace(gitignoreStr) == "" {
+		if err := os.Remove(gitignorePath); err != nil {
+			return false, fmt.Errorf("failed to remove .gitignore: %w", err)
+		}
+		return true, nil
+	}
+	if err := os.WriteFile(gitignorePath, []byte(gitignoreStr), 0644); err != nil {
+		return false, fmt.Errorf("failed to write .gitignore: %w", err)
+	}
+	return true, nil
+}
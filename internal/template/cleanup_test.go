@@ -0,0 +1,103 @@
+package template
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCleanRepo(t *testing.T) {
+	repoPath := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(repoPath, ".gitignore"), []byte("node_modules/\n"), 0644))
+
+	require.NoError(t, GenerateClaudeMd("test-workspace", t.TempDir(), repoPath))
+	require.NoError(t, WriteSettingsLocal(repoPath, SettingsPermissions{Allow: []string{"Bash(go test:*)"}}))
+	require.NoError(t, WriteStatusLine(repoPath, "'/usr/local/bin/claudew' statusline 'test-workspace'"))
+	require.NoError(t, EnsureGitignore(repoPath))
+
+	report, err := CleanRepo(repoPath, repoPath)
+	require.NoError(t, err)
+	assert.Equal(t, []string{".claude/CLAUDE.md", ".claude/settings.local.json", ".claude/", ".gitignore entry for .claude/"}, report.Removed)
+	assert.Empty(t, report.Kept)
+
+	assert.NoDirExists(t, filepath.Join(repoPath, ".claude"))
+	content, err := os.ReadFile(filepath.Join(repoPath, ".gitignore"))
+	require.NoError(t, err)
+	assert.Equal(t, "node_modules/\n", string(content))
+}
+
+func TestCleanRepo_KeepsOtherFiles(t *testing.T) {
+	repoPath := t.TempDir()
+	claudeDir := filepath.Join(repoPath, ".claude")
+	require.NoError(t, GenerateClaudeMd("test-workspace", t.TempDir(), repoPath))
+	require.NoError(t, os.WriteFile(filepath.Join(claudeDir, "settings.json"), []byte("{}\n"), 0644))
+	require.NoError(t, os.WriteFile(filepath.Join(claudeDir, "settings.local.json"),
+		[]byte(`{"permissions": {"allow": ["Read"]}, "model": "opus", "statusLine": {"type": "command", "command": "my-statusline"}}`), 0644))
+	require.NoError(t, WriteSettingsLocal(repoPath, SettingsPermissions{Allow: []string{"Read", "Bash(go test:*)"}, Deny: []string{"WebFetch"}}))
+
+	report, err := CleanRepo(repoPath, repoPath)
+	require.NoError(t, err)
+	assert.Equal(t, []string{".claude/CLAUDE.md", ".claude/settings.local.json"}, report.Removed)
+	assert.Equal(t, []string{".claude/settings.json", ".claude/settings.local.json"}, report.Kept)
+
+	// Only what claudew added is taken out of settings.local.json
+	content, err := os.ReadFile(filepath.Join(claudeDir, "settings.local.json"))
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"permissions": {"allow": ["Read"]}, "model": "opus", "statusLine": {"type": "command", "command": "my-statusline"}}`, string(content))
+}
+
+func TestCleanRepo_KeepsUserPermissionsWithoutRecord(t *testing.T) {
+	repoPath := t.TempDir()
+	claudeDir := filepath.Join(repoPath, ".claude")
+	require.NoError(t, os.MkdirAll(claudeDir, 0755))
+	settings := `{"permissions": {"allow": ["Read"]}}`
+	require.NoError(t, os.WriteFile(filepath.Join(claudeDir, "settings.local.json"), []byte(settings), 0644))
+
+	report, err := CleanRepo(repoPath, repoPath)
+	require.NoError(t, err)
+	assert.Empty(t, report.Removed)
+	assert.Equal(t, []string{".claude/settings.local.json"}, report.Kept)
+
+	content, err := os.ReadFile(filepath.Join(claudeDir, "settings.local.json"))
+	require.NoError(t, err)
+	assert.JSONEq(t, settings, string(content))
+}
+
+func TestCleanRepo_KeepsForeignClaudeMd(t *testing.T) {
+	repoPath := t.TempDir()
+	claudeDir := filepath.Join(repoPath, ".claude")
+	require.NoError(t, os.MkdirAll(claudeDir, 0755))
+	require.NoError(t, os.WriteFile(filepath.Join(claudeDir, "CLAUDE.md"), []byte("# Team conventions\n"), 0644))
+
+	report, err := CleanRepo(repoPath, repoPath)
+	require.NoError(t, err)
+	assert.Empty(t, report.Removed)
+	assert.Equal(t, []string{".claude/CLAUDE.md"}, report.Kept)
+	assert.FileExists(t, filepath.Join(claudeDir, "CLAUDE.md"))
+}
+
+func TestCleanRepo_Subdir(t *testing.T) {
+	repoPath := t.TempDir()
+	workDir := filepath.Join(repoPath, "services", "api")
+	require.NoError(t, os.MkdirAll(workDir, 0755))
+	require.NoError(t, GenerateClaudeMd("test-workspace", t.TempDir(), workDir))
+	require.NoError(t, EnsureGitignore(repoPath))
+
+	report, err := CleanRepo(repoPath, workDir)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"services/api/.claude/CLAUDE.md", "services/api/.claude/", ".gitignore entry for .claude/"}, report.Removed)
+
+	// .gitignore only held claudew's entry, so it is gone too
+	assert.NoFileExists(t, filepath.Join(repoPath, ".gitignore"))
+	assert.NoDirExists(t, filepath.Join(workDir, ".claude"))
+}
+
+func TestCleanRepo_NothingToClean(t *testing.T) {
+	report, err := CleanRepo(t.TempDir(), t.TempDir())
+	require.NoError(t, err)
+	assert.Empty(t, report.Removed)
+	assert.Empty(t, report.Kept)
+}
@@ -0,0 +1,245 @@
+package template
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+)
+
+// SettingsPermissions is the permissions block of a Claude Code settings file
+type SettingsPermissions struct {
+	Allow []string `json:"allow,omitempty"`
+	Deny  []string `json:"deny,omitempty"`
+}
+
+// SettingsStatusLine is the statusLine block of a Claude Code settings file
+type SettingsStatusLine struct {
+	Type    string `json:"type"`
+	Command string `json:"command"`
+}
+
+// addedPermissionsFile records, next to settings.local.json, the permission entries
+// claudew added, so they can be replaced or removed without touching the user's own
+const addedPermissionsFile = ".claudew-permissions.json"
+
+// WriteSettingsLocal merges a permission preset into the repo's
+// .claude/settings.local.json. Entries added by an earlier preset are taken out first,
+// entries the user already has are left as they are, and any other settings in the
+// file are preserved.
+func WriteSettingsLocal(repoPath string, perms SettingsPermissions) error {
+	claudeDir := filepath.Join(repoPath, ".claude")
+	if err := os.MkdirAll(claudeDir, 0755); err != nil {
+		return fmt.Errorf("failed to create .claude directory: %w", err)
+	}
+	settingsPath := filepath.Join(claudeDir, "settings.local.json")
+	settings, err := readSettingsLocal(settingsPath)
+	if err != nil {
+		return err
+	}
+	previous, err := readAddedPermissions(claudeDir)
+	if err != nil {
+		return err
+	}
+
+	block, err := permissionsBlock(settings)
+	if err != nil {
+		return err
+	}
+	var added SettingsPermissions
+	for _, list := range []struct {
+		key      string
+		previous []string
+		preset   []string
+		added    *[]string
+	}{
+		{"allow", previous.Allow, perms.Allow, &added.Allow},
+		{"deny", previous.Deny, perms.Deny, &added.Deny},
+	} {
+		entries, err := permissionList(block, list.key)
+		if err != nil {
+			return err
+		}
+		entries = removeEntries(entries, list.previous)
+		for _, entry := range list.preset {
+			if !containsEntry(entries, entry) {
+				entries = append(entries, entry)
+				*list.added = append(*list.added, entry)
+			}
+		}
+		if err := setPermissionList(block, list.key, entries); err != nil {
+			return err
+		}
+	}
+	if err := setPermissionsBlock(settings, block); err != nil {
+		return err
+	}
+
+	if err := writeSettingsLocal(settingsPath, settings); err != nil {
+		return err
+	}
+	return writeAddedPermissions(claudeDir, added)
+}
+
+// WriteStatusLine points the repo's Claude statusline at the given command,
+// preserving any other settings already in the file
+func WriteStatusLine(repoPath, command string) error {
+	return updateSettingsLocal(repoPath, "statusLine", SettingsStatusLine{Type: "command", Command: command})
+}
+
+// updateSettingsLocal sets a single top-level key in .claude/settings.local.json
+func updateSettingsLocal(repoPath, key string, value interface{}) error {
+	claudeDir := filepath.Join(repoPath, ".claude")
+	settingsPath := filepath.Join(claudeDir, "settings.local.json")
+
+	if err := os.MkdirAll(claudeDir, 0755); err != nil {
+		return fmt.Errorf("failed to create .claude directory: %w", err)
+	}
+
+	settings, err := readSettingsLocal(settingsPath)
+	if err != nil {
+		return err
+	}
+	raw, err := json.Marshal(value)
+	if err != nil {
+		return fmt.Errorf("failed to marshal settings: %w", err)
+	}
+	settings[key] = raw
+	return writeSettingsLocal(settingsPath, settings)
+}
+
+// readSettingsLocal reads a settings.local.json, keeping each top-level value as is.
+// A missing file reads as empty.
+func readSettingsLocal(settingsPath string) (map[string]json.RawMessage, error) {
+	settings := make(map[string]json.RawMessage)
+	data, err := os.ReadFile(settingsPath)
+	if err != nil && !os.IsNotExist(err) {
+		return nil, fmt.Errorf("failed to read settings.local.json: %w", err)
+	}
+	if len(data) > 0 {
+		if err := json.Unmarshal(data, &settings); err != nil {
+			return nil, fmt.Errorf("failed to parse settings.local.json: %w", err)
+		}
+	}
+	return settings, nil
+}
+
+// writeSettingsLocal writes settings to a settings.local.json
+func writeSettingsLocal(settingsPath string, settings map[string]json.RawMessage) error {
+	out, err := json.MarshalIndent(settings, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to marshal settings: %w", err)
+	}
+	if err := os.WriteFile(settingsPath, append(out, '\n'), 0644); err != nil {
+		return fmt.Errorf("failed to write settings.local.json: %w", err)
+	}
+	return nil
+}
+
+// permissionsBlock returns the permissions object of the settings, keeping keys
+// other than allow and deny (such as ask or defaultMode) as they are
+func permissionsBlock(settings map[string]json.RawMessage) (map[string]json.RawMessage, error) {
+	block := make(map[string]json.RawMessage)
+	if raw, ok := settings["permissions"]; ok {
+		if err := json.Unmarshal(raw, &block); err != nil {
+			return nil, fmt.Errorf("failed to parse permissions in settings.local.json: %w", err)
+		}
+	}
+	return block, nil
+}
+
+// setPermissionsBlock stores the permissions object, dropping it once it is empty
+func setPermissionsBlock(settings, block map[string]json.RawMessage) error {
+	if len(block) == 0 {
+		delete(settings, "permissions")
+		return nil
+	}
+	raw, err := json.Marshal(block)
+	if err != nil {
+		return fmt.Errorf("failed to marshal permissions: %w", err)
+	}
+	settings["permissions"] = raw
+	return nil
+}
+
+// permissionList returns one of the permission lists, e.g. "allow"
+func permissionList(block map[string]json.RawMessage, key string) ([]string, error) {
+	var entries []string
+	if raw, ok := block[key]; ok {
+		if err := json.Unmarshal(raw, &entries); err != nil {
+			return nil, fmt.Errorf("failed to parse permissions.%s in settings.local.json: %w", key, err)
+		}
+	}
+	return entries, nil
+}
+
+// setPermissionList stores one of the permission lists, dropping it once it is empty
+func setPermissionList(block map[string]json.RawMessage, key string, entries []string) error {
+	if len(entries) == 0 {
+		delete(block, key)
+		return nil
+	}
+	raw, err := json.Marshal(entries)
+	if err != nil {
+		return fmt.Errorf("failed to marshal permissions: %w", err)
+	}
+	block[key] = raw
+	return nil
+}
+
+// readAddedPermissions reads the permission entries claudew last added to the
+// settings.local.json in claudeDir. A missing record means none.
+func readAddedPermissions(claudeDir string) (SettingsPermissions, error) {
+	var added SettingsPermissions
+	data, err := os.ReadFile(filepath.Join(claudeDir, addedPermissionsFile))
+	if os.IsNotExist(err) {
+		return added, nil
+	}
+	if err != nil {
+		return added, fmt.Errorf("failed to read %s: %w", addedPermissionsFile, err)
+	}
+	if err := json.Unmarshal(data, &added); err != nil {
+		return added, fmt.Errorf("failed to parse %s: %w", addedPermissionsFile, err)
+	}
+	return added, nil
+}
+
+// writeAddedPermissions records the permission entries claudew added, removing the
+// record when there are none
+func writeAddedPermissions(claudeDir string, added SettingsPermissions) error {
+	path := filepath.Join(claudeDir, addedPermissionsFile)
+	if len(added.Allow) == 0 && len(added.Deny) == 0 {
+		if err := os.Remove(path); err != nil && !os.IsNotExist(err) {
+			return fmt.Errorf("failed to remove %s: %w", addedPermissionsFile, err)
+		}
+		return nil
+	}
+	out, err := json.MarshalIndent(added, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to marshal permissions: %w", err)
+	}
+	if err := os.WriteFile(path, append(out, '\n'), 0644); err != nil {
+		return fmt.Errorf("failed to write %s: %w", addedPermissionsFile, err)
+	}
+	return nil
+}
+
+// removeEntries returns entries without any of those in remove
+func removeEntries(entries, remove []string) []string {
+	var kept []string
+	for _, entry := range entries {
+		if !containsEntry(remove, entry) {
+			kept = append(kept, entry)
+		}
+	}
+	return kept
+}
+
+func containsEntry(entries []string, entry string) bool {
+	for _, e := range entries {
+		if e == entry {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,102 @@
+package template
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWriteSettingsLocal(t *testing.T) {
+	repoPath := t.TempDir()
+
+	err := WriteSettingsLocal(repoPath, SettingsPermissions{
+		Allow: []string{"Bash(go test:*)"},
+		Deny:  []string{"WebFetch"},
+	})
+	require.NoError(t, err)
+
+	data, err := os.ReadFile(filepath.Join(repoPath, ".claude", "settings.local.json"))
+	require.NoError(t, err)
+
+	var settings struct {
+		Permissions SettingsPermissions `json:"permissions"`
+	}
+	require.NoError(t, json.Unmarshal(data, &settings))
+	assert.Equal(t, []string{"Bash(go test:*)"}, settings.Permissions.Allow)
+	assert.Equal(t, []string{"WebFetch"}, settings.Permissions.Deny)
+}
+
+func TestWriteSettingsLocal_PreservesOtherSettings(t *testing.T) {
+	repoPath := t.TempDir()
+	settingsPath := filepath.Join(repoPath, ".claude", "settings.local.json")
+	require.NoError(t, os.MkdirAll(filepath.Dir(settingsPath), 0755))
+	require.NoError(t, os.WriteFile(settingsPath, []byte(`{"model": "opus", "permissions": {"allow": ["Read"], "ask": ["Bash"]}}`), 0644))
+
+	err := WriteSettingsLocal(repoPath, SettingsPermissions{Deny: []string{"WebFetch"}})
+	require.NoError(t, err)
+
+	data, err := os.ReadFile(settingsPath)
+	require.NoError(t, err)
+
+	var settings map[string]interface{}
+	require.NoError(t, json.Unmarshal(data, &settings))
+	assert.Equal(t, "opus", settings["model"])
+
+	perms := settings["permissions"].(map[string]interface{})
+	assert.Equal(t, []interface{}{"Read"}, perms["allow"])
+	assert.Equal(t, []interface{}{"Bash"}, perms["ask"])
+	assert.Equal(t, []interface{}{"WebFetch"}, perms["deny"])
+}
+
+func TestWriteSettingsLocal_ReplacesPreviousPreset(t *testing.T) {
+	repoPath := t.TempDir()
+	settingsPath := filepath.Join(repoPath, ".claude", "settings.local.json")
+	require.NoError(t, os.MkdirAll(filepath.Dir(settingsPath), 0755))
+	require.NoError(t, os.WriteFile(settingsPath, []byte(`{"permissions": {"allow": ["Read"]}}`), 0644))
+
+	require.NoError(t, WriteSettingsLocal(repoPath, SettingsPermissions{Allow: []string{"Read", "Bash(go test:*)"}}))
+	require.NoError(t, WriteSettingsLocal(repoPath, SettingsPermissions{Deny: []string{"WebFetch"}}))
+
+	data, err := os.ReadFile(settingsPath)
+	require.NoError(t, err)
+	var settings struct {
+		Permissions SettingsPermissions `json:"permissions"`
+	}
+	require.NoError(t, json.Unmarshal(data, &settings))
+	// The user's own "Read" survives both presets; the first preset's addition is gone
+	assert.Equal(t, []string{"Read"}, settings.Permissions.Allow)
+	assert.Equal(t, []string{"WebFetch"}, settings.Permissions.Deny)
+}
+
+func TestWriteSettingsLocal_InvalidExistingFile(t *testing.T) {
+	repoPath := t.TempDir()
+	settingsPath := filepath.Join(repoPath, ".claude", "settings.local.json")
+	require.NoError(t, os.MkdirAll(filepath.Dir(settingsPath), 0755))
+	require.NoError(t, os.WriteFile(settingsPath, []byte(`not json`), 0644))
+
+	err := WriteSettingsLocal(repoPath, SettingsPermissions{})
+	assert.Error(t, err)
+}
+
+func TestWriteStatusLine(t *testing.T) {
+	repoPath := t.TempDir()
+
+	require.NoError(t, WriteSettingsLocal(repoPath, SettingsPermissions{Deny: []string{"WebFetch"}}))
+	require.NoError(t, WriteStatusLine(repoPath, "/usr/local/bin/claudew statusline my-ws"))
+
+	data, err := os.ReadFile(filepath.Join(repoPath, ".claude", "settings.local.json"))
+	require.NoError(t, err)
+
+	var settings struct {
+		Permissions SettingsPermissions `json:"permissions"`
+		StatusLine  SettingsStatusLine  `json:"statusLine"`
+	}
+	require.NoError(t, json.Unmarshal(data, &settings))
+	assert.Equal(t, []string{"WebFetch"}, settings.Permissions.Deny)
+	assert.Equal(t, "command", settings.StatusLine.Type)
+	assert.Equal(t, "/usr/local/bin/claudew statusline my-ws", settings.StatusLine.Command)
+}
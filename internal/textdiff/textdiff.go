@@ -0,0 +1,269 @@
+package textdiff
+
+import (
+	"fmt"
+	"strings"
+	"unicode/utf8"
+)
+
+// Op is the kind of change a line represents
+type Op int
+
+const (
+	Equal Op = iota
+	Delete
+	Insert
+)
+
+// Line is one line of a diff
+type Line struct {
+	Op   Op
+	Text string
+}
+
+// maxLCSCells caps the size of the table Lines builds, which takes len(a)*len(b)
+// ints once common leading and trailing lines are set aside. Bigger changes are
+// shown as the changed region deleted and inserted whole.
+const maxLCSCells = 4_000_000
+
+// Lines diffs a against b line by line using a longest common subsequence
+func Lines(a, b []string) []Line {
+	// Lines both sides start or end with are equal; only the middle needs diffing
+	head := 0
+	for head < len(a) && head < len(b) && a[head] == b[head] {
+		head++
+	}
+	tail := 0
+	for tail < len(a)-head && tail < len(b)-head && a[len(a)-1-tail] == b[len(b)-1-tail] {
+		tail++
+	}
+
+	var lines []Line
+	for _, text := range a[:head] {
+		lines = append(lines, Line{Equal, text})
+	}
+	lines = append(lines, lcsLines(a[head:len(a)-tail], b[head:len(b)-tail])...)
+	for _, text := range a[len(a)-tail:] {
+		lines = append(lines, Line{Equal, text})
+	}
+	return lines
+}
+
+// lcsLines diffs a against b with a longest common subsequence table, or replaces a
+// with b whole when the table would exceed maxLCSCells
+func lcsLines(a, b []string) []Line {
+	var lines []Line
+	if (len(a)+1)*(len(b)+1) > maxLCSCells {
+		for _, text := range a {
+			lines = append(lines, Line{Delete, text})
+		}
+		for _, text := range b {
+			lines = append(lines, Line{Insert, text})
+		}
+		return lines
+	}
+
+	// lcs[i][j] is the LCS length of a[i:] and b[j:]
+	lcs := make([][]int, len(a)+1)
+	for i := range lcs {
+		lcs[i] = make([]int, len(b)+1)
+	}
+	for i := len(a) - 1; i >= 0; i-- {
+		for j := len(b) - 1; j >= 0; j-- {
+			if a[i] == b[j] {
+				lcs[i][j] = lcs[i+1][j+1] + 1
+			} else {
+				lcs[i][j] = max(lcs[i+1][j], lcs[i][j+1])
+			}
+		}
+	}
+
+	i, j := 0, 0
+	for i < len(a) && j < len(b) {
+		switch {
+		case a[i] == b[j]:
+			lines = append(lines, Line{Equal, a[i]})
+			i++
+			j++
+		case lcs[i+1][j] >= lcs[i][j+1]:
+			lines = append(lines, Line{Delete, a[i]})
+			i++
+		default:
+			lines = append(lines, Line{Insert, b[j]})
+			j++
+		}
+	}
+	for ; i < len(a); i++ {
+		lines = append(lines, Line{Delete, a[i]})
+	}
+	for ; j < len(b); j++ {
+		lines = append(lines, Line{Insert, b[j]})
+	}
+	return lines
+}
+
+// Unified returns a unified diff of a and b with the given lines of context,
+// or "" if they are equal
+func Unified(a, b, fromName, toName string, context int) string {
+	diff := Lines(splitLines(a), splitLines(b))
+
+	var out strings.Builder
+	for _, h := range hunks(diff, context) {
+		if out.Len() == 0 {
+			fmt.Fprintf(&out, "--- %s\n+++ %s\n", fromName, toName)
+		}
+		fmt.Fprintf(&out, "@@ -%s +%s @@\n", hunkRange(h.fromStart, h.fromCount), hunkRange(h.toStart, h.toCount))
+		for _, line := range h.lines {
+			out.WriteString(prefix(line.Op) + line.Text + "\n")
+		}
+	}
+	return out.String()
+}
+
+// SideBySide returns a and b in two columns of the given total width, marking changed
+// lines with | (changed), < (only in a) or > (only in b), or "" if they are equal
+func SideBySide(a, b string, width int) string {
+	diff := Lines(splitLines(a), splitLines(b))
+	if !hasChanges(diff) {
+		return ""
+	}
+
+	col := (width - 3) / 2
+	if col < 10 {
+		col = 10
+	}
+
+	var out strings.Builder
+	row := func(left, mark, right string) {
+		line := fmt.Sprintf("%s %s %s", pad(left, col), mark, truncate(right, col))
+		out.WriteString(strings.TrimRight(line, " ") + "\n")
+	}
+
+	for i := 0; i < len(diff); {
+		if diff[i].Op == Equal {
+			row(diff[i].Text, " ", diff[i].Text)
+			i++
+			continue
+		}
+
+		// Pair up a run of deletions with the insertions that follow it
+		var deleted, inserted []string
+		for ; i < len(diff) && diff[i].Op == Delete; i++ {
+			deleted = append(deleted, diff[i].Text)
+		}
+		for ; i < len(diff) && diff[i].Op == Insert; i++ {
+			inserted = append(inserted, diff[i].Text)
+		}
+		for k := 0; k < max(len(deleted), len(inserted)); k++ {
+			switch {
+			case k < len(deleted) && k < len(inserted):
+				row(deleted[k], "|", inserted[k])
+			case k < len(deleted):
+				row(deleted[k], "<", "")
+			default:
+				row("", ">", inserted[k])
+			}
+		}
+	}
+	return out.String()
+}
+
+type hunk struct {
+	fromStart, fromCount int
+	toStart, toCount     int
+	lines                []Line
+}
+
+// hunks groups changed lines with up to context unchanged lines around them,
+// merging groups whose context would overlap
+func hunks(diff []Line, context int) []hunk {
+	var result []hunk
+
+	for i := 0; i < len(diff); {
+		if diff[i].Op == Equal {
+			i++
+			continue
+		}
+
+		// Extend the group while the next change is within reach of this one's context
+		start := max(i-context, 0)
+		last := i
+		for j := i + 1; j < len(diff) && j-last <= 2*context+1; j++ {
+			if diff[j].Op != Equal {
+				last = j
+			}
+		}
+		end := min(last+context+1, len(diff))
+
+		h := hunk{fromStart: 1, toStart: 1, lines: diff[start:end]}
+		for _, line := range diff[:start] {
+			if line.Op != Insert {
+				h.fromStart++
+			}
+			if line.Op != Delete {
+				h.toStart++
+			}
+		}
+		for _, line := range h.lines {
+			if line.Op != Insert {
+				h.fromCount++
+			}
+			if line.Op != Delete {
+				h.toCount++
+			}
+		}
+		result = append(result, h)
+		i = end
+	}
+	return result
+}
+
+func hunkRange(start, count int) string {
+	if count == 0 {
+		start--
+	}
+	if count == 1 {
+		return fmt.Sprintf("%d", start)
+	}
+	return fmt.Sprintf("%d,%d", start, count)
+}
+
+func prefix(op Op) string {
+	switch op {
+	case Delete:
+		return "-"
+	case Insert:
+		return "+"
+	}
+	return " "
+}
+
+func hasChanges(diff []Line) bool {
+	for _, line := range diff {
+		if line.Op != Equal {
+			return true
+		}
+	}
+	return false
+}
+
+// splitLines splits text into lines, ignoring a trailing newline
+func splitLines(text string) []string {
+	if text == "" {
+		return nil
+	}
+	return strings.Split(strings.TrimSuffix(text, "\n"), "\n")
+}
+
+func truncate(s string, width int) string {
+	if utf8.RuneCountInString(s) <= width {
+		return s
+	}
+	runes := []rune(s)
+	return string(runes[:width-1]) + "…"
+}
+
+func pad(s string, width int) string {
+	s = truncate(s, width)
+	return s + strings.Repeat(" ", width-utf8.RuneCountInString(s))
+}
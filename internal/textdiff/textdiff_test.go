@@ -0,0 +1,98 @@
+package textdiff
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLines(t *testing.T) {
+	diff := Lines([]string{"a", "b", "c"}, []string{"a", "x", "c", "d"})
+	assert.Equal(t, []Line{
+		{Equal, "a"},
+		{Delete, "b"},
+		{Insert, "x"},
+		{Equal, "c"},
+		{Insert, "d"},
+	}, diff)
+}
+
+func TestLines_LargeChangeReplacedWhole(t *testing.T) {
+	var a, b []string
+	for i := 0; i < 3000; i++ {
+		a = append(a, fmt.Sprintf("old %d", i))
+		b = append(b, fmt.Sprintf("new %d", i))
+	}
+	a = append([]string{"same"}, append(a, "end")...)
+	b = append([]string{"same"}, append(b, "end")...)
+
+	diff := Lines(a, b)
+	require.Len(t, diff, 6002)
+	assert.Equal(t, Line{Equal, "same"}, diff[0])
+	assert.Equal(t, Line{Delete, "old 0"}, diff[1])
+	assert.Equal(t, Line{Insert, "new 0"}, diff[3001])
+	assert.Equal(t, Line{Equal, "end"}, diff[6001])
+}
+
+func TestUnified_Equal(t *testing.T) {
+	assert.Empty(t, Unified("same\n", "same\n", "a", "b", 3))
+	assert.Empty(t, Unified("", "", "a", "b", 3))
+}
+
+func TestUnified(t *testing.T) {
+	a := "1\n2\n3\n4\n5\n6\n7\n8\n9\n10\n"
+	b := "1\n2\nthree\n4\n5\n6\n7\n8\n9\n10\n11\n"
+
+	want := `--- a/context.md
++++ b/context.md
+@@ -1,6 +1,6 @@
+ 1
+ 2
+-3
++three
+ 4
+ 5
+ 6
+@@ -8,3 +8,4 @@
+ 8
+ 9
+ 10
++11
+`
+	assert.Equal(t, want, Unified(a, b, "a/context.md", "b/context.md", 3))
+}
+
+func TestUnified_MergesNearbyChanges(t *testing.T) {
+	a := "1\n2\n3\n4\n5\n"
+	b := "x\n2\n3\n4\ny\n"
+
+	want := `--- a
++++ b
+@@ -1,5 +1,5 @@
+-1
++x
+ 2
+ 3
+ 4
+-5
++y
+`
+	assert.Equal(t, want, Unified(a, b, "a", "b", 3))
+}
+
+func TestUnified_FromEmpty(t *testing.T) {
+	want := "--- a\n+++ b\n@@ -0,0 +1,2 @@\n+new\n+lines\n"
+	assert.Equal(t, want, Unified("", "new\nlines\n", "a", "b", 3))
+}
+
+func TestSideBySide(t *testing.T) {
+	assert.Empty(t, SideBySide("a\n", "a\n", 40))
+
+	out := SideBySide("keep\nold\ngone\n", "keep\nnew\n", 23)
+	want := "keep         keep\n" +
+		"old        | new\n" +
+		"gone       <\n"
+	assert.Equal(t, want, out)
+}
@@ -0,0 +1,235 @@
+package transcript
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/pmossman/claudew/internal/table"
+)
+
+// maxToolInputChars caps how much of a tool call's input Render shows
+const maxToolInputChars = 300
+
+// usage is the token accounting Claude Code records on assistant messages
+type usage struct {
+	InputTokens              int `json:"input_tokens"`
+	OutputTokens             int `json:"output_tokens"`
+	CacheCreationInputTokens int `json:"cache_creation_input_tokens"`
+	CacheReadInputTokens     int `json:"cache_read_input_tokens"`
+}
+
+// Session summarizes one transcript file
+type Session struct {
+	Path         string
+	ID           string // file name without .jsonl, Claude Code's session ID
+	Started      time.Time
+	Ended        time.Time
+	Messages     int // main-thread user and assistant text messages
+	InputTokens  int // including cache reads and writes
+	OutputTokens int
+	FirstPrompt  string // first user message, on one line
+}
+
+// List summarizes every transcript in a project directory, newest first
+func List(projectDir string) ([]Session, error) {
+	entries, err := os.ReadDir(projectDir)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read transcripts: %w", err)
+	}
+
+	var sessions []Session
+	for _, e := range entries {
+		if e.IsDir() || filepath.Ext(e.Name()) != ".jsonl" {
+			continue
+		}
+		session, err := Summarize(filepath.Join(projectDir, e.Name()))
+		if err != nil {
+			continue
+		}
+		sessions = append(sessions, session)
+	}
+
+	sort.Slice(sessions, func(i, j int) bool {
+		return sessions[i].Started.After(sessions[j].Started)
+	})
+	return sessions, nil
+}
+
+// Summarize reads a transcript's time span, message count and token usage. A message
+// split across several lines carries its usage on each, so usage is counted once per
+// message ID. Files without timestamps fall back to their modification time.
+func Summarize(path string) (Session, error) {
+	session := Session{Path: path, ID: strings.TrimSuffix(filepath.Base(path), ".jsonl")}
+	counted := map[string]bool{}
+	err := eachEntry(path, func(e entry) {
+		if ts, err := time.Parse(time.RFC3339Nano, e.Timestamp); err == nil {
+			if session.Started.IsZero() || ts.Before(session.Started) {
+				session.Started = ts
+			}
+			if ts.After(session.Ended) {
+				session.Ended = ts
+			}
+		}
+		if e.IsSidechain || (e.Type != "user" && e.Type != "assistant") {
+			return
+		}
+		if text := contentText(e.Message.Content); text != "" {
+			session.Messages++
+			if session.FirstPrompt == "" && e.Type == "user" {
+				session.FirstPrompt = strings.Join(strings.Fields(text), " ")
+			}
+		}
+		if u := e.Message.Usage; u != nil && (e.Message.ID == "" || !counted[e.Message.ID]) {
+			counted[e.Message.ID] = true
+			session.InputTokens += u.InputTokens + u.CacheCreationInputTokens + u.CacheReadInputTokens
+			session.OutputTokens += u.OutputTokens
+		}
+	})
+	if err != nil {
+		return Session{}, err
+	}
+
+	if session.Started.IsZero() {
+		if info, err := os.Stat(path); err == nil {
+			session.Started = info.ModTime()
+			session.Ended = info.ModTime()
+		}
+	}
+	return session, nil
+}
+
+// Render formats a transcript for reading: every main-thread message under a
+// timestamped heading, with the tools Claude called and their (truncated) input
+func Render(path string) (string, error) {
+	var b strings.Builder
+	err := eachEntry(path, func(e entry) {
+		if e.IsSidechain || (e.Type != "user" && e.Type != "assistant") {
+			return
+		}
+		text := contentText(e.Message.Content)
+		tools := toolCalls(e.Message.Content)
+		if text == "" && len(tools) == 0 {
+			return
+		}
+
+		label := "User"
+		if e.Type == "assistant" {
+			label = "Assistant"
+		}
+		if ts, err := time.Parse(time.RFC3339Nano, e.Timestamp); err == nil {
+			label += " · " + ts.Local().Format("2006-01-02 15:04:05")
+		}
+		fmt.Fprintf(&b, "── %s\n", label)
+		if text != "" {
+			fmt.Fprintf(&b, "%s\n", text)
+		}
+		for _, tool := range tools {
+			fmt.Fprintf(&b, "  → %s\n", tool)
+		}
+		b.WriteString("\n")
+	})
+	return b.String(), err
+}
+
+// toolCalls returns the tool_use blocks of message content as "Name {input}" lines
+func toolCalls(raw json.RawMessage) []string {
+	var blocks []struct {
+		Type  string          `json:"type"`
+		Name  string          `json:"name"`
+		Input json.RawMessage `json:"input"`
+	}
+	if json.Unmarshal(raw, &blocks) != nil {
+		return nil
+	}
+	var calls []string
+	for _, block := range blocks {
+		if block.Type != "tool_use" {
+			continue
+		}
+		input := strings.TrimSpace(string(block.Input))
+		input = table.Truncate(input, maxToolInputChars, false)
+		calls = append(calls, strings.TrimSpace(block.Name+" "+input))
+	}
+	return calls
+}
+
+// eachEntry calls fn for every parseable line of a transcript
+func eachEntry(path string, fn func(entry)) error {
+	f, err := os.Open(path)
+	if err != nil {
+		return fmt.Errorf("failed to open transcript: %w", err)
+	}
+	defer f.Close()
+
+	reader := bufio.NewReader(f)
+	for {
+		line, err := reader.ReadBytes('\n')
+		if len(line) > 0 {
+			var e entry
+			if json.Unmarshal(line, &e) == nil {
+				fn(e)
+			}
+		}
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return fmt.Errorf("failed to read transcript: %w", err)
+		}
+	}
+}
+
+// editTools are the tools Claude Code changes files with
+var editTools = map[string]bool{"Write": true, "Edit": true, "MultiEdit": true}
+
+// FileEdits returns when the main thread of a transcript wrote or edited filePath,
+// oldest first
+func FileEdits(path, filePath string) ([]time.Time, error) {
+	target := filepath.Clean(filePath)
+	var edits []time.Time
+	err := eachEntry(path, func(e entry) {
+		if e.IsSidechain || e.Type != "assistant" {
+			return
+		}
+		var blocks []struct {
+			Type  string `json:"type"`
+			Name  string `json:"name"`
+			Input struct {
+				FilePath string `json:"file_path"`
+			} `json:"input"`
+		}
+		if json.Unmarshal(e.Message.Content, &blocks) != nil {
+			return
+		}
+		ts, _ := time.Parse(time.RFC3339Nano, e.Timestamp)
+		for _, block := range blocks {
+			if block.Type == "tool_use" && editTools[block.Name] && block.Input.FilePath != "" &&
+				filepath.Clean(block.Input.FilePath) == target {
+				edits = append(edits, ts)
+			}
+		}
+	})
+	return edits, err
+}
+
+// Activity returns when the main thread of a transcript had a user or assistant
+// entry, tool calls and results included, in file order
+func Activity(path string) ([]time.Time, error) {
+	var times []time.Time
+	err := eachEntry(path, func(e entry) {
+		if e.IsSidechain || (e.Type != "user" && e.Type != "assistant") {
+			return
+		}
+		if ts, err := time.Parse(time.RFC3339Nano, e.Timestamp); err == nil {
+			times = append(times, ts)
+		}
+	})
+	return times, err
+}
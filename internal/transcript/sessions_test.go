@@ -0,0 +1,92 @@
+package transcript
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/pmossman/claudew/internal/table"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func writeTranscript(t *testing.T, dir, name string, lines ...string) string {
+	t.Helper()
+	path := filepath.Join(dir, name)
+	require.NoError(t, os.WriteFile(path, []byte(strings.Join(lines, "\n")+"\n"), 0644))
+	return path
+}
+
+func TestSummarize(t *testing.T) {
+	path := writeTranscript(t, t.TempDir(), "abc-123.jsonl",
+		`{"type":"summary","summary":"ignored"}`,
+		`{"type":"user","timestamp":"2026-03-01T09:00:00.000Z","message":{"role":"user","content":"Fix the\nlogin bug"}}`,
+		`{"type":"assistant","timestamp":"2026-03-01T09:00:05.000Z","message":{"id":"msg_1","role":"assistant","content":[{"type":"text","text":"Looking"}],"usage":{"input_tokens":10,"cache_read_input_tokens":100,"output_tokens":5}}}`,
+		`{"type":"assistant","timestamp":"2026-03-01T09:00:06.000Z","message":{"id":"msg_1","role":"assistant","content":[{"type":"tool_use","name":"Read","input":{}}],"usage":{"input_tokens":10,"cache_read_input_tokens":100,"output_tokens":5}}}`,
+		`{"type":"assistant","isSidechain":true,"timestamp":"2026-03-01T09:10:00.000Z","message":{"id":"msg_2","content":[{"type":"text","text":"subagent"}],"usage":{"input_tokens":1,"output_tokens":1}}}`,
+		`{"type":"assistant","timestamp":"2026-03-01T09:30:00.000Z","message":{"id":"msg_3","role":"assistant","content":[{"type":"text","text":"Fixed it."}],"usage":{"input_tokens":20,"cache_creation_input_tokens":30,"output_tokens":15}}}`,
+	)
+
+	session, err := Summarize(path)
+	require.NoError(t, err)
+	assert.Equal(t, "abc-123", session.ID)
+	assert.Equal(t, time.Date(2026, 3, 1, 9, 0, 0, 0, time.UTC), session.Started)
+	assert.Equal(t, time.Date(2026, 3, 1, 9, 30, 0, 0, time.UTC), session.Ended)
+	assert.Equal(t, 3, session.Messages)
+	// msg_1 is counted once, and sidechain usage is skipped
+	assert.Equal(t, 160, session.InputTokens)
+	assert.Equal(t, 20, session.OutputTokens)
+	assert.Equal(t, "Fix the login bug", session.FirstPrompt)
+}
+
+func TestList(t *testing.T) {
+	dir := t.TempDir()
+	writeTranscript(t, dir, "old.jsonl", `{"type":"user","timestamp":"2026-01-01T00:00:00Z","message":{"content":"old"}}`)
+	writeTranscript(t, dir, "new.jsonl", `{"type":"user","timestamp":"2026-02-01T00:00:00Z","message":{"content":"new"}}`)
+	writeTranscript(t, dir, "notes.txt", "not a transcript")
+
+	sessions, err := List(dir)
+	require.NoError(t, err)
+	require.Len(t, sessions, 2)
+	assert.Equal(t, "new", sessions[0].ID)
+	assert.Equal(t, "old", sessions[1].ID)
+
+	_, err = List(filepath.Join(dir, "missing"))
+	assert.Error(t, err)
+}
+
+func TestRender(t *testing.T) {
+	path := writeTranscript(t, t.TempDir(), "t.jsonl",
+		`{"type":"user","message":{"role":"user","content":"Run the tests"}}`,
+		`{"type":"assistant","message":{"role":"assistant","content":[{"type":"text","text":"Running them"},{"type":"tool_use","name":"Bash","input":{"command":"go test ./..."}}]}}`,
+		`{"type":"user","message":{"role":"user","content":[{"type":"tool_result","content":"ok"}]}}`,
+		`{"type":"assistant","message":{"role":"assistant","content":[{"type":"tool_use","name":"Write","input":{"content":"`+strings.Repeat("x", 400)+`"}}]}}`,
+	)
+
+	out, err := Render(path)
+	require.NoError(t, err)
+	assert.Contains(t, out, "── User\nRun the tests\n")
+	assert.Contains(t, out, "── Assistant\nRunning them\n  → Bash {\"command\":\"go test ./...\"}\n")
+	assert.NotContains(t, out, "ok\n")
+	assert.Contains(t, out, "  → Write {\"content\":\"xxx")
+	assert.Contains(t, out, table.Ellipsis+"\n")
+}
+
+func TestFileEdits(t *testing.T) {
+	path := writeTranscript(t, t.TempDir(), "t.jsonl",
+		`{"type":"assistant","timestamp":"2026-03-01T09:00:00Z","message":{"content":[{"type":"tool_use","name":"Read","input":{"file_path":"/ws/a/continuation.md"}}]}}`,
+		`{"type":"assistant","timestamp":"2026-03-01T09:10:00Z","message":{"content":[{"type":"tool_use","name":"Write","input":{"file_path":"/ws/a/continuation.md"}}]}}`,
+		`{"type":"assistant","timestamp":"2026-03-01T09:20:00Z","message":{"content":[{"type":"tool_use","name":"Edit","input":{"file_path":"/ws/b/continuation.md"}}]}}`,
+		`{"type":"assistant","isSidechain":true,"timestamp":"2026-03-01T09:25:00Z","message":{"content":[{"type":"tool_use","name":"Edit","input":{"file_path":"/ws/a/continuation.md"}}]}}`,
+		`{"type":"assistant","timestamp":"2026-03-01T09:30:00Z","message":{"content":[{"type":"text","text":"Saving"},{"type":"tool_use","name":"Edit","input":{"file_path":"/ws/a/./continuation.md"}}]}}`,
+	)
+
+	edits, err := FileEdits(path, "/ws/a/continuation.md")
+	require.NoError(t, err)
+	assert.Equal(t, []time.Time{
+		time.Date(2026, 3, 1, 9, 10, 0, 0, time.UTC),
+		time.Date(2026, 3, 1, 9, 30, 0, 0, time.UTC),
+	}, edits)
+}
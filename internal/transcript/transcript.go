@@ -0,0 +1,139 @@
+package transcript
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"unicode/utf8"
+)
+
+var projectDirPattern = regexp.MustCompile(`[^A-Za-z0-9]`)
+
+// Message is one user or assistant turn from a Claude Code transcript
+type Message struct {
+	Role string
+	Text string
+}
+
+// ProjectDir returns the directory Claude Code stores transcripts in for sessions
+// started in workDir, e.g. ~/.claude/projects/-Users-me-repo
+func ProjectDir(claudeHome, workDir string) string {
+	return filepath.Join(claudeHome, "projects", projectDirPattern.ReplaceAllString(workDir, "-"))
+}
+
+// Latest returns the most recently modified transcript in a project directory
+func Latest(projectDir string) (string, error) {
+	entries, err := os.ReadDir(projectDir)
+	if err != nil {
+		return "", fmt.Errorf("failed to read transcripts: %w", err)
+	}
+
+	var latest string
+	var latestMod int64
+	for _, entry := range entries {
+		if entry.IsDir() || filepath.Ext(entry.Name()) != ".jsonl" {
+			continue
+		}
+		info, err := entry.Info()
+		if err != nil {
+			continue
+		}
+		if mod := info.ModTime().UnixNano(); latest == "" || mod > latestMod {
+			latest = filepath.Join(projectDir, entry.Name())
+			latestMod = mod
+		}
+	}
+
+	if latest == "" {
+		return "", fmt.Errorf("no transcripts in %s", projectDir)
+	}
+	return latest, nil
+}
+
+// entry is the subset of a transcript line needed to read messages
+type entry struct {
+	Type        string `json:"type"`
+	IsSidechain bool   `json:"isSidechain"`
+	Timestamp   string `json:"timestamp"`
+	Message     struct {
+		ID      string          `json:"id"`
+		Role    string          `json:"role"`
+		Content json.RawMessage `json:"content"`
+		Usage   *usage          `json:"usage"`
+	} `json:"message"`
+}
+
+// Read returns the main-thread user and assistant text messages of a transcript, in order.
+// Tool calls, tool results and subagent turns are skipped.
+func Read(path string) ([]Message, error) {
+	var messages []Message
+	err := eachEntry(path, func(e entry) {
+		if !e.IsSidechain && (e.Type == "user" || e.Type == "assistant") {
+			if text := contentText(e.Message.Content); text != "" {
+				messages = append(messages, Message{Role: e.Type, Text: text})
+			}
+		}
+	})
+	if err != nil {
+		return nil, err
+	}
+	return messages, nil
+}
+
+// contentText extracts the text from message content, which is either a string or a list of blocks
+func contentText(raw json.RawMessage) string {
+	var s string
+	if json.Unmarshal(raw, &s) == nil {
+		return strings.TrimSpace(s)
+	}
+
+	var blocks []struct {
+		Type string `json:"type"`
+		Text string `json:"text"`
+	}
+	if json.Unmarshal(raw, &blocks) != nil {
+		return ""
+	}
+	var parts []string
+	for _, block := range blocks {
+		if block.Type == "text" && strings.TrimSpace(block.Text) != "" {
+			parts = append(parts, strings.TrimSpace(block.Text))
+		}
+	}
+	return strings.Join(parts, "\n")
+}
+
+// Excerpt renders the most recent messages as "User:"/"Assistant:" text of at most maxChars,
+// dropping older messages first
+func Excerpt(messages []Message, maxChars int) string {
+	var kept []string
+	total := 0
+	for i := len(messages) - 1; i >= 0; i-- {
+		label := "User"
+		if messages[i].Role == "assistant" {
+			label = "Assistant"
+		}
+		text := fmt.Sprintf("%s: %s", label, messages[i].Text)
+		if total+len(text) > maxChars {
+			if len(kept) == 0 && maxChars > 3 {
+				// Always keep part of the latest message
+				tail := text[len(text)-maxChars+3:]
+				for len(tail) > 0 && !utf8.RuneStart(tail[0]) {
+					tail = tail[1:] // don't start inside a multi-byte character
+				}
+				kept = append(kept, "..."+tail)
+			}
+			break
+		}
+		kept = append(kept, text)
+		total += len(text) + 2
+	}
+
+	for i, j := 0, len(kept)-1; i < j; i, j = i+1, j-1 {
+		kept[i], kept[j] = kept[j], kept[i]
+	}
+	return strings.Join(kept, "\n\n")
+}
@@ -0,0 +1,88 @@
+package transcript
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+	"unicode/utf8"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestProjectDir(t *testing.T) {
+	assert.Equal(t, "/home/me/.claude/projects/-Users-me-dev-my-app-1", ProjectDir("/home/me/.claude", "/Users/me/dev/my_app.1"))
+}
+
+func TestLatest(t *testing.T) {
+	dir := t.TempDir()
+	older := filepath.Join(dir, "older.jsonl")
+	newer := filepath.Join(dir, "newer.jsonl")
+	require.NoError(t, os.WriteFile(older, []byte("{}"), 0644))
+	require.NoError(t, os.WriteFile(newer, []byte("{}"), 0644))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "notes.txt"), []byte(""), 0644))
+	past := time.Now().Add(-time.Hour)
+	require.NoError(t, os.Chtimes(older, past, past))
+
+	latest, err := Latest(dir)
+	require.NoError(t, err)
+	assert.Equal(t, newer, latest)
+}
+
+func TestLatest_Empty(t *testing.T) {
+	_, err := Latest(t.TempDir())
+	assert.Error(t, err)
+
+	_, err = Latest(filepath.Join(t.TempDir(), "missing"))
+	assert.Error(t, err)
+}
+
+func TestRead(t *testing.T) {
+	lines := []string{
+		`{"type":"summary","summary":"ignored"}`,
+		`{"type":"user","message":{"role":"user","content":"Fix the login bug"}}`,
+		`{"type":"assistant","message":{"role":"assistant","content":[{"type":"text","text":"Looking at auth.go"},{"type":"tool_use","name":"Read"}]}}`,
+		`{"type":"user","message":{"role":"user","content":[{"type":"tool_result","content":"file contents"}]}}`,
+		`{"type":"assistant","isSidechain":true,"message":{"content":[{"type":"text","text":"subagent"}]}}`,
+		`not json`,
+		`{"type":"assistant","message":{"role":"assistant","content":[{"type":"text","text":"Fixed it."}]}}`,
+	}
+	path := filepath.Join(t.TempDir(), "t.jsonl")
+	require.NoError(t, os.WriteFile(path, []byte(strings.Join(lines, "\n")), 0644))
+
+	messages, err := Read(path)
+	require.NoError(t, err)
+	assert.Equal(t, []Message{
+		{Role: "user", Text: "Fix the login bug"},
+		{Role: "assistant", Text: "Looking at auth.go"},
+		{Role: "assistant", Text: "Fixed it."},
+	}, messages)
+}
+
+func TestExcerpt(t *testing.T) {
+	messages := []Message{
+		{Role: "user", Text: "first question"},
+		{Role: "assistant", Text: "first answer"},
+		{Role: "user", Text: "second question"},
+	}
+
+	assert.Equal(t, "User: first question\n\nAssistant: first answer\n\nUser: second question", Excerpt(messages, 1000))
+
+	// Older messages are dropped first
+	assert.Equal(t, "Assistant: first answer\n\nUser: second question", Excerpt(messages, 50))
+
+	// The latest message is truncated rather than dropped
+	excerpt := Excerpt(messages, 10)
+	assert.Len(t, excerpt, 10)
+	assert.True(t, strings.HasPrefix(excerpt, "..."))
+	assert.True(t, strings.HasSuffix(excerpt, "uestion"))
+
+	// A cut never splits a multi-byte character
+	excerpt = Excerpt([]Message{{Role: "user", Text: "naïve café ☕☕"}}, 10)
+	assert.True(t, utf8.ValidString(excerpt))
+	assert.LessOrEqual(t, len(excerpt), 10)
+
+	assert.Equal(t, "", Excerpt(nil, 100))
+}
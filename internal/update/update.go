@@ -0,0 +1,205 @@
+package update
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// DefaultAPIURL is the GitHub API endpoint for claudew's repository
+const DefaultAPIURL = "https://api.github.com/repos/pmossman/claude-workspace"
+
+// ChecksumsAssetName is the release asset listing SHA-256 sums of the binaries
+const ChecksumsAssetName = "checksums.txt"
+
+// Asset is a downloadable file attached to a release
+type Asset struct {
+	Name        string `json:"name"`
+	DownloadURL string `json:"browser_download_url"`
+}
+
+// Release is a published GitHub release
+type Release struct {
+	TagName string  `json:"tag_name"`
+	Assets  []Asset `json:"assets"`
+}
+
+// Client talks to the GitHub releases API
+type Client struct {
+	APIURL string
+	HTTP   *http.Client
+}
+
+// NewClient creates a client for claudew's GitHub releases
+func NewClient() *Client {
+	return &Client{
+		APIURL: DefaultAPIURL,
+		HTTP:   &http.Client{Timeout: 60 * time.Second},
+	}
+}
+
+// Latest fetches the most recent published release
+func (c *Client) Latest() (*Release, error) {
+	resp, err := c.HTTP.Get(c.APIURL + "/releases/latest")
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch latest release: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("failed to fetch latest release: GitHub returned status %d", resp.StatusCode)
+	}
+
+	var release Release
+	if err := json.NewDecoder(resp.Body).Decode(&release); err != nil {
+		return nil, fmt.Errorf("failed to parse release: %w", err)
+	}
+	return &release, nil
+}
+
+// Download saves the contents of url to dest
+func (c *Client) Download(url, dest string) error {
+	resp, err := c.HTTP.Get(url)
+	if err != nil {
+		return fmt.Errorf("failed to download %s: %w", url, err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("failed to download %s: status %d", url, resp.StatusCode)
+	}
+
+	f, err := os.Create(dest)
+	if err != nil {
+		return fmt.Errorf("failed to create %s: %w", dest, err)
+	}
+	defer f.Close()
+
+	if _, err := io.Copy(f, resp.Body); err != nil {
+		return fmt.Errorf("failed to write %s: %w", dest, err)
+	}
+	return nil
+}
+
+// Asset returns the release asset with the given name
+func (r *Release) Asset(name string) (*Asset, error) {
+	for i := range r.Assets {
+		if r.Assets[i].Name == name {
+			return &r.Assets[i], nil
+		}
+	}
+	return nil, fmt.Errorf("release %s has no asset named %s", r.TagName, name)
+}
+
+// AssetName returns the name of the release binary for a platform, e.g. claudew_darwin_arm64
+func AssetName(goos, goarch string) string {
+	return fmt.Sprintf("claudew_%s_%s", goos, goarch)
+}
+
+// ParseChecksums parses sha256sum output ("<hex>  <name>" per line) into a map of name to hash
+func ParseChecksums(data string) map[string]string {
+	sums := make(map[string]string)
+	for _, line := range strings.Split(data, "\n") {
+		fields := strings.Fields(line)
+		if len(fields) != 2 {
+			continue
+		}
+		sums[strings.TrimPrefix(fields[1], "*")] = strings.ToLower(fields[0])
+	}
+	return sums
+}
+
+// FileSHA256 returns the hex SHA-256 of a file
+func FileSHA256(path string) (string, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return "", fmt.Errorf("failed to open %s: %w", path, err)
+	}
+	defer f.Close()
+
+	h := sha256.New()
+	if _, err := io.Copy(h, f); err != nil {
+		return "", fmt.Errorf("failed to hash %s: %w", path, err)
+	}
+	return hex.EncodeToString(h.Sum(nil)), nil
+}
+
+// CompareVersions compares two dotted versions such as "v1.2.3", returning -1, 0 or 1.
+// Missing or non-numeric components count as 0.
+func CompareVersions(a, b string) int {
+	pa := strings.Split(strings.TrimPrefix(a, "v"), ".")
+	pb := strings.Split(strings.TrimPrefix(b, "v"), ".")
+	for i := 0; i < len(pa) || i < len(pb); i++ {
+		na, nb := versionPart(pa, i), versionPart(pb, i)
+		if na < nb {
+			return -1
+		}
+		if na > nb {
+			return 1
+		}
+	}
+	return 0
+}
+
+// versionPattern matches a dotted version number such as "2.0.14"
+var versionPattern = regexp.MustCompile(`\d+(\.\d+)+`)
+
+// ExtractVersion returns the first dotted version number in a program's --version
+// output, e.g. "2.0.14" from "2.0.14 (Claude Code)", or "" if there is none
+func ExtractVersion(output string) string {
+	return versionPattern.FindString(output)
+}
+
+// versionPart returns the numeric value of the i-th version component, ignoring suffixes like "-rc1"
+func versionPart(parts []string, i int) int {
+	if i >= len(parts) {
+		return 0
+	}
+	digits := parts[i]
+	if idx := strings.IndexFunc(digits, func(r rune) bool { return r < '0' || r > '9' }); idx >= 0 {
+		digits = digits[:idx]
+	}
+	n, _ := strconv.Atoi(digits)
+	return n
+}
+
+// ReplaceExecutable atomically replaces the binary at target with src
+func ReplaceExecutable(target, src string) error {
+	// Stage next to the target so the final rename stays on one filesystem
+	staged := filepath.Join(filepath.Dir(target), "."+filepath.Base(target)+".new")
+
+	in, err := os.Open(src)
+	if err != nil {
+		return fmt.Errorf("failed to open %s: %w", src, err)
+	}
+	defer in.Close()
+
+	out, err := os.OpenFile(staged, os.O_CREATE|os.O_WRONLY|os.O_TRUNC, 0755)
+	if err != nil {
+		return fmt.Errorf("failed to stage new binary: %w", err)
+	}
+	if _, err := io.Copy(out, in); err != nil {
+		out.Close()
+		os.Remove(staged)
+		return fmt.Errorf("failed to stage new binary: %w", err)
+	}
+	if err := out.Close(); err != nil {
+		os.Remove(staged)
+		return fmt.Errorf("failed to stage new binary: %w", err)
+	}
+
+	if err := os.Rename(staged, target); err != nil {
+		os.Remove(staged)
+		return fmt.Errorf("failed to replace %s: %w", target, err)
+	}
+	return nil
+}
@@ -0,0 +1,114 @@
+package update
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestClient_Latest(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/releases/latest", r.URL.Path)
+		fmt.Fprint(w, `{"tag_name":"v1.4.0","assets":[{"name":"claudew_linux_amd64","browser_download_url":"https://example.com/bin"}]}`)
+	}))
+	defer server.Close()
+
+	client := &Client{APIURL: server.URL, HTTP: server.Client()}
+	release, err := client.Latest()
+	require.NoError(t, err)
+	assert.Equal(t, "v1.4.0", release.TagName)
+
+	asset, err := release.Asset("claudew_linux_amd64")
+	require.NoError(t, err)
+	assert.Equal(t, "https://example.com/bin", asset.DownloadURL)
+
+	_, err = release.Asset("claudew_plan9_386")
+	assert.Error(t, err)
+}
+
+func TestClient_LatestError(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNotFound)
+	}))
+	defer server.Close()
+
+	client := &Client{APIURL: server.URL, HTTP: server.Client()}
+	_, err := client.Latest()
+	assert.Error(t, err)
+}
+
+func TestClient_Download(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, "binary contents")
+	}))
+	defer server.Close()
+
+	client := &Client{APIURL: server.URL, HTTP: server.Client()}
+	dest := filepath.Join(t.TempDir(), "claudew")
+	require.NoError(t, client.Download(server.URL+"/bin", dest))
+
+	data, err := os.ReadFile(dest)
+	require.NoError(t, err)
+	assert.Equal(t, "binary contents", string(data))
+}
+
+func TestAssetName(t *testing.T) {
+	assert.Equal(t, "claudew_darwin_arm64", AssetName("darwin", "arm64"))
+}
+
+func TestParseChecksums(t *testing.T) {
+	sums := ParseChecksums("ABC123  claudew_linux_amd64\ndef456 *claudew_darwin_arm64\n\ngarbage\n")
+	assert.Equal(t, "abc123", sums["claudew_linux_amd64"])
+	assert.Equal(t, "def456", sums["claudew_darwin_arm64"])
+	assert.Len(t, sums, 2)
+}
+
+func TestFileSHA256(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "f")
+	require.NoError(t, os.WriteFile(path, []byte("hello"), 0644))
+
+	sum, err := FileSHA256(path)
+	require.NoError(t, err)
+	assert.Equal(t, "2cf24dba5fb0a30e26e83b2ac5b9e29e1b161e5c1fa7425e73043362938b9824", sum)
+}
+
+func TestCompareVersions(t *testing.T) {
+	assert.Equal(t, 0, CompareVersions("v1.2.3", "1.2.3"))
+	assert.Equal(t, -1, CompareVersions("v1.2.3", "v1.10.0"))
+	assert.Equal(t, 1, CompareVersions("v2.0", "v1.9.9"))
+	assert.Equal(t, 0, CompareVersions("v1.2", "v1.2.0"))
+	assert.Equal(t, -1, CompareVersions("v1.2.3-rc1", "v1.2.4"))
+}
+
+func TestExtractVersion(t *testing.T) {
+	assert.Equal(t, "2.0.14", ExtractVersion("2.0.14 (Claude Code)\n"))
+	assert.Equal(t, "1.2.3", ExtractVersion("claude version v1.2.3"))
+	assert.Equal(t, "", ExtractVersion("command not found"))
+}
+
+func TestReplaceExecutable(t *testing.T) {
+	dir := t.TempDir()
+	target := filepath.Join(dir, "claudew")
+	src := filepath.Join(dir, "download")
+	require.NoError(t, os.WriteFile(target, []byte("old"), 0755))
+	require.NoError(t, os.WriteFile(src, []byte("new"), 0644))
+
+	require.NoError(t, ReplaceExecutable(target, src))
+
+	data, err := os.ReadFile(target)
+	require.NoError(t, err)
+	assert.Equal(t, "new", string(data))
+
+	info, err := os.Stat(target)
+	require.NoError(t, err)
+	assert.Equal(t, os.FileMode(0755), info.Mode().Perm())
+
+	_, err = os.Stat(filepath.Join(dir, ".claudew.new"))
+	assert.True(t, os.IsNotExist(err))
+}
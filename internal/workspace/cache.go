@@ -0,0 +1,107 @@
+package workspace
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+)
+
+// menuCacheFile holds the cached workspace summaries, in the workspaces directory
+const menuCacheFile = ".menu-cache.json"
+
+// cachedSummary is a summary file's text as of its modification time and size
+type cachedSummary struct {
+	ModTime time.Time `json:"mod_time"`
+	Size    int64     `json:"size"`
+	Summary string    `json:"summary"`
+}
+
+// fileCache remembers the summaries read through a Manager, so menus that show
+// every workspace only stat the files that haven't changed. Larger files like
+// context.md are only needed for the selected workspace, so they are never cached.
+type fileCache struct {
+	entries map[string]cachedSummary
+	dirty   bool
+}
+
+// EnableCache makes GetSummary serve unchanged summaries from the cache saved by
+// SaveCache. A missing or corrupt cache starts out empty.
+func (m *Manager) EnableCache() {
+	m.cache = &fileCache{entries: map[string]cachedSummary{}}
+	data, err := os.ReadFile(filepath.Join(m.baseDir, menuCacheFile))
+	if err != nil {
+		return
+	}
+	if err := json.Unmarshal(data, &m.cache.entries); err != nil {
+		m.cache.entries = map[string]cachedSummary{}
+	}
+}
+
+// SaveCache writes the cache back if anything was re-read, dropping the entries
+// of workspaces that no longer exist
+func (m *Manager) SaveCache() error {
+	if m.cache == nil || !m.cache.dirty {
+		return nil
+	}
+	for path := range m.cache.entries {
+		if _, err := os.Stat(filepath.Dir(path)); os.IsNotExist(err) {
+			delete(m.cache.entries, path)
+		}
+	}
+
+	data, err := json.Marshal(m.cache.entries)
+	if err != nil {
+		return fmt.Errorf("failed to encode menu cache: %w", err)
+	}
+	// Previews run concurrently, so replace the file rather than writing it in place
+	tmp, err := os.CreateTemp(m.baseDir, menuCacheFile+".*")
+	if err != nil {
+		return fmt.Errorf("failed to write menu cache: %w", err)
+	}
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		os.Remove(tmp.Name())
+		return fmt.Errorf("failed to write menu cache: %w", err)
+	}
+	if err := tmp.Close(); err != nil {
+		os.Remove(tmp.Name())
+		return fmt.Errorf("failed to write menu cache: %w", err)
+	}
+	if err := os.Rename(tmp.Name(), filepath.Join(m.baseDir, menuCacheFile)); err != nil {
+		os.Remove(tmp.Name())
+		return fmt.Errorf("failed to write menu cache: %w", err)
+	}
+	m.cache.dirty = false
+	return nil
+}
+
+// readSummary reads a summary file, from the cache when it is enabled and the
+// file is unchanged since it was cached
+func (m *Manager) readSummary(path string) (string, error) {
+	if m.cache == nil {
+		data, err := os.ReadFile(path)
+		return string(data), err
+	}
+
+	info, err := os.Stat(path)
+	if err != nil {
+		if _, ok := m.cache.entries[path]; ok {
+			delete(m.cache.entries, path)
+			m.cache.dirty = true
+		}
+		return "", err
+	}
+	if entry, ok := m.cache.entries[path]; ok && entry.ModTime.Equal(info.ModTime()) && entry.Size == info.Size() {
+		return entry.Summary, nil
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return "", err
+	}
+	m.cache.entries[path] = cachedSummary{ModTime: info.ModTime(), Size: info.Size(), Summary: string(data)}
+	m.cache.dirty = true
+	return string(data), nil
+}
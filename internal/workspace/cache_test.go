@@ -0,0 +1,61 @@
+package workspace
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestManager_Cache(t *testing.T) {
+	tmpDir := t.TempDir()
+	mgr := NewManager(tmpDir)
+	require.NoError(t, mgr.Create("test-ws"))
+	summaryPath := filepath.Join(mgr.GetPath("test-ws"), "summary.txt")
+	require.NoError(t, os.WriteFile(summaryPath, []byte("First summary"), 0644))
+
+	mgr.EnableCache()
+	assert.Equal(t, "First summary", mgr.GetSummary("test-ws"))
+	require.NoError(t, mgr.SaveCache())
+	assert.FileExists(t, filepath.Join(tmpDir, menuCacheFile))
+
+	// A new manager serves the unchanged file from the saved cache
+	cached := NewManager(tmpDir)
+	cached.EnableCache()
+	entry := cached.cache.entries[summaryPath]
+	entry.Summary = "From cache"
+	cached.cache.entries[summaryPath] = entry
+	assert.Equal(t, "From cache", cached.GetSummary("test-ws"))
+	assert.False(t, cached.cache.dirty)
+
+	// Changed files are re-read
+	require.NoError(t, os.WriteFile(summaryPath, []byte("Second summary"), 0644))
+	later := time.Now().Add(time.Minute)
+	require.NoError(t, os.Chtimes(summaryPath, later, later))
+	assert.Equal(t, "Second summary", cached.GetSummary("test-ws"))
+	assert.True(t, cached.cache.dirty)
+
+	// Deleted files drop out
+	require.NoError(t, os.Remove(summaryPath))
+	assert.Equal(t, "(no summary)", cached.GetSummary("test-ws"))
+	assert.NotContains(t, cached.cache.entries, summaryPath)
+
+	// Other files are read directly, never cached
+	require.NoError(t, os.WriteFile(filepath.Join(mgr.GetPath("test-ws"), "continuation.md"), []byte("Next steps"), 0644))
+	assert.Equal(t, "Next steps", cached.GetContinuation("test-ws"))
+	assert.Len(t, cached.cache.entries, 0)
+}
+
+func TestManager_CacheCorrupt(t *testing.T) {
+	tmpDir := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(tmpDir, menuCacheFile), []byte("{not json"), 0644))
+
+	mgr := NewManager(tmpDir)
+	require.NoError(t, mgr.Create("test-ws"))
+	mgr.EnableCache()
+	assert.Equal(t, "", mgr.GetContinuation("test-ws"))
+	require.NoError(t, mgr.SaveCache())
+}
@@ -0,0 +1,74 @@
+package workspace
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+)
+
+// EnvFileName is the per-workspace file of variables exported into the tmux session
+const EnvFileName = ".claudew.env"
+
+var envKeyPattern = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_]*$`)
+
+// IsValidEnvKey reports whether key is a valid environment variable name
+func IsValidEnvKey(key string) bool {
+	return envKeyPattern.MatchString(key)
+}
+
+// GetEnvFilePath returns the path to a workspace's env file
+func (m *Manager) GetEnvFilePath(name string) string {
+	return filepath.Join(m.GetPath(name), EnvFileName)
+}
+
+// LoadEnv reads a workspace's env file, returning an empty map if it doesn't exist
+func (m *Manager) LoadEnv(name string) (map[string]string, error) {
+	data, err := os.ReadFile(m.GetEnvFilePath(name))
+	if os.IsNotExist(err) {
+		return map[string]string{}, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read %s: %w", EnvFileName, err)
+	}
+
+	env, err := ParseEnv(string(data))
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse %s: %w", EnvFileName, err)
+	}
+	return env, nil
+}
+
+// ParseEnv parses dotenv-style KEY=VALUE lines.
+// Blank lines, # comments and a leading "export " are allowed; values may be quoted.
+func ParseEnv(data string) (map[string]string, error) {
+	env := make(map[string]string)
+
+	for i, line := range strings.Split(data, "\n") {
+		line = strings.TrimSpace(line)
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		line = strings.TrimPrefix(line, "export ")
+
+		key, value, ok := strings.Cut(line, "=")
+		if !ok {
+			return nil, fmt.Errorf("line %d: expected KEY=VALUE", i+1)
+		}
+
+		key = strings.TrimSpace(key)
+		if !IsValidEnvKey(key) {
+			return nil, fmt.Errorf("line %d: invalid variable name '%s'", i+1, key)
+		}
+
+		value = strings.TrimSpace(value)
+		if len(value) >= 2 && (value[0] == '"' || value[0] == '\'') && value[len(value)-1] == value[0] {
+			value = value[1 : len(value)-1]
+		}
+
+		env[key] = value
+	}
+
+	return env, nil
+}
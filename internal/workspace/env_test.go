@@ -0,0 +1,58 @@
+package workspace
+
+import (
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseEnv(t *testing.T) {
+	env, err := ParseEnv(`
+# Dev server
+PORT=3001
+export API_URL=http://localhost:3001
+SECRET="with spaces"
+SINGLE='quoted'
+EMPTY=
+EQUALS=a=b
+`)
+	require.NoError(t, err)
+
+	assert.Equal(t, map[string]string{
+		"PORT":    "3001",
+		"API_URL": "http://localhost:3001",
+		"SECRET":  "with spaces",
+		"SINGLE":  "quoted",
+		"EMPTY":   "",
+		"EQUALS":  "a=b",
+	}, env)
+}
+
+func TestParseEnv_Invalid(t *testing.T) {
+	_, err := ParseEnv("PORT=3001\nnot a variable\n")
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "line 2")
+
+	_, err = ParseEnv("1PORT=3001")
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "invalid variable name")
+}
+
+func TestManager_LoadEnv(t *testing.T) {
+	mgr := NewManager(t.TempDir())
+	require.NoError(t, mgr.Create("test-ws"))
+
+	// Missing file is not an error
+	env, err := mgr.LoadEnv("test-ws")
+	require.NoError(t, err)
+	assert.Empty(t, env)
+
+	err = os.WriteFile(mgr.GetEnvFilePath("test-ws"), []byte("PORT=3001\n"), 0600)
+	require.NoError(t, err)
+
+	env, err = mgr.LoadEnv("test-ws")
+	require.NoError(t, err)
+	assert.Equal(t, "3001", env["PORT"])
+}
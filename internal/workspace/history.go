@@ -0,0 +1,140 @@
+package workspace
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/pmossman/claudew/internal/execx"
+	"github.com/pmossman/claudew/internal/table"
+)
+
+// historyIgnore keeps session state, caches and secrets out of the notes history.
+// Only workspace directories are tracked: the workspaces directory is also where the
+// config, its backups and the debug log live by default.
+const historyIgnore = `# Written by claudew: only workspace notes are tracked
+/*
+!/*/
+!/.gitignore
+
+# Session state and secrets inside workspaces
+.lock
+.context-usage
+.claude-exit
+session.log
+.claudew.env
+
+# Links to the workspaces' current clones
+/*/repo
+/archived/*/repo
+`
+
+// HistoryEnabled reports whether the workspaces directory is a git repository whose
+// history records changes to the workspace notes
+func (m *Manager) HistoryEnabled() bool {
+	_, err := os.Stat(filepath.Join(m.baseDir, ".git"))
+	return err == nil
+}
+
+// EnableHistory makes the workspaces directory a git repository and commits the
+// notes as they are, so later changes show up in the history. Enabling it twice
+// is harmless.
+func (m *Manager) EnableHistory() error {
+	if !m.HistoryEnabled() {
+		if err := m.git("init", "-q"); err != nil {
+			return fmt.Errorf("failed to initialize history: %w", err)
+		}
+	}
+	ignorePath := filepath.Join(m.baseDir, ".gitignore")
+	if _, err := os.Stat(ignorePath); os.IsNotExist(err) {
+		if err := os.WriteFile(ignorePath, []byte(historyIgnore), 0644); err != nil {
+			return fmt.Errorf("failed to write .gitignore: %w", err)
+		}
+	}
+	return m.commitHistory("Start tracking workspace notes", ".")
+}
+
+// RecordHistory commits the current state of a workspace's files with a message
+// prefixed by the workspace name, if history is enabled and anything changed.
+// Workspaces whose directory is outside the workspaces directory aren't tracked.
+func (m *Manager) RecordHistory(name, message string) error {
+	if !m.HistoryEnabled() || !m.inHistory(name) {
+		return nil
+	}
+	return m.commitHistory(name+": "+message, name)
+}
+
+// RecordRename commits a workspace directory's move from oldName to newName
+func (m *Manager) RecordRename(oldName, newName string) error {
+	if !m.HistoryEnabled() || !m.inHistory(newName) {
+		return nil
+	}
+	return m.commitHistory(fmt.Sprintf("%s: rename from %s", newName, oldName), oldName, newName)
+}
+
+// inHistory reports whether a workspace's directory is the one the history tracks
+// for it, rather than a directory override elsewhere
+func (m *Manager) inHistory(name string) bool {
+	return m.GetPath(name) == filepath.Join(m.baseDir, filepath.Base(name))
+}
+
+// recordHistory is RecordHistory for saves, which shouldn't fail because their
+// history couldn't be written
+func (m *Manager) recordHistory(name, message string) {
+	_ = m.RecordHistory(name, message)
+}
+
+// commitHistory commits every change under paths, relative to the workspaces
+// directory. Deleted and moved files are included.
+func (m *Manager) commitHistory(message string, paths ...string) error {
+	args := append([]string{"add", "-A", "--"}, paths...)
+	if err := m.git(args...); err != nil {
+		return fmt.Errorf("failed to stage workspace notes: %w", err)
+	}
+
+	args = append([]string{"diff", "--cached", "--quiet", "--"}, paths...)
+	err := m.git(args...)
+	if err == nil {
+		return nil // Nothing changed
+	}
+	var exitErr *execx.ExitError
+	if !errors.As(err, &exitErr) || exitErr.ExitCode() != 1 {
+		return fmt.Errorf("failed to check workspace notes: %w", err)
+	}
+
+	// The notes are committed by claudew rather than a person, and git may not
+	// know who the user is
+	args = append([]string{"-c", "user.name=claudew", "-c", "user.email=claudew@localhost",
+		"commit", "-q", "--no-verify", "-m", message, "--"}, paths...)
+	if err := m.git(args...); err != nil {
+		return fmt.Errorf("failed to commit workspace notes: %w", err)
+	}
+	return nil
+}
+
+// HistoryPath returns the path of a workspace, or of one of its files, relative to
+// the workspaces directory, for running git commands against the history
+func (m *Manager) HistoryPath(name, file string) (string, error) {
+	if !m.inHistory(name) {
+		return "", fmt.Errorf("workspace '%s' lives in %s, outside %s, so its notes aren't in the history", name, m.GetPath(name), m.baseDir)
+	}
+	return filepath.Join(filepath.Base(name), file), nil
+}
+
+// git runs a git command in the workspaces directory
+func (m *Manager) git(args ...string) error {
+	cmd := execx.Command("git", append([]string{"-C", m.baseDir}, args...)...)
+	output, err := cmd.CombinedOutput()
+	if err != nil && len(output) > 0 {
+		return fmt.Errorf("%w: %s", err, strings.TrimSpace(string(output)))
+	}
+	return err
+}
+
+// firstLine returns the first line of text, shortened to suit a commit message
+func firstLine(text string) string {
+	line, _, _ := strings.Cut(strings.TrimSpace(text), "\n")
+	return table.Truncate(line, 60, false)
+}
@@ -0,0 +1,123 @@
+package workspace
+
+import (
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+	"unicode/utf8"
+
+	"github.com/pmossman/claudew/internal/config"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// historyLog returns the subjects of the commits in the workspaces directory, newest first
+func historyLog(t *testing.T, dir string, paths ...string) []string {
+	t.Helper()
+	args := append([]string{"-C", dir, "log", "--format=%s", "--"}, paths...)
+	out, err := exec.Command("git", args...).Output()
+	require.NoError(t, err)
+	return strings.Split(strings.TrimSpace(string(out)), "\n")
+}
+
+func TestHistory(t *testing.T) {
+	tmpDir := t.TempDir()
+	mgr := NewManager(tmpDir)
+	require.NoError(t, mgr.Create("auth"))
+
+	// Without history, saves don't create a repository
+	assert.False(t, mgr.HistoryEnabled())
+	require.NoError(t, mgr.SaveContinuation("auth", "Next: tests\n"))
+	assert.NoDirExists(t, filepath.Join(tmpDir, ".git"))
+
+	require.NoError(t, mgr.EnableHistory())
+	assert.True(t, mgr.HistoryEnabled())
+	require.NoError(t, mgr.EnableHistory())
+	assert.Equal(t, []string{"Start tracking workspace notes"}, historyLog(t, tmpDir))
+
+	require.NoError(t, mgr.SaveContinuation("auth", "Next: ship\n"))
+	require.NoError(t, mgr.SaveContinuation("auth", "Next: ship\n")) // unchanged, no commit
+	require.NoError(t, mgr.AppendDecision("auth", "Use JWT\nbecause sessions don't scale", time.Now()))
+	require.NoError(t, mgr.CreateLock("auth", 123))
+	require.NoError(t, mgr.SaveContextUsage("auth", 40))
+	require.NoError(t, mgr.RecordHistory("auth", "snapshot"))
+
+	assert.Equal(t, []string{
+		"auth: add decision: Use JWT",
+		"auth: update continuation.md",
+		"Start tracking workspace notes",
+	}, historyLog(t, tmpDir))
+	path, err := mgr.HistoryPath("auth", "continuation.md")
+	require.NoError(t, err)
+	assert.Equal(t, []string{"auth: update continuation.md", "Start tracking workspace notes"},
+		historyLog(t, tmpDir, path))
+
+	// Claude's own edits are picked up by the next recorded change
+	require.NoError(t, os.WriteFile(filepath.Join(mgr.GetPath("auth"), "context.md"), []byte("Working on login\n"), 0644))
+	require.NoError(t, mgr.RecordHistory("auth", "snapshot on stop"))
+	assert.Equal(t, "auth: snapshot on stop", historyLog(t, tmpDir)[0])
+
+	require.NoError(t, mgr.Archive("auth"))
+	assert.Equal(t, "auth: archive", historyLog(t, tmpDir)[0])
+	status, err := exec.Command("git", "-C", tmpDir, "status", "--porcelain").Output()
+	require.NoError(t, err)
+	assert.Empty(t, string(status))
+
+	require.NoError(t, mgr.RemoveArchived("auth"))
+	assert.NoDirExists(t, mgr.ArchivedPath("auth"))
+	assert.Equal(t, "auth: offload", historyLog(t, tmpDir)[0])
+}
+
+func TestHistory_IgnoresTopLevelFiles(t *testing.T) {
+	tmpDir := t.TempDir()
+	mgr := NewManager(tmpDir)
+	require.NoError(t, mgr.Create("auth"))
+	require.NoError(t, os.WriteFile(filepath.Join(tmpDir, "config.json"), []byte("{}"), 0644))
+	require.NoError(t, os.WriteFile(filepath.Join(tmpDir, "debug.log"), []byte("log"), 0644))
+	require.NoError(t, mgr.EnableHistory())
+
+	files, err := exec.Command("git", "-C", tmpDir, "ls-files").Output()
+	require.NoError(t, err)
+	assert.Equal(t, ".gitignore\nauth/context.md\nauth/continuation.md\nauth/decisions.md\nauth/summary.txt\n", string(files))
+
+	require.NoError(t, os.Rename(mgr.GetPath("auth"), mgr.GetPath("login")))
+	require.NoError(t, mgr.RecordRename("auth", "login"))
+	assert.Equal(t, "login: rename from auth", historyLog(t, tmpDir)[0])
+	status, err := exec.Command("git", "-C", tmpDir, "status", "--porcelain").Output()
+	require.NoError(t, err)
+	assert.Empty(t, string(status))
+}
+
+func TestHistory_SkipsWorkspacesOutside(t *testing.T) {
+	tmpDir := t.TempDir()
+	outside := filepath.Join(t.TempDir(), "notes")
+	cfg := &config.Config{
+		Workspaces: map[string]*config.Workspace{"in-repo": {Name: "in-repo", Dir: outside}},
+		Settings:   config.Settings{WorkspaceDir: tmpDir},
+	}
+	mgr := NewManagerForConfig(cfg)
+	require.NoError(t, mgr.EnableHistory())
+
+	require.NoError(t, mgr.Create("in-repo"))
+	require.NoError(t, mgr.SaveContinuation("in-repo", "Next: ship\n"))
+	assert.FileExists(t, filepath.Join(outside, "continuation.md"))
+	assert.Equal(t, []string{"Start tracking workspace notes"}, historyLog(t, tmpDir))
+
+	_, err := mgr.HistoryPath("in-repo", "")
+	assert.Error(t, err)
+
+	// Archiving moves the directory into the history
+	require.NoError(t, mgr.Archive("in-repo"))
+	assert.NoDirExists(t, outside)
+	assert.Equal(t, "in-repo: archive", historyLog(t, tmpDir)[0])
+}
+
+func TestFirstLine(t *testing.T) {
+	assert.Equal(t, "Fix login", firstLine("  Fix login\nmore detail\n"))
+	long := firstLine(strings.Repeat("é", 80))
+	assert.True(t, utf8.ValidString(long))
+	assert.Equal(t, 60, utf8.RuneCountInString(long))
+}
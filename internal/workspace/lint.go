@@ -0,0 +1,39 @@
+package workspace
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+// LintLimits are the thresholds a workspace's files are checked against. Zero disables a check.
+type LintLimits struct {
+	ContextWords    int           // most words context.md should hold
+	ContinuationAge time.Duration // longest continuation.md may go without an update
+}
+
+// Lint checks a workspace's files against limits and returns a warning for each problem.
+// The continuation's age is only checked when checkContinuation is set, since it only
+// has to be kept current while Claude is working in the workspace.
+func (m *Manager) Lint(name string, limits LintLimits, checkContinuation bool, now time.Time) []string {
+	var warnings []string
+
+	if limits.ContextWords > 0 {
+		if words := len(strings.Fields(m.ReadContext(name))); words > limits.ContextWords {
+			warnings = append(warnings, fmt.Sprintf("context.md has %d words (limit %d) - condense it", words, limits.ContextWords))
+		}
+	}
+
+	if checkContinuation && limits.ContinuationAge > 0 {
+		info, err := os.Stat(filepath.Join(m.GetPath(name), "continuation.md"))
+		if err != nil {
+			warnings = append(warnings, "continuation.md is missing")
+		} else if age := now.Sub(info.ModTime()); age > limits.ContinuationAge {
+			warnings = append(warnings, fmt.Sprintf("continuation.md not updated in %dh (limit %dh)", int(age.Hours()), int(limits.ContinuationAge.Hours())))
+		}
+	}
+
+	return warnings
+}
@@ -0,0 +1,49 @@
+package workspace
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLint_ContextWords(t *testing.T) {
+	mgr := NewManager(t.TempDir())
+	require.NoError(t, mgr.Create("test-ws"))
+	limits := LintLimits{ContextWords: 5}
+
+	require.NoError(t, mgr.SaveContext("test-ws", "one two three four five"))
+	assert.Empty(t, mgr.Lint("test-ws", limits, false, time.Now()))
+
+	require.NoError(t, mgr.SaveContext("test-ws", strings.Repeat("word ", 8)))
+	assert.Equal(t, []string{"context.md has 8 words (limit 5) - condense it"}, mgr.Lint("test-ws", limits, false, time.Now()))
+}
+
+func TestLint_ContinuationAge(t *testing.T) {
+	mgr := NewManager(t.TempDir())
+	require.NoError(t, mgr.Create("test-ws"))
+	limits := LintLimits{ContinuationAge: 24 * time.Hour}
+
+	path := filepath.Join(mgr.GetPath("test-ws"), "continuation.md")
+	updated := time.Now().Add(-30 * time.Hour)
+	require.NoError(t, os.Chtimes(path, updated, updated))
+
+	// Only checked for workspaces Claude is working in
+	assert.Empty(t, mgr.Lint("test-ws", limits, false, time.Now()))
+	assert.Equal(t, []string{"continuation.md not updated in 30h (limit 24h)"}, mgr.Lint("test-ws", limits, true, time.Now()))
+
+	require.NoError(t, mgr.SaveContinuation("test-ws", "next steps"))
+	assert.Empty(t, mgr.Lint("test-ws", limits, true, time.Now()))
+}
+
+func TestLint_Disabled(t *testing.T) {
+	mgr := NewManager(t.TempDir())
+	require.NoError(t, mgr.Create("test-ws"))
+	require.NoError(t, mgr.SaveContext("test-ws", strings.Repeat("word ", 5000)))
+
+	assert.Empty(t, mgr.Lint("test-ws", LintLimits{}, true, time.Now()))
+}
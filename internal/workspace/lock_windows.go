@@ -0,0 +1,80 @@
+//go:build windows
+
+package workspace
+
+import (
+	"os"
+	"syscall"
+	"unsafe"
+)
+
+var (
+	kernel32         = syscall.NewLazyDLL("kernel32.dll")
+	procLockFileEx   = kernel32.NewProc("LockFileEx")
+	procUnlockFileEx = kernel32.NewProc("UnlockFileEx")
+)
+
+const (
+	lockfileFailImmediately = 0x1
+	lockfileExclusiveLock   = 0x2
+
+	errorLockViolation syscall.Errno = 33
+
+	// stillActive is the exit code GetExitCodeProcess reports for a running process
+	stillActive = 259
+
+	// Windows locks are mandatory, so the locked byte lies far past the end of the
+	// file to keep the PID readable while the lock is held
+	lockOffsetHigh = 0x40000000
+)
+
+// lockFile takes a non-blocking LockFileEx lock on f, returning errLockHeld if
+// another handle holds a conflicting one
+func lockFile(f *os.File, exclusive bool) error {
+	flags := uintptr(lockfileFailImmediately)
+	if exclusive {
+		flags |= lockfileExclusiveLock
+	}
+	ol := syscall.Overlapped{OffsetHigh: lockOffsetHigh}
+	r, _, err := procLockFileEx.Call(f.Fd(), flags, 0, 1, 0, uintptr(unsafe.Pointer(&ol)))
+	if r == 0 {
+		if err == errorLockViolation || err == syscall.ERROR_IO_PENDING {
+			return errLockHeld
+		}
+		return err
+	}
+	return nil
+}
+
+// unlockFile releases a lock taken with lockFile
+func unlockFile(f *os.File) error {
+	ol := syscall.Overlapped{OffsetHigh: lockOffsetHigh}
+	r, _, err := procUnlockFileEx.Call(f.Fd(), 0, 1, 0, uintptr(unsafe.Pointer(&ol)))
+	if r == 0 {
+		return err
+	}
+	return nil
+}
+
+// removeHeldLockFile can't take a lock over on Windows: the holder's open handle
+// keeps the file from being removed, and its LockFileEx lock stays with the file
+func removeHeldLockFile(path string) error {
+	return ErrStealUnsupported
+}
+
+// processAlive reports whether a process with pid is running. Signals don't exist
+// on Windows, so this asks for the process's exit code instead.
+func processAlive(pid int) bool {
+	h, err := syscall.OpenProcess(syscall.PROCESS_QUERY_INFORMATION, false, uint32(pid))
+	if err != nil {
+		// Access denied still means the process exists
+		return err == syscall.ERROR_ACCESS_DENIED
+	}
+	defer syscall.CloseHandle(h)
+
+	var code uint32
+	if err := syscall.GetExitCodeProcess(h, &code); err != nil {
+		return false
+	}
+	return code == stillActive
+}
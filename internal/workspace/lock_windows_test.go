@@ -0,0 +1,22 @@
+//go:build windows
+
+package workspace
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestManager_StealLock_Unsupported(t *testing.T) {
+	mgr := NewManager(t.TempDir())
+	require.NoError(t, mgr.Create("test-ws"))
+
+	lock, err := mgr.AcquireLock("test-ws", 12345)
+	require.NoError(t, err)
+	defer lock.Release()
+
+	_, err = mgr.StealLock("test-ws", 67890)
+	assert.ErrorIs(t, err, ErrStealUnsupported)
+}
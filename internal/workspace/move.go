@@ -0,0 +1,82 @@
+package workspace
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"syscall"
+)
+
+// rename is os.Rename, replaced in tests to simulate moves across filesystems
+var rename = os.Rename
+
+// moveDir moves a directory to dst. A workspace directory overridden with --dir may
+// live on another filesystem than the archive, where rename fails with EXDEV, so the
+// directory is then copied and the original removed once the copy is complete.
+func moveDir(src, dst string) error {
+	err := rename(src, dst)
+	if err == nil || !errors.Is(err, syscall.EXDEV) {
+		return err
+	}
+	if err := copyTree(src, dst); err != nil {
+		os.RemoveAll(dst)
+		return fmt.Errorf("failed to copy %s across filesystems: %w", src, err)
+	}
+	return os.RemoveAll(src)
+}
+
+// copyTree copies the directory src to dst, which must not exist, keeping file modes
+// and symlinks (such as the repo link) as they are
+func copyTree(src, dst string) error {
+	return filepath.WalkDir(src, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		rel, err := filepath.Rel(src, path)
+		if err != nil {
+			return err
+		}
+		target := filepath.Join(dst, rel)
+		info, err := d.Info()
+		if err != nil {
+			return err
+		}
+
+		switch {
+		case d.IsDir():
+			return os.Mkdir(target, info.Mode().Perm())
+		case d.Type()&fs.ModeSymlink != 0:
+			link, err := os.Readlink(path)
+			if err != nil {
+				return err
+			}
+			return os.Symlink(link, target)
+		case d.Type().IsRegular():
+			return copyRegularFile(path, target, info.Mode().Perm())
+		}
+		// Sockets, pipes and the like can't be copied and mean nothing elsewhere
+		return nil
+	})
+}
+
+// copyRegularFile copies one file's contents to a new file with the given mode
+func copyRegularFile(src, dst string, mode fs.FileMode) error {
+	in, err := os.Open(src)
+	if err != nil {
+		return err
+	}
+	defer in.Close()
+
+	out, err := os.OpenFile(dst, os.O_WRONLY|os.O_CREATE|os.O_EXCL, mode)
+	if err != nil {
+		return err
+	}
+	if _, err := io.Copy(out, in); err != nil {
+		out.Close()
+		return err
+	}
+	return out.Close()
+}
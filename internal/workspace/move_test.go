@@ -0,0 +1,48 @@
+package workspace
+
+import (
+	"os"
+	"path/filepath"
+	"syscall"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestManager_Archive_AcrossFilesystems(t *testing.T) {
+	rename = func(oldpath, newpath string) error {
+		return &os.LinkError{Op: "rename", Old: oldpath, New: newpath, Err: syscall.EXDEV}
+	}
+	t.Cleanup(func() { rename = os.Rename })
+
+	tmpDir := t.TempDir()
+	mgr := NewManager(tmpDir)
+	require.NoError(t, mgr.Create("test-ws"))
+	wsPath := mgr.GetPath("test-ws")
+	require.NoError(t, os.WriteFile(filepath.Join(wsPath, "summary.txt"), []byte("Test summary"), 0644))
+	require.NoError(t, os.MkdirAll(filepath.Join(wsPath, "research"), 0755))
+	require.NoError(t, os.WriteFile(filepath.Join(wsPath, "research", "notes.md"), []byte("notes"), 0600))
+	require.NoError(t, os.Symlink("/nonexistent/clone", filepath.Join(wsPath, "repo")))
+
+	require.NoError(t, mgr.Archive("test-ws"))
+	assert.NoDirExists(t, wsPath)
+
+	archivedPath := mgr.ArchivedPath("test-ws")
+	data, err := os.ReadFile(filepath.Join(archivedPath, "summary.txt"))
+	require.NoError(t, err)
+	assert.Equal(t, "Test summary", string(data))
+	info, err := os.Stat(filepath.Join(archivedPath, "research", "notes.md"))
+	require.NoError(t, err)
+	assert.Equal(t, os.FileMode(0600), info.Mode().Perm())
+	link, err := os.Readlink(filepath.Join(archivedPath, "repo"))
+	require.NoError(t, err)
+	assert.Equal(t, "/nonexistent/clone", link)
+}
+
+func TestMoveDir_OtherErrors(t *testing.T) {
+	tmpDir := t.TempDir()
+	err := moveDir(filepath.Join(tmpDir, "missing"), filepath.Join(tmpDir, "dst"))
+	assert.Error(t, err)
+	assert.NoDirExists(t, filepath.Join(tmpDir, "dst"))
+}
@@ -1,17 +1,23 @@
 package workspace
 
 import (
+	"errors"
 	"fmt"
 	"os"
 	"path/filepath"
+	"slices"
 	"strconv"
 	"strings"
-	"syscall"
+	"time"
+
+	"github.com/pmossman/claudew/internal/config"
 )
 
 // Manager handles workspace directory operations
 type Manager struct {
 	baseDir string
+	cfg     *config.Config // nil unless created with NewManagerForConfig
+	cache   *fileCache     // nil unless EnableCache was called
 }
 
 // NewManager creates a new workspace manager
@@ -19,9 +25,23 @@ func NewManager(baseDir string) *Manager {
 	return &Manager{baseDir: baseDir}
 }
 
-// GetPath returns the full path to a workspace directory
+// NewManagerForConfig creates a workspace manager for cfg's workspace directory that
+// honors each workspace's directory override. Overrides are read from cfg when a path
+// is needed, so workspaces added to cfg later are resolved too.
+func NewManagerForConfig(cfg *config.Config) *Manager {
+	return &Manager{baseDir: cfg.Settings.WorkspaceDir, cfg: cfg}
+}
+
+// GetPath returns the full path to a workspace directory: its configured directory
+// if it has one, otherwise <baseDir>/<name>
 // Note: This does path traversal prevention by sanitizing the name
 func (m *Manager) GetPath(name string) string {
+	if m.cfg != nil {
+		if ws, ok := m.cfg.Workspaces[name]; ok && ws.Dir != "" {
+			return filepath.Clean(ws.Dir)
+		}
+	}
+
 	// Prevent path traversal - check name for dangerous patterns BEFORE joining
 	// This prevents attacks like "../escape" or "/etc/passwd"
 	if strings.Contains(name, "..") || strings.HasPrefix(name, "/") || strings.HasPrefix(name, "\\") {
@@ -67,6 +87,7 @@ func (m *Manager) Create(name string) error {
 		}
 	}
 
+	m.recordHistory(name, "create workspace")
 	return nil
 }
 
@@ -80,11 +101,11 @@ func (m *Manager) Exists(name string) bool {
 // GetSummary reads the summary.txt file for a workspace
 func (m *Manager) GetSummary(name string) string {
 	summaryPath := filepath.Join(m.GetPath(name), "summary.txt")
-	data, err := os.ReadFile(summaryPath)
+	data, err := m.readSummary(summaryPath)
 	if err != nil || len(data) == 0 {
 		return "(no summary)"
 	}
-	return strings.TrimSpace(string(data))
+	return strings.TrimSpace(data)
 }
 
 // GetContinuation reads the continuation.md file for a workspace
@@ -100,25 +121,41 @@ func (m *Manager) GetContinuation(name string) string {
 // SaveContinuation writes content to the continuation.md file for a workspace
 func (m *Manager) SaveContinuation(name, content string) error {
 	contPath := filepath.Join(m.GetPath(name), "continuation.md")
-	return os.WriteFile(contPath, []byte(content), 0644)
+	if err := os.WriteFile(contPath, []byte(content), 0644); err != nil {
+		return err
+	}
+	m.recordHistory(name, "update continuation.md")
+	return nil
 }
 
 // SaveContext writes content to the context.md file for a workspace
 func (m *Manager) SaveContext(name, content string) error {
 	contextPath := filepath.Join(m.GetPath(name), "context.md")
-	return os.WriteFile(contextPath, []byte(content), 0644)
+	if err := os.WriteFile(contextPath, []byte(content), 0644); err != nil {
+		return err
+	}
+	m.recordHistory(name, "update context.md")
+	return nil
 }
 
 // SaveDecisions writes content to the decisions.md file for a workspace
 func (m *Manager) SaveDecisions(name, content string) error {
 	decisionsPath := filepath.Join(m.GetPath(name), "decisions.md")
-	return os.WriteFile(decisionsPath, []byte(content), 0644)
+	if err := os.WriteFile(decisionsPath, []byte(content), 0644); err != nil {
+		return err
+	}
+	m.recordHistory(name, "update decisions.md")
+	return nil
 }
 
 // SaveSummary writes content to the summary.txt file for a workspace
 func (m *Manager) SaveSummary(name, content string) error {
 	summaryPath := filepath.Join(m.GetPath(name), "summary.txt")
-	return os.WriteFile(summaryPath, []byte(content), 0644)
+	if err := os.WriteFile(summaryPath, []byte(content), 0644); err != nil {
+		return err
+	}
+	m.recordHistory(name, "update summary.txt")
+	return nil
 }
 
 // GetContext reads the context.md file for a workspace
@@ -130,12 +167,253 @@ func (m *Manager) GetContext(name string) string {
 	}
 	// Return first 200 chars for preview
 	text := strings.TrimSpace(string(data))
-	if len(text) > 200 {
-		return text[:200] + "..."
+	if runes := []rune(text); len(runes) > 200 {
+		return string(runes[:200]) + "..."
 	}
 	return text
 }
 
+// ReadContext reads the full context.md file for a workspace
+func (m *Manager) ReadContext(name string) string {
+	contextPath := filepath.Join(m.GetPath(name), "context.md")
+	data, err := os.ReadFile(contextPath)
+	if err != nil {
+		return ""
+	}
+	return string(data)
+}
+
+// AppendDecision adds a timestamped entry to a workspace's decisions.md, in the
+// "## [Timestamp] Topic" format the CLAUDE.md protocol asks Claude to use
+func (m *Manager) AppendDecision(name, text string, now time.Time) error {
+	text = strings.TrimSpace(text)
+	if text == "" {
+		return fmt.Errorf("decision is empty")
+	}
+	if !m.Exists(name) {
+		return fmt.Errorf("workspace '%s' does not exist", name)
+	}
+
+	decisionsPath := filepath.Join(m.GetPath(name), "decisions.md")
+	existing, err := os.ReadFile(decisionsPath)
+	if err != nil && !os.IsNotExist(err) {
+		return fmt.Errorf("failed to read decisions.md: %w", err)
+	}
+
+	content := strings.TrimRight(string(existing), "\n")
+	if content != "" {
+		content += "\n\n"
+	}
+	content += fmt.Sprintf("## [%s] Decision\n%s\n", now.Format("2006-01-02 15:04"), text)
+	if err := os.WriteFile(decisionsPath, []byte(content), 0644); err != nil {
+		return err
+	}
+	m.recordHistory(name, "add decision: "+firstLine(text))
+	return nil
+}
+
+// commitsHeading starts the section of context.md that AppendCommit keeps
+const commitsHeading = "## Recent commits"
+
+// maxContextCommits is how many commits AppendCommit keeps in context.md, so the
+// log doesn't crowd out the rest of Claude's working memory
+const maxContextCommits = 15
+
+// AppendCommit records a commit in the "Recent commits" section of a workspace's
+// context.md, creating the section at the end of the file if needed and dropping
+// the oldest entries past maxContextCommits
+func (m *Manager) AppendCommit(name, hash, subject string, now time.Time) error {
+	if !m.Exists(name) {
+		return fmt.Errorf("workspace '%s' does not exist", name)
+	}
+
+	contextPath := filepath.Join(m.GetPath(name), "context.md")
+	existing, err := os.ReadFile(contextPath)
+	if err != nil && !os.IsNotExist(err) {
+		return fmt.Errorf("failed to read context.md: %w", err)
+	}
+
+	lines := strings.Split(strings.TrimRight(string(existing), "\n"), "\n")
+	if len(lines) == 1 && lines[0] == "" {
+		lines = nil
+	}
+
+	// The section runs from its heading to the next heading or the end of the file
+	start := slices.Index(lines, commitsHeading)
+	if start < 0 {
+		if len(lines) > 0 {
+			lines = append(lines, "")
+		}
+		lines = append(lines, commitsHeading)
+		start = len(lines) - 1
+	}
+	end := start + 1
+	for end < len(lines) && !strings.HasPrefix(lines[end], "#") {
+		end++
+	}
+
+	var entries []string
+	for _, line := range lines[start+1 : end] {
+		if strings.HasPrefix(line, "- ") {
+			entries = append(entries, line)
+		}
+	}
+	entries = append(entries, fmt.Sprintf("- %s %s %s", now.Format("2006-01-02 15:04"), hash, subject))
+	if len(entries) > maxContextCommits {
+		entries = entries[len(entries)-maxContextCommits:]
+	}
+
+	section := append([]string{commitsHeading}, entries...)
+	if end < len(lines) {
+		section = append(section, "")
+	}
+	lines = append(lines[:start], append(section, lines[end:]...)...)
+	if err := os.WriteFile(contextPath, []byte(strings.Join(lines, "\n")+"\n"), 0644); err != nil {
+		return err
+	}
+	m.recordHistory(name, fmt.Sprintf("log commit %s %s", hash, subject))
+	return nil
+}
+
+// ReadDecisions reads the full decisions.md file for a workspace
+func (m *Manager) ReadDecisions(name string) string {
+	data, err := os.ReadFile(filepath.Join(m.GetPath(name), "decisions.md"))
+	if err != nil {
+		return ""
+	}
+	return string(data)
+}
+
+// ListResearch returns the names of the files in a workspace's research directory, sorted
+func (m *Manager) ListResearch(name string) ([]string, error) {
+	entries, err := os.ReadDir(filepath.Join(m.GetPath(name), "research"))
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to read research directory: %w", err)
+	}
+
+	var files []string
+	for _, entry := range entries {
+		if !entry.IsDir() {
+			files = append(files, entry.Name())
+		}
+	}
+	return files, nil
+}
+
+// SaveContextUsage records the context-usage percentage reported by the Claude statusline
+func (m *Manager) SaveContextUsage(name string, percent int) error {
+	usagePath := filepath.Join(m.GetPath(name), ".context-usage")
+	return os.WriteFile(usagePath, []byte(strconv.Itoa(percent)), 0644)
+}
+
+// GetContextUsage reads the last recorded context-usage percentage, if any
+func (m *Manager) GetContextUsage(name string) (int, bool) {
+	usagePath := filepath.Join(m.GetPath(name), ".context-usage")
+	data, err := os.ReadFile(usagePath)
+	if err != nil {
+		return 0, false
+	}
+	percent, err := strconv.Atoi(strings.TrimSpace(string(data)))
+	if err != nil {
+		return 0, false
+	}
+	return percent, true
+}
+
+// ClearContextUsage removes the recorded context usage, e.g. when a fresh session starts
+func (m *Manager) ClearContextUsage(name string) error {
+	usagePath := filepath.Join(m.GetPath(name), ".context-usage")
+	if err := os.Remove(usagePath); err != nil && !os.IsNotExist(err) {
+		return err
+	}
+	return nil
+}
+
+// ClaudeExitPath returns the file a supervised Claude launch writes its exit status to
+func (m *Manager) ClaudeExitPath(name string) string {
+	return filepath.Join(m.GetPath(name), ".claude-exit")
+}
+
+// GetClaudeExit reads the exit status of the last supervised Claude launch, if it has exited
+func (m *Manager) GetClaudeExit(name string) (int, bool) {
+	data, err := os.ReadFile(m.ClaudeExitPath(name))
+	if err != nil {
+		return 0, false
+	}
+	code, err := strconv.Atoi(strings.TrimSpace(string(data)))
+	if err != nil {
+		return 0, false
+	}
+	return code, true
+}
+
+// ClearClaudeExit removes the recorded exit status
+func (m *Manager) ClearClaudeExit(name string) error {
+	if err := os.Remove(m.ClaudeExitPath(name)); err != nil && !os.IsNotExist(err) {
+		return err
+	}
+	return nil
+}
+
+// SessionLogPath returns the log foreground sessions record their starts and exits in
+func (m *Manager) SessionLogPath(name string) string {
+	return filepath.Join(m.GetPath(name), "session.log")
+}
+
+// AppendSessionLog adds a line stamped with now to the workspace's session log
+func (m *Manager) AppendSessionLog(name, line string, now time.Time) error {
+	f, err := os.OpenFile(m.SessionLogPath(name), os.O_WRONLY|os.O_CREATE|os.O_APPEND, 0644)
+	if err != nil {
+		return err
+	}
+	if _, err := fmt.Fprintf(f, "%s %s\n", now.Format(time.RFC3339), line); err != nil {
+		f.Close()
+		return err
+	}
+	return f.Close()
+}
+
+// RepoLinkPath returns the symlink in a workspace's directory that points at its clone
+func (m *Manager) RepoLinkPath(name string) string {
+	return filepath.Join(m.GetPath(name), "repo")
+}
+
+// LinkRepo points a workspace's repo symlink at repoPath, so editors, terminals and
+// scripts can use one path across clone reassignments. An empty repoPath removes the
+// link. Anything named repo that isn't a symlink is left alone.
+func (m *Manager) LinkRepo(name, repoPath string) error {
+	linkPath := m.RepoLinkPath(name)
+	info, err := os.Lstat(linkPath)
+	if err == nil && info.Mode()&os.ModeSymlink == 0 {
+		return fmt.Errorf("%s exists and is not a symlink", linkPath)
+	}
+
+	if repoPath == "" {
+		if err := os.Remove(linkPath); err != nil && !os.IsNotExist(err) {
+			return fmt.Errorf("failed to remove repo link: %w", err)
+		}
+		return nil
+	}
+	if target, err := os.Readlink(linkPath); err == nil && target == repoPath {
+		return nil
+	}
+
+	// Swap the new link in with a rename, so the path never goes missing
+	tmpPath := linkPath + ".tmp"
+	_ = os.Remove(tmpPath)
+	if err := os.Symlink(repoPath, tmpPath); err != nil {
+		return fmt.Errorf("failed to create repo link: %w", err)
+	}
+	if err := os.Rename(tmpPath, linkPath); err != nil {
+		_ = os.Remove(tmpPath)
+		return fmt.Errorf("failed to create repo link: %w", err)
+	}
+	return nil
+}
+
 // CreateLock creates a lock file for a workspace
 func (m *Manager) CreateLock(name string, pid int) error {
 	lockPath := filepath.Join(m.GetPath(name), ".lock")
@@ -153,10 +431,135 @@ func (m *Manager) RemoveLock(name string) error {
 	return nil
 }
 
-// CheckLock checks if a workspace is locked and if the process is still running
-func (m *Manager) CheckLock(name string) (bool, int, error) {
+// lockMarker tags lock files whose lifetime is tracked with flock rather than PID liveness.
+// On Windows the lock is a LockFileEx lock, which behaves the same way.
+const lockMarker = "flock"
+
+// errLockHeld is returned by lockFile when another process holds a conflicting lock
+var errLockHeld = errors.New("lock held by another process")
+
+// ErrLocked is returned by AcquireLock when another process holds the workspace lock
+type ErrLocked struct {
+	Name string
+	PID  int
+}
+
+func (e *ErrLocked) Error() string {
+	return fmt.Sprintf("workspace '%s' is locked by another process (PID %d)", e.Name, e.PID)
+}
+
+// Lock is a held workspace lock. The kernel releases it automatically if the
+// holding process exits, so a crashed session never leaves a stale lock behind.
+type Lock struct {
+	file *os.File
+	path string
+}
+
+// AcquireLock takes an exclusive flock on the workspace lock file and records
+// the PID of the holder. The lock is held until Release is called or the
+// process exits.
+func (m *Manager) AcquireLock(name string, pid int) (*Lock, error) {
 	lockPath := filepath.Join(m.GetPath(name), ".lock")
+	f, err := os.OpenFile(lockPath, os.O_RDWR|os.O_CREATE, 0644)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open lock file: %w", err)
+	}
+
+	if err := lockFile(f, true); err != nil {
+		f.Close()
+		if err == errLockHeld {
+			holder, _, _ := readLockFile(lockPath)
+			return nil, &ErrLocked{Name: name, PID: holder}
+		}
+		return nil, fmt.Errorf("failed to lock workspace: %w", err)
+	}
+
+	if err := f.Truncate(0); err != nil {
+		f.Close()
+		return nil, fmt.Errorf("failed to write lock file: %w", err)
+	}
+	if _, err := f.WriteAt([]byte(fmt.Sprintf("%d %s", pid, lockMarker)), 0); err != nil {
+		f.Close()
+		return nil, fmt.Errorf("failed to write lock file: %w", err)
+	}
+
+	return &Lock{file: f, path: lockPath}, nil
+}
+
+// ErrStealUnsupported is returned by StealLock where a held lock file can't be replaced
+var ErrStealUnsupported = errors.New("taking a workspace lock from another process is not supported on this platform")
+
+// StealLock takes the workspace lock from the process holding it by replacing the
+// lock file. The previous holder keeps its flock on the old, unlinked file, which no
+// longer counts. On Windows, where a file held open by another process can't be
+// removed, it returns ErrStealUnsupported.
+func (m *Manager) StealLock(name string, pid int) (*Lock, error) {
+	if err := removeHeldLockFile(filepath.Join(m.GetPath(name), ".lock")); err != nil {
+		return nil, err
+	}
+	return m.AcquireLock(name, pid)
+}
+
+// Release releases the lock. The lock file is left in place: unlinking it could
+// pull it out from under a process that has just opened it to take the lock, leaving
+// that process holding a lock on a file nobody else can see.
+func (l *Lock) Release() error {
+	if l == nil || l.file == nil {
+		return nil
+	}
+	_ = unlockFile(l.file)
+	err := l.file.Close()
+	l.file = nil
+	return err
+}
+
+// readLockFile parses a lock file, returning the recorded PID and whether
+// the lock is flock-managed
+func readLockFile(lockPath string) (int, bool, error) {
 	data, err := os.ReadFile(lockPath)
+	if err != nil {
+		return 0, false, err
+	}
+
+	fields := strings.Fields(string(data))
+	if len(fields) == 0 {
+		return 0, false, fmt.Errorf("invalid lock file: empty")
+	}
+
+	pid, err := strconv.Atoi(fields[0])
+	if err != nil {
+		return 0, false, fmt.Errorf("invalid lock file: %w", err)
+	}
+
+	managed := len(fields) > 1 && fields[1] == lockMarker
+	return pid, managed, nil
+}
+
+// isFlockHeld reports whether another open file description holds a flock on the file
+func isFlockHeld(lockPath string) (bool, error) {
+	f, err := os.Open(lockPath)
+	if err != nil {
+		return false, err
+	}
+	defer f.Close()
+
+	if err := lockFile(f, false); err != nil {
+		if err == errLockHeld {
+			return true, nil
+		}
+		return false, err
+	}
+	_ = unlockFile(f)
+	return false, nil
+}
+
+// CheckLock checks if a workspace is locked and if the process is still running.
+// Locks taken with AcquireLock are considered held only while their flock is held,
+// which avoids false positives when a PID has been reused by another process.
+// Legacy PID-only lock files fall back to a process liveness check.
+func (m *Manager) CheckLock(name string) (bool, int, error) {
+	lockPath := filepath.Join(m.GetPath(name), ".lock")
+	pid, managed, err := readLockFile(lockPath)
 	if err != nil {
 		if os.IsNotExist(err) {
 			return false, 0, nil
@@ -164,32 +567,58 @@ func (m *Manager) CheckLock(name string) (bool, int, error) {
 		return false, 0, err
 	}
 
-	pid, err := strconv.Atoi(strings.TrimSpace(string(data)))
-	if err != nil {
-		return false, 0, fmt.Errorf("invalid lock file: %w", err)
+	if managed {
+		held, err := isFlockHeld(lockPath)
+		if err != nil {
+			if os.IsNotExist(err) {
+				return false, 0, nil
+			}
+			return false, pid, err
+		}
+		return held, pid, nil
 	}
 
 	// Check if process is still running
-	process, err := os.FindProcess(pid)
+	return processAlive(pid), pid, nil
+}
+
+// LockState describes a workspace's lock file
+type LockState struct {
+	Exists  bool // a lock file is present
+	PID     int  // PID recorded in the lock file
+	Held    bool // the holder is still running
+	Managed bool // lifetime is tracked with flock rather than PID liveness
+}
+
+// GetLockState inspects a workspace's lock file
+func (m *Manager) GetLockState(name string) (LockState, error) {
+	lockPath := filepath.Join(m.GetPath(name), ".lock")
+	pid, managed, err := readLockFile(lockPath)
 	if err != nil {
-		// Process doesn't exist
-		return false, pid, nil
+		if os.IsNotExist(err) {
+			return LockState{}, nil
+		}
+		// An unparseable lock file can't be held by anyone
+		return LockState{Exists: true}, nil
 	}
 
-	// Try to send signal 0 to check if process exists
-	err = process.Signal(syscall.Signal(0))
+	held, _, err := m.CheckLock(name)
 	if err != nil {
-		// Process doesn't exist or we can't signal it
-		return false, pid, nil
+		return LockState{}, err
 	}
 
-	return true, pid, nil
+	return LockState{Exists: true, PID: pid, Held: held, Managed: managed}, nil
+}
+
+// ArchivedPath returns where an archived workspace's directory is kept
+func (m *Manager) ArchivedPath(name string) string {
+	return filepath.Join(m.baseDir, "archived", filepath.Base(name))
 }
 
 // Archive moves a workspace to an archived subdirectory
 func (m *Manager) Archive(name string) error {
 	wsPath := m.GetPath(name)
-	archivePath := filepath.Join(m.baseDir, "archived", name)
+	archivePath := m.ArchivedPath(name)
 
 	// Create archived directory
 	if err := os.MkdirAll(filepath.Join(m.baseDir, "archived"), 0755); err != nil {
@@ -197,10 +626,28 @@ func (m *Manager) Archive(name string) error {
 	}
 
 	// Move workspace
-	if err := os.Rename(wsPath, archivePath); err != nil {
+	if err := moveDir(wsPath, archivePath); err != nil {
 		return fmt.Errorf("failed to archive workspace: %w", err)
 	}
 
+	if m.HistoryEnabled() {
+		paths := []string{filepath.Join("archived", name)}
+		if m.inHistory(name) {
+			paths = append(paths, name)
+		}
+		_ = m.commitHistory(name+": archive", paths...)
+	}
+	return nil
+}
+
+// RemoveArchived deletes an archived workspace's directory, e.g. once it's safely backed up
+func (m *Manager) RemoveArchived(name string) error {
+	if err := os.RemoveAll(m.ArchivedPath(name)); err != nil {
+		return fmt.Errorf("failed to remove archived workspace: %w", err)
+	}
+	if m.HistoryEnabled() {
+		_ = m.commitHistory(name+": offload", filepath.Join("archived", filepath.Base(name)))
+	}
 	return nil
 }
 
@@ -269,5 +716,72 @@ func (m *Manager) Clone(fromName, toName string) error {
 		}
 	}
 
+	m.recordHistory(toName, "copy notes from "+fromName)
+	return nil
+}
+
+// MergeInto appends fromName's context.md and decisions.md to toName's under a header
+// naming the source, and copies its research files, prefixing names that already exist
+func (m *Manager) MergeInto(fromName, toName string, now time.Time) error {
+	if !m.Exists(fromName) {
+		return fmt.Errorf("source workspace '%s' does not exist", fromName)
+	}
+	if !m.Exists(toName) {
+		return fmt.Errorf("target workspace '%s' does not exist", toName)
+	}
+
+	header := fmt.Sprintf("## Merged from workspace '%s' (%s)", fromName, now.Format("2006-01-02"))
+	for _, file := range []string{"context.md", "decisions.md"} {
+		data, err := os.ReadFile(filepath.Join(m.GetPath(fromName), file))
+		if err != nil && !os.IsNotExist(err) {
+			return fmt.Errorf("failed to read %s: %w", file, err)
+		}
+		content := strings.TrimSpace(string(data))
+		if content == "" {
+			continue
+		}
+
+		dstFile := filepath.Join(m.GetPath(toName), file)
+		existing, err := os.ReadFile(dstFile)
+		if err != nil && !os.IsNotExist(err) {
+			return fmt.Errorf("failed to read %s: %w", file, err)
+		}
+
+		merged := strings.TrimRight(string(existing), "\n")
+		if merged != "" {
+			merged += "\n\n"
+		}
+		merged += header + "\n\n" + content + "\n"
+		if err := os.WriteFile(dstFile, []byte(merged), 0644); err != nil {
+			return fmt.Errorf("failed to write %s: %w", file, err)
+		}
+	}
+
+	files, err := m.ListResearch(fromName)
+	if err != nil {
+		return err
+	}
+	dstResearch := filepath.Join(m.GetPath(toName), "research")
+	if len(files) > 0 {
+		if err := os.MkdirAll(dstResearch, 0755); err != nil {
+			return fmt.Errorf("failed to create research directory: %w", err)
+		}
+	}
+	for _, file := range files {
+		data, err := os.ReadFile(filepath.Join(m.GetPath(fromName), "research", file))
+		if err != nil {
+			return fmt.Errorf("failed to read research file %s: %w", file, err)
+		}
+
+		dstFile := filepath.Join(dstResearch, file)
+		if _, err := os.Stat(dstFile); err == nil {
+			dstFile = filepath.Join(dstResearch, fromName+"-"+file)
+		}
+		if err := os.WriteFile(dstFile, data, 0644); err != nil {
+			return fmt.Errorf("failed to write research file %s: %w", file, err)
+		}
+	}
+
+	m.recordHistory(toName, "merge notes from "+fromName)
 	return nil
 }
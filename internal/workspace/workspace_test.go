@@ -1,10 +1,15 @@
 package workspace
 
 import (
+	"fmt"
 	"os"
 	"path/filepath"
+	"runtime"
+	"strings"
 	"testing"
+	"time"
 
+	"github.com/pmossman/claudew/internal/config"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
@@ -24,6 +29,25 @@ func TestManager_GetPath(t *testing.T) {
 	assert.Equal(t, "/tmp/workspaces/test-ws", path)
 }
 
+func TestManager_GetPath_DirOverride(t *testing.T) {
+	cfg := &config.Config{
+		Workspaces: map[string]*config.Workspace{
+			"default": {Name: "default"},
+			"in-repo": {Name: "in-repo", Dir: "/src/app/.claudew/"},
+		},
+		Settings: config.Settings{WorkspaceDir: "/tmp/workspaces"},
+	}
+	mgr := NewManagerForConfig(cfg)
+
+	assert.Equal(t, "/tmp/workspaces/default", mgr.GetPath("default"))
+	assert.Equal(t, "/src/app/.claudew", mgr.GetPath("in-repo"))
+	assert.Equal(t, "/tmp/workspaces/unknown", mgr.GetPath("unknown"))
+
+	// Workspaces added after the manager was created are resolved too
+	cfg.Workspaces["later"] = &config.Workspace{Name: "later", Dir: "/notes/later"}
+	assert.Equal(t, "/notes/later", mgr.GetPath("later"))
+}
+
 func TestManager_Create(t *testing.T) {
 	tmpDir := t.TempDir()
 	mgr := NewManager(tmpDir)
@@ -454,3 +478,348 @@ func TestManager_Create_PermissionError(t *testing.T) {
 	err = mgr.Create("test-ws")
 	assert.Error(t, err)
 }
+
+func TestManager_AcquireLock(t *testing.T) {
+	tmpDir := t.TempDir()
+	mgr := NewManager(tmpDir)
+	mgr.Create("test-ws")
+
+	lock, err := mgr.AcquireLock("test-ws", os.Getpid())
+	require.NoError(t, err)
+
+	// Lock should be reported as held
+	locked, pid, err := mgr.CheckLock("test-ws")
+	require.NoError(t, err)
+	assert.True(t, locked)
+	assert.Equal(t, os.Getpid(), pid)
+
+	// A second acquisition should fail while the first is held
+	_, err = mgr.AcquireLock("test-ws", 12345)
+	var lockedErr *ErrLocked
+	require.ErrorAs(t, err, &lockedErr)
+	assert.Equal(t, os.Getpid(), lockedErr.PID)
+
+	// Releasing leaves the lock file in place, no longer held
+	require.NoError(t, lock.Release())
+	assert.FileExists(t, filepath.Join(mgr.GetPath("test-ws"), ".lock"))
+
+	locked, _, err = mgr.CheckLock("test-ws")
+	require.NoError(t, err)
+	assert.False(t, locked)
+
+	// Releasing twice is harmless
+	assert.NoError(t, lock.Release())
+}
+
+func TestManager_StealLock(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("held locks can't be stolen on Windows")
+	}
+	tmpDir := t.TempDir()
+	mgr := NewManager(tmpDir)
+	mgr.Create("test-ws")
+
+	first, err := mgr.AcquireLock("test-ws", 12345)
+	require.NoError(t, err)
+
+	stolen, err := mgr.StealLock("test-ws", os.Getpid())
+	require.NoError(t, err)
+
+	locked, pid, err := mgr.CheckLock("test-ws")
+	require.NoError(t, err)
+	assert.True(t, locked)
+	assert.Equal(t, os.Getpid(), pid)
+
+	// The previous holder releasing leaves the new lock in place
+	require.NoError(t, first.Release())
+	locked, pid, err = mgr.CheckLock("test-ws")
+	require.NoError(t, err)
+	assert.True(t, locked)
+	assert.Equal(t, os.Getpid(), pid)
+
+	require.NoError(t, stolen.Release())
+	locked, _, err = mgr.CheckLock("test-ws")
+	require.NoError(t, err)
+	assert.False(t, locked)
+}
+
+func TestManager_CheckLock_StaleFlockLock(t *testing.T) {
+	tmpDir := t.TempDir()
+	mgr := NewManager(tmpDir)
+	mgr.Create("test-ws")
+
+	// A flock-managed lock file left behind by a dead process, whose PID has
+	// since been reused (by this test process), must not be reported as held
+	lockPath := filepath.Join(mgr.GetPath("test-ws"), ".lock")
+	err := os.WriteFile(lockPath, []byte(fmt.Sprintf("%d flock", os.Getpid())), 0644)
+	require.NoError(t, err)
+
+	locked, pid, err := mgr.CheckLock("test-ws")
+	require.NoError(t, err)
+	assert.False(t, locked)
+	assert.Equal(t, os.Getpid(), pid)
+
+	// The stale lock can be broken by acquiring it
+	lock, err := mgr.AcquireLock("test-ws", os.Getpid())
+	require.NoError(t, err)
+	defer lock.Release()
+
+	locked, _, err = mgr.CheckLock("test-ws")
+	require.NoError(t, err)
+	assert.True(t, locked)
+}
+
+func TestManager_ReadContext(t *testing.T) {
+	tmpDir := t.TempDir()
+	mgr := NewManager(tmpDir)
+	mgr.Create("test-ws")
+
+	// Long context is returned in full, unlike GetContext
+	longContent := strings.Repeat("a", 300)
+	err := mgr.SaveContext("test-ws", longContent)
+	require.NoError(t, err)
+	assert.Equal(t, longContent, mgr.ReadContext("test-ws"))
+
+	// Missing workspace returns empty string
+	assert.Equal(t, "", mgr.ReadContext("nonexistent"))
+}
+
+func TestManager_GetLockState(t *testing.T) {
+	tmpDir := t.TempDir()
+	mgr := NewManager(tmpDir)
+	mgr.Create("test-ws")
+
+	// No lock file
+	state, err := mgr.GetLockState("test-ws")
+	require.NoError(t, err)
+	assert.False(t, state.Exists)
+
+	// Held flock lock
+	lock, err := mgr.AcquireLock("test-ws", os.Getpid())
+	require.NoError(t, err)
+
+	state, err = mgr.GetLockState("test-ws")
+	require.NoError(t, err)
+	assert.True(t, state.Exists)
+	assert.True(t, state.Held)
+	assert.True(t, state.Managed)
+	assert.Equal(t, os.Getpid(), state.PID)
+	require.NoError(t, lock.Release())
+
+	// Legacy lock with a dead PID
+	err = mgr.CreateLock("test-ws", 999999)
+	require.NoError(t, err)
+
+	state, err = mgr.GetLockState("test-ws")
+	require.NoError(t, err)
+	assert.True(t, state.Exists)
+	assert.False(t, state.Held)
+	assert.False(t, state.Managed)
+
+	// Garbage lock file
+	err = os.WriteFile(filepath.Join(mgr.GetPath("test-ws"), ".lock"), []byte("garbage"), 0644)
+	require.NoError(t, err)
+
+	state, err = mgr.GetLockState("test-ws")
+	require.NoError(t, err)
+	assert.True(t, state.Exists)
+	assert.False(t, state.Held)
+}
+
+func TestManager_ContextUsage(t *testing.T) {
+	tmpDir := t.TempDir()
+	mgr := NewManager(tmpDir)
+	mgr.Create("test-ws")
+
+	_, ok := mgr.GetContextUsage("test-ws")
+	assert.False(t, ok)
+
+	require.NoError(t, mgr.SaveContextUsage("test-ws", 78))
+	percent, ok := mgr.GetContextUsage("test-ws")
+	assert.True(t, ok)
+	assert.Equal(t, 78, percent)
+
+	require.NoError(t, mgr.ClearContextUsage("test-ws"))
+	_, ok = mgr.GetContextUsage("test-ws")
+	assert.False(t, ok)
+
+	// Clearing again is a no-op
+	assert.NoError(t, mgr.ClearContextUsage("test-ws"))
+}
+
+func TestManager_ReadDecisionsAndListResearch(t *testing.T) {
+	tmpDir := t.TempDir()
+	mgr := NewManager(tmpDir)
+	require.NoError(t, mgr.Create("test-ws"))
+
+	assert.Empty(t, mgr.ReadDecisions("test-ws"))
+	require.NoError(t, mgr.SaveDecisions("test-ws", "Use sqlite\n"))
+	assert.Equal(t, "Use sqlite\n", mgr.ReadDecisions("test-ws"))
+
+	files, err := mgr.ListResearch("test-ws")
+	require.NoError(t, err)
+	assert.Empty(t, files)
+
+	researchDir := filepath.Join(mgr.GetPath("test-ws"), "research")
+	require.NoError(t, os.WriteFile(filepath.Join(researchDir, "b.md"), []byte("b"), 0644))
+	require.NoError(t, os.WriteFile(filepath.Join(researchDir, "a.md"), []byte("a"), 0644))
+	require.NoError(t, os.Mkdir(filepath.Join(researchDir, "sub"), 0755))
+
+	files, err = mgr.ListResearch("test-ws")
+	require.NoError(t, err)
+	assert.Equal(t, []string{"a.md", "b.md"}, files)
+
+	// Missing workspace has no research
+	files, err = mgr.ListResearch("missing")
+	require.NoError(t, err)
+	assert.Empty(t, files)
+}
+
+func TestManager_MergeInto(t *testing.T) {
+	tmpDir := t.TempDir()
+	mgr := NewManager(tmpDir)
+	require.NoError(t, mgr.Create("src"))
+	require.NoError(t, mgr.Create("dst"))
+
+	require.NoError(t, mgr.SaveContext("src", "Explored caching\n"))
+	require.NoError(t, mgr.SaveContext("dst", "# Context\nMain work\n"))
+	require.NoError(t, mgr.SaveDecisions("src", "Use redis\n"))
+
+	srcResearch := filepath.Join(mgr.GetPath("src"), "research")
+	dstResearch := filepath.Join(mgr.GetPath("dst"), "research")
+	require.NoError(t, os.WriteFile(filepath.Join(srcResearch, "notes.md"), []byte("src notes"), 0644))
+	require.NoError(t, os.WriteFile(filepath.Join(srcResearch, "api.md"), []byte("api"), 0644))
+	require.NoError(t, os.WriteFile(filepath.Join(dstResearch, "notes.md"), []byte("dst notes"), 0644))
+
+	now := time.Date(2026, 3, 4, 0, 0, 0, 0, time.UTC)
+	require.NoError(t, mgr.MergeInto("src", "dst", now))
+
+	assert.Equal(t, "# Context\nMain work\n\n## Merged from workspace 'src' (2026-03-04)\n\nExplored caching\n", mgr.ReadContext("dst"))
+	assert.Equal(t, "## Merged from workspace 'src' (2026-03-04)\n\nUse redis\n", mgr.ReadDecisions("dst"))
+
+	files, err := mgr.ListResearch("dst")
+	require.NoError(t, err)
+	assert.Equal(t, []string{"api.md", "notes.md", "src-notes.md"}, files)
+
+	data, err := os.ReadFile(filepath.Join(dstResearch, "notes.md"))
+	require.NoError(t, err)
+	assert.Equal(t, "dst notes", string(data))
+
+	assert.Error(t, mgr.MergeInto("missing", "dst", now))
+	assert.Error(t, mgr.MergeInto("src", "missing", now))
+}
+
+func TestManager_ClaudeExit(t *testing.T) {
+	tmpDir := t.TempDir()
+	mgr := NewManager(tmpDir)
+	require.NoError(t, mgr.Create("test-ws"))
+
+	_, ok := mgr.GetClaudeExit("test-ws")
+	assert.False(t, ok)
+
+	// Written by the shell as "echo $? > file"
+	require.NoError(t, os.WriteFile(mgr.ClaudeExitPath("test-ws"), []byte("137\n"), 0644))
+	code, ok := mgr.GetClaudeExit("test-ws")
+	assert.True(t, ok)
+	assert.Equal(t, 137, code)
+
+	require.NoError(t, mgr.ClearClaudeExit("test-ws"))
+	_, ok = mgr.GetClaudeExit("test-ws")
+	assert.False(t, ok)
+	assert.NoError(t, mgr.ClearClaudeExit("test-ws"))
+}
+
+func TestManager_AppendSessionLog(t *testing.T) {
+	tmpDir := t.TempDir()
+	mgr := NewManager(tmpDir)
+	require.NoError(t, mgr.Create("test-ws"))
+
+	start := time.Date(2026, 3, 1, 9, 0, 0, 0, time.UTC)
+	require.NoError(t, mgr.AppendSessionLog("test-ws", "started claude (PID 4242)", start))
+	require.NoError(t, mgr.AppendSessionLog("test-ws", "claude exited with status 0", start.Add(time.Hour)))
+
+	data, err := os.ReadFile(mgr.SessionLogPath("test-ws"))
+	require.NoError(t, err)
+	assert.Equal(t, "2026-03-01T09:00:00Z started claude (PID 4242)\n2026-03-01T10:00:00Z claude exited with status 0\n", string(data))
+
+	assert.Error(t, mgr.AppendSessionLog("missing", "line", start))
+}
+
+func TestManager_AppendDecision(t *testing.T) {
+	tmpDir := t.TempDir()
+	mgr := NewManager(tmpDir)
+	require.NoError(t, mgr.Create("test-ws"))
+
+	now := time.Date(2026, 3, 1, 9, 30, 0, 0, time.UTC)
+	require.NoError(t, mgr.AppendDecision("test-ws", "  Use Postgres, not SQLite\n", now))
+	require.NoError(t, mgr.AppendDecision("test-ws", "Keep the v1 API", now.Add(time.Hour)))
+	assert.Equal(t, "## [2026-03-01 09:30] Decision\nUse Postgres, not SQLite\n\n## [2026-03-01 10:30] Decision\nKeep the v1 API\n", mgr.ReadDecisions("test-ws"))
+
+	assert.Error(t, mgr.AppendDecision("test-ws", " ", now))
+	assert.Error(t, mgr.AppendDecision("missing", "text", now))
+}
+
+func TestManager_AppendCommit(t *testing.T) {
+	tmpDir := t.TempDir()
+	mgr := NewManager(tmpDir)
+	require.NoError(t, mgr.Create("test-ws"))
+
+	now := time.Date(2026, 3, 1, 9, 30, 0, 0, time.UTC)
+	require.NoError(t, mgr.AppendCommit("test-ws", "abc1234", "Add login form", now))
+	assert.Equal(t, "## Recent commits\n- 2026-03-01 09:30 abc1234 Add login form\n", mgr.ReadContext("test-ws"))
+
+	// Claude's own notes before and after the section are kept
+	require.NoError(t, mgr.SaveContext("test-ws", "## Objective\nShip login\n\n## Recent commits\n- 2026-03-01 09:30 abc1234 Add login form\n\n## Next\nTests\n"))
+	require.NoError(t, mgr.AppendCommit("test-ws", "def5678", "Validate email", now.Add(time.Hour)))
+	assert.Equal(t, "## Objective\nShip login\n\n## Recent commits\n- 2026-03-01 09:30 abc1234 Add login form\n- 2026-03-01 10:30 def5678 Validate email\n\n## Next\nTests\n", mgr.ReadContext("test-ws"))
+
+	require.NoError(t, mgr.SaveContext("test-ws", "Working on login.\n"))
+	require.NoError(t, mgr.AppendCommit("test-ws", "def5678", "Validate email", now))
+	assert.Equal(t, "Working on login.\n\n## Recent commits\n- 2026-03-01 09:30 def5678 Validate email\n", mgr.ReadContext("test-ws"))
+
+	assert.Error(t, mgr.AppendCommit("missing", "abc1234", "Add login form", now))
+}
+
+func TestManager_AppendCommit_KeepsRecent(t *testing.T) {
+	tmpDir := t.TempDir()
+	mgr := NewManager(tmpDir)
+	require.NoError(t, mgr.Create("test-ws"))
+
+	now := time.Date(2026, 3, 1, 9, 30, 0, 0, time.UTC)
+	for i := 0; i < maxContextCommits+5; i++ {
+		require.NoError(t, mgr.AppendCommit("test-ws", fmt.Sprintf("c%d", i), "Commit", now))
+	}
+	context := mgr.ReadContext("test-ws")
+	assert.Equal(t, maxContextCommits, strings.Count(context, "\n- "))
+	assert.NotContains(t, context, " c4 ")
+	assert.Contains(t, context, " c5 ")
+}
+
+func TestManager_LinkRepo(t *testing.T) {
+	tmpDir := t.TempDir()
+	mgr := NewManager(filepath.Join(tmpDir, "workspaces"))
+	require.NoError(t, mgr.Create("test-ws"))
+	first, second := filepath.Join(tmpDir, "clone-1"), filepath.Join(tmpDir, "clone-2")
+	require.NoError(t, os.MkdirAll(first, 0755))
+	require.NoError(t, os.MkdirAll(second, 0755))
+
+	require.NoError(t, mgr.LinkRepo("test-ws", first))
+	target, err := os.Readlink(mgr.RepoLinkPath("test-ws"))
+	require.NoError(t, err)
+	assert.Equal(t, first, target)
+
+	// Relinking replaces the target, and linking again is a no-op
+	require.NoError(t, mgr.LinkRepo("test-ws", second))
+	require.NoError(t, mgr.LinkRepo("test-ws", second))
+	target, err = os.Readlink(mgr.RepoLinkPath("test-ws"))
+	require.NoError(t, err)
+	assert.Equal(t, second, target)
+
+	require.NoError(t, mgr.LinkRepo("test-ws", ""))
+	assert.NoFileExists(t, mgr.RepoLinkPath("test-ws"))
+	require.NoError(t, mgr.LinkRepo("test-ws", ""), "nothing to remove")
+
+	// A real file named repo is never replaced
+	require.NoError(t, os.WriteFile(mgr.RepoLinkPath("test-ws"), []byte("notes"), 0644))
+	assert.Error(t, mgr.LinkRepo("test-ws", first))
+}